@@ -62,19 +62,19 @@ const docTemplate = `{
                     "400": {
                         "description": "Ошибка валидации: неверный формат запроса, пустое или некорректное выражение.",
                         "schema": {
-                            "$ref": "#/definitions/handler.ErrorResponse"
+                            "$ref": "#/definitions/apierror.Response"
                         }
                     },
                     "401": {
                         "description": "Ошибка аутентификации: JWT токен отсутствует, невалиден или истек.",
                         "schema": {
-                            "$ref": "#/definitions/handler.ErrorResponse"
+                            "$ref": "#/definitions/apierror.Response"
                         }
                     },
                     "500": {
                         "description": "Внутренняя ошибка сервера при создании задачи или взаимодействии с другими сервисами.",
                         "schema": {
-                            "$ref": "#/definitions/handler.ErrorResponse"
+                            "$ref": "#/definitions/apierror.Response"
                         }
                     }
                 }
@@ -114,19 +114,19 @@ const docTemplate = `{
                     "400": {
                         "description": "Неверный формат запроса.",
                         "schema": {
-                            "$ref": "#/definitions/handler.ErrorResponse"
+                            "$ref": "#/definitions/apierror.Response"
                         }
                     },
                     "401": {
                         "description": "Ошибка аутентификации: неверный логин или пароль.",
                         "schema": {
-                            "$ref": "#/definitions/handler.ErrorResponse"
+                            "$ref": "#/definitions/apierror.Response"
                         }
                     },
                     "500": {
                         "description": "Внутренняя ошибка сервера при попытке входа.",
                         "schema": {
-                            "$ref": "#/definitions/handler.ErrorResponse"
+                            "$ref": "#/definitions/apierror.Response"
                         }
                     }
                 }
@@ -169,19 +169,19 @@ const docTemplate = `{
                     "400": {
                         "description": "Ошибка валидации: неверный формат логина или пароля, или неверное тело запроса.",
                         "schema": {
-                            "$ref": "#/definitions/handler.ErrorResponse"
+                            "$ref": "#/definitions/apierror.Response"
                         }
                     },
                     "409": {
                         "description": "Конфликт: пользователь с таким логином уже существует.",
                         "schema": {
-                            "$ref": "#/definitions/handler.ErrorResponse"
+                            "$ref": "#/definitions/apierror.Response"
                         }
                     },
                     "500": {
                         "description": "Внутренняя ошибка сервера при попытке регистрации.",
                         "schema": {
-                            "$ref": "#/definitions/handler.ErrorResponse"
+                            "$ref": "#/definitions/apierror.Response"
                         }
                     }
                 }
@@ -215,13 +215,13 @@ const docTemplate = `{
                     "401": {
                         "description": "Ошибка аутентификации: JWT токен отсутствует, невалиден или истек.",
                         "schema": {
-                            "$ref": "#/definitions/handler.ErrorResponse"
+                            "$ref": "#/definitions/apierror.Response"
                         }
                     },
                     "500": {
                         "description": "Внутренняя ошибка сервера при получении списка задач.",
                         "schema": {
-                            "$ref": "#/definitions/handler.ErrorResponse"
+                            "$ref": "#/definitions/apierror.Response"
                         }
                     }
                 }
@@ -261,25 +261,25 @@ const docTemplate = `{
                     "400": {
                         "description": "Невалидный формат ID задачи (не UUID).",
                         "schema": {
-                            "$ref": "#/definitions/handler.ErrorResponse"
+                            "$ref": "#/definitions/apierror.Response"
                         }
                     },
                     "401": {
                         "description": "Ошибка аутентификации: JWT токен отсутствует, невалиден или истек.",
                         "schema": {
-                            "$ref": "#/definitions/handler.ErrorResponse"
+                            "$ref": "#/definitions/apierror.Response"
                         }
                     },
                     "404": {
                         "description": "Задача с указанным ID не найдена или не принадлежит текущему пользователю.",
                         "schema": {
-                            "$ref": "#/definitions/handler.ErrorResponse"
+                            "$ref": "#/definitions/apierror.Response"
                         }
                     },
                     "500": {
                         "description": "Внутренняя ошибка сервера при получении деталей задачи.",
                         "schema": {
-                            "$ref": "#/definitions/handler.ErrorResponse"
+                            "$ref": "#/definitions/apierror.Response"
                         }
                     }
                 }
@@ -287,6 +287,102 @@ const docTemplate = `{
         }
     },
     "definitions": {
+        "apierror.Code": {
+            "type": "string",
+            "enum": [
+                "INVALID_REQUEST",
+                "EXPRESSION_PARSE_ERROR",
+                "EXPRESSION_LIMIT_EXCEEDED",
+                "EXPRESSION_REJECTED",
+                "ESTIMATE_EXCEEDED",
+                "UNAUTHORIZED",
+                "INVALID_CREDENTIALS",
+                "FORBIDDEN",
+                "NOT_FOUND",
+                "TASK_NOT_FOUND",
+                "TASK_NOT_RETRYABLE",
+                "TASK_STATE_CONFLICT",
+                "WEBHOOK_NOT_FOUND",
+                "WEBHOOK_EXISTS",
+                "USER_EXISTS",
+                "IDEMPOTENCY_KEY_REUSED",
+                "CONFLICT",
+                "METHOD_NOT_ALLOWED",
+                "PAYLOAD_TOO_LARGE",
+                "RATE_LIMITED",
+                "QUOTA_EXCEEDED",
+                "SERVICE_OVERLOADED",
+                "SERVICE_UNAVAILABLE",
+                "TIMEOUT",
+                "INTERNAL_ERROR"
+            ],
+            "x-enum-varnames": [
+                "CodeInvalidRequest",
+                "CodeExpressionParseError",
+                "CodeExpressionLimitExceeded",
+                "CodeExpressionRejected",
+                "CodeEstimateExceeded",
+                "CodeUnauthorized",
+                "CodeInvalidCredentials",
+                "CodeForbidden",
+                "CodeNotFound",
+                "CodeTaskNotFound",
+                "CodeTaskNotRetryable",
+                "CodeTaskStateConflict",
+                "CodeWebhookNotFound",
+                "CodeWebhookExists",
+                "CodeUserExists",
+                "CodeIdempotencyKeyReused",
+                "CodeConflict",
+                "CodeMethodNotAllowed",
+                "CodePayloadTooLarge",
+                "CodeRateLimited",
+                "CodeQuotaExceeded",
+                "CodeServiceOverloaded",
+                "CodeServiceUnavailable",
+                "CodeTimeout",
+                "CodeInternal"
+            ]
+        },
+        "apierror.Response": {
+            "type": "object",
+            "properties": {
+                "code": {
+                    "description": "Стабильный код ошибки, по которому клиент выбирает реакцию",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/apierror.Code"
+                        }
+                    ],
+                    "example": "TASK_NOT_FOUND"
+                },
+                "details": {
+                    "description": "Детали ошибки, зависят от кода: limit для EXPRESSION_LIMIT_EXCEEDED, retry_after_seconds для RATE_LIMITED, QUOTA_EXCEEDED и SERVICE_OVERLOADED, index для ошибки в пакете",
+                    "type": "object",
+                    "additionalProperties": {}
+                },
+                "error": {
+                    "description": "Устарело: повторяет message для клиентов версии 1",
+                    "type": "string",
+                    "example": "задача не найдена или нет прав доступа"
+                },
+                "message": {
+                    "description": "Сообщение для человека, текст может меняться",
+                    "type": "string",
+                    "example": "задача не найдена или нет прав доступа"
+                },
+                "request_id": {
+                    "description": "ID запроса из заголовка X-Request-ID",
+                    "type": "string",
+                    "example": "3f1c6a0e-8f4b-4f7a-9a43-2b8d8a6f0c11"
+                },
+                "version": {
+                    "description": "Версия формата ошибки",
+                    "type": "integer",
+                    "example": 2
+                }
+            }
+        },
         "handler.CalculateRequest": {
             "type": "object",
             "required": [
@@ -310,16 +406,6 @@ const docTemplate = `{
                 }
             }
         },
-        "handler.ErrorResponse": {
-            "type": "object",
-            "properties": {
-                "error": {
-                    "description": "Текстовое описание ошибки",
-                    "type": "string",
-                    "example": "Сообщение об ошибке"
-                }
-            }
-        },
         "handler.LoginRequest": {
             "type": "object",
             "properties": {
@@ -364,6 +450,11 @@ const docTemplate = `{
                 "created_at": {
                     "type": "string"
                 },
+                "error_code": {
+                    "description": "Тип ошибки, если статус failed: DIVISION_BY_ZERO, DOMAIN_ERROR, OVERFLOW, TIMEOUT, WORKER_UNAVAILABLE, CANCELLED или INTERNAL",
+                    "type": "string",
+                    "example": "DIVISION_BY_ZERO"
+                },
                 "error_message": {
                     "type": "string"
                 },
@@ -391,6 +482,11 @@ const docTemplate = `{
                 "created_at": {
                     "type": "string"
                 },
+                "error_code": {
+                    "description": "Тип ошибки, если статус failed: DIVISION_BY_ZERO, DOMAIN_ERROR, OVERFLOW, TIMEOUT, WORKER_UNAVAILABLE, CANCELLED или INTERNAL",
+                    "type": "string",
+                    "example": "DIVISION_BY_ZERO"
+                },
                 "expression": {
                     "type": "string"
                 },
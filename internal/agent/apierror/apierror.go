@@ -0,0 +1,124 @@
+// Package apierror - формат ошибок HTTP API Агента: стабильный код для клиентского кода,
+// сообщение для человека, детали и ID запроса.
+package apierror
+
+import (
+	"math"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/labstack/echo/v4"
+)
+
+// Version - версия формата ошибки. Версия 1 содержала только поле error.
+const Version = 2
+
+// Code - стабильный код ошибки. Коды не меняются между версиями API, в отличие от текста сообщений.
+type Code string
+
+const (
+	CodeInvalidRequest          Code = "INVALID_REQUEST"
+	CodeExpressionParseError    Code = "EXPRESSION_PARSE_ERROR"
+	CodeExpressionLimitExceeded Code = "EXPRESSION_LIMIT_EXCEEDED"
+	CodeExpressionRejected      Code = "EXPRESSION_REJECTED"
+	CodeEstimateExceeded        Code = "ESTIMATE_EXCEEDED"
+	CodeUnauthorized            Code = "UNAUTHORIZED"
+	CodeInvalidCredentials      Code = "INVALID_CREDENTIALS"
+	CodeForbidden               Code = "FORBIDDEN"
+	CodeNotFound                Code = "NOT_FOUND"
+	CodeTaskNotFound            Code = "TASK_NOT_FOUND"
+	CodeTaskNotRetryable        Code = "TASK_NOT_RETRYABLE"
+	CodeTaskStateConflict       Code = "TASK_STATE_CONFLICT"
+	CodeWebhookNotFound         Code = "WEBHOOK_NOT_FOUND"
+	CodeWebhookExists           Code = "WEBHOOK_EXISTS"
+	CodeUserExists              Code = "USER_EXISTS"
+	CodeIdempotencyKeyReused    Code = "IDEMPOTENCY_KEY_REUSED"
+	CodeConflict                Code = "CONFLICT"
+	CodeMethodNotAllowed        Code = "METHOD_NOT_ALLOWED"
+	CodePayloadTooLarge         Code = "PAYLOAD_TOO_LARGE"
+	CodeRateLimited             Code = "RATE_LIMITED"
+	CodeQuotaExceeded           Code = "QUOTA_EXCEEDED"
+	CodeServiceOverloaded       Code = "SERVICE_OVERLOADED"
+	CodeServiceUnavailable      Code = "SERVICE_UNAVAILABLE"
+	CodeTimeout                 Code = "TIMEOUT"
+	CodeInternal                Code = "INTERNAL_ERROR"
+)
+
+const internalMessage = "Внутренняя ошибка сервера"
+
+// Response - тело ответа с ошибкой. Поле error повторяет message для клиентов версии 1.
+type Response struct {
+	// Версия формата ошибки
+	Version int `json:"version" example:"2"`
+	// Стабильный код ошибки, по которому клиент выбирает реакцию
+	Code Code `json:"code" example:"TASK_NOT_FOUND"`
+	// Сообщение для человека, текст может меняться
+	Message string `json:"message" example:"задача не найдена или нет прав доступа"`
+	// Детали ошибки, зависят от кода: limit для EXPRESSION_LIMIT_EXCEEDED, retry_after_seconds для RATE_LIMITED, QUOTA_EXCEEDED и SERVICE_OVERLOADED, index для ошибки в пакете
+	Details map[string]any `json:"details,omitempty"`
+	// ID запроса из заголовка X-Request-ID
+	RequestID string `json:"request_id,omitempty" example:"3f1c6a0e-8f4b-4f7a-9a43-2b8d8a6f0c11"`
+	// Устарело: повторяет message для клиентов версии 1
+	Error string `json:"error" example:"задача не найдена или нет прав доступа"`
+}
+
+// Error - ошибка, готовая к отправке клиенту. RetryAfter > 0 выставляет заголовок Retry-After.
+type Error struct {
+	Status     int
+	Code       Code
+	Message    string
+	Details    map[string]any
+	RetryAfter time.Duration
+}
+
+func New(status int, code Code, message string) *Error {
+	return &Error{Status: status, Code: code, Message: message}
+}
+
+func Internal() *Error {
+	return New(http.StatusInternalServerError, CodeInternal, internalMessage)
+}
+
+func (e *Error) Error() string { return e.Message }
+
+func (e *Error) WithDetail(key string, value any) *Error {
+	if e.Details == nil {
+		e.Details = make(map[string]any)
+	}
+	e.Details[key] = value
+	return e
+}
+
+// WithRetryAfter выставляет Retry-After и дублирует его в details.retry_after_seconds.
+func (e *Error) WithRetryAfter(d time.Duration) *Error {
+	e.RetryAfter = d
+	return e.WithDetail("retry_after_seconds", retryAfterSeconds(d))
+}
+
+func (e *Error) Response(requestID string) Response {
+	return Response{
+		Version:   Version,
+		Code:      e.Code,
+		Message:   e.Message,
+		Details:   e.Details,
+		RequestID: requestID,
+		Error:     e.Message,
+	}
+}
+
+// Respond отправляет ошибку клиенту. ID запроса берется из заголовка, выставленного echomiddleware.RequestID.
+func Respond(c echo.Context, e *Error) error {
+	if e.RetryAfter > 0 {
+		c.Response().Header().Set("Retry-After", strconv.Itoa(retryAfterSeconds(e.RetryAfter)))
+	}
+	return c.JSON(e.Status, e.Response(RequestID(c)))
+}
+
+func RequestID(c echo.Context) string {
+	return c.Response().Header().Get(echo.HeaderXRequestID)
+}
+
+func retryAfterSeconds(d time.Duration) int {
+	return max(int(math.Ceil(d.Seconds())), 1)
+}
@@ -0,0 +1,127 @@
+package apierror
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/Qu1nel/YaLyceum-GoProject-Final/internal/agent/repository"
+	"github.com/Qu1nel/YaLyceum-GoProject-Final/internal/agent/service"
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestFromError(t *testing.T) {
+	grpcNotFound := status.Error(codes.NotFound, "задача abc не найдена")
+	tests := []struct {
+		name    string
+		err     error
+		status  int
+		code    Code
+		message string
+	}{
+		{
+			name:    "обернутый gRPC статус не попадает в сообщение",
+			err:     fmt.Errorf("%w: %w", service.ErrTaskNotFound, grpcNotFound),
+			status:  http.StatusNotFound,
+			code:    CodeTaskNotFound,
+			message: service.ErrTaskNotFound.Error(),
+		},
+		{
+			name:    "сообщение сервиса сохраняется",
+			err:     fmt.Errorf("%w: оценка 12s больше лимита 5s", service.ErrEstimateExceeded),
+			status:  http.StatusUnprocessableEntity,
+			code:    CodeEstimateExceeded,
+			message: "выражение превышает допустимое время вычисления: оценка 12s больше лимита 5s",
+		},
+		{
+			name:    "ошибка репозитория",
+			err:     repository.ErrLoginAlreadyExists,
+			status:  http.StatusConflict,
+			code:    CodeUserExists,
+			message: repository.ErrLoginAlreadyExists.Error(),
+		},
+		{
+			name:    "ошибка разбора выражения",
+			err:     &service.ExpressionRejectedError{Reason: service.ExpressionReasonParseError, Message: "ошибка в выражении: unexpected token EOF"},
+			status:  http.StatusBadRequest,
+			code:    CodeExpressionParseError,
+			message: "выражение отклонено: ошибка в выражении: unexpected token EOF",
+		},
+		{
+			name:    "gRPC статус без ошибки сервиса",
+			err:     status.Error(codes.Unavailable, "connection refused"),
+			status:  http.StatusServiceUnavailable,
+			code:    CodeServiceUnavailable,
+			message: "Сервис вычислений недоступен, повторите попытку позже",
+		},
+		{
+			name:    "неизвестная ошибка",
+			err:     fmt.Errorf("ошибка сервиса вычислений: %w", status.Error(codes.Internal, "panic: nil map")),
+			status:  http.StatusInternalServerError,
+			code:    CodeInternal,
+			message: internalMessage,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			apiErr := FromError(tt.err)
+			assert.Equal(t, tt.status, apiErr.Status)
+			assert.Equal(t, tt.code, apiErr.Code)
+			assert.Equal(t, tt.message, apiErr.Message)
+		})
+	}
+}
+
+func TestFromError_ExpressionLimit(t *testing.T) {
+	apiErr := FromError(&service.ExpressionRejectedError{
+		Reason:  service.ExpressionReasonLimitExceeded,
+		Limit:   "EXPR_MAX_NODES",
+		Message: "превышен лимит EXPR_MAX_NODES: 1500 узлов в дереве выражения, максимум 1000",
+	})
+	assert.Equal(t, CodeExpressionLimitExceeded, apiErr.Code)
+	assert.Equal(t, map[string]any{"limit": "EXPR_MAX_NODES"}, apiErr.Details)
+}
+
+func TestRespond(t *testing.T) {
+	e := echo.New()
+	rec := httptest.NewRecorder()
+	c := e.NewContext(httptest.NewRequest(http.MethodPost, "/calculate", nil), rec)
+	c.Response().Header().Set(echo.HeaderXRequestID, "req-1")
+
+	quotaErr := &service.QuotaExceededError{Message: "превышена суточная квота задач", RetryAfter: 90500 * time.Millisecond}
+	require.NoError(t, Respond(c, FromError(fmt.Errorf("отправка: %w", quotaErr))))
+
+	assert.Equal(t, http.StatusTooManyRequests, rec.Code)
+	assert.Equal(t, "91", rec.Header().Get("Retry-After"))
+	var body Response
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &body))
+	assert.Equal(t, Response{
+		Version:   Version,
+		Code:      CodeQuotaExceeded,
+		Message:   "превышена суточная квота задач",
+		Details:   map[string]any{"retry_after_seconds": float64(91)},
+		RequestID: "req-1",
+		Error:     "превышена суточная квота задач",
+	}, body)
+}
+
+func TestHTTPErrorHandler_UnknownRoute(t *testing.T) {
+	e := echo.New()
+	e.HTTPErrorHandler = HTTPErrorHandler(zap.NewNop())
+	rec := httptest.NewRecorder()
+	e.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/api/v1/unknown", nil))
+
+	assert.Equal(t, http.StatusNotFound, rec.Code)
+	var body Response
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &body))
+	assert.Equal(t, CodeNotFound, body.Code)
+	assert.Equal(t, Version, body.Version)
+}
@@ -0,0 +1,150 @@
+package apierror
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/Qu1nel/YaLyceum-GoProject-Final/internal/agent/repository"
+	"github.com/Qu1nel/YaLyceum-GoProject-Final/internal/agent/service"
+	"github.com/labstack/echo/v4"
+	"go.uber.org/zap"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// sentinels - ошибки сервисов и репозиториев, текст которых можно показывать клиенту.
+var sentinels = []struct {
+	err    error
+	status int
+	code   Code
+}{
+	{service.ErrTaskNotFound, http.StatusNotFound, CodeTaskNotFound},
+	{service.ErrTaskNotRetryable, http.StatusConflict, CodeTaskNotRetryable},
+	{service.ErrTaskStateConflict, http.StatusConflict, CodeTaskStateConflict},
+	{service.ErrInvalidTaskQuery, http.StatusBadRequest, CodeInvalidRequest},
+	{service.ErrIdempotencyKeyReused, http.StatusConflict, CodeIdempotencyKeyReused},
+	{service.ErrEstimateExceeded, http.StatusUnprocessableEntity, CodeEstimateExceeded},
+	{service.ErrServiceOverloaded, http.StatusTooManyRequests, CodeServiceOverloaded},
+	{service.ErrInvalidAdminRequest, http.StatusBadRequest, CodeInvalidRequest},
+	{service.ErrWebhookNotFound, http.StatusNotFound, CodeWebhookNotFound},
+	{service.ErrWebhookExists, http.StatusConflict, CodeWebhookExists},
+	{service.ErrInvalidWebhookRequest, http.StatusBadRequest, CodeInvalidRequest},
+	{service.ErrInvalidLoginFormat, http.StatusBadRequest, CodeInvalidRequest},
+	{service.ErrInvalidPasswordFormat, http.StatusBadRequest, CodeInvalidRequest},
+	{service.ErrInvalidCredentials, http.StatusUnauthorized, CodeInvalidCredentials},
+	{repository.ErrLoginAlreadyExists, http.StatusConflict, CodeUserExists},
+}
+
+// grpcCodes - ответ на gRPC статус, который сервис не перевел в свою ошибку.
+var grpcCodes = map[codes.Code]struct {
+	status  int
+	code    Code
+	message string
+}{
+	codes.InvalidArgument:    {http.StatusBadRequest, CodeInvalidRequest, ""},
+	codes.NotFound:           {http.StatusNotFound, CodeNotFound, ""},
+	codes.AlreadyExists:      {http.StatusConflict, CodeConflict, ""},
+	codes.FailedPrecondition: {http.StatusConflict, CodeConflict, ""},
+	codes.PermissionDenied:   {http.StatusForbidden, CodeForbidden, ""},
+	codes.Unauthenticated:    {http.StatusUnauthorized, CodeUnauthorized, ""},
+	codes.ResourceExhausted:  {http.StatusTooManyRequests, CodeServiceOverloaded, service.ErrServiceOverloaded.Error()},
+	codes.Unavailable:        {http.StatusServiceUnavailable, CodeServiceUnavailable, "Сервис вычислений недоступен, повторите попытку позже"},
+	codes.DeadlineExceeded:   {http.StatusGatewayTimeout, CodeTimeout, "Сервис вычислений не ответил вовремя"},
+}
+
+// FromError переводит ошибку сервиса в ответ клиенту. Неизвестные ошибки становятся INTERNAL_ERROR
+// без исходного текста, чтобы не раскрывать внутренние детали.
+func FromError(err error) *Error {
+	var quotaErr *service.QuotaExceededError
+	if errors.As(err, &quotaErr) {
+		apiErr := New(http.StatusTooManyRequests, CodeQuotaExceeded, quotaErr.Message)
+		if quotaErr.RetryAfter > 0 {
+			apiErr.WithRetryAfter(quotaErr.RetryAfter)
+		}
+		return apiErr
+	}
+	var rejected *service.ExpressionRejectedError
+	if errors.As(err, &rejected) {
+		switch rejected.Reason {
+		case service.ExpressionReasonParseError:
+			return New(http.StatusBadRequest, CodeExpressionParseError, rejected.Error())
+		case service.ExpressionReasonLimitExceeded:
+			return New(http.StatusBadRequest, CodeExpressionLimitExceeded, rejected.Error()).WithDetail("limit", rejected.Limit)
+		}
+		return New(http.StatusBadRequest, CodeExpressionRejected, rejected.Error())
+	}
+	st, isGRPC := status.FromError(err)
+	for _, sentinel := range sentinels {
+		if errors.Is(err, sentinel.err) {
+			// Обернутый gRPC статус не показывается клиенту: достаточно текста самой ошибки сервиса.
+			if isGRPC {
+				return New(sentinel.status, sentinel.code, sentinel.err.Error())
+			}
+			return New(sentinel.status, sentinel.code, err.Error())
+		}
+	}
+	if isGRPC {
+		if mapped, known := grpcCodes[st.Code()]; known {
+			message := mapped.message
+			if message == "" {
+				message = st.Message()
+			}
+			return New(mapped.status, mapped.code, message)
+		}
+	}
+	return Internal()
+}
+
+// HTTPErrorHandler отдает в едином формате и ошибки самого Echo: неизвестный маршрут, неподдерживаемый метод,
+// слишком большое тело запроса.
+func HTTPErrorHandler(log *zap.Logger) echo.HTTPErrorHandler {
+	return func(err error, c echo.Context) {
+		if c.Response().Committed {
+			return
+		}
+		apiErr := FromError(err)
+		var httpErr *echo.HTTPError
+		if errors.As(err, &httpErr) {
+			apiErr = fromHTTPError(httpErr)
+		}
+		if apiErr.Status >= http.StatusInternalServerError {
+			log.Error("Необработанная ошибка HTTP запроса", zap.String("path", c.Path()), zap.Error(err))
+		}
+
+		if c.Request().Method == http.MethodHead {
+			err = c.NoContent(apiErr.Status)
+		} else {
+			err = Respond(c, apiErr)
+		}
+		if err != nil {
+			log.Error("Не удалось отправить ответ с ошибкой", zap.Error(err))
+		}
+	}
+}
+
+func fromHTTPError(httpErr *echo.HTTPError) *Error {
+	message := http.StatusText(httpErr.Code)
+	if text, ok := httpErr.Message.(string); ok {
+		message = text
+	}
+	switch httpErr.Code {
+	case http.StatusNotFound:
+		return New(httpErr.Code, CodeNotFound, message)
+	case http.StatusMethodNotAllowed:
+		return New(httpErr.Code, CodeMethodNotAllowed, message)
+	case http.StatusRequestEntityTooLarge:
+		return New(httpErr.Code, CodePayloadTooLarge, message)
+	case http.StatusUnauthorized:
+		return New(httpErr.Code, CodeUnauthorized, message)
+	case http.StatusForbidden:
+		return New(httpErr.Code, CodeForbidden, message)
+	case http.StatusTooManyRequests:
+		return New(httpErr.Code, CodeRateLimited, message)
+	case http.StatusServiceUnavailable:
+		return New(httpErr.Code, CodeServiceUnavailable, message)
+	}
+	if httpErr.Code >= http.StatusInternalServerError {
+		return Internal()
+	}
+	return New(httpErr.Code, CodeInvalidRequest, message)
+}
@@ -4,27 +4,35 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"net"
 	"net/http"
 	"os"
 	"time"
 
 	"github.com/Qu1nel/YaLyceum-GoProject-Final/docs/agent_api"
+	"github.com/Qu1nel/YaLyceum-GoProject-Final/internal/agent/apierror"
 	"github.com/Qu1nel/YaLyceum-GoProject-Final/internal/agent/client"
 	"github.com/Qu1nel/YaLyceum-GoProject-Final/internal/agent/config"
 	"github.com/Qu1nel/YaLyceum-GoProject-Final/internal/agent/handler"
 	"github.com/Qu1nel/YaLyceum-GoProject-Final/internal/agent/middleware"
+	"github.com/Qu1nel/YaLyceum-GoProject-Final/internal/agent/ratelimit"
 	"github.com/Qu1nel/YaLyceum-GoProject-Final/internal/agent/repository"
 	"github.com/Qu1nel/YaLyceum-GoProject-Final/internal/agent/service"
 	"github.com/Qu1nel/YaLyceum-GoProject-Final/internal/pkg/hasher"
 	"github.com/Qu1nel/YaLyceum-GoProject-Final/internal/pkg/jwtauth"
 	"github.com/Qu1nel/YaLyceum-GoProject-Final/internal/pkg/logger"
+	"github.com/Qu1nel/YaLyceum-GoProject-Final/internal/pkg/metrics"
 	"github.com/Qu1nel/YaLyceum-GoProject-Final/internal/pkg/postgres"
+	"github.com/Qu1nel/YaLyceum-GoProject-Final/internal/pkg/requestid"
 	"github.com/Qu1nel/YaLyceum-GoProject-Final/internal/pkg/shutdown"
+	"github.com/Qu1nel/YaLyceum-GoProject-Final/internal/pkg/tracing"
 
 	"github.com/jackc/pgx/v5/pgxpool"
 	"github.com/labstack/echo/v4"
 	echomiddleware "github.com/labstack/echo/v4/middleware"
+	"github.com/prometheus/client_golang/prometheus"
 	echoSwagger "github.com/swaggo/echo-swagger"
+	"go.opentelemetry.io/contrib/instrumentation/github.com/labstack/echo/otelecho"
 	"go.uber.org/fx"
 	"go.uber.org/zap"
 	"golang.org/x/crypto/bcrypt"
@@ -125,31 +133,68 @@ func Run() {
 				log.Info("JWT менеджер успешно создан", zap.Duration("token_ttl", cfg.JWT.TokenTTL))
 				return manager, nil
 			},
+			func(cfg *config.Config, pool *pgxpool.Pool) ratelimit.Store {
+				if cfg.RateLimit.Backend == config.RateLimitBackendPostgres {
+					return ratelimit.NewPostgresStore(pool)
+				}
+				return ratelimit.NewMemoryStore()
+			},
 			middleware.JWTAuth,
 			client.NewOrchestratorServiceClient,
 			repository.NewPgxUserRepository,
 			service.NewAuthService,
 			service.NewTaskService,
+			service.NewWebhookService,
+			service.NewAdminService,
 			handler.NewAuthHandler,
 			handler.NewTaskHandler,
+			handler.NewWebhookHandler,
+			handler.NewAdminHandler,
 			NewEchoServer,
 		),
 
+		fx.Invoke(func(lc fx.Lifecycle, cfg *config.Config, log *zap.Logger) error {
+			provider, err := tracing.Setup(appCtx, "agent", cfg.Tracing)
+			if err != nil {
+				log.Error("Не удалось настроить трассировку Агента", zap.Error(err))
+				return err
+			}
+			log.Info("Трассировка Агента настроена", zap.String("exporter", cfg.Tracing.Exporter))
+			lc.Append(fx.Hook{
+				OnStop: func(ctx context.Context) error {
+					return provider.Shutdown(ctx)
+				},
+			})
+			return nil
+		}),
 		fx.Invoke(func(lc fx.Lifecycle, e *echo.Echo, cfg *config.Config, log *zap.Logger,
 			pool *pgxpool.Pool,
 			authHandler *handler.AuthHandler,
 			taskHandler *handler.TaskHandler,
+			webhookHandler *handler.WebhookHandler,
+			adminHandler *handler.AdminHandler,
+			userRepo repository.UserRepository,
 			jwtAuthMiddleware echo.MiddlewareFunc,
+			rateLimitStore ratelimit.Store,
 		) {
 
 			apiV1 := e.Group("/api/v1")
+			rateLimitPolicy := ratelimit.Policy{
+				Default:  cfg.RateLimit.Default,
+				Routes:   cfg.RateLimit.Routes,
+				BasePath: "/api/v1",
+			}
 
-			authHandler.RegisterRoutes(apiV1)
+			authHandler.RegisterRoutes(apiV1, middleware.RateLimitByIP(rateLimitStore, rateLimitPolicy, log))
 
 			protectedGroup := apiV1.Group("")
-			protectedGroup.Use(jwtAuthMiddleware)
+			protectedGroup.Use(jwtAuthMiddleware, middleware.RateLimitByUser(rateLimitStore, rateLimitPolicy, log))
 
 			taskHandler.RegisterRoutes(protectedGroup)
+			webhookHandler.RegisterRoutes(protectedGroup)
+
+			adminGroup := protectedGroup.Group("/admin", middleware.AdminOnly(userRepo, log))
+			adminHandler.RegisterRoutes(adminGroup)
 
 			agent_api.SwaggerInfo.Title = "API Калькулятора Выражений - Agent"
 			agent_api.SwaggerInfo.Description = "Документация API для Agent сервиса."
@@ -159,6 +204,7 @@ func Run() {
 			agent_api.SwaggerInfo.Schemes = []string{"http", "https"}
 
 			e.GET("/swagger/*", echoSwagger.WrapHandler)
+
 			log.Info("Swagger UI доступен по /swagger/index.html", zap.String("host", agent_api.SwaggerInfo.Host))
 
 			httpServer := &http.Server{
@@ -168,6 +214,7 @@ func Run() {
 				WriteTimeout: 10 * time.Second,
 				IdleTimeout:  60 * time.Second,
 			}
+			httpServer.RegisterOnShutdown(taskHandler.CloseStreams)
 
 			lc.Append(fx.Hook{
 
@@ -198,6 +245,40 @@ func Run() {
 				},
 			})
 
+			sweepCtx, stopSweep := context.WithCancel(appCtx)
+			lc.Append(fx.Hook{
+				OnStart: func(ctx context.Context) error {
+					log.Info("Запуск очистки корзин лимита запросов",
+						zap.String("backend", cfg.RateLimit.Backend),
+						zap.Duration("interval", cfg.RateLimit.SweepInterval),
+					)
+					go ratelimit.RunSweeper(sweepCtx, rateLimitStore, cfg.RateLimit.SweepInterval, rateLimitPolicy.LongestPeriod(), log)
+					return nil
+				},
+				OnStop: func(ctx context.Context) error {
+					stopSweep()
+					return nil
+				},
+			})
+
+			// Метрики раскрывают маршруты и нагрузку, поэтому отдаются на отдельном внутреннем порту, а не на публичном API.
+			prometheus.MustRegister(metrics.NewPgxPoolCollector(pool))
+			metricsServer := metrics.NewServer(cfg.Server.MetricsPort)
+			lc.Append(fx.Hook{
+				OnStart: func(ctx context.Context) error {
+					log.Info("Запуск HTTP сервера метрик Agent", zap.String("адрес", metricsServer.Addr))
+					go func() {
+						if err := metricsServer.ListenAndServe(); err != nil && !errors.Is(err, http.ErrServerClosed) {
+							log.Error("HTTP сервер метрик Agent неожиданно завершил работу", zap.Error(err))
+						}
+					}()
+					return nil
+				},
+				OnStop: func(ctx context.Context) error {
+					return metricsServer.Shutdown(ctx)
+				},
+			})
+
 			serversToStop := map[string]func(context.Context) error{
 				"http": httpServer.Shutdown,
 			}
@@ -224,13 +305,35 @@ func Run() {
 	log.Info("Сервис Agent успешно завершил работу.")
 }
 
+// newIPExtractor определяет IP клиента для лимита по IP. Без TRUSTED_PROXIES берется адрес соединения:
+// X-Forwarded-For задает сам клиент, и доверие к нему позволило бы обойти лимит.
+// Заголовок учитывается только от прокси из TRUSTED_PROXIES.
+func newIPExtractor(trustedProxies []*net.IPNet) echo.IPExtractor {
+	if len(trustedProxies) == 0 {
+		return echo.ExtractIPDirect()
+	}
+	options := []echo.TrustOption{echo.TrustLoopback(false), echo.TrustLinkLocal(false), echo.TrustPrivateNet(false)}
+	for _, proxy := range trustedProxies {
+		options = append(options, echo.TrustIPRange(proxy))
+	}
+	return echo.ExtractIPFromXFFHeader(options...)
+}
+
 func NewEchoServer(log *zap.Logger, cfg *config.Config) *echo.Echo {
 	e := echo.New()
 	e.HideBanner = true
 	e.HidePort = true
+	e.IPExtractor = newIPExtractor(cfg.Server.TrustedProxies)
+	e.HTTPErrorHandler = apierror.HTTPErrorHandler(log)
 
 	e.Use(echomiddleware.RequestID())
 
+	e.Use(otelecho.Middleware("agent"))
+
+	e.Use(requestid.EchoMiddleware(log))
+
+	e.Use(metrics.EchoMiddleware())
+
 	e.Use(RequestZapLogger(log))
 
 	e.Use(echomiddleware.RecoverWithConfig(echomiddleware.RecoverConfig{
@@ -249,7 +352,11 @@ func NewEchoServer(log *zap.Logger, cfg *config.Config) *echo.Echo {
 
 		AllowOrigins: []string{"*"},
 		AllowMethods: []string{http.MethodGet, http.MethodPost, http.MethodPut, http.MethodDelete, http.MethodOptions},
-		AllowHeaders: []string{echo.HeaderOrigin, echo.HeaderContentType, echo.HeaderAccept, echo.HeaderAuthorization},
+		AllowHeaders: []string{echo.HeaderOrigin, echo.HeaderContentType, echo.HeaderAccept, echo.HeaderAuthorization, handler.HeaderIdempotencyKey},
+		ExposeHeaders: []string{
+			"X-Next-Page-Token", "Retry-After", handler.HeaderIdempotentReplayed,
+			middleware.HeaderRateLimitLimit, middleware.HeaderRateLimitRemaining, middleware.HeaderRateLimitReset, middleware.HeaderRateLimitPolicy,
+		},
 	}))
 
 	return e
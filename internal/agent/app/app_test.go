@@ -0,0 +1,32 @@
+package app
+
+import (
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewIPExtractor(t *testing.T) {
+	_, proxyNet, err := net.ParseCIDR("10.0.0.0/24")
+	require.NoError(t, err)
+
+	request := func(remoteAddr string) *http.Request {
+		req := httptest.NewRequest(http.MethodPost, "/login", nil)
+		req.RemoteAddr = remoteAddr
+		req.Header.Set(echo.HeaderXForwardedFor, "198.51.100.1")
+		return req
+	}
+
+	direct := newIPExtractor(nil)
+	assert.Equal(t, "127.0.0.1", direct(request("127.0.0.1:1234")), "без TRUSTED_PROXIES заголовок не учитывается даже от loopback")
+	assert.Equal(t, "10.0.0.5", direct(request("10.0.0.5:1234")))
+
+	trusted := newIPExtractor([]*net.IPNet{proxyNet})
+	assert.Equal(t, "198.51.100.1", trusted(request("10.0.0.5:1234")))
+	assert.Equal(t, "192.168.1.10", trusted(request("192.168.1.10:1234")), "приватная сеть вне TRUSTED_PROXIES не доверенная")
+}
@@ -5,8 +5,11 @@ import (
 	"fmt"
 	"time"
 
+	"github.com/Qu1nel/YaLyceum-GoProject-Final/internal/pkg/metrics"
+	"github.com/Qu1nel/YaLyceum-GoProject-Final/internal/pkg/requestid"
 	pb "github.com/Qu1nel/YaLyceum-GoProject-Final/proto/gen/orchestrator"
 
+	"go.opentelemetry.io/contrib/instrumentation/google.golang.org/grpc/otelgrpc"
 	"go.uber.org/fx"
 	"go.uber.org/zap"
 	"google.golang.org/grpc"
@@ -32,6 +35,9 @@ func NewOrchestratorServiceClient(params OrchestratorClientParams) (pb.Orchestra
 
 	opts := []grpc.DialOption{
 		grpc.WithTransportCredentials(insecure.NewCredentials()),
+		grpc.WithChainUnaryInterceptor(metrics.UnaryClientInterceptor(), requestid.UnaryClientInterceptor()),
+		grpc.WithChainStreamInterceptor(metrics.StreamClientInterceptor(), requestid.StreamClientInterceptor()),
+		grpc.WithStatsHandler(otelgrpc.NewClientHandler()),
 	}
 
 	conn, err := grpc.NewClient(params.Config.GetOrchestratorAddress(), opts...)
@@ -3,25 +3,60 @@ package config
 import (
 	"fmt"
 	"log"
+	"net"
 	"os"
 	"strings"
 	"time"
 
+	"github.com/Qu1nel/YaLyceum-GoProject-Final/internal/agent/ratelimit"
+	"github.com/Qu1nel/YaLyceum-GoProject-Final/internal/pkg/tracing"
 	"github.com/spf13/viper"
 )
 
 type Config struct {
-	AppEnv             string           `mapstructure:"APP_ENV"`
-	Server             ServerConfig     `mapstructure:",squash"`
-	Database           DatabaseConfig   `mapstructure:",squash"`
-	JWT                JWTConfig        `mapstructure:",squash"`
-	Logger             LoggerConfig     `mapstructure:",squash"`
-	GracefulTimeout    time.Duration    `mapstructure:"GRACEFUL_TIMEOUT"`
-	OrchestratorClient GRPCClientConfig `mapstructure:",squash"`
+	AppEnv             string                 `mapstructure:"APP_ENV"`
+	Server             ServerConfig           `mapstructure:",squash"`
+	Database           DatabaseConfig         `mapstructure:",squash"`
+	JWT                JWTConfig              `mapstructure:",squash"`
+	Logger             LoggerConfig           `mapstructure:",squash"`
+	GracefulTimeout    time.Duration          `mapstructure:"GRACEFUL_TIMEOUT"`
+	OrchestratorClient GRPCClientConfig       `mapstructure:",squash"`
+	Limits             ExpressionLimitsConfig `mapstructure:",squash"`
+	RateLimit          RateLimitConfig        `mapstructure:",squash"`
+	Tracing            tracing.Config         `mapstructure:",squash"`
+}
+
+// ExpressionLimitsConfig - лимиты сложности выражения для быстрой проверки до вызова Оркестратора.
+// Полная проверка (включая EXPR_MAX_NODES, EXPR_MAX_DEPTH и EXPR_MAX_LITERAL) выполняется Оркестратором.
+type ExpressionLimitsConfig struct {
+	MaxBytes int `mapstructure:"EXPR_MAX_BYTES"`
+}
+
+const (
+	RateLimitBackendMemory   = "memory"
+	RateLimitBackendPostgres = "postgres"
+)
+
+// RateLimitConfig - лимиты частоты запросов: по UserID для защищенных маршрутов и по IP для /register и /login.
+type RateLimitConfig struct {
+	Backend       string                    `mapstructure:"RATE_LIMIT_BACKEND"`
+	DefaultRaw    string                    `mapstructure:"RATE_LIMIT_DEFAULT"`
+	Default       ratelimit.Rule            `mapstructure:"-"`
+	RoutesRaw     string                    `mapstructure:"RATE_LIMIT_ROUTES"`
+	Routes        map[string]ratelimit.Rule `mapstructure:"-"`
+	SweepInterval time.Duration             `mapstructure:"RATE_LIMIT_SWEEP_INTERVAL"`
 }
 
 type ServerConfig struct {
-	Port string `mapstructure:"AGENT_HTTP_PORT"`
+	Port              string        `mapstructure:"AGENT_HTTP_PORT"`
+	MetricsPort       string        `mapstructure:"AGENT_METRICS_PORT"`
+	RetryAfter        time.Duration `mapstructure:"SUBMIT_RETRY_AFTER"`
+	SSEHeartbeatEvery time.Duration `mapstructure:"SSE_HEARTBEAT_INTERVAL"`
+	BatchMaxSize      int           `mapstructure:"SUBMIT_BATCH_MAX_SIZE"`
+	MaxWait           time.Duration `mapstructure:"SYNC_WAIT_MAX"`
+	// TrustedProxiesRaw - CIDR прокси через запятую, от которых принимается X-Forwarded-For.
+	TrustedProxiesRaw string       `mapstructure:"TRUSTED_PROXIES"`
+	TrustedProxies    []*net.IPNet `mapstructure:"-"`
 }
 
 type DatabaseConfig struct {
@@ -57,10 +92,25 @@ func Load() (*Config, error) {
 	v.SetDefault("DB_POOL_MAX_CONNS", 10)
 
 	v.SetDefault("AGENT_HTTP_PORT", "8080")
+	v.SetDefault("AGENT_METRICS_PORT", "9100")
+	v.SetDefault("SUBMIT_RETRY_AFTER", "5s")
+	v.SetDefault("SSE_HEARTBEAT_INTERVAL", "15s")
+	v.SetDefault("SUBMIT_BATCH_MAX_SIZE", 100)
+	v.SetDefault("SYNC_WAIT_MAX", "30s")
+	v.SetDefault("TRUSTED_PROXIES", "")
+	v.SetDefault("EXPR_MAX_BYTES", 4096)
+	v.SetDefault("RATE_LIMIT_BACKEND", RateLimitBackendMemory)
+	v.SetDefault("RATE_LIMIT_DEFAULT", "120/1m")
+	v.SetDefault("RATE_LIMIT_ROUTES", "POST /login=20/1m,POST /register=10/1m")
+	v.SetDefault("RATE_LIMIT_SWEEP_INTERVAL", "1m")
 	v.SetDefault("JWT_SECRET", "default_jwt_secret_please_change_32_chars_long")
 	v.SetDefault("JWT_TOKEN_TTL", "1h")
 	v.SetDefault("ORCHESTRATOR_GRPC_ADDRESS", "orchestrator_default:50051")
 	v.SetDefault("GRPC_CLIENT_TIMEOUT", "5s")
+	v.SetDefault("TRACING_EXPORTER", tracing.ExporterNone)
+	v.SetDefault("TRACING_OTLP_ENDPOINT", "localhost:4317")
+	v.SetDefault("TRACING_OTLP_INSECURE", true)
+	v.SetDefault("TRACING_SAMPLE_RATIO", 1.0)
 
 	if appEnv := os.Getenv("APP_ENV"); appEnv != "test" {
 		v.SetConfigName(".env")
@@ -88,6 +138,48 @@ func Load() (*Config, error) {
 	if cfg.Server.Port == "" {
 		return nil, fmt.Errorf("AGENT_HTTP_PORT (из env или default) не установлен")
 	}
+	if cfg.Server.MetricsPort == "" {
+		return nil, fmt.Errorf("AGENT_METRICS_PORT не может быть пустым")
+	}
+	if cfg.Server.MetricsPort == cfg.Server.Port {
+		return nil, fmt.Errorf("AGENT_METRICS_PORT должен отличаться от AGENT_HTTP_PORT: метрики не отдаются на публичном порту")
+	}
+	if cfg.Server.RetryAfter <= 0 {
+		return nil, fmt.Errorf("SUBMIT_RETRY_AFTER должен быть положительной длительностью")
+	}
+	if cfg.Server.SSEHeartbeatEvery <= 0 {
+		return nil, fmt.Errorf("SSE_HEARTBEAT_INTERVAL должен быть положительной длительностью")
+	}
+	if cfg.Server.BatchMaxSize <= 0 {
+		return nil, fmt.Errorf("SUBMIT_BATCH_MAX_SIZE должен быть положительным числом")
+	}
+	if cfg.Server.MaxWait <= 0 {
+		return nil, fmt.Errorf("SYNC_WAIT_MAX должен быть положительной длительностью")
+	}
+	trustedProxies, err := parseTrustedProxies(cfg.Server.TrustedProxiesRaw)
+	if err != nil {
+		return nil, err
+	}
+	cfg.Server.TrustedProxies = trustedProxies
+	if cfg.Limits.MaxBytes <= 0 {
+		return nil, fmt.Errorf("EXPR_MAX_BYTES должен быть положительным")
+	}
+	if cfg.RateLimit.Backend != RateLimitBackendMemory && cfg.RateLimit.Backend != RateLimitBackendPostgres {
+		return nil, fmt.Errorf("RATE_LIMIT_BACKEND должен быть '%s' или '%s', получено '%s'", RateLimitBackendMemory, RateLimitBackendPostgres, cfg.RateLimit.Backend)
+	}
+	defaultRule, err := ratelimit.ParseRule(cfg.RateLimit.DefaultRaw)
+	if err != nil {
+		return nil, fmt.Errorf("RATE_LIMIT_DEFAULT: %w", err)
+	}
+	cfg.RateLimit.Default = defaultRule
+	routes, err := ratelimit.ParseRoutes(cfg.RateLimit.RoutesRaw)
+	if err != nil {
+		return nil, fmt.Errorf("RATE_LIMIT_ROUTES: %w", err)
+	}
+	cfg.RateLimit.Routes = routes
+	if cfg.RateLimit.SweepInterval <= 0 {
+		return nil, fmt.Errorf("RATE_LIMIT_SWEEP_INTERVAL должен быть положительной длительностью")
+	}
 	if cfg.Database.DSN == "" || (os.Getenv("APP_ENV") == "test" && cfg.Database.DSN == v.GetString("POSTGRES_DSN") && os.Getenv("POSTGRES_DSN") != cfg.Database.DSN) {
 
 		return nil, fmt.Errorf("POSTGRES_DSN для Агента не установлен или равен дефолтному в тесте (текущий: '%s', ожидался из env: '%s')", cfg.Database.DSN, os.Getenv("POSTGRES_DSN"))
@@ -109,6 +201,25 @@ func Load() (*Config, error) {
 	if cfg.OrchestratorClient.Timeout <= 0 {
 		return nil, fmt.Errorf("GRPC_CLIENT_TIMEOUT для клиента Оркестратора должен быть положительным")
 	}
+	if err := cfg.Tracing.Validate(); err != nil {
+		return nil, err
+	}
 
 	return &cfg, nil
 }
+
+func parseTrustedProxies(raw string) ([]*net.IPNet, error) {
+	var proxies []*net.IPNet
+	for _, part := range strings.Split(raw, ",") {
+		part = strings.TrimSpace(part)
+		if part == "" {
+			continue
+		}
+		_, ipNet, err := net.ParseCIDR(part)
+		if err != nil {
+			return nil, fmt.Errorf("TRUSTED_PROXIES: некорректная подсеть '%s': %w", part, err)
+		}
+		proxies = append(proxies, ipNet)
+	}
+	return proxies, nil
+}
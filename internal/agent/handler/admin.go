@@ -0,0 +1,91 @@
+package handler
+
+import (
+	"net/http"
+
+	"github.com/Qu1nel/YaLyceum-GoProject-Final/internal/agent/apierror"
+	"github.com/Qu1nel/YaLyceum-GoProject-Final/internal/agent/middleware"
+	"github.com/Qu1nel/YaLyceum-GoProject-Final/internal/agent/service"
+	"github.com/Qu1nel/YaLyceum-GoProject-Final/internal/pkg/logger"
+	"github.com/google/uuid"
+
+	"github.com/labstack/echo/v4"
+	"go.uber.org/zap"
+)
+
+type ForceFailRequest struct {
+	Reason string `json:"reason,omitempty" example:"вычисление зависло после сбоя Воркера"`
+}
+
+type AdminHandler struct {
+	log          *zap.Logger
+	adminService service.AdminService
+}
+
+func NewAdminHandler(log *zap.Logger, adminService service.AdminService) *AdminHandler {
+	return &AdminHandler{log: log, adminService: adminService}
+}
+
+func (h *AdminHandler) ListEvaluations(c echo.Context) error {
+	evaluations, err := h.adminService.ListActiveEvaluations(c.Request().Context())
+	if err != nil {
+		return h.errorResponse(c, "ListEvaluations", err)
+	}
+	return c.JSON(http.StatusOK, evaluations)
+}
+
+func (h *AdminHandler) GetQueue(c echo.Context) error {
+	queueStatus, err := h.adminService.GetQueueStatus(c.Request().Context())
+	if err != nil {
+		return h.errorResponse(c, "GetQueue", err)
+	}
+	return c.JSON(http.StatusOK, queueStatus)
+}
+
+func (h *AdminHandler) GetTaskCounts(c echo.Context) error {
+	counts, err := h.adminService.GetTaskCounts(c.Request().Context())
+	if err != nil {
+		return h.errorResponse(c, "GetTaskCounts", err)
+	}
+	return c.JSON(http.StatusOK, counts)
+}
+
+func (h *AdminHandler) ForceFailTask(c echo.Context) error {
+	log := logger.FromContext(c.Request().Context(), h.log)
+	adminID, _ := middleware.GetUserIDFromContext(c)
+
+	taskIDStr := c.Param("id")
+	if _, err := uuid.Parse(taskIDStr); err != nil {
+		return apierror.Respond(c, apierror.New(http.StatusBadRequest, apierror.CodeInvalidRequest, "Невалидный формат ID задачи"))
+	}
+	var req ForceFailRequest
+	if c.Request().ContentLength != 0 {
+		if err := c.Bind(&req); err != nil {
+			return apierror.Respond(c, apierror.New(http.StatusBadRequest, apierror.CodeInvalidRequest, "Неверное тело запроса"))
+		}
+	}
+
+	log.Warn("Запрос принудительного завершения задачи администратором",
+		zap.String("adminID", adminID),
+		zap.String("taskID", taskIDStr),
+		zap.String("reason", req.Reason),
+	)
+	failed, err := h.adminService.ForceFailTask(c.Request().Context(), taskIDStr, req.Reason)
+	if err != nil {
+		return h.errorResponse(c, "ForceFailTask", err)
+	}
+	return c.JSON(http.StatusOK, failed)
+}
+
+func (h *AdminHandler) errorResponse(c echo.Context, method string, err error) error {
+	logger.FromContext(c.Request().Context(), h.log).Warn("Ошибка от AdminService", zap.String("method", method), zap.Error(err))
+	return apierror.Respond(c, apierror.FromError(err))
+}
+
+// RegisterRoutes регистрирует маршруты в группе, защищенной JWTAuth и AdminOnly.
+func (h *AdminHandler) RegisterRoutes(adminGroup *echo.Group) {
+	adminGroup.GET("/evaluations", h.ListEvaluations)
+	adminGroup.GET("/queue", h.GetQueue)
+	adminGroup.GET("/tasks/counts", h.GetTaskCounts)
+	adminGroup.POST("/tasks/:id/fail", h.ForceFailTask)
+}
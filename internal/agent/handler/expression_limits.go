@@ -0,0 +1,21 @@
+package handler
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/Qu1nel/YaLyceum-GoProject-Final/internal/agent/apierror"
+)
+
+// checkExpressionLimits - быстрая проверка длины выражения до вызова Оркестратора. Возвращает nil, если лимит не нарушен.
+// Глубину дерева проверяет только Оркестратор: по тексту ее не оценить, лишние скобки узлов не добавляют.
+func (h *TaskHandler) checkExpressionLimits(expression string) *apierror.Error {
+	if len(expression) > h.maxExpressionBytes {
+		return limitExceeded("EXPR_MAX_BYTES", fmt.Sprintf("Превышен лимит EXPR_MAX_BYTES: длина выражения %d байт, максимум %d", len(expression), h.maxExpressionBytes))
+	}
+	return nil
+}
+
+func limitExceeded(limit, message string) *apierror.Error {
+	return apierror.New(http.StatusBadRequest, apierror.CodeExpressionLimitExceeded, message).WithDetail("limit", limit)
+}
@@ -0,0 +1,23 @@
+package handler
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/Qu1nel/YaLyceum-GoProject-Final/internal/agent/apierror"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCheckExpressionLimits(t *testing.T) {
+	h := &TaskHandler{maxExpressionBytes: 4096}
+
+	// Глубина дерева у числа в 201 паре скобок равна 1: Оркестратор примет его и при EXPR_MAX_DEPTH=200.
+	redundant := strings.Repeat("(", 201) + "1" + strings.Repeat(")", 201)
+	assert.Nil(t, h.checkExpressionLimits(redundant))
+
+	apiErr := h.checkExpressionLimits(strings.Repeat("1", 4097))
+	require.NotNil(t, apiErr)
+	assert.Equal(t, apierror.CodeExpressionLimitExceeded, apiErr.Code)
+	assert.Equal(t, "EXPR_MAX_BYTES", apiErr.Details["limit"])
+}
@@ -1,121 +1,518 @@
 package handler
 
 import (
-	"errors"
 	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
 
+	"github.com/Qu1nel/YaLyceum-GoProject-Final/internal/agent/apierror"
+	"github.com/Qu1nel/YaLyceum-GoProject-Final/internal/agent/config"
 	"github.com/Qu1nel/YaLyceum-GoProject-Final/internal/agent/middleware"
 	"github.com/Qu1nel/YaLyceum-GoProject-Final/internal/agent/service"
+	"github.com/Qu1nel/YaLyceum-GoProject-Final/internal/pkg/logger"
 	"github.com/google/uuid"
 
 	"github.com/labstack/echo/v4"
 	"go.uber.org/zap"
 )
 
+const (
+	// HeaderIdempotencyKey - ключ клиента, по которому повтор POST /calculate возвращает исходную задачу.
+	HeaderIdempotencyKey = "Idempotency-Key"
+	// HeaderIdempotentReplayed выставляется в ответе на повтор запроса с тем же ключом.
+	HeaderIdempotentReplayed = "Idempotent-Replayed"
+
+	maxIdempotencyKeyLength = 255
+)
+
 type CalculateRequest struct {
-	Expression string `json:"expression" validate:"required" example:"(2+2)*4"`
+	Expression  string `json:"expression" validate:"required" example:"(2+2)*4"`
+	CallbackURL string `json:"callback_url,omitempty" example:"https://example.com/hooks/calc"`
+	// MaxEstimatedDuration - длительность вида "10s"; выражение с большей оценкой времени вычисления отклоняется.
+	// Учитывается только в POST /calculate.
+	MaxEstimatedDuration string `json:"max_estimated_duration,omitempty" example:"10s"`
 }
 
 type CalculateResponse struct {
-	TaskID string `json:"task_id" example:"a1b2c3d4-e5f6-7890-1234-567890abcdef"`
+	TaskID        string                `json:"task_id" example:"a1b2c3d4-e5f6-7890-1234-567890abcdef"`
+	QueuePosition int32                 `json:"queue_position,omitempty" example:"3"`
+	Estimate      *service.CostEstimate `json:"estimate,omitempty"`
+}
+
+type BatchCalculateRequest struct {
+	Items []CalculateRequest `json:"items"`
+}
+
+type ValidateRequest struct {
+	Expression string `json:"expression" validate:"required" example:"(2+2)*4"`
+}
+
+type RetryResponse struct {
+	TaskID        string `json:"task_id" example:"a1b2c3d4-e5f6-7890-1234-567890abcdef"`
+	AttemptNumber int32  `json:"attempt_number" example:"2"`
+	QueuePosition int32  `json:"queue_position,omitempty" example:"3"`
 }
 
 type TaskHandler struct {
-	log         *zap.Logger
-	taskService service.TaskService
+	log          *zap.Logger
+	taskService  service.TaskService
+	retryAfter   time.Duration
+	sseHeartbeat time.Duration
+	batchMaxSize int
+	maxWait      time.Duration
+
+	maxExpressionBytes int
+
+	streamsDone      chan struct{}
+	closeStreamsOnce sync.Once
 }
 
-func NewTaskHandler(log *zap.Logger, taskService service.TaskService) *TaskHandler {
+func NewTaskHandler(log *zap.Logger, taskService service.TaskService, cfg *config.Config) *TaskHandler {
 	return &TaskHandler{
-		log:         log,
-		taskService: taskService,
+		log:          log,
+		taskService:  taskService,
+		retryAfter:   cfg.Server.RetryAfter,
+		sseHeartbeat: cfg.Server.SSEHeartbeatEvery,
+		batchMaxSize: cfg.Server.BatchMaxSize,
+		maxWait:      cfg.Server.MaxWait,
+
+		maxExpressionBytes: cfg.Limits.MaxBytes,
+
+		streamsDone: make(chan struct{}),
 	}
 }
 
 func (h *TaskHandler) Calculate(c echo.Context) error {
+	log := logger.FromContext(c.Request().Context(), h.log)
 	userID, ok := middleware.GetUserIDFromContext(c)
 	if !ok {
-		h.log.Error("Не удалось получить UserID из контекста в /calculate")
-		return c.JSON(http.StatusInternalServerError, ErrorResponse{Error: "Внутренняя ошибка сервера"})
+		log.Error("Не удалось получить UserID из контекста в /calculate")
+		return apierror.Respond(c, apierror.Internal())
 	}
 
-	h.log.Info("Получен запрос на вычисление", zap.String("userID", userID))
+	log.Info("Получен запрос на вычисление", zap.String("userID", userID))
 
 	var req CalculateRequest
 	if err := c.Bind(&req); err != nil {
-		h.log.Warn("Не удалось привязать тело запроса /calculate", zap.Error(err), zap.String("userID", userID))
-		return c.JSON(http.StatusBadRequest, ErrorResponse{Error: "Неверное тело запроса"})
+		log.Warn("Не удалось привязать тело запроса /calculate", zap.Error(err), zap.String("userID", userID))
+		return apierror.Respond(c, apierror.New(http.StatusBadRequest, apierror.CodeInvalidRequest, "Неверное тело запроса"))
 	}
 
 	if req.Expression == "" {
-		return c.JSON(http.StatusBadRequest, ErrorResponse{Error: "Поле 'expression' не может быть пустым"})
+		return apierror.Respond(c, apierror.New(http.StatusBadRequest, apierror.CodeInvalidRequest, "Поле 'expression' не может быть пустым"))
+	}
+	if violation := h.checkExpressionLimits(req.Expression); violation != nil {
+		return apierror.Respond(c, violation)
+	}
+	if req.CallbackURL != "" && !isValidWebhookURL(req.CallbackURL) {
+		return apierror.Respond(c, apierror.New(http.StatusBadRequest, apierror.CodeInvalidRequest, "Поле 'callback_url' должно быть абсолютным http или https адресом во внешней сети"))
+	}
+	idempotencyKey := c.Request().Header.Get(HeaderIdempotencyKey)
+	if len(idempotencyKey) > maxIdempotencyKeyLength {
+		return apierror.Respond(c, apierror.New(http.StatusBadRequest, apierror.CodeInvalidRequest, "Заголовок Idempotency-Key длиннее "+strconv.Itoa(maxIdempotencyKeyLength)+" символов"))
+	}
+	var maxEstimatedDuration time.Duration
+	if req.MaxEstimatedDuration != "" {
+		d, err := time.ParseDuration(req.MaxEstimatedDuration)
+		if err != nil || d <= 0 {
+			return apierror.Respond(c, apierror.New(http.StatusBadRequest, apierror.CodeInvalidRequest, "Поле 'max_estimated_duration' должно быть положительной длительностью, например 10s"))
+		}
+		maxEstimatedDuration = d
+	}
+	wait, ok := h.parseWait(c)
+	if !ok {
+		return apierror.Respond(c, apierror.New(http.StatusBadRequest, apierror.CodeInvalidRequest, invalidWaitMessage))
 	}
 
-	h.log.Info("Принято выражение от пользователя",
+	log.Info("Принято выражение от пользователя",
 		zap.String("userID", userID),
 		zap.String("expression", req.Expression),
+		zap.String("idempotencyKey", idempotencyKey),
+	)
+
+	opts := service.SubmitOptions{CallbackURL: req.CallbackURL, IdempotencyKey: idempotencyKey, MaxEstimatedDuration: maxEstimatedDuration}
+	submitted, err := h.taskService.SubmitNewTask(c.Request().Context(), userID, req.Expression, opts)
+	if err != nil {
+		return h.serviceErrorResponse(c, "SubmitNewTask", err)
+	}
+
+	log.Info("Задача успешно принята к обработке",
+		zap.String("taskID", submitted.TaskID),
+		zap.Int32("queuePosition", submitted.QueuePosition),
+		zap.Bool("replayed", submitted.Replayed),
+		zap.String("userID", userID),
 	)
+	if submitted.Replayed {
+		c.Response().Header().Set(HeaderIdempotentReplayed, "true")
+	}
+
+	if wait > 0 {
+		taskDetails, err := h.waitForTask(c, userID, submitted.TaskID, wait)
+		if err != nil {
+			return h.serviceErrorResponse(c, "WaitForTask", err)
+		}
+		// Задача, не завершившаяся за время ожидания, по-прежнему только принята к обработке.
+		if taskDetails.Terminal() {
+			return c.JSON(http.StatusOK, taskDetails)
+		}
+		return c.JSON(http.StatusAccepted, taskDetails)
+	}
+	return c.JSON(http.StatusAccepted, CalculateResponse{TaskID: submitted.TaskID, QueuePosition: submitted.QueuePosition, Estimate: submitted.Estimate})
+}
+
+// CalculateBatch принимает до batchMaxSize выражений. Невалидные выражения не отклоняют весь пакет:
+// для каждого в ответе есть task_id или error.
+func (h *TaskHandler) CalculateBatch(c echo.Context) error {
+	log := logger.FromContext(c.Request().Context(), h.log)
+	userID, ok := middleware.GetUserIDFromContext(c)
+	if !ok {
+		log.Error("Не удалось получить UserID из контекста в /calculate/batch")
+		return apierror.Respond(c, apierror.Internal())
+	}
+
+	var req BatchCalculateRequest
+	if err := c.Bind(&req); err != nil {
+		log.Warn("Не удалось привязать тело запроса /calculate/batch", zap.Error(err), zap.String("userID", userID))
+		return apierror.Respond(c, apierror.New(http.StatusBadRequest, apierror.CodeInvalidRequest, "Неверное тело запроса"))
+	}
+	if len(req.Items) == 0 {
+		return apierror.Respond(c, apierror.New(http.StatusBadRequest, apierror.CodeInvalidRequest, "Поле 'items' не может быть пустым"))
+	}
+	if len(req.Items) > h.batchMaxSize {
+		return apierror.Respond(c, apierror.New(http.StatusBadRequest, apierror.CodeInvalidRequest, "Слишком много выражений в пакете, максимум "+strconv.Itoa(h.batchMaxSize)))
+	}
+
+	items := make([]service.BatchItem, 0, len(req.Items))
+	for i, item := range req.Items {
+		// Пакет со слишком большим выражением отклоняется целиком, как и пакет длиннее SUBMIT_BATCH_MAX_SIZE.
+		if violation := h.checkExpressionLimits(item.Expression); violation != nil {
+			violation.Message = "items[" + strconv.Itoa(i) + "]: " + violation.Message
+			return apierror.Respond(c, violation.WithDetail("index", i))
+		}
+		items = append(items, service.BatchItem{Expression: item.Expression, CallbackURL: item.CallbackURL})
+	}
+	log.Info("Получен пакет выражений", zap.String("userID", userID), zap.Int("count", len(items)))
 
-	taskID, err := h.taskService.SubmitNewTask(c.Request().Context(), userID, req.Expression)
+	submission, err := h.taskService.SubmitBatch(c.Request().Context(), userID, items)
 	if err != nil {
-		h.log.Error("Ошибка от TaskService при SubmitNewTask", zap.Error(err), zap.String("userID", userID))
+		return h.serviceErrorResponse(c, "SubmitBatch", err)
+	}
+
+	log.Info("Пакет выражений обработан",
+		zap.String("userID", userID),
+		zap.Int32("accepted", submission.Accepted),
+		zap.Int32("rejected", submission.Rejected),
+	)
+	return c.JSON(http.StatusAccepted, submission)
+}
+
+// Validate проверяет выражение без создания задачи. Невалидное выражение - не ошибка запроса:
+// ответ 200 с valid=false и позициями ошибок.
+func (h *TaskHandler) Validate(c echo.Context) error {
+	log := logger.FromContext(c.Request().Context(), h.log)
+	userID, ok := middleware.GetUserIDFromContext(c)
+	if !ok {
+		log.Error("Не удалось получить UserID из контекста в /validate")
+		return apierror.Respond(c, apierror.Internal())
+	}
 
-		return c.JSON(http.StatusInternalServerError, ErrorResponse{Error: err.Error()})
+	var req ValidateRequest
+	if err := c.Bind(&req); err != nil {
+		log.Warn("Не удалось привязать тело запроса /validate", zap.Error(err), zap.String("userID", userID))
+		return apierror.Respond(c, apierror.New(http.StatusBadRequest, apierror.CodeInvalidRequest, "Неверное тело запроса"))
+	}
+	if req.Expression == "" {
+		return apierror.Respond(c, apierror.New(http.StatusBadRequest, apierror.CodeInvalidRequest, "Поле 'expression' не может быть пустым"))
+	}
+	if len(req.Expression) > h.maxExpressionBytes {
+		return apierror.Respond(c, h.checkExpressionLimits(req.Expression))
 	}
 
-	h.log.Info("Задача успешно принята к обработке", zap.String("taskID", taskID), zap.String("userID", userID))
-	return c.JSON(http.StatusAccepted, CalculateResponse{TaskID: taskID})
+	validation, err := h.taskService.ValidateExpression(c.Request().Context(), userID, req.Expression)
+	if err != nil {
+		return h.serviceErrorResponse(c, "ValidateExpression", err)
+	}
+	return c.JSON(http.StatusOK, validation)
 }
 
+// GetTasks возвращает страницу задач пользователя. Курсор следующей страницы
+// передается в заголовке X-Next-Page-Token, чтобы тело ответа оставалось массивом.
 func (h *TaskHandler) GetTasks(c echo.Context) error {
+	return h.listTasks(c, false)
+}
+
+// GetTrash возвращает задачи из корзины с теми же параметрами, что и GetTasks.
+func (h *TaskHandler) GetTrash(c echo.Context) error {
+	return h.listTasks(c, true)
+}
+
+func (h *TaskHandler) listTasks(c echo.Context, deleted bool) error {
+	log := logger.FromContext(c.Request().Context(), h.log)
 	userID, ok := middleware.GetUserIDFromContext(c)
 	if !ok {
-		h.log.Error("Не удалось получить UserID из контекста в /tasks")
-		return c.JSON(http.StatusInternalServerError, ErrorResponse{Error: "Внутренняя ошибка сервера"})
+		log.Error("Не удалось получить UserID из контекста в /tasks")
+		return apierror.Respond(c, apierror.Internal())
+	}
+
+	query := service.TaskListQuery{
+		Deleted:     deleted,
+		Search:      c.QueryParam("search"),
+		PageToken:   c.QueryParam("page_token"),
+		CreatedFrom: c.QueryParam("created_from"),
+		CreatedTo:   c.QueryParam("created_to"),
+		SortOrder:   c.QueryParam("sort"),
+	}
+	if v := c.QueryParam("page_size"); v != "" {
+		pageSize, err := strconv.ParseInt(v, 10, 32)
+		if err != nil || pageSize < 1 {
+			return apierror.Respond(c, apierror.New(http.StatusBadRequest, apierror.CodeInvalidRequest, "Параметр 'page_size' должен быть положительным числом"))
+		}
+		query.PageSize = int32(pageSize)
+	}
+	if v := c.QueryParam("status"); v != "" {
+		query.Statuses = strings.Split(v, ",")
 	}
 
-	h.log.Info("Запрос списка задач для пользователя", zap.String("userID", userID))
-	tasks, err := h.taskService.GetUserTasks(c.Request().Context(), userID)
+	log.Info("Запрос списка задач для пользователя", zap.String("userID", userID), zap.Bool("trash", deleted))
+	page, err := h.taskService.GetUserTasks(c.Request().Context(), userID, query)
 	if err != nil {
-		h.log.Error("Ошибка от TaskService при GetUserTasks", zap.Error(err), zap.String("userID", userID))
-		return c.JSON(http.StatusInternalServerError, ErrorResponse{Error: err.Error()})
+		return h.serviceErrorResponse(c, "GetUserTasks", err)
 	}
 
-	if tasks == nil {
-		tasks = []service.TaskListItem{}
+	if page.NextPageToken != "" {
+		c.Response().Header().Set("X-Next-Page-Token", page.NextPageToken)
 	}
 
-	return c.JSON(http.StatusOK, tasks)
+	return c.JSON(http.StatusOK, page.Tasks)
 }
 
 func (h *TaskHandler) GetTaskByID(c echo.Context) error {
+	log := logger.FromContext(c.Request().Context(), h.log)
 	userID, ok := middleware.GetUserIDFromContext(c)
 	if !ok {
-		h.log.Error("Не удалось получить UserID из контекста в /tasks/{id}")
-		return c.JSON(http.StatusInternalServerError, ErrorResponse{Error: "Внутренняя ошибка сервера"})
+		log.Error("Не удалось получить UserID из контекста в /tasks/{id}")
+		return apierror.Respond(c, apierror.Internal())
 	}
 
 	taskIDStr := c.Param("id")
 	if _, err := uuid.Parse(taskIDStr); err != nil {
-		h.log.Warn("Запрос деталей задачи с невалидным форматом ID", zap.String("taskID_str", taskIDStr), zap.Error(err))
-		return c.JSON(http.StatusBadRequest, ErrorResponse{Error: "Невалидный формат ID задачи"})
+		log.Warn("Запрос деталей задачи с невалидным форматом ID", zap.String("taskID_str", taskIDStr), zap.Error(err))
+		return apierror.Respond(c, apierror.New(http.StatusBadRequest, apierror.CodeInvalidRequest, "Невалидный формат ID задачи"))
 	}
 
-	h.log.Info("Запрос деталей задачи", zap.String("userID", userID), zap.String("taskID", taskIDStr))
-	taskDetails, err := h.taskService.GetTaskDetails(c.Request().Context(), userID, taskIDStr)
+	wait, ok := h.parseWait(c)
+	if !ok {
+		return apierror.Respond(c, apierror.New(http.StatusBadRequest, apierror.CodeInvalidRequest, invalidWaitMessage))
+	}
+
+	log.Info("Запрос деталей задачи", zap.String("userID", userID), zap.String("taskID", taskIDStr), zap.Duration("wait", wait))
+	var taskDetails *service.TaskDetails
+	var err error
+	if wait > 0 {
+		taskDetails, err = h.waitForTask(c, userID, taskIDStr, wait)
+	} else {
+		taskDetails, err = h.taskService.GetTaskDetails(c.Request().Context(), userID, taskIDStr)
+	}
 	if err != nil {
-		h.log.Warn("Ошибка от TaskService при GetTaskDetails", zap.Error(err), zap.String("userID", userID), zap.String("taskID", taskIDStr))
-		if errors.Is(err, service.ErrTaskNotFound) {
-			return c.JSON(http.StatusNotFound, ErrorResponse{Error: err.Error()})
-		}
-		return c.JSON(http.StatusInternalServerError, ErrorResponse{Error: err.Error()})
+		return h.serviceErrorResponse(c, "GetTaskDetails", err)
 	}
 
 	return c.JSON(http.StatusOK, taskDetails)
 }
 
+func (h *TaskHandler) RetryTask(c echo.Context) error {
+	log := logger.FromContext(c.Request().Context(), h.log)
+	userID, ok := middleware.GetUserIDFromContext(c)
+	if !ok {
+		log.Error("Не удалось получить UserID из контекста в /tasks/{id}/retry")
+		return apierror.Respond(c, apierror.Internal())
+	}
+
+	taskIDStr := c.Param("id")
+	if _, err := uuid.Parse(taskIDStr); err != nil {
+		log.Warn("Запрос перезапуска задачи с невалидным форматом ID", zap.String("taskID_str", taskIDStr), zap.Error(err))
+		return apierror.Respond(c, apierror.New(http.StatusBadRequest, apierror.CodeInvalidRequest, "Невалидный формат ID задачи"))
+	}
+
+	log.Info("Запрос перезапуска задачи", zap.String("userID", userID), zap.String("taskID", taskIDStr))
+	retried, err := h.taskService.RetryTask(c.Request().Context(), userID, taskIDStr)
+	if err != nil {
+		return h.serviceErrorResponse(c, "RetryTask", err)
+	}
+
+	log.Info("Задача поставлена на повторное вычисление",
+		zap.String("taskID", retried.TaskID),
+		zap.Int32("attemptNumber", retried.AttemptNumber),
+		zap.String("userID", userID),
+	)
+	return c.JSON(http.StatusAccepted, RetryResponse{
+		TaskID:        retried.TaskID,
+		AttemptNumber: retried.AttemptNumber,
+		QueuePosition: retried.QueuePosition,
+	})
+}
+
+func (h *TaskHandler) DeleteTask(c echo.Context) error {
+	log := logger.FromContext(c.Request().Context(), h.log)
+	userID, ok := middleware.GetUserIDFromContext(c)
+	if !ok {
+		log.Error("Не удалось получить UserID из контекста в DELETE /tasks/{id}")
+		return apierror.Respond(c, apierror.Internal())
+	}
+
+	taskIDStr := c.Param("id")
+	if _, err := uuid.Parse(taskIDStr); err != nil {
+		return apierror.Respond(c, apierror.New(http.StatusBadRequest, apierror.CodeInvalidRequest, "Невалидный формат ID задачи"))
+	}
+	purge := false
+	if v := c.QueryParam("purge"); v != "" {
+		var err error
+		if purge, err = strconv.ParseBool(v); err != nil {
+			return apierror.Respond(c, apierror.New(http.StatusBadRequest, apierror.CodeInvalidRequest, "Параметр 'purge' должен быть true или false"))
+		}
+	}
+
+	log.Info("Запрос удаления задачи", zap.String("userID", userID), zap.String("taskID", taskIDStr), zap.Bool("purge", purge))
+	if err := h.taskService.DeleteTask(c.Request().Context(), userID, taskIDStr, purge); err != nil {
+		return h.serviceErrorResponse(c, "DeleteTask", err)
+	}
+	return c.NoContent(http.StatusNoContent)
+}
+
+func (h *TaskHandler) RestoreTask(c echo.Context) error {
+	log := logger.FromContext(c.Request().Context(), h.log)
+	userID, ok := middleware.GetUserIDFromContext(c)
+	if !ok {
+		log.Error("Не удалось получить UserID из контекста в /tasks/{id}/restore")
+		return apierror.Respond(c, apierror.Internal())
+	}
+
+	taskIDStr := c.Param("id")
+	if _, err := uuid.Parse(taskIDStr); err != nil {
+		return apierror.Respond(c, apierror.New(http.StatusBadRequest, apierror.CodeInvalidRequest, "Невалидный формат ID задачи"))
+	}
+
+	log.Info("Запрос восстановления задачи", zap.String("userID", userID), zap.String("taskID", taskIDStr))
+	if err := h.taskService.RestoreTask(c.Request().Context(), userID, taskIDStr); err != nil {
+		return h.serviceErrorResponse(c, "RestoreTask", err)
+	}
+	return c.JSON(http.StatusOK, map[string]string{"message": "Задача восстановлена из корзины"})
+}
+
+func (h *TaskHandler) GetTaskTrace(c echo.Context) error {
+	log := logger.FromContext(c.Request().Context(), h.log)
+	userID, ok := middleware.GetUserIDFromContext(c)
+	if !ok {
+		log.Error("Не удалось получить UserID из контекста в /tasks/{id}/trace")
+		return apierror.Respond(c, apierror.Internal())
+	}
+
+	taskIDStr := c.Param("id")
+	if _, err := uuid.Parse(taskIDStr); err != nil {
+		return apierror.Respond(c, apierror.New(http.StatusBadRequest, apierror.CodeInvalidRequest, "Невалидный формат ID задачи"))
+	}
+
+	log.Info("Запрос трассировки задачи", zap.String("userID", userID), zap.String("taskID", taskIDStr))
+	trace, err := h.taskService.GetTaskTrace(c.Request().Context(), userID, taskIDStr)
+	if err != nil {
+		return h.serviceErrorResponse(c, "GetTaskTrace", err)
+	}
+	return c.JSON(http.StatusOK, trace)
+}
+
+// GetTaskAST отдает дерево выражения в JSON, а с ?format=dot - только граф в формате Graphviz DOT.
+func (h *TaskHandler) GetTaskAST(c echo.Context) error {
+	log := logger.FromContext(c.Request().Context(), h.log)
+	userID, ok := middleware.GetUserIDFromContext(c)
+	if !ok {
+		log.Error("Не удалось получить UserID из контекста в /tasks/{id}/ast")
+		return apierror.Respond(c, apierror.Internal())
+	}
+
+	taskIDStr := c.Param("id")
+	if _, err := uuid.Parse(taskIDStr); err != nil {
+		return apierror.Respond(c, apierror.New(http.StatusBadRequest, apierror.CodeInvalidRequest, "Невалидный формат ID задачи"))
+	}
+	format := c.QueryParam("format")
+	if format != "" && format != "json" && format != "dot" {
+		return apierror.Respond(c, apierror.New(http.StatusBadRequest, apierror.CodeInvalidRequest, "Параметр format должен быть json или dot"))
+	}
+
+	log.Info("Запрос дерева выражения задачи", zap.String("userID", userID), zap.String("taskID", taskIDStr), zap.String("format", format))
+	taskAST, err := h.taskService.GetTaskAST(c.Request().Context(), userID, taskIDStr)
+	if err != nil {
+		return h.serviceErrorResponse(c, "GetTaskAST", err)
+	}
+	if format == "dot" {
+		return c.Blob(http.StatusOK, "text/vnd.graphviz; charset=utf-8", []byte(taskAST.Dot))
+	}
+	return c.JSON(http.StatusOK, taskAST)
+}
+
+// GetStats отдает сводку по задачам пользователя для дашборда во фронтенде.
+func (h *TaskHandler) GetStats(c echo.Context) error {
+	log := logger.FromContext(c.Request().Context(), h.log)
+	userID, ok := middleware.GetUserIDFromContext(c)
+	if !ok {
+		log.Error("Не удалось получить UserID из контекста в /stats")
+		return apierror.Respond(c, apierror.Internal())
+	}
+
+	stats, err := h.taskService.GetUserStats(c.Request().Context(), userID)
+	if err != nil {
+		return h.serviceErrorResponse(c, "GetUserStats", err)
+	}
+	return c.JSON(http.StatusOK, stats)
+}
+
+// GetUsage отдает потребление пользователя за текущие сутки и месяц и остаток квот.
+func (h *TaskHandler) GetUsage(c echo.Context) error {
+	log := logger.FromContext(c.Request().Context(), h.log)
+	userID, ok := middleware.GetUserIDFromContext(c)
+	if !ok {
+		log.Error("Не удалось получить UserID из контекста в /usage")
+		return apierror.Respond(c, apierror.Internal())
+	}
+
+	usage, err := h.taskService.GetUsage(c.Request().Context(), userID)
+	if err != nil {
+		return h.serviceErrorResponse(c, "GetUsage", err)
+	}
+	return c.JSON(http.StatusOK, usage)
+}
+
+// serviceErrorResponse отвечает на ошибку TaskService. Перегрузку сервиса вычислений дополняет Retry-After,
+// исчерпанная квота приходит с Retry-After до ее обновления.
+func (h *TaskHandler) serviceErrorResponse(c echo.Context, method string, err error) error {
+	log := logger.FromContext(c.Request().Context(), h.log)
+	apiErr := apierror.FromError(err)
+	switch apiErr.Code {
+	case apierror.CodeInternal:
+		log.Error("Ошибка от TaskService", zap.String("method", method), zap.Error(err))
+	case apierror.CodeServiceOverloaded:
+		log.Warn("Сервис вычислений перегружен, запрос отклонен", zap.String("method", method))
+		apiErr.WithRetryAfter(h.retryAfter)
+	default:
+		log.Warn("Ошибка от TaskService", zap.String("method", method), zap.String("code", string(apiErr.Code)), zap.Error(err))
+	}
+	return apierror.Respond(c, apiErr)
+}
+
 func (h *TaskHandler) RegisterRoutes(protectedGroup *echo.Group) {
 	protectedGroup.POST("/calculate", h.Calculate)
+	protectedGroup.POST("/calculate/batch", h.CalculateBatch)
+	protectedGroup.POST("/validate", h.Validate)
 	protectedGroup.GET("/tasks", h.GetTasks)
+	protectedGroup.GET("/tasks/trash", h.GetTrash)
 	protectedGroup.GET("/tasks/:id", h.GetTaskByID)
+	protectedGroup.DELETE("/tasks/:id", h.DeleteTask)
+	protectedGroup.POST("/tasks/:id/retry", h.RetryTask)
+	protectedGroup.POST("/tasks/:id/restore", h.RestoreTask)
+	protectedGroup.GET("/tasks/:id/trace", h.GetTaskTrace)
+	protectedGroup.GET("/tasks/:id/ast", h.GetTaskAST)
+	protectedGroup.GET("/tasks/:id/events", h.StreamTaskEvents)
+	protectedGroup.GET("/stats", h.GetStats)
+	protectedGroup.GET("/usage", h.GetUsage)
 }
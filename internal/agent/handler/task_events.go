@@ -0,0 +1,137 @@
+package handler
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/Qu1nel/YaLyceum-GoProject-Final/internal/agent/apierror"
+	"github.com/Qu1nel/YaLyceum-GoProject-Final/internal/agent/middleware"
+	"github.com/Qu1nel/YaLyceum-GoProject-Final/internal/agent/service"
+	"github.com/Qu1nel/YaLyceum-GoProject-Final/internal/pkg/logger"
+	"github.com/google/uuid"
+
+	"github.com/labstack/echo/v4"
+	"go.uber.org/zap"
+)
+
+// CloseStreams завершает открытые SSE потоки, чтобы остановка HTTP сервера не ждала их до таймаута.
+func (h *TaskHandler) CloseStreams() {
+	h.closeStreamsOnce.Do(func() { close(h.streamsDone) })
+}
+
+// StreamTaskEvents транслирует события задачи в формате Server-Sent Events.
+// Поток закрывается после терминального статуса задачи или при отключении клиента.
+func (h *TaskHandler) StreamTaskEvents(c echo.Context) error {
+	log := logger.FromContext(c.Request().Context(), h.log)
+	userID, ok := middleware.GetUserIDFromContext(c)
+	if !ok {
+		log.Error("Не удалось получить UserID из контекста в /tasks/{id}/events")
+		return apierror.Respond(c, apierror.Internal())
+	}
+
+	taskIDStr := c.Param("id")
+	if _, err := uuid.Parse(taskIDStr); err != nil {
+		return apierror.Respond(c, apierror.New(http.StatusBadRequest, apierror.CodeInvalidRequest, "Невалидный формат ID задачи"))
+	}
+
+	log.Info("Подписка на события задачи", zap.String("userID", userID), zap.String("taskID", taskIDStr))
+
+	ctx, cancel := context.WithCancel(c.Request().Context())
+	defer cancel()
+
+	events := make(chan service.TaskEvent)
+	watchErr := make(chan error, 1)
+	go func() {
+		watchErr <- h.taskService.WatchTask(ctx, userID, taskIDStr, func(event service.TaskEvent) error {
+			select {
+			case events <- event:
+				return nil
+			case <-ctx.Done():
+				return ctx.Err()
+			}
+		})
+	}()
+
+	heartbeat := time.NewTicker(h.sseHeartbeat)
+	defer heartbeat.Stop()
+
+	res := c.Response()
+	started := false
+	eventID := 0
+	lastStatus := ""
+
+	for {
+		select {
+		case event := <-events:
+			if !started {
+				h.startEventStream(c)
+				started = true
+			}
+			eventID++
+			name := "progress"
+			if event.Status != lastStatus {
+				name = "status"
+				lastStatus = event.Status
+			}
+			if err := writeSSE(res, eventID, name, event); err != nil {
+				log.Debug("Не удалось отправить событие задачи клиенту", zap.String("taskID", taskIDStr), zap.Error(err))
+				return nil
+			}
+			if event.Terminal() {
+				return nil
+			}
+		case err := <-watchErr:
+			if err == nil || ctx.Err() != nil {
+				return nil
+			}
+			if !started {
+				return h.serviceErrorResponse(c, "WatchTask", err)
+			}
+			log.Warn("Поток событий задачи прерван", zap.String("taskID", taskIDStr), zap.Error(err))
+			eventID++
+			_ = writeSSE(res, eventID, "error", apierror.FromError(err).Response(apierror.RequestID(c)))
+			return nil
+		case <-heartbeat.C:
+			if !started {
+				continue
+			}
+			if _, err := fmt.Fprint(res, ": keepalive\n\n"); err != nil {
+				return nil
+			}
+			res.Flush()
+		case <-h.streamsDone:
+			return nil
+		case <-ctx.Done():
+			return nil
+		}
+	}
+}
+
+func (h *TaskHandler) startEventStream(c echo.Context) {
+	res := c.Response()
+	// Поток живет дольше WriteTimeout HTTP сервера, поэтому снимаем дедлайн записи для этого соединения.
+	if err := http.NewResponseController(res).SetWriteDeadline(time.Time{}); err != nil {
+		logger.FromContext(c.Request().Context(), h.log).Debug("Не удалось снять дедлайн записи для SSE потока", zap.Error(err))
+	}
+	res.Header().Set(echo.HeaderContentType, "text/event-stream")
+	res.Header().Set(echo.HeaderCacheControl, "no-cache")
+	res.Header().Set(echo.HeaderConnection, "keep-alive")
+	res.Header().Set("X-Accel-Buffering", "no")
+	res.WriteHeader(http.StatusOK)
+	res.Flush()
+}
+
+func writeSSE(res *echo.Response, id int, event string, payload any) error {
+	data, err := json.Marshal(payload)
+	if err != nil {
+		return err
+	}
+	if _, err := fmt.Fprintf(res, "id: %d\nevent: %s\ndata: %s\n\n", id, event, data); err != nil {
+		return err
+	}
+	res.Flush()
+	return nil
+}
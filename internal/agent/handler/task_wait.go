@@ -0,0 +1,61 @@
+package handler
+
+import (
+	"context"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/Qu1nel/YaLyceum-GoProject-Final/internal/agent/service"
+	"github.com/Qu1nel/YaLyceum-GoProject-Final/internal/pkg/logger"
+
+	"github.com/labstack/echo/v4"
+	"go.uber.org/zap"
+)
+
+// waitWriteMargin - запас к дедлайну записи ответа сверх времени ожидания на запрос деталей задачи.
+const waitWriteMargin = 5 * time.Second
+
+const invalidWaitMessage = "Параметр wait должен быть неотрицательной длительностью, например 5s или 500ms"
+
+// parseWait разбирает ?wait=: длительность ("5s", "500ms") или целое число секунд.
+// Значение больше SYNC_WAIT_MAX урезается до него. false означает невалидное значение.
+func (h *TaskHandler) parseWait(c echo.Context) (time.Duration, bool) {
+	raw := c.QueryParam("wait")
+	if raw == "" {
+		return 0, true
+	}
+	wait, err := time.ParseDuration(raw)
+	if err != nil {
+		seconds, convErr := strconv.Atoi(raw)
+		if convErr != nil {
+			return 0, false
+		}
+		wait = time.Duration(seconds) * time.Second
+	}
+	if wait < 0 {
+		return 0, false
+	}
+	return min(wait, h.maxWait), true
+}
+
+// waitForTask блокирует запрос до терминального статуса задачи, истечения wait, отключения клиента
+// или остановки сервера и возвращает детали задачи.
+func (h *TaskHandler) waitForTask(c echo.Context, userID, taskID string, wait time.Duration) (*service.TaskDetails, error) {
+	// Ожидание может быть дольше WriteTimeout HTTP сервера, поэтому продлеваем дедлайн записи для этого ответа.
+	if err := http.NewResponseController(c.Response()).SetWriteDeadline(time.Now().Add(wait + waitWriteMargin)); err != nil {
+		logger.FromContext(c.Request().Context(), h.log).Debug("Не удалось продлить дедлайн записи для ожидания задачи", zap.Error(err))
+	}
+
+	ctx, cancel := context.WithCancel(c.Request().Context())
+	defer cancel()
+	go func() {
+		select {
+		case <-h.streamsDone:
+			cancel()
+		case <-ctx.Done():
+		}
+	}()
+
+	return h.taskService.WaitForTask(ctx, userID, taskID, wait)
+}
@@ -0,0 +1,180 @@
+package handler
+
+import (
+	"net/http"
+	"net/url"
+	"strconv"
+
+	"github.com/Qu1nel/YaLyceum-GoProject-Final/internal/agent/apierror"
+	"github.com/Qu1nel/YaLyceum-GoProject-Final/internal/agent/middleware"
+	"github.com/Qu1nel/YaLyceum-GoProject-Final/internal/agent/service"
+	"github.com/Qu1nel/YaLyceum-GoProject-Final/internal/pkg/logger"
+	"github.com/Qu1nel/YaLyceum-GoProject-Final/internal/pkg/netguard"
+	"github.com/google/uuid"
+
+	"github.com/labstack/echo/v4"
+	"go.uber.org/zap"
+)
+
+type CreateWebhookRequest struct {
+	URL string `json:"url" example:"https://example.com/hooks/calc"`
+}
+
+type WebhookSecretResponse struct {
+	Secret string `json:"secret" example:"whsec_3f9c..."`
+}
+
+type WebhookHandler struct {
+	log            *zap.Logger
+	webhookService service.WebhookService
+}
+
+func NewWebhookHandler(log *zap.Logger, webhookService service.WebhookService) *WebhookHandler {
+	return &WebhookHandler{log: log, webhookService: webhookService}
+}
+
+// isValidWebhookURL проверяет, что URL абсолютный, использует http или https и не указывает на localhost или внутренний IP.
+func isValidWebhookURL(raw string) bool {
+	u, err := url.Parse(raw)
+	return err == nil && (u.Scheme == "http" || u.Scheme == "https") && u.Host != "" && netguard.CheckHost(u.Hostname()) == nil
+}
+
+func (h *WebhookHandler) CreateWebhook(c echo.Context) error {
+	userID, ok := middleware.GetUserIDFromContext(c)
+	if !ok {
+		logger.FromContext(c.Request().Context(), h.log).Error("Не удалось получить UserID из контекста в POST /webhooks")
+		return apierror.Respond(c, apierror.Internal())
+	}
+
+	var req CreateWebhookRequest
+	if err := c.Bind(&req); err != nil {
+		return apierror.Respond(c, apierror.New(http.StatusBadRequest, apierror.CodeInvalidRequest, "Неверное тело запроса"))
+	}
+	if !isValidWebhookURL(req.URL) {
+		return apierror.Respond(c, apierror.New(http.StatusBadRequest, apierror.CodeInvalidRequest, "Поле 'url' должно быть абсолютным http или https адресом во внешней сети"))
+	}
+
+	endpoint, err := h.webhookService.CreateEndpoint(c.Request().Context(), userID, req.URL)
+	if err != nil {
+		return h.errorResponse(c, "CreateWebhook", err)
+	}
+	return c.JSON(http.StatusCreated, endpoint)
+}
+
+func (h *WebhookHandler) ListWebhooks(c echo.Context) error {
+	userID, ok := middleware.GetUserIDFromContext(c)
+	if !ok {
+		logger.FromContext(c.Request().Context(), h.log).Error("Не удалось получить UserID из контекста в GET /webhooks")
+		return apierror.Respond(c, apierror.Internal())
+	}
+
+	endpoints, err := h.webhookService.ListEndpoints(c.Request().Context(), userID)
+	if err != nil {
+		return h.errorResponse(c, "ListWebhooks", err)
+	}
+	return c.JSON(http.StatusOK, endpoints)
+}
+
+func (h *WebhookHandler) DeleteWebhook(c echo.Context) error {
+	userID, ok := middleware.GetUserIDFromContext(c)
+	if !ok {
+		logger.FromContext(c.Request().Context(), h.log).Error("Не удалось получить UserID из контекста в DELETE /webhooks/{id}")
+		return apierror.Respond(c, apierror.Internal())
+	}
+
+	endpointID := c.Param("id")
+	if _, err := uuid.Parse(endpointID); err != nil {
+		return apierror.Respond(c, apierror.New(http.StatusBadRequest, apierror.CodeInvalidRequest, "Невалидный формат ID webhook"))
+	}
+
+	if err := h.webhookService.DeleteEndpoint(c.Request().Context(), userID, endpointID); err != nil {
+		return h.errorResponse(c, "DeleteWebhook", err)
+	}
+	return c.NoContent(http.StatusNoContent)
+}
+
+func (h *WebhookHandler) GetSecret(c echo.Context) error {
+	return h.secret(c, false)
+}
+
+func (h *WebhookHandler) RotateSecret(c echo.Context) error {
+	return h.secret(c, true)
+}
+
+func (h *WebhookHandler) secret(c echo.Context, rotate bool) error {
+	userID, ok := middleware.GetUserIDFromContext(c)
+	if !ok {
+		logger.FromContext(c.Request().Context(), h.log).Error("Не удалось получить UserID из контекста в /webhooks/secret")
+		return apierror.Respond(c, apierror.Internal())
+	}
+
+	secret, err := h.webhookService.GetSecret(c.Request().Context(), userID, rotate)
+	if err != nil {
+		return h.errorResponse(c, "GetSecret", err)
+	}
+	return c.JSON(http.StatusOK, WebhookSecretResponse{Secret: secret})
+}
+
+func (h *WebhookHandler) ListDeliveries(c echo.Context) error {
+	userID, ok := middleware.GetUserIDFromContext(c)
+	if !ok {
+		logger.FromContext(c.Request().Context(), h.log).Error("Не удалось получить UserID из контекста в /webhooks/deliveries")
+		return apierror.Respond(c, apierror.Internal())
+	}
+
+	taskID := c.QueryParam("task_id")
+	if taskID != "" {
+		if _, err := uuid.Parse(taskID); err != nil {
+			return apierror.Respond(c, apierror.New(http.StatusBadRequest, apierror.CodeInvalidRequest, "Невалидный формат ID задачи"))
+		}
+	}
+	var limit int32
+	if v := c.QueryParam("limit"); v != "" {
+		parsed, err := strconv.ParseInt(v, 10, 32)
+		if err != nil || parsed < 1 {
+			return apierror.Respond(c, apierror.New(http.StatusBadRequest, apierror.CodeInvalidRequest, "Параметр 'limit' должен быть положительным числом"))
+		}
+		limit = int32(parsed)
+	}
+
+	deliveries, err := h.webhookService.ListDeliveries(c.Request().Context(), userID, taskID, limit)
+	if err != nil {
+		return h.errorResponse(c, "ListDeliveries", err)
+	}
+	return c.JSON(http.StatusOK, deliveries)
+}
+
+func (h *WebhookHandler) Redeliver(c echo.Context) error {
+	log := logger.FromContext(c.Request().Context(), h.log)
+	userID, ok := middleware.GetUserIDFromContext(c)
+	if !ok {
+		log.Error("Не удалось получить UserID из контекста в /webhooks/deliveries/{id}/redeliver")
+		return apierror.Respond(c, apierror.Internal())
+	}
+
+	deliveryID := c.Param("id")
+	if _, err := uuid.Parse(deliveryID); err != nil {
+		return apierror.Respond(c, apierror.New(http.StatusBadRequest, apierror.CodeInvalidRequest, "Невалидный формат ID доставки"))
+	}
+
+	log.Info("Запрос повторной доставки webhook", zap.String("userID", userID), zap.String("deliveryID", deliveryID))
+	if err := h.webhookService.Redeliver(c.Request().Context(), userID, deliveryID); err != nil {
+		return h.errorResponse(c, "Redeliver", err)
+	}
+	return c.JSON(http.StatusAccepted, map[string]string{"message": "Доставка поставлена в очередь"})
+}
+
+func (h *WebhookHandler) errorResponse(c echo.Context, method string, err error) error {
+	logger.FromContext(c.Request().Context(), h.log).Warn("Ошибка от WebhookService", zap.String("method", method), zap.Error(err))
+	return apierror.Respond(c, apierror.FromError(err))
+}
+
+func (h *WebhookHandler) RegisterRoutes(protectedGroup *echo.Group) {
+	protectedGroup.POST("/webhooks", h.CreateWebhook)
+	protectedGroup.GET("/webhooks", h.ListWebhooks)
+	protectedGroup.DELETE("/webhooks/:id", h.DeleteWebhook)
+	protectedGroup.GET("/webhooks/secret", h.GetSecret)
+	protectedGroup.POST("/webhooks/secret/rotate", h.RotateSecret)
+	protectedGroup.GET("/webhooks/deliveries", h.ListDeliveries)
+	protectedGroup.POST("/webhooks/deliveries/:id/redeliver", h.Redeliver)
+}
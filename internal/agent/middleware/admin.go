@@ -0,0 +1,43 @@
+package middleware
+
+import (
+	"context"
+	"errors"
+	"net/http"
+
+	"github.com/Qu1nel/YaLyceum-GoProject-Final/internal/agent/apierror"
+	"github.com/Qu1nel/YaLyceum-GoProject-Final/internal/agent/repository"
+	"github.com/Qu1nel/YaLyceum-GoProject-Final/internal/pkg/logger"
+	"github.com/labstack/echo/v4"
+	"go.uber.org/zap"
+)
+
+type AdminChecker interface {
+	IsAdmin(ctx context.Context, userID string) (bool, error)
+}
+
+// AdminOnly пропускает только администраторов. Ставится после JWTAuth: права читаются из БД
+// на каждый запрос, поэтому отзыв прав действует сразу, без перевыпуска токена.
+func AdminOnly(checker AdminChecker, log *zap.Logger) echo.MiddlewareFunc {
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			reqLog := logger.FromContext(c.Request().Context(), log)
+			userID, ok := GetUserIDFromContext(c)
+			if !ok {
+				reqLog.Error("AdminOnly вызван без UserID в контексте")
+				return apierror.Respond(c, apierror.Internal())
+			}
+
+			isAdmin, err := checker.IsAdmin(c.Request().Context(), userID)
+			if err != nil && !errors.Is(err, repository.ErrUserNotFound) {
+				reqLog.Error("Ошибка проверки прав администратора", zap.String("userID", userID), zap.Error(err))
+				return apierror.Respond(c, apierror.Internal())
+			}
+			if !isAdmin {
+				reqLog.Warn("Попытка доступа к административному API без прав", zap.String("userID", userID))
+				return apierror.Respond(c, apierror.New(http.StatusForbidden, apierror.CodeForbidden, "Доступ разрешен только администраторам"))
+			}
+			return next(c)
+		}
+	}
+}
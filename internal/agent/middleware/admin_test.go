@@ -0,0 +1,73 @@
+package middleware
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/Qu1nel/YaLyceum-GoProject-Final/internal/agent/apierror"
+	"github.com/Qu1nel/YaLyceum-GoProject-Final/internal/agent/repository"
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+type stubAdminChecker struct {
+	isAdmin bool
+	err     error
+}
+
+func (s stubAdminChecker) IsAdmin(context.Context, string) (bool, error) {
+	return s.isAdmin, s.err
+}
+
+func doAdminRequest(t *testing.T, checker AdminChecker, userID string) (*httptest.ResponseRecorder, bool) {
+	t.Helper()
+	e := echo.New()
+	called := false
+	e.GET("/admin/stats", func(c echo.Context) error {
+		called = true
+		return c.NoContent(http.StatusOK)
+	}, AdminOnly(checker, zap.NewNop()))
+
+	req := httptest.NewRequest(http.MethodGet, "/admin/stats", nil)
+	if userID != "" {
+		req = req.WithContext(context.WithValue(req.Context(), UserIDKey, userID))
+	}
+	rec := httptest.NewRecorder()
+	e.ServeHTTP(rec, req)
+	return rec, called
+}
+
+func TestAdminOnly(t *testing.T) {
+	tests := []struct {
+		name       string
+		checker    stubAdminChecker
+		userID     string
+		wantStatus int
+		wantCode   apierror.Code
+	}{
+		{name: "администратор", checker: stubAdminChecker{isAdmin: true}, userID: "admin", wantStatus: http.StatusOK},
+		{name: "не администратор", checker: stubAdminChecker{}, userID: "user", wantStatus: http.StatusForbidden, wantCode: apierror.CodeForbidden},
+		{name: "пользователь удален", checker: stubAdminChecker{err: repository.ErrUserNotFound}, userID: "user", wantStatus: http.StatusForbidden, wantCode: apierror.CodeForbidden},
+		{name: "ошибка проверки прав", checker: stubAdminChecker{err: errors.New("БД недоступна")}, userID: "user", wantStatus: http.StatusInternalServerError, wantCode: apierror.CodeInternal},
+		{name: "нет UserID в контексте", checker: stubAdminChecker{isAdmin: true}, wantStatus: http.StatusInternalServerError, wantCode: apierror.CodeInternal},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rec, called := doAdminRequest(t, tt.checker, tt.userID)
+
+			assert.Equal(t, tt.wantStatus, rec.Code)
+			assert.Equal(t, tt.wantStatus == http.StatusOK, called)
+			if tt.wantCode != "" {
+				var body apierror.Response
+				require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &body))
+				assert.Equal(t, tt.wantCode, body.Code)
+			}
+		})
+	}
+}
@@ -5,6 +5,7 @@ import (
 	"net/http"
 	"strings"
 
+	"github.com/Qu1nel/YaLyceum-GoProject-Final/internal/agent/apierror"
 	"github.com/Qu1nel/YaLyceum-GoProject-Final/internal/pkg/jwtauth"
 	"github.com/labstack/echo/v4"
 	"go.uber.org/zap"
@@ -14,35 +15,31 @@ type contextKey string
 
 const UserIDKey contextKey = "userID"
 
-type ErrorResponse struct {
-	Error string `json:"error"`
-}
-
 func JWTAuth(jwtManager *jwtauth.Manager, log *zap.Logger) echo.MiddlewareFunc {
 	return func(next echo.HandlerFunc) echo.HandlerFunc {
 		return func(c echo.Context) error {
 			authHeader := c.Request().Header.Get(echo.HeaderAuthorization)
 			if authHeader == "" {
 				log.Warn("Отсутствует заголовок Authorization")
-				return c.JSON(http.StatusUnauthorized, ErrorResponse{Error: "Отсутствует токен авторизации"})
+				return apierror.Respond(c, apierror.New(http.StatusUnauthorized, apierror.CodeUnauthorized, "Отсутствует токен авторизации"))
 			}
 
 			parts := strings.Split(authHeader, " ")
 			if len(parts) != 2 || strings.ToLower(parts[0]) != "bearer" {
 				log.Warn("Неверный формат заголовка Authorization", zap.String("header", authHeader))
-				return c.JSON(http.StatusUnauthorized, ErrorResponse{Error: "Неверный формат токена авторизации"})
+				return apierror.Respond(c, apierror.New(http.StatusUnauthorized, apierror.CodeUnauthorized, "Неверный формат токена авторизации"))
 			}
 
 			tokenString := parts[1]
 			if tokenString == "" {
 				log.Warn("Пустой токен в заголовке Authorization")
-				return c.JSON(http.StatusUnauthorized, ErrorResponse{Error: "Пустой токен авторизации"})
+				return apierror.Respond(c, apierror.New(http.StatusUnauthorized, apierror.CodeUnauthorized, "Пустой токен авторизации"))
 			}
 
 			userID, err := jwtManager.Verify(tokenString)
 			if err != nil {
 				log.Warn("Ошибка проверки JWT токена", zap.Error(err))
-				return c.JSON(http.StatusUnauthorized, ErrorResponse{Error: "Невалидный или истекший токен авторизации"})
+				return apierror.Respond(c, apierror.New(http.StatusUnauthorized, apierror.CodeUnauthorized, "Невалидный или истекший токен авторизации"))
 			}
 
 			reqCtx := context.WithValue(c.Request().Context(), UserIDKey, userID)
@@ -0,0 +1,77 @@
+package middleware
+
+import (
+	"fmt"
+	"math"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/Qu1nel/YaLyceum-GoProject-Final/internal/agent/apierror"
+	"github.com/Qu1nel/YaLyceum-GoProject-Final/internal/agent/ratelimit"
+	"github.com/Qu1nel/YaLyceum-GoProject-Final/internal/pkg/logger"
+	"github.com/labstack/echo/v4"
+	"go.uber.org/zap"
+)
+
+const (
+	HeaderRateLimitLimit     = "RateLimit-Limit"
+	HeaderRateLimitRemaining = "RateLimit-Remaining"
+	HeaderRateLimitReset     = "RateLimit-Reset"
+	HeaderRateLimitPolicy    = "RateLimit-Policy"
+)
+
+// RateLimitByUser ограничивает частоту запросов пользователя. Ставится после JWTAuth.
+func RateLimitByUser(store ratelimit.Store, policy ratelimit.Policy, log *zap.Logger) echo.MiddlewareFunc {
+	return rateLimit(store, policy, log, func(c echo.Context) (string, bool) {
+		userID, ok := GetUserIDFromContext(c)
+		return "user:" + userID, ok
+	})
+}
+
+// RateLimitByIP ограничивает частоту запросов с одного адреса для маршрутов без авторизации.
+func RateLimitByIP(store ratelimit.Store, policy ratelimit.Policy, log *zap.Logger) echo.MiddlewareFunc {
+	return rateLimit(store, policy, log, func(c echo.Context) (string, bool) {
+		return "ip:" + c.RealIP(), true
+	})
+}
+
+func rateLimit(store ratelimit.Store, policy ratelimit.Policy, log *zap.Logger, subject func(c echo.Context) (string, bool)) echo.MiddlewareFunc {
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			reqLog := logger.FromContext(c.Request().Context(), log)
+			key, ok := subject(c)
+			if !ok {
+				reqLog.Error("RateLimit вызван без UserID в контексте")
+				return apierror.Respond(c, apierror.Internal())
+			}
+
+			bucket, rule := policy.RuleFor(c.Request().Method, c.Path())
+			key += "|" + bucket
+			decision, err := store.Take(c.Request().Context(), key, rule)
+			if err != nil {
+				// Недоступное хранилище лимитов не должно останавливать API.
+				reqLog.Error("Ошибка проверки лимита запросов, запрос пропущен", zap.String("key", key), zap.Error(err))
+				return next(c)
+			}
+
+			header := c.Response().Header()
+			header.Set(HeaderRateLimitLimit, strconv.Itoa(decision.Limit))
+			header.Set(HeaderRateLimitRemaining, strconv.Itoa(decision.Remaining))
+			header.Set(HeaderRateLimitReset, strconv.Itoa(ceilSeconds(decision.Reset)))
+			header.Set(HeaderRateLimitPolicy, fmt.Sprintf("%d;w=%d", rule.Limit, ceilSeconds(rule.Period)))
+
+			if !decision.Allowed {
+				retryAfter := max(ceilSeconds(decision.RetryAfter), 1)
+				reqLog.Warn("Превышен лимит запросов", zap.String("key", key), zap.Int("retryAfterSec", retryAfter))
+				apiErr := apierror.New(http.StatusTooManyRequests, apierror.CodeRateLimited, fmt.Sprintf("Слишком много запросов, повторите через %d с", retryAfter))
+				return apierror.Respond(c, apiErr.WithDetail("policy", bucket).WithRetryAfter(decision.RetryAfter))
+			}
+			return next(c)
+		}
+	}
+}
+
+func ceilSeconds(d time.Duration) int {
+	return int(math.Ceil(d.Seconds()))
+}
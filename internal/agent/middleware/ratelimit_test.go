@@ -0,0 +1,93 @@
+package middleware
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/Qu1nel/YaLyceum-GoProject-Final/internal/agent/apierror"
+	"github.com/Qu1nel/YaLyceum-GoProject-Final/internal/agent/ratelimit"
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+type failingStore struct{}
+
+func (failingStore) Take(context.Context, string, ratelimit.Rule) (ratelimit.Decision, error) {
+	return ratelimit.Decision{}, errors.New("хранилище недоступно")
+}
+
+func (failingStore) Sweep(context.Context, time.Duration) error { return nil }
+
+func newRateLimitedEcho(mw echo.MiddlewareFunc) *echo.Echo {
+	e := echo.New()
+	e.IPExtractor = echo.ExtractIPDirect()
+	e.POST("/login", func(c echo.Context) error {
+		return c.NoContent(http.StatusOK)
+	}, mw)
+	return e
+}
+
+func doLogin(e *echo.Echo, remoteAddr, forwardedFor string) *httptest.ResponseRecorder {
+	req := httptest.NewRequest(http.MethodPost, "/login", nil)
+	req.RemoteAddr = remoteAddr
+	if forwardedFor != "" {
+		req.Header.Set(echo.HeaderXForwardedFor, forwardedFor)
+	}
+	rec := httptest.NewRecorder()
+	e.ServeHTTP(rec, req)
+	return rec
+}
+
+func TestRateLimitByIP_RejectsOverLimit(t *testing.T) {
+	policy := ratelimit.Policy{Default: ratelimit.Rule{Limit: 1, Period: time.Minute}}
+	e := newRateLimitedEcho(RateLimitByIP(ratelimit.NewMemoryStore(), policy, zap.NewNop()))
+
+	rec := doLogin(e, "203.0.113.7:1234", "")
+	require.Equal(t, http.StatusOK, rec.Code)
+	assert.Equal(t, "1", rec.Header().Get(HeaderRateLimitLimit))
+	assert.Equal(t, "0", rec.Header().Get(HeaderRateLimitRemaining))
+	assert.Equal(t, "60", rec.Header().Get(HeaderRateLimitReset))
+	assert.Equal(t, "1;w=60", rec.Header().Get(HeaderRateLimitPolicy))
+
+	rec = doLogin(e, "203.0.113.7:1234", "")
+	require.Equal(t, http.StatusTooManyRequests, rec.Code)
+	assert.Equal(t, "60", rec.Header().Get(echo.HeaderRetryAfter))
+	assert.Equal(t, "0", rec.Header().Get(HeaderRateLimitRemaining))
+	var body apierror.Response
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &body))
+	assert.Equal(t, apierror.CodeRateLimited, body.Code)
+	assert.Equal(t, "default", body.Details["policy"])
+
+	// Поддельный X-Forwarded-For не дает новую корзину: IP берется из соединения.
+	rec = doLogin(e, "203.0.113.7:1234", "198.51.100.1")
+	assert.Equal(t, http.StatusTooManyRequests, rec.Code)
+
+	rec = doLogin(e, "203.0.113.8:1234", "")
+	assert.Equal(t, http.StatusOK, rec.Code)
+}
+
+func TestRateLimit_StoreErrorPassesRequest(t *testing.T) {
+	policy := ratelimit.Policy{Default: ratelimit.Rule{Limit: 1, Period: time.Minute}}
+	e := newRateLimitedEcho(RateLimitByIP(failingStore{}, policy, zap.NewNop()))
+
+	for range 3 {
+		rec := doLogin(e, "203.0.113.7:1234", "")
+		assert.Equal(t, http.StatusOK, rec.Code)
+		assert.Empty(t, rec.Header().Get(HeaderRateLimitLimit))
+	}
+}
+
+func TestRateLimitByUser_WithoutUserID(t *testing.T) {
+	policy := ratelimit.Policy{Default: ratelimit.Rule{Limit: 1, Period: time.Minute}}
+	e := newRateLimitedEcho(RateLimitByUser(ratelimit.NewMemoryStore(), policy, zap.NewNop()))
+
+	rec := doLogin(e, "203.0.113.7:1234", "")
+	assert.Equal(t, http.StatusInternalServerError, rec.Code)
+}
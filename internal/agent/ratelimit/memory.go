@@ -0,0 +1,52 @@
+package ratelimit
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+type bucket struct {
+	tokens    float64
+	updatedAt time.Time
+}
+
+// memoryStore хранит корзины в памяти процесса: лимит действует отдельно на каждый экземпляр Агента.
+type memoryStore struct {
+	mu      sync.Mutex
+	buckets map[string]*bucket
+	now     func() time.Time
+}
+
+func NewMemoryStore() Store {
+	return &memoryStore{buckets: make(map[string]*bucket), now: time.Now}
+}
+
+func (s *memoryStore) Take(_ context.Context, key string, rule Rule) (Decision, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	now := s.now()
+	b, ok := s.buckets[key]
+	if !ok {
+		b = &bucket{tokens: float64(rule.Limit), updatedAt: now}
+		s.buckets[key] = b
+	}
+	var allowed bool
+	b.tokens, allowed = refill(b.tokens, now.Sub(b.updatedAt), rule)
+	b.updatedAt = now
+	return decide(rule, b.tokens, allowed), nil
+}
+
+func (s *memoryStore) Sweep(_ context.Context, idle time.Duration) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	threshold := s.now().Add(-idle)
+	for key, b := range s.buckets {
+		if b.updatedAt.Before(threshold) {
+			delete(s.buckets, key)
+		}
+	}
+	return nil
+}
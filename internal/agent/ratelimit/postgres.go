@@ -0,0 +1,58 @@
+package ratelimit
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+)
+
+type DBPoolIface interface {
+	QueryRow(ctx context.Context, sql string, args ...interface{}) pgx.Row
+	Exec(ctx context.Context, sql string, arguments ...interface{}) (pgconn.CommandTag, error)
+}
+
+// Пополнение и списание выполняются одним UPSERT, поэтому несколько экземпляров Агента
+// делят корзину без гонок. Все выражения SET вычисляются по старой строке b.
+const (
+	refilledTokensSQL = `LEAST($2::double precision, b.tokens + GREATEST(EXTRACT(EPOCH FROM NOW() - b.updated_at)::double precision, 0) * $3::double precision)`
+
+	takeTokenQuery = `
+		INSERT INTO rate_limit_buckets AS b (key, tokens, allowed, updated_at)
+		VALUES ($1, $2::double precision - 1, TRUE, NOW())
+		ON CONFLICT (key) DO UPDATE SET
+			tokens = CASE WHEN ` + refilledTokensSQL + ` >= 1 THEN ` + refilledTokensSQL + ` - 1 ELSE ` + refilledTokensSQL + ` END,
+			allowed = ` + refilledTokensSQL + ` >= 1,
+			updated_at = NOW()
+		RETURNING tokens, allowed`
+
+	sweepBucketsQuery = `DELETE FROM rate_limit_buckets WHERE updated_at < NOW() - make_interval(secs => $1)`
+)
+
+// postgresStore хранит корзины в таблице rate_limit_buckets: лимит общий для всех экземпляров Агента.
+type postgresStore struct {
+	db DBPoolIface
+}
+
+func NewPostgresStore(db DBPoolIface) Store {
+	return &postgresStore{db: db}
+}
+
+func (s *postgresStore) Take(ctx context.Context, key string, rule Rule) (Decision, error) {
+	var tokens float64
+	var allowed bool
+	err := s.db.QueryRow(ctx, takeTokenQuery, key, float64(rule.Limit), rule.tokensPerSecond()).Scan(&tokens, &allowed)
+	if err != nil {
+		return Decision{}, fmt.Errorf("ошибка списания маркера для '%s': %w", key, err)
+	}
+	return decide(rule, tokens, allowed), nil
+}
+
+func (s *postgresStore) Sweep(ctx context.Context, idle time.Duration) error {
+	if _, err := s.db.Exec(ctx, sweepBucketsQuery, idle.Seconds()); err != nil {
+		return fmt.Errorf("ошибка удаления простаивающих корзин: %w", err)
+	}
+	return nil
+}
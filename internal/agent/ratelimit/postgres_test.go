@@ -0,0 +1,71 @@
+package ratelimit
+
+import (
+	"context"
+	"errors"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/pashagolub/pgxmock/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPostgresStore_Take(t *testing.T) {
+	mock, err := pgxmock.NewPool()
+	require.NoError(t, err)
+	defer mock.Close()
+
+	store := NewPostgresStore(mock)
+	rule := Rule{Limit: 10, Period: 5 * time.Second}
+
+	mock.ExpectQuery(regexp.QuoteMeta(`INSERT INTO rate_limit_buckets AS b (key, tokens, allowed, updated_at)`)).
+		WithArgs("user:1|default", 10.0, 2.0).
+		WillReturnRows(pgxmock.NewRows([]string{"tokens", "allowed"}).AddRow(0.5, false))
+
+	decision, err := store.Take(context.Background(), "user:1|default", rule)
+
+	require.NoError(t, err)
+	assert.Equal(t, Decision{
+		Allowed:    false,
+		Limit:      10,
+		Remaining:  0,
+		Reset:      4750 * time.Millisecond,
+		RetryAfter: 250 * time.Millisecond,
+	}, decision)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestPostgresStore_TakeError(t *testing.T) {
+	mock, err := pgxmock.NewPool()
+	require.NoError(t, err)
+	defer mock.Close()
+
+	store := NewPostgresStore(mock)
+	dbErr := errors.New("connection refused")
+
+	mock.ExpectQuery(regexp.QuoteMeta(`INSERT INTO rate_limit_buckets`)).
+		WithArgs("ip:10.0.0.1|POST /login", 1.0, 1.0).
+		WillReturnError(dbErr)
+
+	_, err = store.Take(context.Background(), "ip:10.0.0.1|POST /login", Rule{Limit: 1, Period: time.Second})
+
+	assert.ErrorIs(t, err, dbErr)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestPostgresStore_Sweep(t *testing.T) {
+	mock, err := pgxmock.NewPool()
+	require.NoError(t, err)
+	defer mock.Close()
+
+	store := NewPostgresStore(mock)
+
+	mock.ExpectExec(regexp.QuoteMeta(`DELETE FROM rate_limit_buckets WHERE updated_at < NOW() - make_interval(secs => $1)`)).
+		WithArgs(3600.0).
+		WillReturnResult(pgxmock.NewResult("DELETE", 3))
+
+	require.NoError(t, store.Sweep(context.Background(), time.Hour))
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
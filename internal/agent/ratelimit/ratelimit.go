@@ -0,0 +1,150 @@
+package ratelimit
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+	"time"
+
+	"go.uber.org/zap"
+)
+
+// Rule - корзина маркеров на Limit запросов, которая полностью пополняется за Period.
+// Limit одновременно задает допустимый всплеск запросов.
+type Rule struct {
+	Limit  int
+	Period time.Duration
+}
+
+// ParseRule разбирает правило вида "10/1m": 10 запросов за минуту.
+func ParseRule(raw string) (Rule, error) {
+	limitRaw, periodRaw, ok := strings.Cut(strings.TrimSpace(raw), "/")
+	if !ok {
+		return Rule{}, fmt.Errorf("правило '%s' должно иметь вид <запросов>/<период>, например 10/1m", raw)
+	}
+	limit, err := strconv.Atoi(limitRaw)
+	if err != nil || limit <= 0 {
+		return Rule{}, fmt.Errorf("невалидное число запросов в правиле '%s'", raw)
+	}
+	period, err := time.ParseDuration(periodRaw)
+	if err != nil || period <= 0 {
+		return Rule{}, fmt.Errorf("невалидный период в правиле '%s'", raw)
+	}
+	return Rule{Limit: limit, Period: period}, nil
+}
+
+// ParseRoutes разбирает переопределения вида "POST /login=10/1m,POST /register=5/1m".
+func ParseRoutes(raw string) (map[string]Rule, error) {
+	routes := make(map[string]Rule)
+	for _, entry := range strings.Split(raw, ",") {
+		entry = strings.TrimSpace(entry)
+		if entry == "" {
+			continue
+		}
+		separator := strings.LastIndex(entry, "=")
+		if separator < 0 {
+			return nil, fmt.Errorf("запись '%s' должна иметь вид <МЕТОД> <путь>=<правило>", entry)
+		}
+		route := strings.Join(strings.Fields(entry[:separator]), " ")
+		if method, path, ok := strings.Cut(route, " "); !ok || method != strings.ToUpper(method) || !strings.HasPrefix(path, "/") {
+			return nil, fmt.Errorf("маршрут '%s' должен иметь вид <МЕТОД> <путь>, например POST /login", route)
+		}
+		rule, err := ParseRule(entry[separator+1:])
+		if err != nil {
+			return nil, err
+		}
+		routes[route] = rule
+	}
+	return routes, nil
+}
+
+func (r Rule) tokensPerSecond() float64 {
+	return float64(r.Limit) / r.Period.Seconds()
+}
+
+// Policy выбирает правило для маршрута. Маршруты без переопределения делят одну корзину Default.
+type Policy struct {
+	Default Rule
+	Routes  map[string]Rule
+	// BasePath отбрасывается от пути маршрута перед поиском переопределения.
+	BasePath string
+}
+
+// RuleFor возвращает имя корзины и правило для маршрута Echo (method и шаблон пути, например /api/v1/tasks/:id).
+func (p Policy) RuleFor(method, path string) (string, Rule) {
+	route := method + " " + strings.TrimPrefix(path, p.BasePath)
+	if rule, ok := p.Routes[route]; ok {
+		return route, rule
+	}
+	return "default", p.Default
+}
+
+// LongestPeriod - за это время любая корзина гарантированно пополняется полностью.
+func (p Policy) LongestPeriod() time.Duration {
+	longest := p.Default.Period
+	for _, rule := range p.Routes {
+		longest = max(longest, rule.Period)
+	}
+	return longest
+}
+
+// Decision - результат списания маркера и данные для заголовков RateLimit-*.
+type Decision struct {
+	Allowed   bool
+	Limit     int
+	Remaining int
+	// Reset - через сколько корзина снова будет полной.
+	Reset time.Duration
+	// RetryAfter - через сколько появится маркер для отклоненного запроса.
+	RetryAfter time.Duration
+}
+
+type Store interface {
+	// Take списывает маркер из корзины key. Корзины нет - она создается полной.
+	Take(ctx context.Context, key string, rule Rule) (Decision, error)
+	// Sweep удаляет корзины, не менявшиеся дольше idle: к этому времени они полностью пополнились
+	// и ничем не отличаются от отсутствующих.
+	Sweep(ctx context.Context, idle time.Duration) error
+}
+
+// refill пополняет корзину за прошедшее время и пытается списать один маркер.
+func refill(tokens float64, elapsed time.Duration, rule Rule) (float64, bool) {
+	tokens = min(float64(rule.Limit), tokens+max(elapsed.Seconds(), 0)*rule.tokensPerSecond())
+	if tokens >= 1 {
+		return tokens - 1, true
+	}
+	return tokens, false
+}
+
+func decide(rule Rule, tokens float64, allowed bool) Decision {
+	rate := rule.tokensPerSecond()
+	decision := Decision{
+		Allowed:   allowed,
+		Limit:     rule.Limit,
+		Remaining: int(math.Floor(tokens)),
+		Reset:     time.Duration((float64(rule.Limit) - tokens) / rate * float64(time.Second)),
+	}
+	if !allowed {
+		decision.RetryAfter = time.Duration((1 - tokens) / rate * float64(time.Second))
+	}
+	return decision
+}
+
+// RunSweeper периодически удаляет простаивающие корзины, пока не отменен ctx.
+func RunSweeper(ctx context.Context, store Store, interval, idle time.Duration, log *zap.Logger) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+		if err := store.Sweep(ctx, idle); err != nil && ctx.Err() == nil {
+			log.Error("Ошибка очистки корзин лимита запросов", zap.Error(err))
+		}
+	}
+}
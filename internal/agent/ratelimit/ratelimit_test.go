@@ -0,0 +1,126 @@
+package ratelimit
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseRule(t *testing.T) {
+	rule, err := ParseRule(" 10/1m ")
+	require.NoError(t, err)
+	assert.Equal(t, Rule{Limit: 10, Period: time.Minute}, rule)
+
+	for _, raw := range []string{"", "10", "0/1m", "-1/1m", "x/1m", "10/0s", "10/minute"} {
+		_, err := ParseRule(raw)
+		assert.Error(t, err, raw)
+	}
+}
+
+func TestParseRoutes(t *testing.T) {
+	routes, err := ParseRoutes("POST /login=5/1m, POST   /register=2/1h,")
+	require.NoError(t, err)
+	assert.Equal(t, map[string]Rule{
+		"POST /login":    {Limit: 5, Period: time.Minute},
+		"POST /register": {Limit: 2, Period: time.Hour},
+	}, routes)
+
+	routes, err = ParseRoutes("")
+	require.NoError(t, err)
+	assert.Empty(t, routes)
+
+	for _, raw := range []string{"POST /login", "/login=5/1m", "post /login=5/1m", "POST login=5/1m", "POST /login=5"} {
+		_, err := ParseRoutes(raw)
+		assert.Error(t, err, raw)
+	}
+}
+
+func TestPolicy_RuleFor(t *testing.T) {
+	policy := Policy{
+		Default:  Rule{Limit: 100, Period: time.Minute},
+		Routes:   map[string]Rule{"POST /calculate": {Limit: 10, Period: time.Minute}, "GET /tasks/:id": {Limit: 5, Period: time.Hour}},
+		BasePath: "/api/v1",
+	}
+
+	name, rule := policy.RuleFor("POST", "/api/v1/calculate")
+	assert.Equal(t, "POST /calculate", name)
+	assert.Equal(t, 10, rule.Limit)
+
+	name, rule = policy.RuleFor("GET", "/api/v1/tasks/:id")
+	assert.Equal(t, "GET /tasks/:id", name)
+	assert.Equal(t, 5, rule.Limit)
+
+	name, rule = policy.RuleFor("GET", "/api/v1/calculate")
+	assert.Equal(t, "default", name)
+	assert.Equal(t, 100, rule.Limit)
+
+	assert.Equal(t, time.Hour, policy.LongestPeriod())
+}
+
+func TestMemoryStore_TakeAndRefill(t *testing.T) {
+	now := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	store := &memoryStore{buckets: make(map[string]*bucket), now: func() time.Time { return now }}
+	rule := Rule{Limit: 3, Period: 3 * time.Second}
+	ctx := context.Background()
+
+	for remaining := 2; remaining >= 0; remaining-- {
+		decision, err := store.Take(ctx, "user:1", rule)
+		require.NoError(t, err)
+		assert.True(t, decision.Allowed)
+		assert.Equal(t, remaining, decision.Remaining)
+		assert.Equal(t, 3, decision.Limit)
+	}
+
+	decision, err := store.Take(ctx, "user:1", rule)
+	require.NoError(t, err)
+	assert.False(t, decision.Allowed)
+	assert.Equal(t, 0, decision.Remaining)
+	assert.Equal(t, time.Second, decision.RetryAfter)
+	assert.Equal(t, 3*time.Second, decision.Reset)
+
+	// Корзины разных ключей независимы.
+	decision, err = store.Take(ctx, "user:2", rule)
+	require.NoError(t, err)
+	assert.True(t, decision.Allowed)
+
+	// За полсекунды накапливается только половина маркера.
+	now = now.Add(500 * time.Millisecond)
+	decision, err = store.Take(ctx, "user:1", rule)
+	require.NoError(t, err)
+	assert.False(t, decision.Allowed)
+	assert.Equal(t, 500*time.Millisecond, decision.RetryAfter)
+
+	now = now.Add(500 * time.Millisecond)
+	decision, err = store.Take(ctx, "user:1", rule)
+	require.NoError(t, err)
+	assert.True(t, decision.Allowed)
+
+	// Корзина не наполняется выше Limit.
+	now = now.Add(time.Hour)
+	decision, err = store.Take(ctx, "user:1", rule)
+	require.NoError(t, err)
+	assert.True(t, decision.Allowed)
+	assert.Equal(t, 2, decision.Remaining)
+	assert.Equal(t, time.Second, decision.Reset)
+}
+
+func TestMemoryStore_Sweep(t *testing.T) {
+	now := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	store := &memoryStore{buckets: make(map[string]*bucket), now: func() time.Time { return now }}
+	rule := Rule{Limit: 1, Period: time.Minute}
+	ctx := context.Background()
+
+	_, err := store.Take(ctx, "old", rule)
+	require.NoError(t, err)
+	now = now.Add(2 * time.Minute)
+	_, err = store.Take(ctx, "fresh", rule)
+	require.NoError(t, err)
+
+	require.NoError(t, store.Sweep(ctx, time.Minute))
+
+	assert.NotContains(t, store.buckets, "old")
+	assert.Contains(t, store.buckets, "fresh")
+}
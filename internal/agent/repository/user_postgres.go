@@ -32,6 +32,7 @@ type User struct {
 type UserRepository interface {
 	CreateUser(ctx context.Context, login, passwordHash string) (uuid.UUID, error)
 	GetUserByLogin(ctx context.Context, login string) (*User, error)
+	IsAdmin(ctx context.Context, userID string) (bool, error)
 }
 
 type pgxUserRepository struct {
@@ -87,3 +88,20 @@ func (r *pgxUserRepository) GetUserByLogin(ctx context.Context, login string) (*
 
 	return &user, nil
 }
+
+func (r *pgxUserRepository) IsAdmin(ctx context.Context, userID string) (bool, error) {
+	query := `SELECT is_admin FROM users WHERE id = $1`
+	var isAdmin bool
+
+	err := r.pool.QueryRow(ctx, query, userID).Scan(&isAdmin)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			r.log.Debug("Пользователь не найден по ID", zap.String("userID", userID))
+			return false, ErrUserNotFound
+		}
+		r.log.Error("Не удалось проверить права администратора в БД", zap.Error(err), zap.String("userID", userID))
+		return false, fmt.Errorf("%w: не удалось запросить пользователя: %v", ErrDatabase, err)
+	}
+
+	return isAdmin, nil
+}
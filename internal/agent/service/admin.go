@@ -0,0 +1,214 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/Qu1nel/YaLyceum-GoProject-Final/internal/agent/config"
+	"github.com/Qu1nel/YaLyceum-GoProject-Final/internal/orchestrator/repository"
+	"github.com/Qu1nel/YaLyceum-GoProject-Final/internal/pkg/logger"
+	pb_orchestrator "github.com/Qu1nel/YaLyceum-GoProject-Final/proto/gen/orchestrator"
+	"go.uber.org/zap"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+var ErrInvalidAdminRequest = errors.New("невалидные параметры запроса администратора")
+
+type ActiveEvaluation struct {
+	TaskID             string    `json:"task_id"`
+	UserID             string    `json:"user_id"`
+	Expression         string    `json:"expression" example:"(2+3)*4"`
+	AttemptNumber      int32     `json:"attempt_number" example:"1"`
+	StartedAt          time.Time `json:"started_at"`
+	ElapsedSeconds     float64   `json:"elapsed_seconds" example:"3.2"`
+	InflightOperations int32     `json:"inflight_operations" example:"1"`
+	OperationsDone     int32     `json:"operations_done" example:"1"`
+	OperationsTotal    int32     `json:"operations_total" example:"2"`
+}
+
+type WorkerStatus struct {
+	Address          string    `json:"address" example:"172.18.0.4:50052"`
+	LastSeenAt       time.Time `json:"last_seen_at"`
+	Operations       int64     `json:"operations" example:"1200"`
+	FailedOperations int64     `json:"failed_operations" example:"3"`
+}
+
+type EvaluationQueueStatus struct {
+	Running       int32 `json:"running" example:"10"`
+	Waiting       int32 `json:"waiting" example:"4"`
+	MaxConcurrent int32 `json:"max_concurrent" example:"10"`
+	MaxQueued     int32 `json:"max_queued" example:"100"`
+}
+
+type OperationSlotsStatus struct {
+	Inflight    int32 `json:"inflight" example:"8"`
+	Waiting     int32 `json:"waiting" example:"12"`
+	MaxInflight int32 `json:"max_inflight" example:"8"`
+}
+
+type QueueStatus struct {
+	Evaluations EvaluationQueueStatus `json:"evaluations"`
+	Operations  OperationSlotsStatus  `json:"operations"`
+	Workers     []WorkerStatus        `json:"workers"`
+}
+
+type SystemTaskCounts struct {
+	Total    int64            `json:"total" example:"1500"`
+	ByStatus map[string]int64 `json:"by_status"`
+	Trashed  int64            `json:"trashed" example:"20"`
+}
+
+type ForceFailedTask struct {
+	TaskID         string `json:"task_id"`
+	PreviousStatus string `json:"previous_status" example:"processing"`
+	ErrorMessage   string `json:"error_message"`
+}
+
+// AdminService - операционные представления системы целиком, доступные только администраторам.
+type AdminService interface {
+	ListActiveEvaluations(ctx context.Context) ([]ActiveEvaluation, error)
+	GetQueueStatus(ctx context.Context) (*QueueStatus, error)
+	GetTaskCounts(ctx context.Context) (*SystemTaskCounts, error)
+	ForceFailTask(ctx context.Context, taskID, reason string) (*ForceFailedTask, error)
+}
+
+type adminService struct {
+	log                *zap.Logger
+	orchestratorClient pb_orchestrator.OrchestratorServiceClient
+	grpcClientTimeout  time.Duration
+}
+
+func NewAdminService(
+	log *zap.Logger,
+	orcClient pb_orchestrator.OrchestratorServiceClient,
+	cfg *config.Config,
+) AdminService {
+	return &adminService{
+		log:                log,
+		orchestratorClient: orcClient,
+		grpcClientTimeout:  cfg.OrchestratorClient.Timeout,
+	}
+}
+
+func (s *adminService) ListActiveEvaluations(ctx context.Context) ([]ActiveEvaluation, error) {
+	grpcCtx, cancel := context.WithTimeout(ctx, s.grpcClientTimeout)
+	defer cancel()
+
+	grpcRes, err := s.orchestratorClient.ListActiveEvaluations(grpcCtx, &pb_orchestrator.ListActiveEvaluationsRequest{})
+	if err != nil {
+		return nil, s.wrapError(ctx, "ListActiveEvaluations", err)
+	}
+	evaluations := make([]ActiveEvaluation, 0, len(grpcRes.GetEvaluations()))
+	for _, ev := range grpcRes.GetEvaluations() {
+		evaluation := ActiveEvaluation{
+			TaskID:             ev.GetTaskId(),
+			UserID:             ev.GetUserId(),
+			Expression:         ev.GetExpression(),
+			AttemptNumber:      ev.GetAttemptNumber(),
+			ElapsedSeconds:     ev.GetElapsedSeconds(),
+			InflightOperations: ev.GetInflightOperations(),
+			OperationsDone:     ev.GetOperationsDone(),
+			OperationsTotal:    ev.GetOperationsTotal(),
+		}
+		if startedAt, err := time.Parse(time.RFC3339Nano, ev.GetStartedAt()); err == nil {
+			evaluation.StartedAt = startedAt
+		}
+		evaluations = append(evaluations, evaluation)
+	}
+	return evaluations, nil
+}
+
+func (s *adminService) GetQueueStatus(ctx context.Context) (*QueueStatus, error) {
+	grpcCtx, cancel := context.WithTimeout(ctx, s.grpcClientTimeout)
+	defer cancel()
+
+	grpcRes, err := s.orchestratorClient.GetQueueStatus(grpcCtx, &pb_orchestrator.QueueStatusRequest{})
+	if err != nil {
+		return nil, s.wrapError(ctx, "GetQueueStatus", err)
+	}
+	queueStatus := &QueueStatus{
+		Evaluations: EvaluationQueueStatus{
+			Running:       grpcRes.GetEvaluationsRunning(),
+			Waiting:       grpcRes.GetEvaluationsWaiting(),
+			MaxConcurrent: grpcRes.GetMaxConcurrentEvaluations(),
+			MaxQueued:     grpcRes.GetMaxQueuedEvaluations(),
+		},
+		Operations: OperationSlotsStatus{
+			Inflight:    grpcRes.GetOperationsInflight(),
+			Waiting:     grpcRes.GetOperationsWaiting(),
+			MaxInflight: grpcRes.GetMaxInflightOperations(),
+		},
+		Workers: make([]WorkerStatus, 0, len(grpcRes.GetWorkers())),
+	}
+	for _, w := range grpcRes.GetWorkers() {
+		worker := WorkerStatus{
+			Address:          w.GetAddress(),
+			Operations:       w.GetOperations(),
+			FailedOperations: w.GetFailedOperations(),
+		}
+		if lastSeenAt, err := time.Parse(time.RFC3339Nano, w.GetLastSeenAt()); err == nil {
+			worker.LastSeenAt = lastSeenAt
+		}
+		queueStatus.Workers = append(queueStatus.Workers, worker)
+	}
+	return queueStatus, nil
+}
+
+func (s *adminService) GetTaskCounts(ctx context.Context) (*SystemTaskCounts, error) {
+	grpcCtx, cancel := context.WithTimeout(ctx, s.grpcClientTimeout)
+	defer cancel()
+
+	grpcRes, err := s.orchestratorClient.GetSystemTaskCounts(grpcCtx, &pb_orchestrator.SystemTaskCountsRequest{})
+	if err != nil {
+		return nil, s.wrapError(ctx, "GetSystemTaskCounts", err)
+	}
+	counts := &SystemTaskCounts{
+		Total: grpcRes.GetTotal(),
+		ByStatus: map[string]int64{
+			repository.StatusPending:    0,
+			repository.StatusProcessing: 0,
+			repository.StatusCompleted:  0,
+			repository.StatusFailed:     0,
+		},
+		Trashed: grpcRes.GetTrashed(),
+	}
+	for _, sc := range grpcRes.GetStatusCounts() {
+		counts.ByStatus[sc.GetStatus()] = sc.GetCount()
+	}
+	return counts, nil
+}
+
+func (s *adminService) ForceFailTask(ctx context.Context, taskID, reason string) (*ForceFailedTask, error) {
+	// Отмененному вычислению нужно время, чтобы записать статус, поэтому таймаут больше обычного.
+	grpcCtx, cancel := context.WithTimeout(ctx, 2*s.grpcClientTimeout)
+	defer cancel()
+
+	grpcRes, err := s.orchestratorClient.ForceFailTask(grpcCtx, &pb_orchestrator.ForceFailTaskRequest{TaskId: taskID, Reason: reason})
+	if err != nil {
+		return nil, s.wrapError(ctx, "ForceFailTask", err)
+	}
+	return &ForceFailedTask{
+		TaskID:         grpcRes.GetTaskId(),
+		PreviousStatus: grpcRes.GetPreviousStatus(),
+		ErrorMessage:   grpcRes.GetErrorMessage(),
+	}, nil
+}
+
+func (s *adminService) wrapError(ctx context.Context, method string, err error) error {
+	logger.FromContext(ctx, s.log).Error("Ошибка gRPC вызова из AdminService", zap.String("method", method), zap.Error(err))
+	st, ok := status.FromError(err)
+	if ok {
+		switch st.Code() {
+		case codes.NotFound:
+			return fmt.Errorf("%w: %s", ErrTaskNotFound, st.Message())
+		case codes.FailedPrecondition:
+			return fmt.Errorf("%w: %s", ErrTaskStateConflict, st.Message())
+		case codes.InvalidArgument:
+			return fmt.Errorf("%w: %s", ErrInvalidAdminRequest, st.Message())
+		}
+	}
+	return fmt.Errorf("ошибка административного сервиса: %w", err)
+}
@@ -0,0 +1,124 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/Qu1nel/YaLyceum-GoProject-Final/internal/pkg/logger"
+	pb_orchestrator "github.com/Qu1nel/YaLyceum-GoProject-Final/proto/gen/orchestrator"
+	"go.uber.org/zap"
+)
+
+type AstNode struct {
+	NodePath       string     `json:"node_path" example:"0"`
+	Kind           string     `json:"kind" example:"binary"`
+	Operator       string     `json:"operator" example:"*"`
+	Value          *float64   `json:"value,omitempty" example:"20"`
+	Status         string     `json:"status" example:"completed"`
+	DurationMs     float64    `json:"duration_ms" example:"1.4"`
+	CriticalPathMs float64    `json:"critical_path_ms" example:"2.9"`
+	ErrorMessage   string     `json:"error_message,omitempty"`
+	Children       []*AstNode `json:"children,omitempty"`
+}
+
+type TaskAST struct {
+	TaskID        string   `json:"task_id"`
+	Status        string   `json:"status" example:"completed"`
+	AttemptNumber int32    `json:"attempt_number" example:"1"`
+	Live          bool     `json:"live"`
+	Root          *AstNode `json:"root"`
+	Dot           string   `json:"dot"`
+}
+
+func (s *taskService) GetTaskAST(ctx context.Context, userID, taskID string) (*TaskAST, error) {
+	grpcCtx, cancel := context.WithTimeout(ctx, s.grpcClientTimeout)
+	defer cancel()
+
+	grpcRes, err := s.orchestratorClient.GetTaskAST(grpcCtx, &pb_orchestrator.TaskASTRequest{UserId: userID, TaskId: taskID})
+	if err != nil {
+		logger.FromContext(ctx, s.log).Error("Ошибка gRPC вызова GetTaskAST из TaskService", zap.Error(err), zap.String("userID", userID), zap.String("taskID", taskID))
+		return nil, s.wrapTaskStateError("ошибка получения дерева выражения", err)
+	}
+
+	taskAST := &TaskAST{
+		TaskID:        grpcRes.GetTaskId(),
+		Status:        grpcRes.GetStatus(),
+		AttemptNumber: grpcRes.GetAttemptNumber(),
+		Live:          grpcRes.GetLive(),
+	}
+	if grpcRes.GetRoot() != nil {
+		taskAST.Root = astNodeFromProto(grpcRes.GetRoot())
+	}
+	taskAST.Dot = RenderASTDot(taskAST.Root)
+	return taskAST, nil
+}
+
+func astNodeFromProto(pbNode *pb_orchestrator.AstNode) *AstNode {
+	node := &AstNode{
+		NodePath:       pbNode.GetNodePath(),
+		Kind:           pbNode.GetKind(),
+		Operator:       pbNode.GetOperator(),
+		Status:         pbNode.GetStatus(),
+		DurationMs:     pbNode.GetDurationMs(),
+		CriticalPathMs: pbNode.GetCriticalPathMs(),
+		ErrorMessage:   pbNode.GetErrorMessage(),
+	}
+	if pbNode.GetHasValue() {
+		valueCopy := pbNode.GetValue()
+		node.Value = &valueCopy
+	}
+	for _, child := range pbNode.GetChildren() {
+		node.Children = append(node.Children, astNodeFromProto(child))
+	}
+	return node
+}
+
+var astDotFillColors = map[string]string{
+	"completed":  "palegreen",
+	"failed":     "lightcoral",
+	"processing": "lightgoldenrod",
+	"pending":    "lightgrey",
+	"skipped":    "white",
+}
+
+// RenderASTDot рисует дерево в формате Graphviz DOT: оператор, значение и время в подписи, статус цветом узла.
+func RenderASTDot(root *AstNode) string {
+	var b strings.Builder
+	b.WriteString("digraph ast {\n")
+	b.WriteString("\tnode [shape=box, style=\"rounded,filled\", fontname=\"Helvetica\"];\n")
+	if root != nil {
+		writeASTDotNode(&b, root)
+	}
+	b.WriteString("}\n")
+	return b.String()
+}
+
+func writeASTDotNode(b *strings.Builder, node *AstNode) {
+	label := node.Operator
+	if node.Value != nil && node.Kind != "number" {
+		label += "\n= " + strconv.FormatFloat(*node.Value, 'g', -1, 64)
+	}
+	if node.DurationMs > 0 {
+		label += fmt.Sprintf("\n%.1f мс", node.DurationMs)
+	}
+	if node.ErrorMessage != "" {
+		label += "\n" + node.ErrorMessage
+	}
+	fillColor, ok := astDotFillColors[node.Status]
+	if !ok {
+		fillColor = "white"
+	}
+	fmt.Fprintf(b, "\t%s [label=%s, fillcolor=%s];\n", dotQuote("n"+node.NodePath), dotQuote(label), fillColor)
+	for _, child := range node.Children {
+		fmt.Fprintf(b, "\t%s -> %s;\n", dotQuote("n"+node.NodePath), dotQuote("n"+child.NodePath))
+		writeASTDotNode(b, child)
+	}
+}
+
+// dotQuote экранирует строку для DOT: кавычки и обратный слеш экранируются, перевод строки становится \n.
+func dotQuote(s string) string {
+	replacer := strings.NewReplacer(`\`, `\\`, `"`, `\"`, "\n", `\n`)
+	return `"` + replacer.Replace(s) + `"`
+}
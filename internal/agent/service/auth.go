@@ -9,6 +9,7 @@ import (
 	"github.com/Qu1nel/YaLyceum-GoProject-Final/internal/agent/repository"
 	"github.com/Qu1nel/YaLyceum-GoProject-Final/internal/pkg/hasher"
 	"github.com/Qu1nel/YaLyceum-GoProject-Final/internal/pkg/jwtauth"
+	"github.com/Qu1nel/YaLyceum-GoProject-Final/internal/pkg/logger"
 
 	"go.uber.org/zap"
 	"golang.org/x/crypto/bcrypt"
@@ -51,6 +52,7 @@ func NewAuthService(
 }
 
 func (s *authService) Register(ctx context.Context, login, password string) (string, error) {
+	log := logger.FromContext(ctx, s.log)
 	if !loginRegex.MatchString(login) {
 		return "", ErrInvalidLoginFormat
 	}
@@ -60,7 +62,7 @@ func (s *authService) Register(ctx context.Context, login, password string) (str
 
 	passwordHash, err := s.hasher.Hash(password)
 	if err != nil {
-		s.log.Error("Не удалось захешировать пароль при регистрации", zap.Error(err), zap.String("login", login))
+		log.Error("Не удалось захешировать пароль при регистрации", zap.Error(err), zap.String("login", login))
 		return "", fmt.Errorf("%w: %v", ErrRegistrationFailed, err)
 	}
 
@@ -69,41 +71,42 @@ func (s *authService) Register(ctx context.Context, login, password string) (str
 		if errors.Is(err, repository.ErrLoginAlreadyExists) {
 			return "", repository.ErrLoginAlreadyExists
 		}
-		s.log.Error("Не удалось создать пользователя через репозиторий", zap.Error(err), zap.String("login", login))
+		log.Error("Не удалось создать пользователя через репозиторий", zap.Error(err), zap.String("login", login))
 		return "", fmt.Errorf("%w: ошибка репозитория", ErrRegistrationFailed)
 	}
 
-	s.log.Info("Пользователь успешно зарегистрирован", zap.String("login", login), zap.String("userID", userID.String()))
+	log.Info("Пользователь успешно зарегистрирован", zap.String("login", login), zap.String("userID", userID.String()))
 	return userID.String(), nil
 }
 
 func (s *authService) Login(ctx context.Context, login, password string) (string, string, error) {
+	log := logger.FromContext(ctx, s.log)
 	user, err := s.userRepo.GetUserByLogin(ctx, login)
 	if err != nil {
 		if errors.Is(err, repository.ErrUserNotFound) {
-			s.log.Warn("Попытка входа для несуществующего пользователя", zap.String("login", login))
+			log.Warn("Попытка входа для несуществующего пользователя", zap.String("login", login))
 			return "", "", ErrInvalidCredentials
 		}
-		s.log.Error("Не удалось получить пользователя по логину при входе", zap.Error(err), zap.String("login", login))
+		log.Error("Не удалось получить пользователя по логину при входе", zap.Error(err), zap.String("login", login))
 		return "", "", fmt.Errorf("%w: ошибка репозитория", ErrLoginFailed)
 	}
 
 	err = s.hasher.Compare(user.PasswordHash, password)
 	if err != nil {
 		if errors.Is(err, bcrypt.ErrMismatchedHashAndPassword) {
-			s.log.Warn("Неудачная попытка входа (неверный пароль)", zap.String("login", login))
+			log.Warn("Неудачная попытка входа (неверный пароль)", zap.String("login", login))
 			return "", "", ErrInvalidCredentials
 		}
-		s.log.Error("Ошибка при сравнении хеша пароля", zap.Error(err), zap.String("login", login))
+		log.Error("Ошибка при сравнении хеша пароля", zap.Error(err), zap.String("login", login))
 		return "", "", fmt.Errorf("%w: ошибка сравнения хеша: %v", ErrLoginFailed, err)
 	}
 
 	token, err := s.jwtManager.Generate(user.ID.String())
 	if err != nil {
-		s.log.Error("Не удалось сгенерировать JWT токен", zap.Error(err), zap.String("userID", user.ID.String()))
+		log.Error("Не удалось сгенерировать JWT токен", zap.Error(err), zap.String("userID", user.ID.String()))
 		return "", "", fmt.Errorf("%w: ошибка генерации токена", ErrLoginFailed)
 	}
 
-	s.log.Info("Пользователь успешно вошел в систему", zap.String("login", login), zap.String("userID", user.ID.String()))
+	log.Info("Пользователь успешно вошел в систему", zap.String("login", login), zap.String("userID", user.ID.String()))
 	return user.ID.String(), token, nil
 }
@@ -0,0 +1,33 @@
+package service
+
+import (
+	pb_orchestrator "github.com/Qu1nel/YaLyceum-GoProject-Final/proto/gen/orchestrator"
+)
+
+// CostEstimate - оценка вычисления выражения Оркестратором по задержкам операций Воркера.
+type CostEstimate struct {
+	Operations             map[string]int32 `json:"operations" example:"+:2,*:1"`
+	CriticalPathOperations int32            `json:"critical_path_operations" example:"2"`
+	CriticalPathMs         float64          `json:"critical_path_ms" example:"500"`
+	TotalWorkMs            float64          `json:"total_work_ms" example:"700"`
+	Parallelism            int32            `json:"parallelism" example:"16"`
+	EstimatedDurationMs    float64          `json:"estimated_duration_ms" example:"500"`
+}
+
+func costEstimateFromProto(pbEstimate *pb_orchestrator.CostEstimate) *CostEstimate {
+	if pbEstimate == nil {
+		return nil
+	}
+	estimate := &CostEstimate{
+		Operations:             make(map[string]int32, len(pbEstimate.GetOperations())),
+		CriticalPathOperations: pbEstimate.GetCriticalPathOperations(),
+		CriticalPathMs:         pbEstimate.GetCriticalPathMs(),
+		TotalWorkMs:            pbEstimate.GetTotalWorkMs(),
+		Parallelism:            pbEstimate.GetParallelism(),
+		EstimatedDurationMs:    pbEstimate.GetEstimatedDurationMs(),
+	}
+	for _, op := range pbEstimate.GetOperations() {
+		estimate.Operations[op.GetSymbol()] = op.GetCount()
+	}
+	return estimate
+}
@@ -17,6 +17,265 @@ type OrchestratorServiceClientMock struct {
 	mock.Mock
 }
 
+// CreateWebhookEndpoint provides a mock function with given fields: ctx, in, opts
+func (_m *OrchestratorServiceClientMock) CreateWebhookEndpoint(ctx context.Context, in *orchestrator_grpc.CreateWebhookEndpointRequest, opts ...grpc.CallOption) (*orchestrator_grpc.WebhookEndpoint, error) {
+	_va := make([]interface{}, len(opts))
+	for _i := range opts {
+		_va[_i] = opts[_i]
+	}
+	var _ca []interface{}
+	_ca = append(_ca, ctx, in)
+	_ca = append(_ca, _va...)
+	ret := _m.Called(_ca...)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CreateWebhookEndpoint")
+	}
+
+	var r0 *orchestrator_grpc.WebhookEndpoint
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, *orchestrator_grpc.CreateWebhookEndpointRequest, ...grpc.CallOption) (*orchestrator_grpc.WebhookEndpoint, error)); ok {
+		return rf(ctx, in, opts...)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, *orchestrator_grpc.CreateWebhookEndpointRequest, ...grpc.CallOption) *orchestrator_grpc.WebhookEndpoint); ok {
+		r0 = rf(ctx, in, opts...)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*orchestrator_grpc.WebhookEndpoint)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, *orchestrator_grpc.CreateWebhookEndpointRequest, ...grpc.CallOption) error); ok {
+		r1 = rf(ctx, in, opts...)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// DeleteTask provides a mock function with given fields: ctx, in, opts
+func (_m *OrchestratorServiceClientMock) DeleteTask(ctx context.Context, in *orchestrator_grpc.DeleteTaskRequest, opts ...grpc.CallOption) (*orchestrator_grpc.DeleteTaskResponse, error) {
+	_va := make([]interface{}, len(opts))
+	for _i := range opts {
+		_va[_i] = opts[_i]
+	}
+	var _ca []interface{}
+	_ca = append(_ca, ctx, in)
+	_ca = append(_ca, _va...)
+	ret := _m.Called(_ca...)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DeleteTask")
+	}
+
+	var r0 *orchestrator_grpc.DeleteTaskResponse
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, *orchestrator_grpc.DeleteTaskRequest, ...grpc.CallOption) (*orchestrator_grpc.DeleteTaskResponse, error)); ok {
+		return rf(ctx, in, opts...)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, *orchestrator_grpc.DeleteTaskRequest, ...grpc.CallOption) *orchestrator_grpc.DeleteTaskResponse); ok {
+		r0 = rf(ctx, in, opts...)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*orchestrator_grpc.DeleteTaskResponse)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, *orchestrator_grpc.DeleteTaskRequest, ...grpc.CallOption) error); ok {
+		r1 = rf(ctx, in, opts...)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// DeleteWebhookEndpoint provides a mock function with given fields: ctx, in, opts
+func (_m *OrchestratorServiceClientMock) DeleteWebhookEndpoint(ctx context.Context, in *orchestrator_grpc.DeleteWebhookEndpointRequest, opts ...grpc.CallOption) (*orchestrator_grpc.DeleteWebhookEndpointResponse, error) {
+	_va := make([]interface{}, len(opts))
+	for _i := range opts {
+		_va[_i] = opts[_i]
+	}
+	var _ca []interface{}
+	_ca = append(_ca, ctx, in)
+	_ca = append(_ca, _va...)
+	ret := _m.Called(_ca...)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DeleteWebhookEndpoint")
+	}
+
+	var r0 *orchestrator_grpc.DeleteWebhookEndpointResponse
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, *orchestrator_grpc.DeleteWebhookEndpointRequest, ...grpc.CallOption) (*orchestrator_grpc.DeleteWebhookEndpointResponse, error)); ok {
+		return rf(ctx, in, opts...)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, *orchestrator_grpc.DeleteWebhookEndpointRequest, ...grpc.CallOption) *orchestrator_grpc.DeleteWebhookEndpointResponse); ok {
+		r0 = rf(ctx, in, opts...)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*orchestrator_grpc.DeleteWebhookEndpointResponse)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, *orchestrator_grpc.DeleteWebhookEndpointRequest, ...grpc.CallOption) error); ok {
+		r1 = rf(ctx, in, opts...)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ForceFailTask provides a mock function with given fields: ctx, in, opts
+func (_m *OrchestratorServiceClientMock) ForceFailTask(ctx context.Context, in *orchestrator_grpc.ForceFailTaskRequest, opts ...grpc.CallOption) (*orchestrator_grpc.ForceFailTaskResponse, error) {
+	_va := make([]interface{}, len(opts))
+	for _i := range opts {
+		_va[_i] = opts[_i]
+	}
+	var _ca []interface{}
+	_ca = append(_ca, ctx, in)
+	_ca = append(_ca, _va...)
+	ret := _m.Called(_ca...)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ForceFailTask")
+	}
+
+	var r0 *orchestrator_grpc.ForceFailTaskResponse
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, *orchestrator_grpc.ForceFailTaskRequest, ...grpc.CallOption) (*orchestrator_grpc.ForceFailTaskResponse, error)); ok {
+		return rf(ctx, in, opts...)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, *orchestrator_grpc.ForceFailTaskRequest, ...grpc.CallOption) *orchestrator_grpc.ForceFailTaskResponse); ok {
+		r0 = rf(ctx, in, opts...)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*orchestrator_grpc.ForceFailTaskResponse)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, *orchestrator_grpc.ForceFailTaskRequest, ...grpc.CallOption) error); ok {
+		r1 = rf(ctx, in, opts...)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetQueueStatus provides a mock function with given fields: ctx, in, opts
+func (_m *OrchestratorServiceClientMock) GetQueueStatus(ctx context.Context, in *orchestrator_grpc.QueueStatusRequest, opts ...grpc.CallOption) (*orchestrator_grpc.QueueStatusResponse, error) {
+	_va := make([]interface{}, len(opts))
+	for _i := range opts {
+		_va[_i] = opts[_i]
+	}
+	var _ca []interface{}
+	_ca = append(_ca, ctx, in)
+	_ca = append(_ca, _va...)
+	ret := _m.Called(_ca...)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetQueueStatus")
+	}
+
+	var r0 *orchestrator_grpc.QueueStatusResponse
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, *orchestrator_grpc.QueueStatusRequest, ...grpc.CallOption) (*orchestrator_grpc.QueueStatusResponse, error)); ok {
+		return rf(ctx, in, opts...)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, *orchestrator_grpc.QueueStatusRequest, ...grpc.CallOption) *orchestrator_grpc.QueueStatusResponse); ok {
+		r0 = rf(ctx, in, opts...)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*orchestrator_grpc.QueueStatusResponse)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, *orchestrator_grpc.QueueStatusRequest, ...grpc.CallOption) error); ok {
+		r1 = rf(ctx, in, opts...)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetSystemTaskCounts provides a mock function with given fields: ctx, in, opts
+func (_m *OrchestratorServiceClientMock) GetSystemTaskCounts(ctx context.Context, in *orchestrator_grpc.SystemTaskCountsRequest, opts ...grpc.CallOption) (*orchestrator_grpc.SystemTaskCountsResponse, error) {
+	_va := make([]interface{}, len(opts))
+	for _i := range opts {
+		_va[_i] = opts[_i]
+	}
+	var _ca []interface{}
+	_ca = append(_ca, ctx, in)
+	_ca = append(_ca, _va...)
+	ret := _m.Called(_ca...)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetSystemTaskCounts")
+	}
+
+	var r0 *orchestrator_grpc.SystemTaskCountsResponse
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, *orchestrator_grpc.SystemTaskCountsRequest, ...grpc.CallOption) (*orchestrator_grpc.SystemTaskCountsResponse, error)); ok {
+		return rf(ctx, in, opts...)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, *orchestrator_grpc.SystemTaskCountsRequest, ...grpc.CallOption) *orchestrator_grpc.SystemTaskCountsResponse); ok {
+		r0 = rf(ctx, in, opts...)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*orchestrator_grpc.SystemTaskCountsResponse)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, *orchestrator_grpc.SystemTaskCountsRequest, ...grpc.CallOption) error); ok {
+		r1 = rf(ctx, in, opts...)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetTaskAST provides a mock function with given fields: ctx, in, opts
+func (_m *OrchestratorServiceClientMock) GetTaskAST(ctx context.Context, in *orchestrator_grpc.TaskASTRequest, opts ...grpc.CallOption) (*orchestrator_grpc.TaskASTResponse, error) {
+	_va := make([]interface{}, len(opts))
+	for _i := range opts {
+		_va[_i] = opts[_i]
+	}
+	var _ca []interface{}
+	_ca = append(_ca, ctx, in)
+	_ca = append(_ca, _va...)
+	ret := _m.Called(_ca...)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetTaskAST")
+	}
+
+	var r0 *orchestrator_grpc.TaskASTResponse
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, *orchestrator_grpc.TaskASTRequest, ...grpc.CallOption) (*orchestrator_grpc.TaskASTResponse, error)); ok {
+		return rf(ctx, in, opts...)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, *orchestrator_grpc.TaskASTRequest, ...grpc.CallOption) *orchestrator_grpc.TaskASTResponse); ok {
+		r0 = rf(ctx, in, opts...)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*orchestrator_grpc.TaskASTResponse)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, *orchestrator_grpc.TaskASTRequest, ...grpc.CallOption) error); ok {
+		r1 = rf(ctx, in, opts...)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // GetTaskDetails provides a mock function with given fields: ctx, in, opts
 func (_m *OrchestratorServiceClientMock) GetTaskDetails(ctx context.Context, in *orchestrator_grpc.TaskDetailsRequest, opts ...grpc.CallOption) (*orchestrator_grpc.TaskDetailsResponse, error) {
 	_va := make([]interface{}, len(opts))
@@ -29,23 +288,208 @@ func (_m *OrchestratorServiceClientMock) GetTaskDetails(ctx context.Context, in
 	ret := _m.Called(_ca...)
 
 	if len(ret) == 0 {
-		panic("no return value specified for GetTaskDetails")
+		panic("no return value specified for GetTaskDetails")
+	}
+
+	var r0 *orchestrator_grpc.TaskDetailsResponse
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, *orchestrator_grpc.TaskDetailsRequest, ...grpc.CallOption) (*orchestrator_grpc.TaskDetailsResponse, error)); ok {
+		return rf(ctx, in, opts...)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, *orchestrator_grpc.TaskDetailsRequest, ...grpc.CallOption) *orchestrator_grpc.TaskDetailsResponse); ok {
+		r0 = rf(ctx, in, opts...)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*orchestrator_grpc.TaskDetailsResponse)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, *orchestrator_grpc.TaskDetailsRequest, ...grpc.CallOption) error); ok {
+		r1 = rf(ctx, in, opts...)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetTaskTrace provides a mock function with given fields: ctx, in, opts
+func (_m *OrchestratorServiceClientMock) GetTaskTrace(ctx context.Context, in *orchestrator_grpc.TaskTraceRequest, opts ...grpc.CallOption) (*orchestrator_grpc.TaskTraceResponse, error) {
+	_va := make([]interface{}, len(opts))
+	for _i := range opts {
+		_va[_i] = opts[_i]
+	}
+	var _ca []interface{}
+	_ca = append(_ca, ctx, in)
+	_ca = append(_ca, _va...)
+	ret := _m.Called(_ca...)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetTaskTrace")
+	}
+
+	var r0 *orchestrator_grpc.TaskTraceResponse
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, *orchestrator_grpc.TaskTraceRequest, ...grpc.CallOption) (*orchestrator_grpc.TaskTraceResponse, error)); ok {
+		return rf(ctx, in, opts...)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, *orchestrator_grpc.TaskTraceRequest, ...grpc.CallOption) *orchestrator_grpc.TaskTraceResponse); ok {
+		r0 = rf(ctx, in, opts...)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*orchestrator_grpc.TaskTraceResponse)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, *orchestrator_grpc.TaskTraceRequest, ...grpc.CallOption) error); ok {
+		r1 = rf(ctx, in, opts...)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetUsage provides a mock function with given fields: ctx, in, opts
+func (_m *OrchestratorServiceClientMock) GetUsage(ctx context.Context, in *orchestrator_grpc.UsageRequest, opts ...grpc.CallOption) (*orchestrator_grpc.UsageResponse, error) {
+	_va := make([]interface{}, len(opts))
+	for _i := range opts {
+		_va[_i] = opts[_i]
+	}
+	var _ca []interface{}
+	_ca = append(_ca, ctx, in)
+	_ca = append(_ca, _va...)
+	ret := _m.Called(_ca...)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetUsage")
+	}
+
+	var r0 *orchestrator_grpc.UsageResponse
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, *orchestrator_grpc.UsageRequest, ...grpc.CallOption) (*orchestrator_grpc.UsageResponse, error)); ok {
+		return rf(ctx, in, opts...)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, *orchestrator_grpc.UsageRequest, ...grpc.CallOption) *orchestrator_grpc.UsageResponse); ok {
+		r0 = rf(ctx, in, opts...)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*orchestrator_grpc.UsageResponse)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, *orchestrator_grpc.UsageRequest, ...grpc.CallOption) error); ok {
+		r1 = rf(ctx, in, opts...)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetUserStats provides a mock function with given fields: ctx, in, opts
+func (_m *OrchestratorServiceClientMock) GetUserStats(ctx context.Context, in *orchestrator_grpc.UserStatsRequest, opts ...grpc.CallOption) (*orchestrator_grpc.UserStatsResponse, error) {
+	_va := make([]interface{}, len(opts))
+	for _i := range opts {
+		_va[_i] = opts[_i]
+	}
+	var _ca []interface{}
+	_ca = append(_ca, ctx, in)
+	_ca = append(_ca, _va...)
+	ret := _m.Called(_ca...)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetUserStats")
+	}
+
+	var r0 *orchestrator_grpc.UserStatsResponse
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, *orchestrator_grpc.UserStatsRequest, ...grpc.CallOption) (*orchestrator_grpc.UserStatsResponse, error)); ok {
+		return rf(ctx, in, opts...)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, *orchestrator_grpc.UserStatsRequest, ...grpc.CallOption) *orchestrator_grpc.UserStatsResponse); ok {
+		r0 = rf(ctx, in, opts...)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*orchestrator_grpc.UserStatsResponse)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, *orchestrator_grpc.UserStatsRequest, ...grpc.CallOption) error); ok {
+		r1 = rf(ctx, in, opts...)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetWebhookSecret provides a mock function with given fields: ctx, in, opts
+func (_m *OrchestratorServiceClientMock) GetWebhookSecret(ctx context.Context, in *orchestrator_grpc.WebhookSecretRequest, opts ...grpc.CallOption) (*orchestrator_grpc.WebhookSecretResponse, error) {
+	_va := make([]interface{}, len(opts))
+	for _i := range opts {
+		_va[_i] = opts[_i]
+	}
+	var _ca []interface{}
+	_ca = append(_ca, ctx, in)
+	_ca = append(_ca, _va...)
+	ret := _m.Called(_ca...)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetWebhookSecret")
+	}
+
+	var r0 *orchestrator_grpc.WebhookSecretResponse
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, *orchestrator_grpc.WebhookSecretRequest, ...grpc.CallOption) (*orchestrator_grpc.WebhookSecretResponse, error)); ok {
+		return rf(ctx, in, opts...)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, *orchestrator_grpc.WebhookSecretRequest, ...grpc.CallOption) *orchestrator_grpc.WebhookSecretResponse); ok {
+		r0 = rf(ctx, in, opts...)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*orchestrator_grpc.WebhookSecretResponse)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, *orchestrator_grpc.WebhookSecretRequest, ...grpc.CallOption) error); ok {
+		r1 = rf(ctx, in, opts...)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ListActiveEvaluations provides a mock function with given fields: ctx, in, opts
+func (_m *OrchestratorServiceClientMock) ListActiveEvaluations(ctx context.Context, in *orchestrator_grpc.ListActiveEvaluationsRequest, opts ...grpc.CallOption) (*orchestrator_grpc.ListActiveEvaluationsResponse, error) {
+	_va := make([]interface{}, len(opts))
+	for _i := range opts {
+		_va[_i] = opts[_i]
+	}
+	var _ca []interface{}
+	_ca = append(_ca, ctx, in)
+	_ca = append(_ca, _va...)
+	ret := _m.Called(_ca...)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListActiveEvaluations")
 	}
 
-	var r0 *orchestrator_grpc.TaskDetailsResponse
+	var r0 *orchestrator_grpc.ListActiveEvaluationsResponse
 	var r1 error
-	if rf, ok := ret.Get(0).(func(context.Context, *orchestrator_grpc.TaskDetailsRequest, ...grpc.CallOption) (*orchestrator_grpc.TaskDetailsResponse, error)); ok {
+	if rf, ok := ret.Get(0).(func(context.Context, *orchestrator_grpc.ListActiveEvaluationsRequest, ...grpc.CallOption) (*orchestrator_grpc.ListActiveEvaluationsResponse, error)); ok {
 		return rf(ctx, in, opts...)
 	}
-	if rf, ok := ret.Get(0).(func(context.Context, *orchestrator_grpc.TaskDetailsRequest, ...grpc.CallOption) *orchestrator_grpc.TaskDetailsResponse); ok {
+	if rf, ok := ret.Get(0).(func(context.Context, *orchestrator_grpc.ListActiveEvaluationsRequest, ...grpc.CallOption) *orchestrator_grpc.ListActiveEvaluationsResponse); ok {
 		r0 = rf(ctx, in, opts...)
 	} else {
 		if ret.Get(0) != nil {
-			r0 = ret.Get(0).(*orchestrator_grpc.TaskDetailsResponse)
+			r0 = ret.Get(0).(*orchestrator_grpc.ListActiveEvaluationsResponse)
 		}
 	}
 
-	if rf, ok := ret.Get(1).(func(context.Context, *orchestrator_grpc.TaskDetailsRequest, ...grpc.CallOption) error); ok {
+	if rf, ok := ret.Get(1).(func(context.Context, *orchestrator_grpc.ListActiveEvaluationsRequest, ...grpc.CallOption) error); ok {
 		r1 = rf(ctx, in, opts...)
 	} else {
 		r1 = ret.Error(1)
@@ -91,6 +535,191 @@ func (_m *OrchestratorServiceClientMock) ListUserTasks(ctx context.Context, in *
 	return r0, r1
 }
 
+// ListWebhookDeliveries provides a mock function with given fields: ctx, in, opts
+func (_m *OrchestratorServiceClientMock) ListWebhookDeliveries(ctx context.Context, in *orchestrator_grpc.ListWebhookDeliveriesRequest, opts ...grpc.CallOption) (*orchestrator_grpc.ListWebhookDeliveriesResponse, error) {
+	_va := make([]interface{}, len(opts))
+	for _i := range opts {
+		_va[_i] = opts[_i]
+	}
+	var _ca []interface{}
+	_ca = append(_ca, ctx, in)
+	_ca = append(_ca, _va...)
+	ret := _m.Called(_ca...)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListWebhookDeliveries")
+	}
+
+	var r0 *orchestrator_grpc.ListWebhookDeliveriesResponse
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, *orchestrator_grpc.ListWebhookDeliveriesRequest, ...grpc.CallOption) (*orchestrator_grpc.ListWebhookDeliveriesResponse, error)); ok {
+		return rf(ctx, in, opts...)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, *orchestrator_grpc.ListWebhookDeliveriesRequest, ...grpc.CallOption) *orchestrator_grpc.ListWebhookDeliveriesResponse); ok {
+		r0 = rf(ctx, in, opts...)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*orchestrator_grpc.ListWebhookDeliveriesResponse)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, *orchestrator_grpc.ListWebhookDeliveriesRequest, ...grpc.CallOption) error); ok {
+		r1 = rf(ctx, in, opts...)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ListWebhookEndpoints provides a mock function with given fields: ctx, in, opts
+func (_m *OrchestratorServiceClientMock) ListWebhookEndpoints(ctx context.Context, in *orchestrator_grpc.ListWebhookEndpointsRequest, opts ...grpc.CallOption) (*orchestrator_grpc.ListWebhookEndpointsResponse, error) {
+	_va := make([]interface{}, len(opts))
+	for _i := range opts {
+		_va[_i] = opts[_i]
+	}
+	var _ca []interface{}
+	_ca = append(_ca, ctx, in)
+	_ca = append(_ca, _va...)
+	ret := _m.Called(_ca...)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListWebhookEndpoints")
+	}
+
+	var r0 *orchestrator_grpc.ListWebhookEndpointsResponse
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, *orchestrator_grpc.ListWebhookEndpointsRequest, ...grpc.CallOption) (*orchestrator_grpc.ListWebhookEndpointsResponse, error)); ok {
+		return rf(ctx, in, opts...)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, *orchestrator_grpc.ListWebhookEndpointsRequest, ...grpc.CallOption) *orchestrator_grpc.ListWebhookEndpointsResponse); ok {
+		r0 = rf(ctx, in, opts...)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*orchestrator_grpc.ListWebhookEndpointsResponse)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, *orchestrator_grpc.ListWebhookEndpointsRequest, ...grpc.CallOption) error); ok {
+		r1 = rf(ctx, in, opts...)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// RedeliverWebhook provides a mock function with given fields: ctx, in, opts
+func (_m *OrchestratorServiceClientMock) RedeliverWebhook(ctx context.Context, in *orchestrator_grpc.RedeliverWebhookRequest, opts ...grpc.CallOption) (*orchestrator_grpc.RedeliverWebhookResponse, error) {
+	_va := make([]interface{}, len(opts))
+	for _i := range opts {
+		_va[_i] = opts[_i]
+	}
+	var _ca []interface{}
+	_ca = append(_ca, ctx, in)
+	_ca = append(_ca, _va...)
+	ret := _m.Called(_ca...)
+
+	if len(ret) == 0 {
+		panic("no return value specified for RedeliverWebhook")
+	}
+
+	var r0 *orchestrator_grpc.RedeliverWebhookResponse
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, *orchestrator_grpc.RedeliverWebhookRequest, ...grpc.CallOption) (*orchestrator_grpc.RedeliverWebhookResponse, error)); ok {
+		return rf(ctx, in, opts...)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, *orchestrator_grpc.RedeliverWebhookRequest, ...grpc.CallOption) *orchestrator_grpc.RedeliverWebhookResponse); ok {
+		r0 = rf(ctx, in, opts...)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*orchestrator_grpc.RedeliverWebhookResponse)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, *orchestrator_grpc.RedeliverWebhookRequest, ...grpc.CallOption) error); ok {
+		r1 = rf(ctx, in, opts...)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// RestoreTask provides a mock function with given fields: ctx, in, opts
+func (_m *OrchestratorServiceClientMock) RestoreTask(ctx context.Context, in *orchestrator_grpc.RestoreTaskRequest, opts ...grpc.CallOption) (*orchestrator_grpc.RestoreTaskResponse, error) {
+	_va := make([]interface{}, len(opts))
+	for _i := range opts {
+		_va[_i] = opts[_i]
+	}
+	var _ca []interface{}
+	_ca = append(_ca, ctx, in)
+	_ca = append(_ca, _va...)
+	ret := _m.Called(_ca...)
+
+	if len(ret) == 0 {
+		panic("no return value specified for RestoreTask")
+	}
+
+	var r0 *orchestrator_grpc.RestoreTaskResponse
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, *orchestrator_grpc.RestoreTaskRequest, ...grpc.CallOption) (*orchestrator_grpc.RestoreTaskResponse, error)); ok {
+		return rf(ctx, in, opts...)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, *orchestrator_grpc.RestoreTaskRequest, ...grpc.CallOption) *orchestrator_grpc.RestoreTaskResponse); ok {
+		r0 = rf(ctx, in, opts...)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*orchestrator_grpc.RestoreTaskResponse)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, *orchestrator_grpc.RestoreTaskRequest, ...grpc.CallOption) error); ok {
+		r1 = rf(ctx, in, opts...)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// RetryTask provides a mock function with given fields: ctx, in, opts
+func (_m *OrchestratorServiceClientMock) RetryTask(ctx context.Context, in *orchestrator_grpc.RetryTaskRequest, opts ...grpc.CallOption) (*orchestrator_grpc.RetryTaskResponse, error) {
+	_va := make([]interface{}, len(opts))
+	for _i := range opts {
+		_va[_i] = opts[_i]
+	}
+	var _ca []interface{}
+	_ca = append(_ca, ctx, in)
+	_ca = append(_ca, _va...)
+	ret := _m.Called(_ca...)
+
+	if len(ret) == 0 {
+		panic("no return value specified for RetryTask")
+	}
+
+	var r0 *orchestrator_grpc.RetryTaskResponse
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, *orchestrator_grpc.RetryTaskRequest, ...grpc.CallOption) (*orchestrator_grpc.RetryTaskResponse, error)); ok {
+		return rf(ctx, in, opts...)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, *orchestrator_grpc.RetryTaskRequest, ...grpc.CallOption) *orchestrator_grpc.RetryTaskResponse); ok {
+		r0 = rf(ctx, in, opts...)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*orchestrator_grpc.RetryTaskResponse)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, *orchestrator_grpc.RetryTaskRequest, ...grpc.CallOption) error); ok {
+		r1 = rf(ctx, in, opts...)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // SubmitExpression provides a mock function with given fields: ctx, in, opts
 func (_m *OrchestratorServiceClientMock) SubmitExpression(ctx context.Context, in *orchestrator_grpc.ExpressionRequest, opts ...grpc.CallOption) (*orchestrator_grpc.ExpressionResponse, error) {
 	_va := make([]interface{}, len(opts))
@@ -128,6 +757,117 @@ func (_m *OrchestratorServiceClientMock) SubmitExpression(ctx context.Context, i
 	return r0, r1
 }
 
+// SubmitExpressions provides a mock function with given fields: ctx, in, opts
+func (_m *OrchestratorServiceClientMock) SubmitExpressions(ctx context.Context, in *orchestrator_grpc.BatchExpressionRequest, opts ...grpc.CallOption) (*orchestrator_grpc.BatchExpressionResponse, error) {
+	_va := make([]interface{}, len(opts))
+	for _i := range opts {
+		_va[_i] = opts[_i]
+	}
+	var _ca []interface{}
+	_ca = append(_ca, ctx, in)
+	_ca = append(_ca, _va...)
+	ret := _m.Called(_ca...)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SubmitExpressions")
+	}
+
+	var r0 *orchestrator_grpc.BatchExpressionResponse
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, *orchestrator_grpc.BatchExpressionRequest, ...grpc.CallOption) (*orchestrator_grpc.BatchExpressionResponse, error)); ok {
+		return rf(ctx, in, opts...)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, *orchestrator_grpc.BatchExpressionRequest, ...grpc.CallOption) *orchestrator_grpc.BatchExpressionResponse); ok {
+		r0 = rf(ctx, in, opts...)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*orchestrator_grpc.BatchExpressionResponse)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, *orchestrator_grpc.BatchExpressionRequest, ...grpc.CallOption) error); ok {
+		r1 = rf(ctx, in, opts...)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ValidateExpression provides a mock function with given fields: ctx, in, opts
+func (_m *OrchestratorServiceClientMock) ValidateExpression(ctx context.Context, in *orchestrator_grpc.ValidateExpressionRequest, opts ...grpc.CallOption) (*orchestrator_grpc.ValidateExpressionResponse, error) {
+	_va := make([]interface{}, len(opts))
+	for _i := range opts {
+		_va[_i] = opts[_i]
+	}
+	var _ca []interface{}
+	_ca = append(_ca, ctx, in)
+	_ca = append(_ca, _va...)
+	ret := _m.Called(_ca...)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ValidateExpression")
+	}
+
+	var r0 *orchestrator_grpc.ValidateExpressionResponse
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, *orchestrator_grpc.ValidateExpressionRequest, ...grpc.CallOption) (*orchestrator_grpc.ValidateExpressionResponse, error)); ok {
+		return rf(ctx, in, opts...)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, *orchestrator_grpc.ValidateExpressionRequest, ...grpc.CallOption) *orchestrator_grpc.ValidateExpressionResponse); ok {
+		r0 = rf(ctx, in, opts...)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*orchestrator_grpc.ValidateExpressionResponse)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, *orchestrator_grpc.ValidateExpressionRequest, ...grpc.CallOption) error); ok {
+		r1 = rf(ctx, in, opts...)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// WatchTask provides a mock function with given fields: ctx, in, opts
+func (_m *OrchestratorServiceClientMock) WatchTask(ctx context.Context, in *orchestrator_grpc.WatchTaskRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[orchestrator_grpc.TaskEvent], error) {
+	_va := make([]interface{}, len(opts))
+	for _i := range opts {
+		_va[_i] = opts[_i]
+	}
+	var _ca []interface{}
+	_ca = append(_ca, ctx, in)
+	_ca = append(_ca, _va...)
+	ret := _m.Called(_ca...)
+
+	if len(ret) == 0 {
+		panic("no return value specified for WatchTask")
+	}
+
+	var r0 grpc.ServerStreamingClient[orchestrator_grpc.TaskEvent]
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, *orchestrator_grpc.WatchTaskRequest, ...grpc.CallOption) (grpc.ServerStreamingClient[orchestrator_grpc.TaskEvent], error)); ok {
+		return rf(ctx, in, opts...)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, *orchestrator_grpc.WatchTaskRequest, ...grpc.CallOption) grpc.ServerStreamingClient[orchestrator_grpc.TaskEvent]); ok {
+		r0 = rf(ctx, in, opts...)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(grpc.ServerStreamingClient[orchestrator_grpc.TaskEvent])
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, *orchestrator_grpc.WatchTaskRequest, ...grpc.CallOption) error); ok {
+		r1 = rf(ctx, in, opts...)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // NewOrchestratorServiceClientMock creates a new instance of OrchestratorServiceClientMock. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
 // The first argument is typically a *testing.T value.
 func NewOrchestratorServiceClientMock(t interface {
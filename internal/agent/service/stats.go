@@ -0,0 +1,77 @@
+package service
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/Qu1nel/YaLyceum-GoProject-Final/internal/orchestrator/repository"
+	"github.com/Qu1nel/YaLyceum-GoProject-Final/internal/pkg/logger"
+	pb_orchestrator "github.com/Qu1nel/YaLyceum-GoProject-Final/proto/gen/orchestrator"
+	"go.uber.org/zap"
+)
+
+type UserStats struct {
+	Total    int64            `json:"total" example:"42"`
+	ByStatus map[string]int64 `json:"by_status"`
+	// SuccessRate - доля completed среди завершенных (completed + failed) задач, null если завершенных нет.
+	SuccessRate    *float64            `json:"success_rate" example:"0.9"`
+	CompletionTime CompletionTimeStats `json:"completion_time"`
+	Operators      []OperatorUsage     `json:"operators"`
+}
+
+// CompletionTimeStats - время от отправки выражения до результата по completed задачам.
+type CompletionTimeStats struct {
+	Measured   int64    `json:"measured" example:"30"`
+	AvgSeconds *float64 `json:"avg_seconds" example:"1.8"`
+	P95Seconds *float64 `json:"p95_seconds" example:"4.2"`
+}
+
+type OperatorUsage struct {
+	Symbol        string  `json:"symbol" example:"+"`
+	Count         int64   `json:"count" example:"120"`
+	Failed        int64   `json:"failed" example:"2"`
+	AvgDurationMs float64 `json:"avg_duration_ms" example:"201.5"`
+}
+
+func (s *taskService) GetUserStats(ctx context.Context, userID string) (*UserStats, error) {
+	grpcCtx, cancel := context.WithTimeout(ctx, s.grpcClientTimeout)
+	defer cancel()
+
+	grpcRes, err := s.orchestratorClient.GetUserStats(grpcCtx, &pb_orchestrator.UserStatsRequest{UserId: userID})
+	if err != nil {
+		logger.FromContext(ctx, s.log).Error("Ошибка gRPC вызова GetUserStats из TaskService", zap.Error(err), zap.String("userID", userID))
+		return nil, fmt.Errorf("ошибка получения статистики: %w", err)
+	}
+
+	stats := &UserStats{
+		Total: grpcRes.GetTotal(),
+		ByStatus: map[string]int64{
+			repository.StatusPending:    0,
+			repository.StatusProcessing: 0,
+			repository.StatusCompleted:  0,
+			repository.StatusFailed:     0,
+		},
+		CompletionTime: CompletionTimeStats{Measured: grpcRes.GetCompletedMeasured()},
+		Operators:      make([]OperatorUsage, 0, len(grpcRes.GetOperators())),
+	}
+	for _, sc := range grpcRes.GetStatusCounts() {
+		stats.ByStatus[sc.GetStatus()] = sc.GetCount()
+	}
+	if stats.ByStatus[repository.StatusCompleted]+stats.ByStatus[repository.StatusFailed] > 0 {
+		rate := grpcRes.GetSuccessRate()
+		stats.SuccessRate = &rate
+	}
+	if stats.CompletionTime.Measured > 0 {
+		avg, p95 := grpcRes.GetAvgCompletionSeconds(), grpcRes.GetP95CompletionSeconds()
+		stats.CompletionTime.AvgSeconds, stats.CompletionTime.P95Seconds = &avg, &p95
+	}
+	for _, op := range grpcRes.GetOperators() {
+		stats.Operators = append(stats.Operators, OperatorUsage{
+			Symbol:        op.GetSymbol(),
+			Count:         op.GetCount(),
+			Failed:        op.GetFailed(),
+			AvgDurationMs: op.GetAvgDurationMs(),
+		})
+	}
+	return stats, nil
+}
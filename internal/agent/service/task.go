@@ -4,43 +4,238 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"io"
 	"time"
 
 	"github.com/Qu1nel/YaLyceum-GoProject-Final/internal/agent/config"
 	"github.com/Qu1nel/YaLyceum-GoProject-Final/internal/orchestrator/repository"
+	"github.com/Qu1nel/YaLyceum-GoProject-Final/internal/pkg/logger"
 	pb_orchestrator "github.com/Qu1nel/YaLyceum-GoProject-Final/proto/gen/orchestrator"
 	"go.uber.org/zap"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
 )
 
 var (
-	ErrTaskNotFound = errors.New("задача не найдена или нет прав доступа")
+	ErrTaskNotFound         = errors.New("задача не найдена или нет прав доступа")
+	ErrServiceOverloaded    = errors.New("сервис вычислений перегружен, повторите попытку позже")
+	ErrTaskNotRetryable     = errors.New("задача еще выполняется и не может быть перезапущена")
+	ErrInvalidTaskQuery     = errors.New("невалидные параметры запроса списка задач")
+	ErrTaskStateConflict    = errors.New("операция недоступна в текущем состоянии задачи")
+	ErrIdempotencyKeyReused = errors.New("ключ идемпотентности уже использован для запроса с другим телом")
+	ErrEstimateExceeded     = errors.New("выражение превышает допустимое время вычисления")
+	ErrInvalidExpression    = errors.New("выражение отклонено")
 )
 
+// Причины отказа в выражении, которые Оркестратор передает в ErrorInfo.
+const (
+	ExpressionReasonParseError    = "EXPRESSION_PARSE_ERROR"
+	ExpressionReasonLimitExceeded = "EXPRESSION_LIMIT_EXCEEDED"
+)
+
+// ExpressionRejectedError - Оркестратор отклонил выражение. Reason пуст, если причина не указана,
+// Limit - нарушенный лимит EXPR_MAX_* для EXPRESSION_LIMIT_EXCEEDED.
+type ExpressionRejectedError struct {
+	Reason  string
+	Limit   string
+	Message string
+}
+
+func (e *ExpressionRejectedError) Error() string {
+	return ErrInvalidExpression.Error() + ": " + e.Message
+}
+
+func (e *ExpressionRejectedError) Unwrap() error { return ErrInvalidExpression }
+
+func expressionRejectedFromStatus(st *status.Status) *ExpressionRejectedError {
+	rejected := &ExpressionRejectedError{Message: st.Message()}
+	for _, detail := range st.Details() {
+		if info, ok := detail.(*errdetails.ErrorInfo); ok {
+			rejected.Reason = info.GetReason()
+			rejected.Limit = info.GetMetadata()["limit"]
+		}
+	}
+	return rejected
+}
+
+// SubmitOptions - необязательные параметры создаваемой задачи.
+type SubmitOptions struct {
+	CallbackURL    string
+	IdempotencyKey string
+	// MaxEstimatedDuration - отклонить выражение, если оценка времени вычисления больше (0 - без ограничения).
+	MaxEstimatedDuration time.Duration
+}
+
+type SubmittedTask struct {
+	TaskID        string
+	AttemptNumber int32
+	QueuePosition int32
+	// Replayed - задача создана ранее запросом с тем же ключом идемпотентности.
+	Replayed bool
+	// Estimate - оценка вычисления, nil для Replayed.
+	Estimate *CostEstimate
+}
+
+// BatchItem - выражение из пакетного запроса.
+type BatchItem struct {
+	Expression  string
+	CallbackURL string
+}
+
+// BatchItemResult - результат одного выражения пакета: TaskID или Error.
+type BatchItemResult struct {
+	Index         int32  `json:"index" example:"0"`
+	TaskID        string `json:"task_id,omitempty" example:"a1b2c3d4-e5f6-7890-1234-567890abcdef"`
+	QueuePosition int32  `json:"queue_position,omitempty" example:"3"`
+	Error         string `json:"error,omitempty" example:"ошибка в выражении: unexpected token EOF"`
+}
+
+type BatchSubmission struct {
+	Accepted int32             `json:"accepted" example:"2"`
+	Rejected int32             `json:"rejected" example:"1"`
+	Results  []BatchItemResult `json:"results"`
+}
+
+type TaskAttempt struct {
+	Number       int32      `json:"number"`
+	Status       string     `json:"status"`
+	Result       *float64   `json:"result,omitempty"`
+	ErrorMessage *string    `json:"error_message,omitempty"`
+	StartedAt    *time.Time `json:"started_at,omitempty"`
+	FinishedAt   *time.Time `json:"finished_at,omitempty"`
+	CreatedAt    time.Time  `json:"created_at"`
+}
+
 type TaskListItem struct {
-	ID         string    `json:"id"`
-	Expression string    `json:"expression"`
-	Status     string    `json:"status"`
-	CreatedAt  time.Time `json:"created_at"`
+	ID           string     `json:"id"`
+	Expression   string     `json:"expression"`
+	Status       string     `json:"status"`
+	Result       *float64   `json:"result,omitempty"`
+	ErrorMessage *string    `json:"error_message,omitempty"`
+	ErrorCode    *string    `json:"error_code,omitempty"`
+	CreatedAt    time.Time  `json:"created_at"`
+	DeletedAt    *time.Time `json:"deleted_at,omitempty"`
+}
+
+type TaskListQuery struct {
+	Deleted     bool
+	Search      string
+	PageSize    int32
+	PageToken   string
+	Statuses    []string
+	CreatedFrom string
+	CreatedTo   string
+	SortOrder   string
+}
+
+type TaskListPage struct {
+	Tasks         []TaskListItem
+	NextPageToken string
 }
 
 type TaskDetails struct {
-	ID           string    `json:"id"`
-	Expression   string    `json:"expression"`
-	Status       string    `json:"status"`
-	Result       *float64  `json:"result,omitempty"`
-	ErrorMessage *string   `json:"error_message,omitempty"`
-	CreatedAt    time.Time `json:"created_at"`
-	UpdatedAt    time.Time `json:"updated_at"`
+	ID            string        `json:"id"`
+	Expression    string        `json:"expression"`
+	Status        string        `json:"status"`
+	Result        *float64      `json:"result,omitempty"`
+	ErrorMessage  *string       `json:"error_message,omitempty"`
+	ErrorCode     *string       `json:"error_code,omitempty"`
+	QueuePosition int32         `json:"queue_position,omitempty"`
+	Attempts      []TaskAttempt `json:"attempts,omitempty"`
+	CreatedAt     time.Time     `json:"created_at"`
+	UpdatedAt     time.Time     `json:"updated_at"`
+	DeletedAt     *time.Time    `json:"deleted_at,omitempty"`
+	RequestID     string        `json:"request_id,omitempty"`
+}
+
+func (d TaskDetails) Terminal() bool {
+	return d.Status == repository.StatusCompleted || d.Status == repository.StatusFailed
+}
+
+type TaskOperation struct {
+	Seq           int32     `json:"seq"`
+	OperationID   string    `json:"operation_id"`
+	NodePath      string    `json:"node_path"`
+	Symbol        string    `json:"symbol"`
+	OperandA      float64   `json:"operand_a"`
+	OperandB      float64   `json:"operand_b"`
+	Result        *float64  `json:"result,omitempty"`
+	ErrorMessage  *string   `json:"error_message,omitempty"`
+	WorkerAddress string    `json:"worker_address,omitempty"`
+	StartedAt     time.Time `json:"started_at"`
+	DurationMs    float64   `json:"duration_ms"`
+}
+
+type TraceNode struct {
+	NodePath  string         `json:"node_path"`
+	Kind      string         `json:"kind"`
+	Symbol    string         `json:"symbol"`
+	Value     *float64       `json:"value,omitempty"`
+	Operation *TaskOperation `json:"operation,omitempty"`
+	Children  []*TraceNode   `json:"children,omitempty"`
+}
+
+type TaskTrace struct {
+	TaskID        string          `json:"task_id"`
+	AttemptNumber int32           `json:"attempt_number"`
+	Operations    []TaskOperation `json:"operations"`
+	Tree          *TraceNode      `json:"tree,omitempty"`
+}
+
+type TaskEvent struct {
+	TaskID          string    `json:"task_id"`
+	Status          string    `json:"status"`
+	AttemptNumber   int32     `json:"attempt_number,omitempty"`
+	QueuePosition   int32     `json:"queue_position,omitempty"`
+	OperationsDone  int32     `json:"operations_done"`
+	OperationsTotal int32     `json:"operations_total"`
+	Result          *float64  `json:"result,omitempty"`
+	ErrorMessage    *string   `json:"error_message,omitempty"`
+	ErrorCode       *string   `json:"error_code,omitempty"`
+	Timestamp       time.Time `json:"timestamp"`
+}
+
+func (e TaskEvent) Terminal() bool {
+	return e.Status == repository.StatusCompleted || e.Status == repository.StatusFailed
 }
 
 type TaskService interface {
-	SubmitNewTask(ctx context.Context, userID, expression string) (taskID string, err error)
+	SubmitNewTask(ctx context.Context, userID, expression string, opts SubmitOptions) (*SubmittedTask, error)
+
+	// SubmitBatch отправляет пакет выражений одним вызовом; отклоненные выражения не считаются ошибкой.
+	SubmitBatch(ctx context.Context, userID string, items []BatchItem) (*BatchSubmission, error)
 
-	GetUserTasks(ctx context.Context, userID string) ([]TaskListItem, error)
+	// ValidateExpression проверяет выражение без создания задачи.
+	ValidateExpression(ctx context.Context, userID, expression string) (*ExpressionValidation, error)
+
+	GetUserTasks(ctx context.Context, userID string, query TaskListQuery) (*TaskListPage, error)
 
 	GetTaskDetails(ctx context.Context, userID, taskID string) (*TaskDetails, error)
+
+	// WaitForTask ждет терминального статуса задачи не дольше wait (или до отмены ctx) и возвращает детали задачи.
+	// Ожидание идет по событиям Оркестратора (WatchTask), а не опросом GetTaskDetails.
+	WaitForTask(ctx context.Context, userID, taskID string, wait time.Duration) (*TaskDetails, error)
+
+	RetryTask(ctx context.Context, userID, taskID string) (*SubmittedTask, error)
+
+	DeleteTask(ctx context.Context, userID, taskID string, purge bool) error
+
+	RestoreTask(ctx context.Context, userID, taskID string) error
+
+	GetTaskTrace(ctx context.Context, userID, taskID string) (*TaskTrace, error)
+
+	// GetTaskAST возвращает дерево выражения задачи, размеченное результатами вычисления.
+	GetTaskAST(ctx context.Context, userID, taskID string) (*TaskAST, error)
+
+	GetUserStats(ctx context.Context, userID string) (*UserStats, error)
+
+	// GetUsage возвращает потребление пользователя за текущие сутки и месяц и остаток квот.
+	GetUsage(ctx context.Context, userID string) (*Usage, error)
+
+	// WatchTask вызывает onEvent для каждого события задачи, пока она не достигнет терминального статуса,
+	// ctx не будет отменен или onEvent не вернет ошибку.
+	WatchTask(ctx context.Context, userID, taskID string, onEvent func(TaskEvent) error) error
 }
 
 type taskService struct {
@@ -61,58 +256,154 @@ func NewTaskService(
 	}
 }
 
-func (s *taskService) SubmitNewTask(ctx context.Context, userID, expression string) (string, error) {
+func (s *taskService) SubmitNewTask(ctx context.Context, userID, expression string, opts SubmitOptions) (*SubmittedTask, error) {
 	grpcCtx, cancel := context.WithTimeout(ctx, s.grpcClientTimeout)
 	defer cancel()
 
 	grpcReq := &pb_orchestrator.ExpressionRequest{
-		UserId:     userID,
-		Expression: expression,
+		UserId:         userID,
+		Expression:     expression,
+		CallbackUrl:    opts.CallbackURL,
+		IdempotencyKey: opts.IdempotencyKey,
+
+		MaxEstimatedDurationMs: opts.MaxEstimatedDuration.Milliseconds(),
 	}
 
 	grpcRes, err := s.orchestratorClient.SubmitExpression(grpcCtx, grpcReq)
 	if err != nil {
-		s.log.Error("Ошибка gRPC вызова SubmitExpression из TaskService", zap.Error(err))
+		logger.FromContext(ctx, s.log).Error("Ошибка gRPC вызова SubmitExpression из TaskService", zap.Error(err))
+		st, ok := status.FromError(err)
+		if quotaErr, isQuota := quotaErrorFromStatus(st); ok && isQuota {
+			return nil, quotaErr
+		}
+		if ok && st.Code() == codes.ResourceExhausted {
+			return nil, fmt.Errorf("%w: %w", ErrServiceOverloaded, err)
+		}
+		if ok && st.Code() == codes.AlreadyExists {
+			return nil, fmt.Errorf("%w: %w", ErrIdempotencyKeyReused, err)
+		}
+		if ok && st.Code() == codes.FailedPrecondition {
+			return nil, fmt.Errorf("%w: %s", ErrEstimateExceeded, st.Message())
+		}
+		if ok && st.Code() == codes.InvalidArgument {
+			return nil, expressionRejectedFromStatus(st)
+		}
 
-		return "", fmt.Errorf("ошибка сервиса вычислений: %w", err)
+		return nil, fmt.Errorf("ошибка сервиса вычислений: %w", err)
 	}
-	return grpcRes.GetTaskId(), nil
+	return &SubmittedTask{
+		TaskID:        grpcRes.GetTaskId(),
+		QueuePosition: grpcRes.GetQueuePosition(),
+		Replayed:      grpcRes.GetIdempotentReplay(),
+		Estimate:      costEstimateFromProto(grpcRes.GetEstimate()),
+	}, nil
 }
 
-func (s *taskService) GetUserTasks(ctx context.Context, userID string) ([]TaskListItem, error) {
+func (s *taskService) SubmitBatch(ctx context.Context, userID string, items []BatchItem) (*BatchSubmission, error) {
 	grpcCtx, cancel := context.WithTimeout(ctx, s.grpcClientTimeout)
 	defer cancel()
 
-	grpcReq := &pb_orchestrator.UserTasksRequest{UserId: userID}
+	grpcReq := &pb_orchestrator.BatchExpressionRequest{UserId: userID, Items: make([]*pb_orchestrator.BatchExpressionItem, 0, len(items))}
+	for _, item := range items {
+		grpcReq.Items = append(grpcReq.Items, &pb_orchestrator.BatchExpressionItem{Expression: item.Expression, CallbackUrl: item.CallbackURL})
+	}
+
+	grpcRes, err := s.orchestratorClient.SubmitExpressions(grpcCtx, grpcReq)
+	if err != nil {
+		logger.FromContext(ctx, s.log).Error("Ошибка gRPC вызова SubmitExpressions из TaskService", zap.Error(err), zap.Int("count", len(items)))
+		st, ok := status.FromError(err)
+		if quotaErr, isQuota := quotaErrorFromStatus(st); ok && isQuota {
+			return nil, quotaErr
+		}
+		if ok && st.Code() == codes.ResourceExhausted {
+			return nil, fmt.Errorf("%w: %w", ErrServiceOverloaded, err)
+		}
+
+		return nil, fmt.Errorf("ошибка сервиса вычислений: %w", err)
+	}
+
+	submission := &BatchSubmission{
+		Accepted: grpcRes.GetAccepted(),
+		Rejected: grpcRes.GetRejected(),
+		Results:  make([]BatchItemResult, 0, len(grpcRes.GetResults())),
+	}
+	for _, r := range grpcRes.GetResults() {
+		submission.Results = append(submission.Results, BatchItemResult{
+			Index:         r.GetIndex(),
+			TaskID:        r.GetTaskId(),
+			QueuePosition: r.GetQueuePosition(),
+			Error:         r.GetError(),
+		})
+	}
+	return submission, nil
+}
+
+func (s *taskService) GetUserTasks(ctx context.Context, userID string, query TaskListQuery) (*TaskListPage, error) {
+	log := logger.FromContext(ctx, s.log)
+	grpcCtx, cancel := context.WithTimeout(ctx, s.grpcClientTimeout)
+	defer cancel()
+
+	grpcReq := &pb_orchestrator.UserTasksRequest{
+		UserId:      userID,
+		PageSize:    query.PageSize,
+		PageToken:   query.PageToken,
+		Statuses:    query.Statuses,
+		CreatedFrom: query.CreatedFrom,
+		CreatedTo:   query.CreatedTo,
+		SortOrder:   query.SortOrder,
+		Search:      query.Search,
+		Deleted:     query.Deleted,
+	}
 	grpcRes, err := s.orchestratorClient.ListUserTasks(grpcCtx, grpcReq)
 	if err != nil {
-		s.log.Error("Ошибка gRPC вызова ListUserTasks из TaskService", zap.Error(err), zap.String("userID", userID))
+		log.Error("Ошибка gRPC вызова ListUserTasks из TaskService", zap.Error(err), zap.String("userID", userID))
+		st, ok := status.FromError(err)
+		if ok && st.Code() == codes.InvalidArgument {
+			return nil, fmt.Errorf("%w: %s", ErrInvalidTaskQuery, st.Message())
+		}
 		return nil, fmt.Errorf("ошибка получения списка задач: %w", err)
 	}
 	tasks := make([]TaskListItem, 0, len(grpcRes.GetTasks()))
 	for _, pbTask := range grpcRes.GetTasks() {
 		createdAt, pErr := time.Parse(time.RFC3339Nano, pbTask.GetCreatedAt())
 		if pErr != nil {
-			s.log.Warn("Не удалось распарсить CreatedAt из gRPC ответа", zap.Error(pErr), zap.String("value", pbTask.GetCreatedAt()))
+			log.Warn("Не удалось распарсить CreatedAt из gRPC ответа", zap.Error(pErr), zap.String("value", pbTask.GetCreatedAt()))
 		}
-		tasks = append(tasks, TaskListItem{
+		item := TaskListItem{
 			ID:         pbTask.GetId(),
 			Expression: pbTask.GetExpression(),
 			Status:     pbTask.GetStatus(),
 			CreatedAt:  createdAt,
-		})
+		}
+		if pbTask.GetStatus() == repository.StatusCompleted {
+			resCopy := pbTask.GetResult()
+			item.Result = &resCopy
+		}
+		if pbTask.GetStatus() == repository.StatusFailed && pbTask.GetErrorMessage() != "" {
+			errMsgCopy := pbTask.GetErrorMessage()
+			item.ErrorMessage = &errMsgCopy
+		}
+		if pbTask.GetStatus() == repository.StatusFailed && pbTask.GetErrorCode() != "" {
+			errCodeCopy := pbTask.GetErrorCode()
+			item.ErrorCode = &errCodeCopy
+		}
+		if deletedAt, dErr := time.Parse(time.RFC3339Nano, pbTask.GetDeletedAt()); dErr == nil {
+			item.DeletedAt = &deletedAt
+		}
+		tasks = append(tasks, item)
 	}
-	return tasks, nil
+	return &TaskListPage{Tasks: tasks, NextPageToken: grpcRes.GetNextPageToken()}, nil
 }
 
 func (s *taskService) GetTaskDetails(ctx context.Context, userID, taskID string) (*TaskDetails, error) {
+	log := logger.FromContext(ctx, s.log)
 	grpcCtx, cancel := context.WithTimeout(ctx, s.grpcClientTimeout)
 	defer cancel()
 
 	grpcReq := &pb_orchestrator.TaskDetailsRequest{UserId: userID, TaskId: taskID}
 	grpcRes, err := s.orchestratorClient.GetTaskDetails(grpcCtx, grpcReq)
 	if err != nil {
-		s.log.Error("Ошибка gRPC вызова GetTaskDetails из TaskService", zap.Error(err), zap.String("userID", userID), zap.String("taskID", taskID))
+		log.Error("Ошибка gRPC вызова GetTaskDetails из TaskService", zap.Error(err), zap.String("userID", userID), zap.String("taskID", taskID))
 		st, ok := status.FromError(err)
 		if ok && st.Code() == codes.NotFound {
 
@@ -124,19 +415,20 @@ func (s *taskService) GetTaskDetails(ctx context.Context, userID, taskID string)
 
 	createdAt, cErr := time.Parse(time.RFC3339Nano, grpcRes.GetCreatedAt())
 	if cErr != nil {
-		s.log.Warn("Не удалось распарсить CreatedAt для деталей задачи", zap.Error(cErr), zap.String("value", grpcRes.GetCreatedAt()))
+		log.Warn("Не удалось распарсить CreatedAt для деталей задачи", zap.Error(cErr), zap.String("value", grpcRes.GetCreatedAt()))
 	}
 	updatedAt, uErr := time.Parse(time.RFC3339Nano, grpcRes.GetUpdatedAt())
 	if uErr != nil {
-		s.log.Warn("Не удалось распарсить UpdatedAt для деталей задачи", zap.Error(uErr), zap.String("value", grpcRes.GetUpdatedAt()))
+		log.Warn("Не удалось распарсить UpdatedAt для деталей задачи", zap.Error(uErr), zap.String("value", grpcRes.GetUpdatedAt()))
 	}
 
 	details := &TaskDetails{
-		ID:         grpcRes.GetId(),
-		Expression: grpcRes.GetExpression(),
-		Status:     grpcRes.GetStatus(),
-		CreatedAt:  createdAt,
-		UpdatedAt:  updatedAt,
+		ID:            grpcRes.GetId(),
+		Expression:    grpcRes.GetExpression(),
+		Status:        grpcRes.GetStatus(),
+		QueuePosition: grpcRes.GetQueuePosition(),
+		CreatedAt:     createdAt,
+		UpdatedAt:     updatedAt,
 	}
 	if grpcRes.GetStatus() == repository.StatusCompleted {
 		resCopy := grpcRes.GetResult()
@@ -146,5 +438,249 @@ func (s *taskService) GetTaskDetails(ctx context.Context, userID, taskID string)
 		errMsgCopy := grpcRes.GetErrorMessage()
 		details.ErrorMessage = &errMsgCopy
 	}
+	if grpcRes.GetStatus() == repository.StatusFailed && grpcRes.GetErrorCode() != "" {
+		errCodeCopy := grpcRes.GetErrorCode()
+		details.ErrorCode = &errCodeCopy
+	}
+	if deletedAt, dErr := time.Parse(time.RFC3339Nano, grpcRes.GetDeletedAt()); dErr == nil {
+		details.DeletedAt = &deletedAt
+	}
+	details.RequestID = grpcRes.GetRequestId()
+	for _, pbAttempt := range grpcRes.GetAttempts() {
+		details.Attempts = append(details.Attempts, s.attemptFromProto(pbAttempt))
+	}
 	return details, nil
 }
+
+func (s *taskService) attemptFromProto(pbAttempt *pb_orchestrator.TaskAttempt) TaskAttempt {
+	attempt := TaskAttempt{
+		Number: pbAttempt.GetNumber(),
+		Status: pbAttempt.GetStatus(),
+	}
+	if createdAt, err := time.Parse(time.RFC3339Nano, pbAttempt.GetCreatedAt()); err == nil {
+		attempt.CreatedAt = createdAt
+	} else {
+		s.log.Warn("Не удалось распарсить CreatedAt попытки", zap.Error(err), zap.String("value", pbAttempt.GetCreatedAt()))
+	}
+	if startedAt, err := time.Parse(time.RFC3339Nano, pbAttempt.GetStartedAt()); err == nil {
+		attempt.StartedAt = &startedAt
+	}
+	if finishedAt, err := time.Parse(time.RFC3339Nano, pbAttempt.GetFinishedAt()); err == nil {
+		attempt.FinishedAt = &finishedAt
+	}
+	if pbAttempt.GetStatus() == repository.StatusCompleted {
+		resCopy := pbAttempt.GetResult()
+		attempt.Result = &resCopy
+	}
+	if pbAttempt.GetStatus() == repository.StatusFailed && pbAttempt.GetErrorMessage() != "" {
+		errMsgCopy := pbAttempt.GetErrorMessage()
+		attempt.ErrorMessage = &errMsgCopy
+	}
+	return attempt
+}
+
+func (s *taskService) RetryTask(ctx context.Context, userID, taskID string) (*SubmittedTask, error) {
+	grpcCtx, cancel := context.WithTimeout(ctx, s.grpcClientTimeout)
+	defer cancel()
+
+	grpcReq := &pb_orchestrator.RetryTaskRequest{UserId: userID, TaskId: taskID}
+	grpcRes, err := s.orchestratorClient.RetryTask(grpcCtx, grpcReq)
+	if err != nil {
+		logger.FromContext(ctx, s.log).Error("Ошибка gRPC вызова RetryTask из TaskService", zap.Error(err), zap.String("userID", userID), zap.String("taskID", taskID))
+		st, ok := status.FromError(err)
+		if quotaErr, isQuota := quotaErrorFromStatus(st); ok && isQuota {
+			return nil, quotaErr
+		}
+		if ok {
+			switch st.Code() {
+			case codes.NotFound:
+				return nil, fmt.Errorf("%w: %w", ErrTaskNotFound, err)
+			case codes.FailedPrecondition:
+				return nil, fmt.Errorf("%w: %w", ErrTaskNotRetryable, err)
+			case codes.ResourceExhausted:
+				return nil, fmt.Errorf("%w: %w", ErrServiceOverloaded, err)
+			}
+		}
+
+		return nil, fmt.Errorf("ошибка перезапуска задачи: %w", err)
+	}
+	return &SubmittedTask{
+		TaskID:        grpcRes.GetTaskId(),
+		AttemptNumber: grpcRes.GetAttemptNumber(),
+		QueuePosition: grpcRes.GetQueuePosition(),
+	}, nil
+}
+
+func (s *taskService) DeleteTask(ctx context.Context, userID, taskID string, purge bool) error {
+	grpcCtx, cancel := context.WithTimeout(ctx, s.grpcClientTimeout)
+	defer cancel()
+
+	grpcReq := &pb_orchestrator.DeleteTaskRequest{UserId: userID, TaskId: taskID, Purge: purge}
+	if _, err := s.orchestratorClient.DeleteTask(grpcCtx, grpcReq); err != nil {
+		logger.FromContext(ctx, s.log).Error("Ошибка gRPC вызова DeleteTask из TaskService", zap.Error(err), zap.String("userID", userID), zap.String("taskID", taskID))
+		return s.wrapTaskStateError("ошибка удаления задачи", err)
+	}
+	return nil
+}
+
+func (s *taskService) RestoreTask(ctx context.Context, userID, taskID string) error {
+	grpcCtx, cancel := context.WithTimeout(ctx, s.grpcClientTimeout)
+	defer cancel()
+
+	grpcReq := &pb_orchestrator.RestoreTaskRequest{UserId: userID, TaskId: taskID}
+	if _, err := s.orchestratorClient.RestoreTask(grpcCtx, grpcReq); err != nil {
+		logger.FromContext(ctx, s.log).Error("Ошибка gRPC вызова RestoreTask из TaskService", zap.Error(err), zap.String("userID", userID), zap.String("taskID", taskID))
+		return s.wrapTaskStateError("ошибка восстановления задачи", err)
+	}
+	return nil
+}
+
+func (s *taskService) GetTaskTrace(ctx context.Context, userID, taskID string) (*TaskTrace, error) {
+	grpcCtx, cancel := context.WithTimeout(ctx, s.grpcClientTimeout)
+	defer cancel()
+
+	grpcReq := &pb_orchestrator.TaskTraceRequest{UserId: userID, TaskId: taskID}
+	grpcRes, err := s.orchestratorClient.GetTaskTrace(grpcCtx, grpcReq)
+	if err != nil {
+		logger.FromContext(ctx, s.log).Error("Ошибка gRPC вызова GetTaskTrace из TaskService", zap.Error(err), zap.String("userID", userID), zap.String("taskID", taskID))
+		return nil, s.wrapTaskStateError("ошибка получения трассировки задачи", err)
+	}
+
+	trace := &TaskTrace{
+		TaskID:        grpcRes.GetTaskId(),
+		AttemptNumber: grpcRes.GetAttemptNumber(),
+		Operations:    make([]TaskOperation, 0, len(grpcRes.GetOperations())),
+	}
+	for _, pbOp := range grpcRes.GetOperations() {
+		trace.Operations = append(trace.Operations, *s.operationFromProto(pbOp))
+	}
+	if grpcRes.GetTree() != nil {
+		trace.Tree = s.traceNodeFromProto(grpcRes.GetTree())
+	}
+	return trace, nil
+}
+
+func (s *taskService) operationFromProto(pbOp *pb_orchestrator.TaskOperation) *TaskOperation {
+	op := &TaskOperation{
+		Seq:           pbOp.GetSeq(),
+		OperationID:   pbOp.GetOperationId(),
+		NodePath:      pbOp.GetNodePath(),
+		Symbol:        pbOp.GetSymbol(),
+		OperandA:      pbOp.GetOperandA(),
+		OperandB:      pbOp.GetOperandB(),
+		WorkerAddress: pbOp.GetWorkerAddress(),
+		DurationMs:    pbOp.GetDurationMs(),
+	}
+	if startedAt, err := time.Parse(time.RFC3339Nano, pbOp.GetStartedAt()); err == nil {
+		op.StartedAt = startedAt
+	} else {
+		s.log.Warn("Не удалось распарсить StartedAt операции", zap.Error(err), zap.String("value", pbOp.GetStartedAt()))
+	}
+	if pbOp.GetErrorMessage() != "" {
+		errMsgCopy := pbOp.GetErrorMessage()
+		op.ErrorMessage = &errMsgCopy
+	} else {
+		resCopy := pbOp.GetResult()
+		op.Result = &resCopy
+	}
+	return op
+}
+
+func (s *taskService) traceNodeFromProto(pbNode *pb_orchestrator.TraceNode) *TraceNode {
+	node := &TraceNode{
+		NodePath: pbNode.GetNodePath(),
+		Kind:     pbNode.GetKind(),
+		Symbol:   pbNode.GetSymbol(),
+	}
+	if pbNode.GetKind() == "number" {
+		valueCopy := pbNode.GetValue()
+		node.Value = &valueCopy
+	}
+	if pbNode.GetOperation() != nil {
+		node.Operation = s.operationFromProto(pbNode.GetOperation())
+	}
+	for _, child := range pbNode.GetChildren() {
+		node.Children = append(node.Children, s.traceNodeFromProto(child))
+	}
+	return node
+}
+
+func (s *taskService) WaitForTask(ctx context.Context, userID, taskID string, wait time.Duration) (*TaskDetails, error) {
+	waitCtx, cancel := context.WithTimeout(ctx, wait)
+	defer cancel()
+
+	err := s.WatchTask(waitCtx, userID, taskID, func(TaskEvent) error { return nil })
+	if err != nil && waitCtx.Err() == nil {
+		return nil, err
+	}
+	// Детали нужны и после истечения ожидания, поэтому запрашиваются вне отмененного контекста.
+	return s.GetTaskDetails(context.WithoutCancel(ctx), userID, taskID)
+}
+
+func (s *taskService) WatchTask(ctx context.Context, userID, taskID string, onEvent func(TaskEvent) error) error {
+	log := logger.FromContext(ctx, s.log)
+	// Поток живет, пока жив HTTP запрос, поэтому общий таймаут gRPC клиента здесь не применяется.
+	stream, err := s.orchestratorClient.WatchTask(ctx, &pb_orchestrator.WatchTaskRequest{UserId: userID, TaskId: taskID})
+	if err != nil {
+		log.Error("Ошибка gRPC вызова WatchTask из TaskService", zap.Error(err), zap.String("userID", userID), zap.String("taskID", taskID))
+		return s.wrapTaskStateError("ошибка подписки на события задачи", err)
+	}
+
+	for {
+		pbEvent, err := stream.Recv()
+		if errors.Is(err, io.EOF) {
+			return nil
+		}
+		if err != nil {
+			if ctx.Err() != nil {
+				return ctx.Err()
+			}
+			log.Warn("Поток событий задачи прерван", zap.Error(err), zap.String("userID", userID), zap.String("taskID", taskID))
+			return s.wrapTaskStateError("ошибка получения событий задачи", err)
+		}
+
+		event := TaskEvent{
+			TaskID:          pbEvent.GetTaskId(),
+			Status:          pbEvent.GetStatus(),
+			AttemptNumber:   pbEvent.GetAttemptNumber(),
+			QueuePosition:   pbEvent.GetQueuePosition(),
+			OperationsDone:  pbEvent.GetOperationsDone(),
+			OperationsTotal: pbEvent.GetOperationsTotal(),
+		}
+		if ts, tErr := time.Parse(time.RFC3339Nano, pbEvent.GetTimestamp()); tErr == nil {
+			event.Timestamp = ts
+		}
+		if pbEvent.GetStatus() == repository.StatusCompleted {
+			resCopy := pbEvent.GetResult()
+			event.Result = &resCopy
+		}
+		if pbEvent.GetStatus() == repository.StatusFailed && pbEvent.GetErrorMessage() != "" {
+			errMsgCopy := pbEvent.GetErrorMessage()
+			event.ErrorMessage = &errMsgCopy
+		}
+		if pbEvent.GetStatus() == repository.StatusFailed && pbEvent.GetErrorCode() != "" {
+			errCodeCopy := pbEvent.GetErrorCode()
+			event.ErrorCode = &errCodeCopy
+		}
+
+		if err := onEvent(event); err != nil {
+			return err
+		}
+		if event.Terminal() {
+			return nil
+		}
+	}
+}
+
+func (s *taskService) wrapTaskStateError(prefix string, err error) error {
+	st, ok := status.FromError(err)
+	if ok {
+		switch st.Code() {
+		case codes.NotFound:
+			return fmt.Errorf("%w: %w", ErrTaskNotFound, err)
+		case codes.FailedPrecondition:
+			return fmt.Errorf("%w: %s", ErrTaskStateConflict, st.Message())
+		}
+	}
+	return fmt.Errorf("%s: %w", prefix, err)
+}
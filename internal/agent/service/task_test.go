@@ -14,6 +14,8 @@ import (
 	"github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/require"
 	"go.uber.org/zap"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
 )
@@ -42,9 +44,174 @@ func TestTaskService_SubmitNewTask_Success(t *testing.T) {
 		&pb.ExpressionRequest{UserId: userID, Expression: expression},
 	).Return(&pb.ExpressionResponse{TaskId: expectedTaskID}, nil).Once()
 
-	taskID, err := ts.SubmitNewTask(ctx, userID, expression)
+	submitted, err := ts.SubmitNewTask(ctx, userID, expression, SubmitOptions{})
 	require.NoError(t, err)
-	assert.Equal(t, expectedTaskID, taskID)
+	assert.Equal(t, expectedTaskID, submitted.TaskID)
+	mockOrcClient.AssertExpectations(t)
+}
+
+func TestTaskService_SubmitNewTask_IdempotentReplay(t *testing.T) {
+	ts, mockOrcClient := setupTaskServiceTest(t)
+	userID := uuid.New().String()
+	taskID := uuid.New().String()
+
+	mockOrcClient.On("SubmitExpression",
+		mock.AnythingOfType("*context.timerCtx"),
+		&pb.ExpressionRequest{UserId: userID, Expression: "2+2", IdempotencyKey: "key-1"},
+	).Return(&pb.ExpressionResponse{TaskId: taskID, IdempotentReplay: true}, nil).Once()
+
+	submitted, err := ts.SubmitNewTask(context.Background(), userID, "2+2", SubmitOptions{IdempotencyKey: "key-1"})
+	require.NoError(t, err)
+	assert.Equal(t, taskID, submitted.TaskID)
+	assert.True(t, submitted.Replayed)
+	mockOrcClient.AssertExpectations(t)
+}
+
+func TestTaskService_SubmitNewTask_IdempotencyKeyReused(t *testing.T) {
+	ts, mockOrcClient := setupTaskServiceTest(t)
+
+	mockOrcClient.On("SubmitExpression", mock.AnythingOfType("*context.timerCtx"), mock.Anything).
+		Return(nil, status.Error(codes.AlreadyExists, "ключ идемпотентности уже использован для другого запроса")).Once()
+
+	_, err := ts.SubmitNewTask(context.Background(), uuid.New().String(), "3+3", SubmitOptions{IdempotencyKey: "key-1"})
+	assert.ErrorIs(t, err, ErrIdempotencyKeyReused)
+	mockOrcClient.AssertExpectations(t)
+}
+
+func TestTaskService_SubmitNewTask_Estimate(t *testing.T) {
+	ts, mockOrcClient := setupTaskServiceTest(t)
+	userID := uuid.New().String()
+	taskID := uuid.New().String()
+
+	mockOrcClient.On("SubmitExpression",
+		mock.AnythingOfType("*context.timerCtx"),
+		&pb.ExpressionRequest{UserId: userID, Expression: "(1+2)*3", MaxEstimatedDurationMs: 10000},
+	).Return(&pb.ExpressionResponse{TaskId: taskID, Estimate: &pb.CostEstimate{
+		Operations:             []*pb.OperatorCount{{Symbol: "*", Count: 1}, {Symbol: "+", Count: 1}},
+		CriticalPathOperations: 2,
+		CriticalPathMs:         500,
+		TotalWorkMs:            500,
+		Parallelism:            16,
+		EstimatedDurationMs:    500,
+	}}, nil).Once()
+
+	submitted, err := ts.SubmitNewTask(context.Background(), userID, "(1+2)*3", SubmitOptions{MaxEstimatedDuration: 10 * time.Second})
+	require.NoError(t, err)
+	assert.Equal(t, &CostEstimate{
+		Operations:             map[string]int32{"*": 1, "+": 1},
+		CriticalPathOperations: 2,
+		CriticalPathMs:         500,
+		TotalWorkMs:            500,
+		Parallelism:            16,
+		EstimatedDurationMs:    500,
+	}, submitted.Estimate)
+	mockOrcClient.AssertExpectations(t)
+}
+
+func TestTaskService_SubmitNewTask_EstimateExceeded(t *testing.T) {
+	ts, mockOrcClient := setupTaskServiceTest(t)
+
+	mockOrcClient.On("SubmitExpression", mock.AnythingOfType("*context.timerCtx"), mock.Anything).
+		Return(nil, status.Error(codes.FailedPrecondition, "оценка времени вычисления 3s превышает max_estimated_duration 1s")).Once()
+
+	_, err := ts.SubmitNewTask(context.Background(), uuid.New().String(), "(1+2)*3", SubmitOptions{MaxEstimatedDuration: time.Second})
+	assert.ErrorIs(t, err, ErrEstimateExceeded)
+	assert.Contains(t, err.Error(), "3s")
+	mockOrcClient.AssertExpectations(t)
+}
+
+func TestTaskService_SubmitNewTask_InvalidExpression(t *testing.T) {
+	ts, mockOrcClient := setupTaskServiceTest(t)
+
+	st, err := status.New(codes.InvalidArgument, "превышен лимит EXPR_MAX_NODES: 1500 узлов в дереве выражения, максимум 1000").
+		WithDetails(&errdetails.ErrorInfo{Reason: ExpressionReasonLimitExceeded, Metadata: map[string]string{"limit": "EXPR_MAX_NODES"}})
+	require.NoError(t, err)
+	mockOrcClient.On("SubmitExpression", mock.AnythingOfType("*context.timerCtx"), mock.Anything).
+		Return(nil, st.Err()).Once()
+
+	_, err = ts.SubmitNewTask(context.Background(), uuid.New().String(), "1+1", SubmitOptions{})
+	assert.ErrorIs(t, err, ErrInvalidExpression)
+	assert.Contains(t, err.Error(), "EXPR_MAX_NODES")
+	assert.NotContains(t, err.Error(), "rpc error")
+	var rejected *ExpressionRejectedError
+	require.ErrorAs(t, err, &rejected)
+	assert.Equal(t, ExpressionReasonLimitExceeded, rejected.Reason)
+	assert.Equal(t, "EXPR_MAX_NODES", rejected.Limit)
+	mockOrcClient.AssertExpectations(t)
+}
+
+func TestTaskService_SubmitBatch_Success(t *testing.T) {
+	ts, mockOrcClient := setupTaskServiceTest(t)
+	ctx := context.Background()
+	userID := uuid.New().String()
+	taskID := uuid.New().String()
+
+	mockOrcClient.On("SubmitExpressions",
+		mock.AnythingOfType("*context.timerCtx"),
+		&pb.BatchExpressionRequest{UserId: userID, Items: []*pb.BatchExpressionItem{
+			{Expression: "2+2", CallbackUrl: "https://example.com/hook"},
+			{Expression: "2+"},
+		}},
+	).Return(&pb.BatchExpressionResponse{
+		Accepted: 1,
+		Rejected: 1,
+		Results: []*pb.BatchExpressionResult{
+			{Index: 0, TaskId: taskID, QueuePosition: 2},
+			{Index: 1, Error: "ошибка в выражении: unexpected token EOF"},
+		},
+	}, nil).Once()
+
+	submission, err := ts.SubmitBatch(ctx, userID, []BatchItem{
+		{Expression: "2+2", CallbackURL: "https://example.com/hook"},
+		{Expression: "2+"},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, int32(1), submission.Accepted)
+	assert.Equal(t, int32(1), submission.Rejected)
+	require.Len(t, submission.Results, 2)
+	assert.Equal(t, BatchItemResult{Index: 0, TaskID: taskID, QueuePosition: 2}, submission.Results[0])
+	assert.Equal(t, "ошибка в выражении: unexpected token EOF", submission.Results[1].Error)
+	mockOrcClient.AssertExpectations(t)
+}
+
+func TestTaskService_SubmitBatch_QueueFull(t *testing.T) {
+	ts, mockOrcClient := setupTaskServiceTest(t)
+
+	mockOrcClient.On("SubmitExpressions", mock.AnythingOfType("*context.timerCtx"), mock.Anything).
+		Return(nil, status.Error(codes.ResourceExhausted, "очередь вычислений переполнена")).Once()
+
+	_, err := ts.SubmitBatch(context.Background(), uuid.New().String(), []BatchItem{{Expression: "1+1"}})
+	assert.ErrorIs(t, err, ErrServiceOverloaded)
+	mockOrcClient.AssertExpectations(t)
+}
+
+func TestTaskService_ValidateExpression_Invalid(t *testing.T) {
+	ts, mockOrcClient := setupTaskServiceTest(t)
+	userID := uuid.New().String()
+
+	mockOrcClient.On("ValidateExpression",
+		mock.AnythingOfType("*context.timerCtx"),
+		&pb.ValidateExpressionRequest{UserId: userID, Expression: "2 * )"},
+	).Return(&pb.ValidateExpressionResponse{
+		Errors: []*pb.ExpressionError{{Message: "unexpected token Bracket(\")\")", Line: 1, Column: 5, Token: ")"}},
+	}, nil).Once()
+
+	validation, err := ts.ValidateExpression(context.Background(), userID, "2 * )")
+	require.NoError(t, err)
+	assert.False(t, validation.Valid)
+	assert.Equal(t, []ExpressionError{{Message: "unexpected token Bracket(\")\")", Line: 1, Column: 5, Token: ")"}}, validation.Errors)
+	mockOrcClient.AssertExpectations(t)
+}
+
+func TestTaskService_ValidateExpression_Valid(t *testing.T) {
+	ts, mockOrcClient := setupTaskServiceTest(t)
+
+	mockOrcClient.On("ValidateExpression", mock.AnythingOfType("*context.timerCtx"), mock.Anything).
+		Return(&pb.ValidateExpressionResponse{Valid: true, Normalized: "2 + 2", NodeCount: 3, OperationCount: 1, Depth: 2}, nil).Once()
+
+	validation, err := ts.ValidateExpression(context.Background(), uuid.New().String(), "2+2")
+	require.NoError(t, err)
+	assert.Equal(t, &ExpressionValidation{Valid: true, Errors: []ExpressionError{}, Normalized: "2 + 2", NodeCount: 3, OperationCount: 1, Depth: 2}, validation)
 	mockOrcClient.AssertExpectations(t)
 }
 
@@ -58,7 +225,7 @@ func TestTaskService_SubmitNewTask_gRPCError(t *testing.T) {
 		mock.AnythingOfType("*orchestrator_grpc.ExpressionRequest"),
 	).Return(nil, originalGrpcErr).Once()
 
-	_, err := ts.SubmitNewTask(ctx, uuid.New().String(), "3*3")
+	_, err := ts.SubmitNewTask(ctx, uuid.New().String(), "3*3", SubmitOptions{})
 	require.Error(t, err, "SubmitNewTask должен вернуть ошибку")
 
 	assert.Contains(t, err.Error(), "ошибка сервиса вычислений: ", "Сообщение об ошибке должно начинаться с префикса сервиса")
@@ -75,6 +242,22 @@ func TestTaskService_SubmitNewTask_gRPCError(t *testing.T) {
 	mockOrcClient.AssertExpectations(t)
 }
 
+func TestTaskService_SubmitNewTask_QueueFull(t *testing.T) {
+	ts, mockOrcClient := setupTaskServiceTest(t)
+	ctx := context.Background()
+	grpcErr := status.Error(codes.ResourceExhausted, "очередь вычислений переполнена")
+
+	mockOrcClient.On("SubmitExpression",
+		mock.AnythingOfType("*context.timerCtx"),
+		mock.AnythingOfType("*orchestrator_grpc.ExpressionRequest"),
+	).Return(nil, grpcErr).Once()
+
+	_, err := ts.SubmitNewTask(ctx, uuid.New().String(), "1+1", SubmitOptions{})
+	require.Error(t, err)
+	assert.ErrorIs(t, err, ErrServiceOverloaded, "Ошибка должна быть ErrServiceOverloaded")
+	mockOrcClient.AssertExpectations(t)
+}
+
 func TestTaskService_GetUserTasks_Success(t *testing.T) {
 	ts, mockOrcClient := setupTaskServiceTest(t)
 	ctx := context.Background()
@@ -85,18 +268,24 @@ func TestTaskService_GetUserTasks_Success(t *testing.T) {
 
 	mockResponse := &pb.UserTasksResponse{
 		Tasks: []*pb.TaskBrief{
-			{Id: uuid.New().String(), Expression: "1+1", Status: "completed", CreatedAt: nowStr},
+			{Id: uuid.New().String(), Expression: "1+1", Status: "completed", Result: 2, CreatedAt: nowStr},
 			{Id: uuid.New().String(), Expression: "2*2", Status: "pending", CreatedAt: nowStr},
 		},
+		NextPageToken: "next",
 	}
 	mockOrcClient.On("ListUserTasks",
 		mock.AnythingOfType("*context.timerCtx"),
-		&pb.UserTasksRequest{UserId: userID},
+		&pb.UserTasksRequest{UserId: userID, PageSize: 2, Statuses: []string{"completed", "pending"}},
 	).Return(mockResponse, nil).Once()
 
-	tasks, err := ts.GetUserTasks(ctx, userID)
+	page, err := ts.GetUserTasks(ctx, userID, TaskListQuery{PageSize: 2, Statuses: []string{"completed", "pending"}})
 	require.NoError(t, err)
+	assert.Equal(t, "next", page.NextPageToken)
+	tasks := page.Tasks
 	require.Len(t, tasks, 2)
+	require.NotNil(t, tasks[0].Result)
+	assert.Equal(t, 2.0, *tasks[0].Result)
+	assert.Nil(t, tasks[1].Result)
 	assert.Equal(t, mockResponse.Tasks[0].Id, tasks[0].ID)
 	assert.Equal(t, mockResponse.Tasks[1].Expression, tasks[1].Expression)
 
@@ -139,6 +328,28 @@ func TestTaskService_GetTaskDetails_Success(t *testing.T) {
 	mockOrcClient.AssertExpectations(t)
 }
 
+func TestTaskService_GetTaskDetails_Failed(t *testing.T) {
+	ts, mockOrcClient := setupTaskServiceTest(t)
+	userID := uuid.New().String()
+	taskID := uuid.New().String()
+	nowStr := time.Now().Format(time.RFC3339Nano)
+
+	mockOrcClient.On("GetTaskDetails", mock.Anything, &pb.TaskDetailsRequest{UserId: userID, TaskId: taskID}).
+		Return(&pb.TaskDetailsResponse{
+			Id: taskID, Expression: "1/0", Status: "failed",
+			ErrorMessage: "деление на ноль", ErrorCode: "DIVISION_BY_ZERO",
+			CreatedAt: nowStr, UpdatedAt: nowStr,
+		}, nil).Once()
+
+	details, err := ts.GetTaskDetails(context.Background(), userID, taskID)
+	require.NoError(t, err)
+	assert.Nil(t, details.Result)
+	require.NotNil(t, details.ErrorMessage)
+	assert.Equal(t, "деление на ноль", *details.ErrorMessage)
+	require.NotNil(t, details.ErrorCode)
+	assert.Equal(t, "DIVISION_BY_ZERO", *details.ErrorCode)
+}
+
 func TestTaskService_GetTaskDetails_NotFound(t *testing.T) {
 	ts, mockOrcClient := setupTaskServiceTest(t)
 	ctx := context.Background()
@@ -160,3 +371,344 @@ func TestTaskService_GetTaskDetails_NotFound(t *testing.T) {
 	assert.Contains(t, err.Error(), "задача не найдена в оркестраторе", "Сообщение должно содержать детали gRPC ошибки")
 	mockOrcClient.AssertExpectations(t)
 }
+
+func TestTaskService_RetryTask_Success(t *testing.T) {
+	ts, mockOrcClient := setupTaskServiceTest(t)
+	ctx := context.Background()
+	userID := uuid.New().String()
+	taskID := uuid.New().String()
+
+	mockOrcClient.On("RetryTask",
+		mock.AnythingOfType("*context.timerCtx"),
+		&pb.RetryTaskRequest{UserId: userID, TaskId: taskID},
+	).Return(&pb.RetryTaskResponse{TaskId: taskID, AttemptNumber: 2}, nil).Once()
+
+	retried, err := ts.RetryTask(ctx, userID, taskID)
+	require.NoError(t, err)
+	assert.Equal(t, taskID, retried.TaskID)
+	assert.Equal(t, int32(2), retried.AttemptNumber)
+	mockOrcClient.AssertExpectations(t)
+}
+
+func TestTaskService_RetryTask_NotRetryable(t *testing.T) {
+	ts, mockOrcClient := setupTaskServiceTest(t)
+	ctx := context.Background()
+	grpcErr := status.Error(codes.FailedPrecondition, "задача в статусе 'processing' не может быть перезапущена")
+
+	mockOrcClient.On("RetryTask",
+		mock.AnythingOfType("*context.timerCtx"),
+		mock.AnythingOfType("*orchestrator_grpc.RetryTaskRequest"),
+	).Return(nil, grpcErr).Once()
+
+	_, err := ts.RetryTask(ctx, uuid.New().String(), uuid.New().String())
+	require.Error(t, err)
+	assert.ErrorIs(t, err, ErrTaskNotRetryable, "Ошибка должна быть ErrTaskNotRetryable")
+	mockOrcClient.AssertExpectations(t)
+}
+
+func TestTaskService_DeleteTask_Conflict(t *testing.T) {
+	ts, mockOrcClient := setupTaskServiceTest(t)
+	ctx := context.Background()
+	userID := uuid.New().String()
+	taskID := uuid.New().String()
+	grpcErr := status.Error(codes.FailedPrecondition, "задача в статусе 'processing' не может быть удалена")
+
+	mockOrcClient.On("DeleteTask",
+		mock.AnythingOfType("*context.timerCtx"),
+		&pb.DeleteTaskRequest{UserId: userID, TaskId: taskID, Purge: true},
+	).Return(nil, grpcErr).Once()
+
+	err := ts.DeleteTask(ctx, userID, taskID, true)
+	require.Error(t, err)
+	assert.ErrorIs(t, err, ErrTaskStateConflict)
+	assert.Contains(t, err.Error(), "не может быть удалена")
+	mockOrcClient.AssertExpectations(t)
+}
+
+func TestTaskService_RestoreTask_Success(t *testing.T) {
+	ts, mockOrcClient := setupTaskServiceTest(t)
+	ctx := context.Background()
+	userID := uuid.New().String()
+	taskID := uuid.New().String()
+
+	mockOrcClient.On("RestoreTask",
+		mock.AnythingOfType("*context.timerCtx"),
+		&pb.RestoreTaskRequest{UserId: userID, TaskId: taskID},
+	).Return(&pb.RestoreTaskResponse{}, nil).Once()
+
+	require.NoError(t, ts.RestoreTask(ctx, userID, taskID))
+	mockOrcClient.AssertExpectations(t)
+}
+
+func TestTaskService_GetTaskTrace_Success(t *testing.T) {
+	ts, mockOrcClient := setupTaskServiceTest(t)
+	ctx := context.Background()
+	userID := uuid.New().String()
+	taskID := uuid.New().String()
+	startedAt := time.Now().UTC().Truncate(time.Millisecond)
+
+	rootOp := &pb.TaskOperation{Seq: 1, OperationId: "op-1", NodePath: "0", Symbol: "neg", OperandA: 5,
+		Result: -5, WorkerAddress: "10.0.0.2:50052", StartedAt: startedAt.Format(time.RFC3339Nano), DurationMs: 1.2}
+	mockOrcClient.On("GetTaskTrace",
+		mock.AnythingOfType("*context.timerCtx"),
+		&pb.TaskTraceRequest{UserId: userID, TaskId: taskID},
+	).Return(&pb.TaskTraceResponse{
+		TaskId:        taskID,
+		AttemptNumber: 1,
+		Operations:    []*pb.TaskOperation{rootOp},
+		Tree: &pb.TraceNode{NodePath: "0", Kind: "unary", Symbol: "-", Operation: rootOp, Children: []*pb.TraceNode{
+			{NodePath: "0.0", Kind: "number", Symbol: "5", Value: 5},
+		}},
+	}, nil).Once()
+
+	trace, err := ts.GetTaskTrace(ctx, userID, taskID)
+	require.NoError(t, err)
+	require.Len(t, trace.Operations, 1)
+	assert.Equal(t, startedAt, trace.Operations[0].StartedAt)
+	require.NotNil(t, trace.Operations[0].Result)
+	assert.Equal(t, -5.0, *trace.Operations[0].Result)
+	assert.Nil(t, trace.Operations[0].ErrorMessage)
+
+	require.NotNil(t, trace.Tree)
+	require.NotNil(t, trace.Tree.Operation)
+	assert.Equal(t, "op-1", trace.Tree.Operation.OperationID)
+	require.Len(t, trace.Tree.Children, 1)
+	require.NotNil(t, trace.Tree.Children[0].Value)
+	assert.Equal(t, 5.0, *trace.Tree.Children[0].Value)
+	mockOrcClient.AssertExpectations(t)
+}
+
+func TestTaskService_GetTaskTrace_NotFound(t *testing.T) {
+	ts, mockOrcClient := setupTaskServiceTest(t)
+	ctx := context.Background()
+	userID := uuid.New().String()
+	taskID := uuid.New().String()
+
+	mockOrcClient.On("GetTaskTrace",
+		mock.AnythingOfType("*context.timerCtx"),
+		&pb.TaskTraceRequest{UserId: userID, TaskId: taskID},
+	).Return(nil, status.Error(codes.NotFound, "задача не найдена")).Once()
+
+	_, err := ts.GetTaskTrace(ctx, userID, taskID)
+	assert.ErrorIs(t, err, ErrTaskNotFound)
+	mockOrcClient.AssertExpectations(t)
+}
+
+func TestTaskService_GetTaskAST_Success(t *testing.T) {
+	ts, mockOrcClient := setupTaskServiceTest(t)
+	ctx := context.Background()
+	userID := uuid.New().String()
+	taskID := uuid.New().String()
+
+	mockOrcClient.On("GetTaskAST",
+		mock.AnythingOfType("*context.timerCtx"),
+		&pb.TaskASTRequest{UserId: userID, TaskId: taskID},
+	).Return(&pb.TaskASTResponse{
+		TaskId:        taskID,
+		Status:        "failed",
+		AttemptNumber: 1,
+		Root: &pb.AstNode{NodePath: "0", Kind: "binary", Operator: "/", Status: "failed", DurationMs: 2,
+			CriticalPathMs: 2, ErrorMessage: "деление на \"ноль\"", Children: []*pb.AstNode{
+				{NodePath: "0.0", Kind: "number", Operator: "1", HasValue: true, Value: 1, Status: "completed"},
+				{NodePath: "0.1", Kind: "number", Operator: "0", HasValue: true, Value: 0, Status: "completed"},
+			}},
+	}, nil).Once()
+
+	taskAST, err := ts.GetTaskAST(ctx, userID, taskID)
+	require.NoError(t, err)
+	require.NotNil(t, taskAST.Root)
+	assert.Nil(t, taskAST.Root.Value)
+	assert.Equal(t, "failed", taskAST.Root.Status)
+	require.Len(t, taskAST.Root.Children, 2)
+	require.NotNil(t, taskAST.Root.Children[1].Value)
+	assert.Equal(t, 0.0, *taskAST.Root.Children[1].Value)
+
+	assert.Contains(t, taskAST.Dot, "digraph ast {")
+	assert.Contains(t, taskAST.Dot, `"n0" [label="/\n2.0 мс\nделение на \"ноль\"", fillcolor=lightcoral];`)
+	assert.Contains(t, taskAST.Dot, `"n0" -> "n0.1";`)
+	mockOrcClient.AssertExpectations(t)
+}
+
+func TestTaskService_GetTaskAST_NotFound(t *testing.T) {
+	ts, mockOrcClient := setupTaskServiceTest(t)
+	ctx := context.Background()
+	userID := uuid.New().String()
+	taskID := uuid.New().String()
+
+	mockOrcClient.On("GetTaskAST",
+		mock.AnythingOfType("*context.timerCtx"),
+		&pb.TaskASTRequest{UserId: userID, TaskId: taskID},
+	).Return(nil, status.Error(codes.NotFound, "задача не найдена")).Once()
+
+	_, err := ts.GetTaskAST(ctx, userID, taskID)
+	assert.ErrorIs(t, err, ErrTaskNotFound)
+	mockOrcClient.AssertExpectations(t)
+}
+
+type fakeTaskEventStream struct {
+	grpc.ClientStream
+	events []*pb.TaskEvent
+	err    error
+}
+
+func (f *fakeTaskEventStream) Recv() (*pb.TaskEvent, error) {
+	if len(f.events) == 0 {
+		return nil, f.err
+	}
+	event := f.events[0]
+	f.events = f.events[1:]
+	return event, nil
+}
+
+func TestTaskService_WatchTask_StopsOnTerminal(t *testing.T) {
+	ts, mockOrcClient := setupTaskServiceTest(t)
+	ctx := context.Background()
+	userID := uuid.New().String()
+	taskID := uuid.New().String()
+
+	stream := &fakeTaskEventStream{events: []*pb.TaskEvent{
+		{TaskId: taskID, Status: "processing", OperationsDone: 1, OperationsTotal: 2},
+		{TaskId: taskID, Status: "completed", OperationsDone: 2, OperationsTotal: 2, Result: 7},
+		{TaskId: taskID, Status: "completed"},
+	}}
+	mockOrcClient.On("WatchTask", mock.Anything, &pb.WatchTaskRequest{UserId: userID, TaskId: taskID}).
+		Return(stream, nil).Once()
+
+	var received []TaskEvent
+	err := ts.WatchTask(ctx, userID, taskID, func(event TaskEvent) error {
+		received = append(received, event)
+		return nil
+	})
+	require.NoError(t, err)
+	require.Len(t, received, 2)
+	assert.Nil(t, received[0].Result)
+	require.NotNil(t, received[1].Result)
+	assert.Equal(t, 7.0, *received[1].Result)
+	mockOrcClient.AssertExpectations(t)
+}
+
+func TestTaskService_WatchTask_NotFound(t *testing.T) {
+	ts, mockOrcClient := setupTaskServiceTest(t)
+	userID := uuid.New().String()
+	taskID := uuid.New().String()
+
+	stream := &fakeTaskEventStream{err: status.Error(codes.NotFound, "задача не найдена")}
+	mockOrcClient.On("WatchTask", mock.Anything, &pb.WatchTaskRequest{UserId: userID, TaskId: taskID}).
+		Return(stream, nil).Once()
+
+	err := ts.WatchTask(context.Background(), userID, taskID, func(TaskEvent) error {
+		t.Fatal("событий быть не должно")
+		return nil
+	})
+	assert.ErrorIs(t, err, ErrTaskNotFound)
+}
+
+// blockingTaskEventStream не присылает событий, пока не отменен контекст потока.
+type blockingTaskEventStream struct {
+	grpc.ClientStream
+	ctx context.Context
+}
+
+func (b *blockingTaskEventStream) Recv() (*pb.TaskEvent, error) {
+	<-b.ctx.Done()
+	return nil, status.FromContextError(b.ctx.Err()).Err()
+}
+
+func TestTaskService_WaitForTask_ReturnsDetailsAfterTerminalEvent(t *testing.T) {
+	ts, mockOrcClient := setupTaskServiceTest(t)
+	userID := uuid.New().String()
+	taskID := uuid.New().String()
+
+	stream := &fakeTaskEventStream{events: []*pb.TaskEvent{
+		{TaskId: taskID, Status: "processing"},
+		{TaskId: taskID, Status: "completed", Result: 4},
+	}}
+	mockOrcClient.On("WatchTask", mock.Anything, &pb.WatchTaskRequest{UserId: userID, TaskId: taskID}).
+		Return(stream, nil).Once()
+	mockOrcClient.On("GetTaskDetails", mock.AnythingOfType("*context.timerCtx"), &pb.TaskDetailsRequest{UserId: userID, TaskId: taskID}).
+		Return(&pb.TaskDetailsResponse{Id: taskID, Status: "completed", Result: 4}, nil).Once()
+
+	details, err := ts.WaitForTask(context.Background(), userID, taskID, time.Second)
+	require.NoError(t, err)
+	assert.Equal(t, "completed", details.Status)
+	assert.True(t, details.Terminal())
+	mockOrcClient.AssertExpectations(t)
+}
+
+func TestTaskService_WaitForTask_ReturnsDetailsWhenWaitExpires(t *testing.T) {
+	ts, mockOrcClient := setupTaskServiceTest(t)
+	userID := uuid.New().String()
+	taskID := uuid.New().String()
+
+	mockOrcClient.On("WatchTask", mock.Anything, &pb.WatchTaskRequest{UserId: userID, TaskId: taskID}).
+		Return(func(ctx context.Context, _ *pb.WatchTaskRequest, _ ...grpc.CallOption) (grpc.ServerStreamingClient[pb.TaskEvent], error) {
+			return &blockingTaskEventStream{ctx: ctx}, nil
+		}).Once()
+	mockOrcClient.On("GetTaskDetails", mock.AnythingOfType("*context.timerCtx"), &pb.TaskDetailsRequest{UserId: userID, TaskId: taskID}).
+		Return(&pb.TaskDetailsResponse{Id: taskID, Status: "processing"}, nil).Once()
+
+	started := time.Now()
+	details, err := ts.WaitForTask(context.Background(), userID, taskID, 50*time.Millisecond)
+	require.NoError(t, err)
+	assert.GreaterOrEqual(t, time.Since(started), 50*time.Millisecond)
+	assert.Equal(t, "processing", details.Status)
+	assert.False(t, details.Terminal())
+	mockOrcClient.AssertExpectations(t)
+}
+
+func TestTaskService_WaitForTask_NotFound(t *testing.T) {
+	ts, mockOrcClient := setupTaskServiceTest(t)
+
+	stream := &fakeTaskEventStream{err: status.Error(codes.NotFound, "задача не найдена")}
+	mockOrcClient.On("WatchTask", mock.Anything, mock.Anything).Return(stream, nil).Once()
+
+	_, err := ts.WaitForTask(context.Background(), uuid.New().String(), uuid.New().String(), time.Second)
+	assert.ErrorIs(t, err, ErrTaskNotFound)
+	mockOrcClient.AssertNotCalled(t, "GetTaskDetails", mock.Anything, mock.Anything)
+}
+
+func TestTaskService_GetUserStats_Success(t *testing.T) {
+	ts, mockOrcClient := setupTaskServiceTest(t)
+	userID := uuid.New().String()
+
+	mockOrcClient.On("GetUserStats", mock.Anything, &pb.UserStatsRequest{UserId: userID}).Return(&pb.UserStatsResponse{
+		Total: 5,
+		StatusCounts: []*pb.StatusCount{
+			{Status: "completed", Count: 3},
+			{Status: "failed", Count: 1},
+			{Status: "pending", Count: 1},
+		},
+		SuccessRate:          0.75,
+		CompletedMeasured:    3,
+		AvgCompletionSeconds: 1.5,
+		P95CompletionSeconds: 2.9,
+		Operators:            []*pb.OperatorUsage{{Symbol: "+", Count: 7, Failed: 1, AvgDurationMs: 200}},
+	}, nil).Once()
+
+	stats, err := ts.GetUserStats(context.Background(), userID)
+	require.NoError(t, err)
+	assert.Equal(t, int64(5), stats.Total)
+	assert.Equal(t, map[string]int64{"pending": 1, "processing": 0, "completed": 3, "failed": 1}, stats.ByStatus)
+	require.NotNil(t, stats.SuccessRate)
+	assert.InDelta(t, 0.75, *stats.SuccessRate, 1e-9)
+	require.NotNil(t, stats.CompletionTime.P95Seconds)
+	assert.InDelta(t, 2.9, *stats.CompletionTime.P95Seconds, 1e-9)
+	assert.Equal(t, []OperatorUsage{{Symbol: "+", Count: 7, Failed: 1, AvgDurationMs: 200}}, stats.Operators)
+}
+
+func TestTaskService_GetUserStats_NoFinishedTasks(t *testing.T) {
+	ts, mockOrcClient := setupTaskServiceTest(t)
+	userID := uuid.New().String()
+
+	mockOrcClient.On("GetUserStats", mock.Anything, mock.Anything).Return(&pb.UserStatsResponse{
+		Total:        1,
+		StatusCounts: []*pb.StatusCount{{Status: "pending", Count: 1}},
+	}, nil).Once()
+
+	stats, err := ts.GetUserStats(context.Background(), userID)
+	require.NoError(t, err)
+	assert.Nil(t, stats.SuccessRate)
+	assert.Nil(t, stats.CompletionTime.AvgSeconds)
+	assert.Nil(t, stats.CompletionTime.P95Seconds)
+	assert.Empty(t, stats.Operators)
+}
@@ -0,0 +1,109 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/Qu1nel/YaLyceum-GoProject-Final/internal/pkg/logger"
+	pb_orchestrator "github.com/Qu1nel/YaLyceum-GoProject-Final/proto/gen/orchestrator"
+	"go.uber.org/zap"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+var ErrQuotaExceeded = errors.New("квота пользователя исчерпана")
+
+// QuotaExceededError - Оркестратор отклонил запрос по квоте. RetryAfter - время до обновления квоты.
+type QuotaExceededError struct {
+	Message    string
+	RetryAfter time.Duration
+}
+
+func (e *QuotaExceededError) Error() string { return e.Message }
+
+func (e *QuotaExceededError) Unwrap() error { return ErrQuotaExceeded }
+
+// quotaErrorFromStatus отличает исчерпанную квоту от переполненной очереди: оба приходят как ResourceExhausted,
+// но только квота несет QuotaFailure.
+func quotaErrorFromStatus(st *status.Status) (*QuotaExceededError, bool) {
+	if st.Code() != codes.ResourceExhausted {
+		return nil, false
+	}
+	var quotaErr *QuotaExceededError
+	for _, detail := range st.Details() {
+		switch d := detail.(type) {
+		case *errdetails.QuotaFailure:
+			if quotaErr == nil {
+				quotaErr = &QuotaExceededError{}
+			}
+			quotaErr.Message = st.Message()
+		case *errdetails.RetryInfo:
+			if quotaErr == nil {
+				quotaErr = &QuotaExceededError{}
+			}
+			quotaErr.RetryAfter = d.GetRetryDelay().AsDuration()
+		}
+	}
+	if quotaErr == nil || quotaErr.Message == "" {
+		return nil, false
+	}
+	return quotaErr, true
+}
+
+// UsageCounter - потребление ресурса за период. Limit и Remaining равны null, если квота не ограничена.
+type UsageCounter struct {
+	Used      int64  `json:"used" example:"42"`
+	Limit     *int64 `json:"limit" example:"100"`
+	Remaining *int64 `json:"remaining" example:"58"`
+}
+
+type UsagePeriod struct {
+	Start      time.Time    `json:"start"`
+	ResetAt    time.Time    `json:"reset_at"`
+	Tasks      UsageCounter `json:"tasks"`
+	Operations UsageCounter `json:"operations"`
+	ComputeMs  UsageCounter `json:"compute_ms"`
+}
+
+type Usage struct {
+	Day   UsagePeriod `json:"day"`
+	Month UsagePeriod `json:"month"`
+}
+
+func (s *taskService) GetUsage(ctx context.Context, userID string) (*Usage, error) {
+	grpcCtx, cancel := context.WithTimeout(ctx, s.grpcClientTimeout)
+	defer cancel()
+
+	grpcRes, err := s.orchestratorClient.GetUsage(grpcCtx, &pb_orchestrator.UsageRequest{UserId: userID})
+	if err != nil {
+		logger.FromContext(ctx, s.log).Error("Ошибка gRPC вызова GetUsage из TaskService", zap.Error(err), zap.String("userID", userID))
+		return nil, fmt.Errorf("ошибка получения потребления: %w", err)
+	}
+	return &Usage{
+		Day:   usagePeriodFromProto(grpcRes.GetDay()),
+		Month: usagePeriodFromProto(grpcRes.GetMonth()),
+	}, nil
+}
+
+func usagePeriodFromProto(pbPeriod *pb_orchestrator.UsagePeriod) UsagePeriod {
+	period := UsagePeriod{
+		Tasks:      usageCounterFromProto(pbPeriod.GetTasks()),
+		Operations: usageCounterFromProto(pbPeriod.GetOperations()),
+		ComputeMs:  usageCounterFromProto(pbPeriod.GetComputeMs()),
+	}
+	period.Start, _ = time.Parse(time.RFC3339Nano, pbPeriod.GetStart())
+	period.ResetAt, _ = time.Parse(time.RFC3339Nano, pbPeriod.GetResetAt())
+	return period
+}
+
+func usageCounterFromProto(pbCounter *pb_orchestrator.UsageCounter) UsageCounter {
+	counter := UsageCounter{Used: pbCounter.GetUsed()}
+	if limit := pbCounter.GetLimit(); limit > 0 {
+		remaining := pbCounter.GetRemaining()
+		counter.Limit, counter.Remaining = &limit, &remaining
+	}
+	return counter
+}
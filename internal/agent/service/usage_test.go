@@ -0,0 +1,78 @@
+package service
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	pb "github.com/Qu1nel/YaLyceum-GoProject-Final/proto/gen/orchestrator"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/durationpb"
+)
+
+func TestTaskService_SubmitNewTask_QuotaExceeded(t *testing.T) {
+	ts, mockOrcClient := setupTaskServiceTest(t)
+	userID := uuid.New().String()
+
+	st, err := status.New(codes.ResourceExhausted, "превышена суточная квота задач").WithDetails(
+		&errdetails.QuotaFailure{Violations: []*errdetails.QuotaFailure_Violation{{Subject: "day:tasks"}}},
+		&errdetails.RetryInfo{RetryDelay: durationpb.New(90 * time.Minute)},
+	)
+	require.NoError(t, err)
+	mockOrcClient.On("SubmitExpression", mock.AnythingOfType("*context.timerCtx"), mock.Anything).
+		Return(nil, st.Err()).Once()
+
+	_, err = ts.SubmitNewTask(context.Background(), userID, "2+2", SubmitOptions{})
+	require.ErrorIs(t, err, ErrQuotaExceeded)
+	assert.NotErrorIs(t, err, ErrServiceOverloaded)
+	var quotaErr *QuotaExceededError
+	require.ErrorAs(t, err, &quotaErr)
+	assert.Equal(t, "превышена суточная квота задач", quotaErr.Message)
+	assert.Equal(t, 90*time.Minute, quotaErr.RetryAfter)
+	mockOrcClient.AssertExpectations(t)
+}
+
+func TestTaskService_GetUsage(t *testing.T) {
+	ts, mockOrcClient := setupTaskServiceTest(t)
+	userID := uuid.New().String()
+
+	mockOrcClient.On("GetUsage", mock.AnythingOfType("*context.timerCtx"), &pb.UsageRequest{UserId: userID}).
+		Return(&pb.UsageResponse{
+			Day: &pb.UsagePeriod{
+				Start:      "2025-03-15T00:00:00Z",
+				ResetAt:    "2025-03-16T00:00:00Z",
+				Tasks:      &pb.UsageCounter{Used: 3, Limit: 10, Remaining: 7},
+				Operations: &pb.UsageCounter{Used: 12},
+				ComputeMs:  &pb.UsageCounter{Used: 4500},
+			},
+			Month: &pb.UsagePeriod{
+				Start:      "2025-03-01T00:00:00Z",
+				ResetAt:    "2025-04-01T00:00:00Z",
+				Tasks:      &pb.UsageCounter{Used: 40},
+				Operations: &pb.UsageCounter{Used: 150, Limit: 150, Remaining: 0},
+				ComputeMs:  &pb.UsageCounter{Used: 60000},
+			},
+		}, nil).Once()
+
+	usage, err := ts.GetUsage(context.Background(), userID)
+	require.NoError(t, err)
+
+	assert.Equal(t, time.Date(2025, 3, 16, 0, 0, 0, 0, time.UTC), usage.Day.ResetAt)
+	assert.Equal(t, int64(3), usage.Day.Tasks.Used)
+	require.NotNil(t, usage.Day.Tasks.Limit)
+	assert.Equal(t, int64(10), *usage.Day.Tasks.Limit)
+	assert.Equal(t, int64(7), *usage.Day.Tasks.Remaining)
+	assert.Nil(t, usage.Day.Operations.Limit, "неограниченная квота отдается как null")
+	assert.Nil(t, usage.Day.Operations.Remaining)
+
+	assert.Equal(t, time.Date(2025, 3, 1, 0, 0, 0, 0, time.UTC), usage.Month.Start)
+	require.NotNil(t, usage.Month.Operations.Remaining)
+	assert.Equal(t, int64(0), *usage.Month.Operations.Remaining)
+	mockOrcClient.AssertExpectations(t)
+}
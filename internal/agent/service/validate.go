@@ -0,0 +1,58 @@
+package service
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/Qu1nel/YaLyceum-GoProject-Final/internal/pkg/logger"
+	pb_orchestrator "github.com/Qu1nel/YaLyceum-GoProject-Final/proto/gen/orchestrator"
+	"go.uber.org/zap"
+)
+
+// ExpressionError - ошибка в выражении; line и column считаются с 1.
+type ExpressionError struct {
+	Message string `json:"message" example:"unexpected token Bracket(\")\")"`
+	Line    int32  `json:"line" example:"1"`
+	Column  int32  `json:"column" example:"5"`
+	Token   string `json:"token" example:")"`
+}
+
+type ExpressionValidation struct {
+	Valid          bool              `json:"valid" example:"true"`
+	Errors         []ExpressionError `json:"errors"`
+	Normalized     string            `json:"normalized,omitempty" example:"(2 + 3) * 4"`
+	NodeCount      int32             `json:"node_count,omitempty" example:"5"`
+	OperationCount int32             `json:"operation_count,omitempty" example:"2"`
+	Depth          int32             `json:"depth,omitempty" example:"3"`
+	Estimate       *CostEstimate     `json:"estimate,omitempty"`
+}
+
+func (s *taskService) ValidateExpression(ctx context.Context, userID, expression string) (*ExpressionValidation, error) {
+	grpcCtx, cancel := context.WithTimeout(ctx, s.grpcClientTimeout)
+	defer cancel()
+
+	grpcRes, err := s.orchestratorClient.ValidateExpression(grpcCtx, &pb_orchestrator.ValidateExpressionRequest{UserId: userID, Expression: expression})
+	if err != nil {
+		logger.FromContext(ctx, s.log).Error("Ошибка gRPC вызова ValidateExpression из TaskService", zap.Error(err), zap.String("userID", userID))
+		return nil, fmt.Errorf("ошибка сервиса вычислений: %w", err)
+	}
+
+	validation := &ExpressionValidation{
+		Valid:          grpcRes.GetValid(),
+		Errors:         make([]ExpressionError, 0, len(grpcRes.GetErrors())),
+		Normalized:     grpcRes.GetNormalized(),
+		NodeCount:      grpcRes.GetNodeCount(),
+		OperationCount: grpcRes.GetOperationCount(),
+		Depth:          grpcRes.GetDepth(),
+		Estimate:       costEstimateFromProto(grpcRes.GetEstimate()),
+	}
+	for _, e := range grpcRes.GetErrors() {
+		validation.Errors = append(validation.Errors, ExpressionError{
+			Message: e.GetMessage(),
+			Line:    e.GetLine(),
+			Column:  e.GetColumn(),
+			Token:   e.GetToken(),
+		})
+	}
+	return validation, nil
+}
@@ -0,0 +1,196 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/Qu1nel/YaLyceum-GoProject-Final/internal/agent/config"
+	pb_orchestrator "github.com/Qu1nel/YaLyceum-GoProject-Final/proto/gen/orchestrator"
+	"go.uber.org/zap"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+var (
+	ErrWebhookNotFound       = errors.New("webhook или доставка не найдены")
+	ErrWebhookExists         = errors.New("webhook с таким URL уже зарегистрирован")
+	ErrInvalidWebhookRequest = errors.New("невалидные параметры webhook")
+)
+
+type WebhookEndpoint struct {
+	ID        string    `json:"id"`
+	URL       string    `json:"url"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+type WebhookDelivery struct {
+	ID             string     `json:"id"`
+	TaskID         string     `json:"task_id,omitempty"`
+	EndpointID     string     `json:"endpoint_id,omitempty"`
+	URL            string     `json:"url"`
+	Event          string     `json:"event"`
+	Status         string     `json:"status"`
+	Attempts       int32      `json:"attempts"`
+	LastStatusCode int32      `json:"last_status_code,omitempty"`
+	LastError      string     `json:"last_error,omitempty"`
+	NextAttemptAt  *time.Time `json:"next_attempt_at,omitempty"`
+	DeliveredAt    *time.Time `json:"delivered_at,omitempty"`
+	CreatedAt      time.Time  `json:"created_at"`
+}
+
+type WebhookService interface {
+	CreateEndpoint(ctx context.Context, userID, url string) (*WebhookEndpoint, error)
+	ListEndpoints(ctx context.Context, userID string) ([]WebhookEndpoint, error)
+	DeleteEndpoint(ctx context.Context, userID, endpointID string) error
+	// GetSecret возвращает секрет для проверки подписи webhook, при rotate = true выпускает новый.
+	GetSecret(ctx context.Context, userID string, rotate bool) (string, error)
+	ListDeliveries(ctx context.Context, userID, taskID string, limit int32) ([]WebhookDelivery, error)
+	Redeliver(ctx context.Context, userID, deliveryID string) error
+}
+
+type webhookService struct {
+	log                *zap.Logger
+	orchestratorClient pb_orchestrator.OrchestratorServiceClient
+	grpcClientTimeout  time.Duration
+}
+
+func NewWebhookService(
+	log *zap.Logger,
+	orcClient pb_orchestrator.OrchestratorServiceClient,
+	cfg *config.Config,
+) WebhookService {
+	return &webhookService{
+		log:                log,
+		orchestratorClient: orcClient,
+		grpcClientTimeout:  cfg.OrchestratorClient.Timeout,
+	}
+}
+
+func (s *webhookService) CreateEndpoint(ctx context.Context, userID, url string) (*WebhookEndpoint, error) {
+	grpcCtx, cancel := context.WithTimeout(ctx, s.grpcClientTimeout)
+	defer cancel()
+
+	grpcRes, err := s.orchestratorClient.CreateWebhookEndpoint(grpcCtx, &pb_orchestrator.CreateWebhookEndpointRequest{UserId: userID, Url: url})
+	if err != nil {
+		return nil, s.wrapError("CreateWebhookEndpoint", err)
+	}
+	endpoint := endpointFromProto(grpcRes)
+	return &endpoint, nil
+}
+
+func (s *webhookService) ListEndpoints(ctx context.Context, userID string) ([]WebhookEndpoint, error) {
+	grpcCtx, cancel := context.WithTimeout(ctx, s.grpcClientTimeout)
+	defer cancel()
+
+	grpcRes, err := s.orchestratorClient.ListWebhookEndpoints(grpcCtx, &pb_orchestrator.ListWebhookEndpointsRequest{UserId: userID})
+	if err != nil {
+		return nil, s.wrapError("ListWebhookEndpoints", err)
+	}
+	endpoints := make([]WebhookEndpoint, 0, len(grpcRes.GetEndpoints()))
+	for _, e := range grpcRes.GetEndpoints() {
+		endpoints = append(endpoints, endpointFromProto(e))
+	}
+	return endpoints, nil
+}
+
+func (s *webhookService) DeleteEndpoint(ctx context.Context, userID, endpointID string) error {
+	grpcCtx, cancel := context.WithTimeout(ctx, s.grpcClientTimeout)
+	defer cancel()
+
+	_, err := s.orchestratorClient.DeleteWebhookEndpoint(grpcCtx, &pb_orchestrator.DeleteWebhookEndpointRequest{UserId: userID, EndpointId: endpointID})
+	if err != nil {
+		return s.wrapError("DeleteWebhookEndpoint", err)
+	}
+	return nil
+}
+
+func (s *webhookService) GetSecret(ctx context.Context, userID string, rotate bool) (string, error) {
+	grpcCtx, cancel := context.WithTimeout(ctx, s.grpcClientTimeout)
+	defer cancel()
+
+	grpcRes, err := s.orchestratorClient.GetWebhookSecret(grpcCtx, &pb_orchestrator.WebhookSecretRequest{UserId: userID, Rotate: rotate})
+	if err != nil {
+		return "", s.wrapError("GetWebhookSecret", err)
+	}
+	return grpcRes.GetSecret(), nil
+}
+
+func (s *webhookService) ListDeliveries(ctx context.Context, userID, taskID string, limit int32) ([]WebhookDelivery, error) {
+	grpcCtx, cancel := context.WithTimeout(ctx, s.grpcClientTimeout)
+	defer cancel()
+
+	grpcRes, err := s.orchestratorClient.ListWebhookDeliveries(grpcCtx, &pb_orchestrator.ListWebhookDeliveriesRequest{
+		UserId: userID,
+		TaskId: taskID,
+		Limit:  limit,
+	})
+	if err != nil {
+		return nil, s.wrapError("ListWebhookDeliveries", err)
+	}
+	deliveries := make([]WebhookDelivery, 0, len(grpcRes.GetDeliveries()))
+	for _, d := range grpcRes.GetDeliveries() {
+		deliveries = append(deliveries, deliveryFromProto(d))
+	}
+	return deliveries, nil
+}
+
+func (s *webhookService) Redeliver(ctx context.Context, userID, deliveryID string) error {
+	grpcCtx, cancel := context.WithTimeout(ctx, s.grpcClientTimeout)
+	defer cancel()
+
+	_, err := s.orchestratorClient.RedeliverWebhook(grpcCtx, &pb_orchestrator.RedeliverWebhookRequest{UserId: userID, DeliveryId: deliveryID})
+	if err != nil {
+		return s.wrapError("RedeliverWebhook", err)
+	}
+	return nil
+}
+
+func (s *webhookService) wrapError(method string, err error) error {
+	s.log.Error("Ошибка gRPC вызова из WebhookService", zap.String("method", method), zap.Error(err))
+	st, ok := status.FromError(err)
+	if ok {
+		switch st.Code() {
+		case codes.NotFound:
+			return fmt.Errorf("%w: %s", ErrWebhookNotFound, st.Message())
+		case codes.AlreadyExists:
+			return fmt.Errorf("%w: %s", ErrWebhookExists, st.Message())
+		case codes.InvalidArgument:
+			return fmt.Errorf("%w: %s", ErrInvalidWebhookRequest, st.Message())
+		}
+	}
+	return fmt.Errorf("ошибка сервиса webhook: %w", err)
+}
+
+func endpointFromProto(e *pb_orchestrator.WebhookEndpoint) WebhookEndpoint {
+	endpoint := WebhookEndpoint{ID: e.GetId(), URL: e.GetUrl()}
+	if createdAt, err := time.Parse(time.RFC3339Nano, e.GetCreatedAt()); err == nil {
+		endpoint.CreatedAt = createdAt
+	}
+	return endpoint
+}
+
+func deliveryFromProto(d *pb_orchestrator.WebhookDelivery) WebhookDelivery {
+	delivery := WebhookDelivery{
+		ID:             d.GetId(),
+		TaskID:         d.GetTaskId(),
+		EndpointID:     d.GetEndpointId(),
+		URL:            d.GetUrl(),
+		Event:          d.GetEvent(),
+		Status:         d.GetStatus(),
+		Attempts:       d.GetAttempts(),
+		LastStatusCode: d.GetLastStatusCode(),
+		LastError:      d.GetLastError(),
+	}
+	if createdAt, err := time.Parse(time.RFC3339Nano, d.GetCreatedAt()); err == nil {
+		delivery.CreatedAt = createdAt
+	}
+	if next, err := time.Parse(time.RFC3339Nano, d.GetNextAttemptAt()); err == nil {
+		delivery.NextAttemptAt = &next
+	}
+	if deliveredAt, err := time.Parse(time.RFC3339Nano, d.GetDeliveredAt()); err == nil {
+		delivery.DeliveredAt = &deliveredAt
+	}
+	return delivery
+}
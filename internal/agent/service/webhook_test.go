@@ -0,0 +1,76 @@
+package service
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/Qu1nel/YaLyceum-GoProject-Final/internal/agent/config"
+	"github.com/Qu1nel/YaLyceum-GoProject-Final/internal/agent/service/mocks"
+	pb "github.com/Qu1nel/YaLyceum-GoProject-Final/proto/gen/orchestrator"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func setupWebhookServiceTest(t *testing.T) (WebhookService, *mocks.OrchestratorServiceClientMock) {
+	mockOrcClient := mocks.NewOrchestratorServiceClientMock(t)
+	cfg := &config.Config{OrchestratorClient: config.GRPCClientConfig{Timeout: 5 * time.Second}}
+	return NewWebhookService(zap.NewNop(), mockOrcClient, cfg), mockOrcClient
+}
+
+func TestWebhookService_CreateEndpoint_AlreadyExists(t *testing.T) {
+	ws, mockOrcClient := setupWebhookServiceTest(t)
+
+	mockOrcClient.On("CreateWebhookEndpoint", mock.Anything, mock.Anything).
+		Return(nil, status.Error(codes.AlreadyExists, "webhook с таким URL уже зарегистрирован")).Once()
+
+	_, err := ws.CreateEndpoint(context.Background(), uuid.New().String(), "https://example.com/hook")
+
+	assert.ErrorIs(t, err, ErrWebhookExists)
+}
+
+func TestWebhookService_ListDeliveries(t *testing.T) {
+	ws, mockOrcClient := setupWebhookServiceTest(t)
+	userID, taskID := uuid.New().String(), uuid.New().String()
+	createdAt := time.Now().UTC().Truncate(time.Microsecond)
+	nextAttemptAt := createdAt.Add(time.Minute)
+
+	mockOrcClient.On("ListWebhookDeliveries", mock.Anything, &pb.ListWebhookDeliveriesRequest{UserId: userID, TaskId: taskID, Limit: 10}).
+		Return(&pb.ListWebhookDeliveriesResponse{Deliveries: []*pb.WebhookDelivery{{
+			Id:             "d1",
+			TaskId:         taskID,
+			Url:            "https://example.com/hook",
+			Event:          "task.completed",
+			Status:         "pending",
+			Attempts:       2,
+			LastStatusCode: 503,
+			CreatedAt:      createdAt.Format(time.RFC3339Nano),
+			NextAttemptAt:  nextAttemptAt.Format(time.RFC3339Nano),
+		}}}, nil).Once()
+
+	deliveries, err := ws.ListDeliveries(context.Background(), userID, taskID, 10)
+
+	require.NoError(t, err)
+	require.Len(t, deliveries, 1)
+	assert.Equal(t, int32(503), deliveries[0].LastStatusCode)
+	assert.True(t, createdAt.Equal(deliveries[0].CreatedAt))
+	require.NotNil(t, deliveries[0].NextAttemptAt)
+	assert.True(t, nextAttemptAt.Equal(*deliveries[0].NextAttemptAt))
+	assert.Nil(t, deliveries[0].DeliveredAt)
+}
+
+func TestWebhookService_Redeliver_NotFound(t *testing.T) {
+	ws, mockOrcClient := setupWebhookServiceTest(t)
+
+	mockOrcClient.On("RedeliverWebhook", mock.Anything, mock.Anything).
+		Return(nil, status.Error(codes.NotFound, "доставка не найдена")).Once()
+
+	err := ws.Redeliver(context.Background(), uuid.New().String(), uuid.New().String())
+
+	assert.ErrorIs(t, err, ErrWebhookNotFound)
+}
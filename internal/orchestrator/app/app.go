@@ -5,6 +5,7 @@ import (
 	"errors"
 	"fmt"
 	"net"
+	"net/http"
 	"os"
 	"time"
 
@@ -14,11 +15,16 @@ import (
 	"github.com/Qu1nel/YaLyceum-GoProject-Final/internal/orchestrator/repository"
 	"github.com/Qu1nel/YaLyceum-GoProject-Final/internal/orchestrator/service"
 	"github.com/Qu1nel/YaLyceum-GoProject-Final/internal/pkg/logger"
+	"github.com/Qu1nel/YaLyceum-GoProject-Final/internal/pkg/metrics"
 	"github.com/Qu1nel/YaLyceum-GoProject-Final/internal/pkg/postgres"
+	"github.com/Qu1nel/YaLyceum-GoProject-Final/internal/pkg/requestid"
 	"github.com/Qu1nel/YaLyceum-GoProject-Final/internal/pkg/shutdown"
+	"github.com/Qu1nel/YaLyceum-GoProject-Final/internal/pkg/tracing"
 	pb_orchestrator "github.com/Qu1nel/YaLyceum-GoProject-Final/proto/gen/orchestrator"
 
 	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/prometheus/client_golang/prometheus"
+	"go.opentelemetry.io/contrib/instrumentation/google.golang.org/grpc/otelgrpc"
 	"go.uber.org/fx"
 	"go.uber.org/zap"
 	"google.golang.org/grpc"
@@ -98,24 +104,69 @@ func Run() {
 				return repository.NewPgxTaskRepository(pool, log)
 			},
 
+			func(pool *pgxpool.Pool, log *zap.Logger) repository.WebhookRepository {
+				return repository.NewPgxWebhookRepository(pool, log)
+			},
+
+			func(pool *pgxpool.Pool, log *zap.Logger) repository.UsageRepository {
+				return repository.NewPgxUsageRepository(pool, log)
+			},
+
 			client.NewWorkerServiceClient,
 
 			service.NewExpressionEvaluator,
 
+			service.NewEvaluationQueue,
+
+			service.NewFairOperationScheduler,
+
+			service.NewEvaluationRegistry,
+
+			service.NewRetentionPurger,
+
+			service.NewTaskEventBroker,
+
+			service.NewWebhookDispatcher,
+
+			func(dispatcher *service.WebhookDispatcher) service.WebhookNotifier {
+				return dispatcher
+			},
+
 			grpc_handler.NewOrchestratorServer,
 
 			func(log *zap.Logger) *grpc.Server {
-				srv := grpc.NewServer()
+				srv := grpc.NewServer(
+					grpc.ChainUnaryInterceptor(metrics.UnaryServerInterceptor(), requestid.UnaryServerInterceptor(log)),
+					grpc.ChainStreamInterceptor(metrics.StreamServerInterceptor(), requestid.StreamServerInterceptor(log)),
+					grpc.StatsHandler(otelgrpc.NewServerHandler()),
+				)
 				log.Info("Создан инстанс gRPC сервера")
 				return srv
 			},
 		),
+		fx.Invoke(func(lc fx.Lifecycle, cfg *config.Config, log *zap.Logger) error {
+			provider, err := tracing.Setup(appCtx, "orchestrator", cfg.Tracing)
+			if err != nil {
+				log.Error("Не удалось настроить трассировку Оркестратора", zap.Error(err))
+				return err
+			}
+			log.Info("Трассировка Оркестратора настроена", zap.String("exporter", cfg.Tracing.Exporter))
+			lc.Append(fx.Hook{
+				OnStop: func(ctx context.Context) error {
+					return provider.Shutdown(ctx)
+				},
+			})
+			return nil
+		}),
 		fx.Invoke(func(lc fx.Lifecycle,
 			grpcServer *grpc.Server,
 			orchestratorHandler *grpc_handler.OrchestratorServer,
 			cfg *config.Config,
 			log *zap.Logger,
 			pool *pgxpool.Pool,
+			purger *service.RetentionPurger,
+			webhookDispatcher *service.WebhookDispatcher,
+			queue service.EvaluationQueue,
 		) {
 			pb_orchestrator.RegisterOrchestratorServiceServer(grpcServer, orchestratorHandler)
 			log.Info("gRPC обработчик Оркестратора зарегистрирован")
@@ -139,14 +190,67 @@ func Run() {
 				},
 				OnStop: func(ctx context.Context) error {
 					log.Info("Остановка gRPC сервера Оркестратора...")
+					orchestratorHandler.CloseWatchers()
 					grpcServer.GracefulStop()
 					log.Info("gRPC сервер Оркестратора успешно остановлен.")
 					return nil
 				},
 			})
 
+			purgeCtx, stopPurge := context.WithCancel(appCtx)
+			lc.Append(fx.Hook{
+				OnStart: func(ctx context.Context) error {
+					log.Info("Запуск фоновой очистки устаревших задач",
+						zap.Duration("interval", cfg.Retention.PurgeInterval),
+						zap.Int("completedDays", cfg.Retention.CompletedDays),
+						zap.Int("failedDays", cfg.Retention.FailedDays),
+						zap.Int("trashDays", cfg.Retention.TrashDays),
+					)
+					go purger.Run(purgeCtx)
+					return nil
+				},
+				OnStop: func(ctx context.Context) error {
+					stopPurge()
+					return nil
+				},
+			})
+
+			prometheus.MustRegister(metrics.NewPgxPoolCollector(pool), service.NewQueueCollector(queue))
+			metricsServer := metrics.NewServer(cfg.GRPCServer.MetricsPort)
+			lc.Append(fx.Hook{
+				OnStart: func(ctx context.Context) error {
+					log.Info("Запуск HTTP сервера метрик Оркестратора", zap.String("адрес", metricsServer.Addr))
+					go func() {
+						if err := metricsServer.ListenAndServe(); err != nil && !errors.Is(err, http.ErrServerClosed) {
+							log.Error("HTTP сервер метрик Оркестратора неожиданно завершил работу", zap.Error(err))
+						}
+					}()
+					return nil
+				},
+				OnStop: func(ctx context.Context) error {
+					return metricsServer.Shutdown(ctx)
+				},
+			})
+
+			webhookCtx, stopWebhooks := context.WithCancel(appCtx)
+			lc.Append(fx.Hook{
+				OnStart: func(ctx context.Context) error {
+					log.Info("Запуск фоновой доставки webhook",
+						zap.Duration("pollInterval", cfg.Webhook.PollInterval),
+						zap.Int("maxAttempts", cfg.Webhook.MaxAttempts),
+					)
+					go webhookDispatcher.Run(webhookCtx)
+					return nil
+				},
+				OnStop: func(ctx context.Context) error {
+					stopWebhooks()
+					return nil
+				},
+			})
+
 			serversToStop := map[string]func(context.Context) error{
 				"grpc": func(ctx context.Context) error {
+					orchestratorHandler.CloseWatchers()
 					done := make(chan struct{})
 					go func() {
 						grpcServer.GracefulStop()
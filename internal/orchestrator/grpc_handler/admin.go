@@ -0,0 +1,186 @@
+package grpc_handler
+
+import (
+	"context"
+	"errors"
+	"sort"
+	"time"
+
+	"github.com/Qu1nel/YaLyceum-GoProject-Final/internal/orchestrator/repository"
+	"github.com/Qu1nel/YaLyceum-GoProject-Final/internal/orchestrator/service"
+	pb "github.com/Qu1nel/YaLyceum-GoProject-Final/proto/gen/orchestrator"
+
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// forceFailWaitTimeout - сколько ForceFailTask ждет, пока отмененное вычисление запишет статус failed.
+const forceFailWaitTimeout = 5 * time.Second
+
+const maxForceFailReasonLength = 500
+
+func (s *OrchestratorServer) ListActiveEvaluations(ctx context.Context, _ *pb.ListActiveEvaluationsRequest) (*pb.ListActiveEvaluationsResponse, error) {
+	s.logFor(ctx).Info("Получен gRPC запрос ListActiveEvaluations")
+
+	now := time.Now()
+	active := s.evaluations.Active()
+	response := &pb.ListActiveEvaluationsResponse{Evaluations: make([]*pb.ActiveEvaluation, 0, len(active))}
+	for _, ev := range active {
+		response.Evaluations = append(response.Evaluations, &pb.ActiveEvaluation{
+			TaskId:             ev.TaskID.String(),
+			UserId:             ev.UserID.String(),
+			Expression:         ev.Expression,
+			AttemptNumber:      int32(ev.AttemptNumber),
+			StartedAt:          ev.StartedAt.Format(time.RFC3339Nano),
+			ElapsedSeconds:     now.Sub(ev.StartedAt).Seconds(),
+			InflightOperations: int32(ev.InflightOperations),
+			OperationsDone:     int32(ev.OperationsDone),
+			OperationsTotal:    int32(ev.OperationsTotal),
+		})
+	}
+	return response, nil
+}
+
+func (s *OrchestratorServer) GetQueueStatus(ctx context.Context, _ *pb.QueueStatusRequest) (*pb.QueueStatusResponse, error) {
+	s.logFor(ctx).Info("Получен gRPC запрос GetQueueStatus")
+
+	queueStats := s.queue.Stats()
+	schedulerStats := s.scheduler.Stats()
+	response := &pb.QueueStatusResponse{
+		EvaluationsRunning:       int32(queueStats.Running),
+		EvaluationsWaiting:       int32(queueStats.Waiting),
+		MaxConcurrentEvaluations: int32(queueStats.MaxConcurrent),
+		MaxQueuedEvaluations:     int32(queueStats.MaxQueued),
+		OperationsInflight:       int32(schedulerStats.Inflight),
+		OperationsWaiting:        int32(schedulerStats.Waiting),
+		MaxInflightOperations:    int32(schedulerStats.MaxInflight),
+	}
+	for _, worker := range s.evaluations.Workers() {
+		response.Workers = append(response.Workers, &pb.WorkerStatus{
+			Address:          worker.Address,
+			LastSeenAt:       worker.LastSeenAt.Format(time.RFC3339Nano),
+			Operations:       worker.Operations,
+			FailedOperations: worker.FailedOperations,
+		})
+	}
+	return response, nil
+}
+
+func (s *OrchestratorServer) GetSystemTaskCounts(ctx context.Context, _ *pb.SystemTaskCountsRequest) (*pb.SystemTaskCountsResponse, error) {
+	s.logFor(ctx).Info("Получен gRPC запрос GetSystemTaskCounts")
+
+	counts, err := s.taskRepo.GetSystemTaskCounts(ctx)
+	if err != nil {
+		s.logFor(ctx).Error("Ошибка подсчета задач по статусам", zap.Error(err))
+		return nil, status.Error(codes.Internal, "внутренняя ошибка сервера")
+	}
+
+	response := &pb.SystemTaskCountsResponse{Total: counts.Total, Trashed: counts.Trashed}
+	statuses := make([]string, 0, len(counts.StatusCounts))
+	for st := range counts.StatusCounts {
+		statuses = append(statuses, st)
+	}
+	sort.Strings(statuses)
+	for _, st := range statuses {
+		response.StatusCounts = append(response.StatusCounts, &pb.StatusCount{Status: st, Count: counts.StatusCounts[st]})
+	}
+	return response, nil
+}
+
+// ForceFailTask завершает задачу в статусе pending или processing со статусом failed.
+// Идущее вычисление отменяется и само записывает статус; задачу из очереди или потерянную
+// (например, после перезапуска Оркестратора) завершает сам обработчик.
+func (s *OrchestratorServer) ForceFailTask(ctx context.Context, req *pb.ForceFailTaskRequest) (*pb.ForceFailTaskResponse, error) {
+	s.logFor(ctx).Warn("Получен gRPC запрос ForceFailTask", zap.String("taskID", req.GetTaskId()), zap.String("reason", req.GetReason()))
+
+	taskID, err := uuid.Parse(req.GetTaskId())
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "невалидный формат taskID: %v", err)
+	}
+	if len([]rune(req.GetReason())) > maxForceFailReasonLength {
+		return nil, status.Errorf(codes.InvalidArgument, "причина длиннее %d символов", maxForceFailReasonLength)
+	}
+
+	task, err := s.taskRepo.GetTaskByID(ctx, taskID)
+	if err != nil {
+		if errors.Is(err, repository.ErrTaskNotFound) {
+			return nil, status.Errorf(codes.NotFound, "задача с ID %s не найдена", taskID)
+		}
+		s.logFor(ctx).Error("Ошибка получения задачи для ForceFailTask", zap.Stringer("taskID", taskID), zap.Error(err))
+		return nil, status.Error(codes.Internal, "внутренняя ошибка сервера")
+	}
+	if task.Status != repository.StatusPending && task.Status != repository.StatusProcessing {
+		return nil, status.Errorf(codes.FailedPrecondition, "задача в статусе '%s' уже завершена", task.Status)
+	}
+
+	errMsg := service.NewForceFailedError(req.GetReason()).Error()
+	response := &pb.ForceFailTaskResponse{TaskId: taskID.String(), PreviousStatus: task.Status, ErrorMessage: errMsg}
+
+	// Подписываемся до отмены, чтобы не пропустить терминальное событие отмененного вычисления.
+	events, _, cancel := s.events.Subscribe(taskID)
+	defer cancel()
+
+	outcome, attemptID := s.evaluations.ForceFail(taskID, req.GetReason())
+	if outcome == service.ForceFailRunning {
+		s.logFor(ctx).Warn("Вычисление задачи отменено администратором", zap.Stringer("taskID", taskID))
+		timer := time.NewTimer(forceFailWaitTimeout)
+		defer timer.Stop()
+		for {
+			select {
+			case event, ok := <-events:
+				if !ok || event.Terminal() {
+					return response, nil
+				}
+			case <-timer.C:
+				s.logFor(ctx).Warn("Отмененное вычисление не завершилось за отведенное время", zap.Stringer("taskID", taskID))
+				return response, nil
+			case <-ctx.Done():
+				return nil, status.FromContextError(ctx.Err()).Err()
+			}
+		}
+	}
+
+	if outcome == service.ForceFailUntracked {
+		attemptID = s.lastUnfinishedAttemptID(ctx, taskID)
+	}
+	// Задачу без вычисления в этом экземпляре мог успеть завершить другой, поэтому статус меняется только у незавершенной.
+	if err := s.taskRepo.FailUnfinishedTask(ctx, taskID, repository.ErrorCodeCancelled, errMsg); err != nil {
+		if errors.Is(err, repository.ErrTaskStateConflict) {
+			return nil, status.Errorf(codes.FailedPrecondition, "задача %s уже завершена", taskID)
+		}
+		s.logFor(ctx).Error("Не удалось пометить задачу как failed", zap.Stringer("taskID", taskID), zap.Error(err))
+		return nil, status.Error(codes.Internal, "внутренняя ошибка сервера при завершении задачи")
+	}
+	if attemptID != uuid.Nil {
+		if err := s.taskRepo.FinishAttempt(ctx, attemptID, nil, &errMsg); err != nil {
+			s.logFor(ctx).Error("Не удалось завершить попытку принудительно завершенной задачи", zap.Stringer("attemptID", attemptID), zap.Error(err))
+		}
+	}
+	s.logFor(ctx).Warn("Задача принудительно завершена администратором",
+		zap.Stringer("taskID", taskID),
+		zap.String("previousStatus", task.Status),
+		zap.Bool("wasQueued", outcome == service.ForceFailQueued),
+	)
+
+	service.ObserveTaskStatus(repository.StatusFailed)
+	errCode := repository.ErrorCodeCancelled
+	s.events.Publish(service.TaskEvent{TaskID: taskID, Status: repository.StatusFailed, ErrorMessage: &errMsg, ErrorCode: &errCode})
+	s.webhooks.TaskFinished(taskID)
+	return response, nil
+}
+
+func (s *OrchestratorServer) lastUnfinishedAttemptID(ctx context.Context, taskID uuid.UUID) uuid.UUID {
+	attempts, err := s.taskRepo.GetAttemptsByTaskID(ctx, taskID)
+	if err != nil {
+		s.logFor(ctx).Warn("Не удалось получить попытки задачи для ForceFailTask", zap.Stringer("taskID", taskID), zap.Error(err))
+		return uuid.Nil
+	}
+	for i := len(attempts) - 1; i >= 0; i-- {
+		if attempts[i].FinishedAt == nil {
+			return attempts[i].ID
+		}
+	}
+	return uuid.Nil
+}
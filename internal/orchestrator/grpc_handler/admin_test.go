@@ -0,0 +1,99 @@
+package grpc_handler
+
+import (
+	"context"
+	"testing"
+
+	"github.com/Qu1nel/YaLyceum-GoProject-Final/internal/orchestrator/repository"
+	"github.com/Qu1nel/YaLyceum-GoProject-Final/internal/orchestrator/service"
+	pb "github.com/Qu1nel/YaLyceum-GoProject-Final/proto/gen/orchestrator"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestOrchestratorServer_ForceFailTask_LostEvaluation(t *testing.T) {
+	server, mockTaskRepo, _ := setupOrchestratorServerTest(t)
+	taskID, attemptID := uuid.New(), uuid.New()
+	errMsg := service.NewForceFailedError("зависла после рестарта").Error()
+
+	events, _, cancel := server.events.Subscribe(taskID)
+	defer cancel()
+
+	mockTaskRepo.On("GetTaskByID", mock.Anything, taskID).Return(&repository.Task{ID: taskID, Status: repository.StatusProcessing}, nil).Once()
+	mockTaskRepo.On("GetAttemptsByTaskID", mock.Anything, taskID).Return([]repository.TaskAttempt{{ID: attemptID, AttemptNumber: 1}}, nil).Once()
+	mockTaskRepo.On("FailUnfinishedTask", mock.Anything, taskID, repository.ErrorCodeCancelled, errMsg).Return(nil).Once()
+	mockTaskRepo.On("FinishAttempt", mock.Anything, attemptID, (*float64)(nil), &errMsg).Return(nil).Once()
+
+	res, err := server.ForceFailTask(context.Background(), &pb.ForceFailTaskRequest{TaskId: taskID.String(), Reason: "зависла после рестарта"})
+
+	require.NoError(t, err)
+	assert.Equal(t, repository.StatusProcessing, res.GetPreviousStatus())
+	assert.Equal(t, errMsg, res.GetErrorMessage())
+	event := <-events
+	assert.Equal(t, repository.StatusFailed, event.Status)
+}
+
+func TestOrchestratorServer_ForceFailTask_QueuedEvaluationIsNotStarted(t *testing.T) {
+	server, mockTaskRepo, _ := setupOrchestratorServerTest(t)
+	taskID, attemptID := uuid.New(), uuid.New()
+	server.evaluations.Track(service.ActiveEvaluation{TaskID: taskID}, attemptID)
+	errMsg := service.NewForceFailedError("").Error()
+
+	mockTaskRepo.On("GetTaskByID", mock.Anything, taskID).Return(&repository.Task{ID: taskID, Status: repository.StatusPending}, nil).Once()
+	mockTaskRepo.On("FailUnfinishedTask", mock.Anything, taskID, repository.ErrorCodeCancelled, errMsg).Return(nil).Once()
+	mockTaskRepo.On("FinishAttempt", mock.Anything, attemptID, (*float64)(nil), &errMsg).Return(nil).Once()
+
+	_, err := server.ForceFailTask(context.Background(), &pb.ForceFailTaskRequest{TaskId: taskID.String()})
+	require.NoError(t, err)
+
+	_, started := server.evaluations.Begin(context.Background(), taskID, nil)
+	assert.False(t, started)
+}
+
+func TestOrchestratorServer_ForceFailTask_AlreadyFinished(t *testing.T) {
+	server, mockTaskRepo, _ := setupOrchestratorServerTest(t)
+	taskID := uuid.New()
+
+	mockTaskRepo.On("GetTaskByID", mock.Anything, taskID).Return(&repository.Task{ID: taskID, Status: repository.StatusCompleted}, nil).Once()
+
+	_, err := server.ForceFailTask(context.Background(), &pb.ForceFailTaskRequest{TaskId: taskID.String()})
+	assert.Equal(t, codes.FailedPrecondition, status.Code(err))
+}
+
+func TestOrchestratorServer_ForceFailTask_FinishedConcurrently(t *testing.T) {
+	server, mockTaskRepo, _ := setupOrchestratorServerTest(t)
+	taskID, attemptID := uuid.New(), uuid.New()
+	errMsg := service.NewForceFailedError("").Error()
+
+	mockTaskRepo.On("GetTaskByID", mock.Anything, taskID).Return(&repository.Task{ID: taskID, Status: repository.StatusProcessing}, nil).Once()
+	mockTaskRepo.On("GetAttemptsByTaskID", mock.Anything, taskID).Return([]repository.TaskAttempt{{ID: attemptID, AttemptNumber: 1}}, nil).Once()
+	mockTaskRepo.On("FailUnfinishedTask", mock.Anything, taskID, repository.ErrorCodeCancelled, errMsg).Return(repository.ErrTaskStateConflict).Once()
+
+	_, err := server.ForceFailTask(context.Background(), &pb.ForceFailTaskRequest{TaskId: taskID.String()})
+
+	assert.Equal(t, codes.FailedPrecondition, status.Code(err))
+	mockTaskRepo.AssertNotCalled(t, "FinishAttempt", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestOrchestratorServer_GetSystemTaskCounts(t *testing.T) {
+	server, mockTaskRepo, _ := setupOrchestratorServerTest(t)
+
+	mockTaskRepo.On("GetSystemTaskCounts", mock.Anything).Return(&repository.SystemTaskCounts{
+		Total:        7,
+		StatusCounts: map[string]int64{repository.StatusPending: 2, repository.StatusCompleted: 5},
+		Trashed:      1,
+	}, nil).Once()
+
+	res, err := server.GetSystemTaskCounts(context.Background(), &pb.SystemTaskCountsRequest{})
+
+	require.NoError(t, err)
+	assert.Equal(t, int64(7), res.GetTotal())
+	assert.Equal(t, int64(1), res.GetTrashed())
+	require.Len(t, res.GetStatusCounts(), 2)
+	assert.Equal(t, repository.StatusCompleted, res.GetStatusCounts()[0].GetStatus())
+}
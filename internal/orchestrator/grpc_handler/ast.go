@@ -0,0 +1,150 @@
+package grpc_handler
+
+import (
+	"context"
+	"strconv"
+	"time"
+
+	"github.com/Qu1nel/YaLyceum-GoProject-Final/internal/orchestrator/repository"
+	"github.com/Qu1nel/YaLyceum-GoProject-Final/internal/orchestrator/service"
+	pb "github.com/Qu1nel/YaLyceum-GoProject-Final/proto/gen/orchestrator"
+
+	"github.com/expr-lang/expr/ast"
+	"go.uber.org/zap"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// nodeStatusSkipped - узел не вычислялся, потому что задача завершилась ошибкой раньше.
+const nodeStatusSkipped = "skipped"
+
+// astAnnotations - результаты вычисления, которыми размечаются узлы дерева.
+type astAnnotations struct {
+	taskStatus string
+	taskResult *float64
+	operations map[string]repository.TaskOperation
+	inflight   map[string]time.Time
+	now        time.Time
+}
+
+func (s *OrchestratorServer) GetTaskAST(ctx context.Context, req *pb.TaskASTRequest) (*pb.TaskASTResponse, error) {
+	s.logFor(ctx).Info("Получен gRPC запрос GetTaskAST",
+		zap.String("taskID", req.GetTaskId()),
+		zap.String("requestingUserID", req.GetUserId()),
+	)
+
+	task, err := s.getOwnedTask(ctx, req.GetTaskId(), req.GetUserId(), "GetTaskAST")
+	if err != nil {
+		return nil, err
+	}
+
+	program, compileErr := compileExpression(task.Expression)
+	if compileErr != nil {
+		s.logFor(ctx).Warn("Ошибка компиляции сохраненного выражения для GetTaskAST", zap.Stringer("taskID", task.ID), zap.Error(compileErr))
+		return nil, status.Errorf(codes.FailedPrecondition, "ошибка в выражении: %s", compileErr.Error())
+	}
+
+	response := &pb.TaskASTResponse{TaskId: task.ID.String(), Status: task.Status}
+	annotations := astAnnotations{
+		taskStatus: task.Status,
+		taskResult: task.Result,
+		operations: make(map[string]repository.TaskOperation),
+		now:        time.Now(),
+	}
+
+	// Идущее вычисление сохраняет операции в БД только по завершении, поэтому берем их из памяти.
+	var operations []repository.TaskOperation
+	if snapshot, live := s.evaluations.Snapshot(task.ID); live {
+		response.Live = true
+		response.AttemptNumber = int32(snapshot.AttemptNumber)
+		response.Status = repository.StatusProcessing
+		annotations.taskStatus = repository.StatusProcessing
+		annotations.inflight = snapshot.Inflight
+		operations = snapshot.Operations
+	} else {
+		operations, err = s.taskRepo.GetLatestOperationsByTaskID(ctx, task.ID)
+		if err != nil {
+			s.logFor(ctx).Error("Ошибка получения операций задачи для GetTaskAST", zap.Stringer("taskID", task.ID), zap.Error(err))
+			return nil, status.Error(codes.Internal, "внутренняя ошибка сервера")
+		}
+		if len(operations) > 0 {
+			response.AttemptNumber = int32(operations[0].AttemptNumber)
+		}
+	}
+	for _, op := range operations {
+		annotations.operations[op.NodePath] = op
+	}
+
+	response.Root = buildAnnotatedAST(program.Node(), service.RootNodePath, &annotations)
+	return response, nil
+}
+
+// buildAnnotatedAST обходит дерево так же, как ExpressionEvaluator.Evaluate, чтобы пути узлов совпадали с NodePath операций.
+func buildAnnotatedAST(node ast.Node, path string, annotations *astAnnotations) *pb.AstNode {
+	astNode := &pb.AstNode{NodePath: path}
+	child := func(index int, n ast.Node) *pb.AstNode {
+		return buildAnnotatedAST(n, path+"."+strconv.Itoa(index), annotations)
+	}
+
+	switch n := node.(type) {
+	case *ast.IntegerNode:
+		astNode.Kind, astNode.Operator = "number", strconv.Itoa(n.Value)
+		astNode.HasValue, astNode.Value = true, float64(n.Value)
+		astNode.Status = repository.StatusCompleted
+		return astNode
+	case *ast.FloatNode:
+		astNode.Kind, astNode.Operator = "number", strconv.FormatFloat(n.Value, 'g', -1, 64)
+		astNode.HasValue, astNode.Value = true, n.Value
+		astNode.Status = repository.StatusCompleted
+		return astNode
+	case *ast.UnaryNode:
+		astNode.Kind, astNode.Operator = "unary", n.Operator
+		astNode.Children = []*pb.AstNode{child(0, n.Node)}
+	case *ast.BinaryNode:
+		astNode.Kind, astNode.Operator = "binary", n.Operator
+		astNode.Children = []*pb.AstNode{child(0, n.Left), child(1, n.Right)}
+	default:
+		astNode.Kind, astNode.Operator = "unsupported", node.String()
+	}
+
+	annotations.annotate(astNode)
+	var slowestChild float64
+	for _, c := range astNode.Children {
+		slowestChild = max(slowestChild, c.CriticalPathMs)
+	}
+	astNode.CriticalPathMs = astNode.DurationMs + slowestChild
+	return astNode
+}
+
+func (a *astAnnotations) annotate(astNode *pb.AstNode) {
+	if op, ok := a.operations[astNode.NodePath]; ok {
+		astNode.DurationMs = float64(op.Duration) / float64(time.Millisecond)
+		if op.ErrorMessage != nil {
+			astNode.Status, astNode.ErrorMessage = repository.StatusFailed, *op.ErrorMessage
+		} else {
+			astNode.Status = repository.StatusCompleted
+			if op.Result != nil {
+				astNode.HasValue, astNode.Value = true, *op.Result
+			}
+		}
+		return
+	}
+	if startedAt, ok := a.inflight[astNode.NodePath]; ok {
+		astNode.Status = repository.StatusProcessing
+		astNode.DurationMs = float64(a.now.Sub(startedAt)) / float64(time.Millisecond)
+		return
+	}
+
+	switch a.taskStatus {
+	case repository.StatusPending, repository.StatusProcessing:
+		astNode.Status = repository.StatusPending
+	case repository.StatusFailed:
+		astNode.Status = nodeStatusSkipped
+	default:
+		// Завершенная задача без сохраненных операций: результат известен только для корня.
+		astNode.Status = repository.StatusCompleted
+		if astNode.NodePath == service.RootNodePath && a.taskResult != nil {
+			astNode.HasValue, astNode.Value = true, *a.taskResult
+		}
+	}
+}
@@ -0,0 +1,100 @@
+package grpc_handler
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/Qu1nel/YaLyceum-GoProject-Final/internal/orchestrator/repository"
+	"github.com/Qu1nel/YaLyceum-GoProject-Final/internal/orchestrator/service"
+	pb "github.com/Qu1nel/YaLyceum-GoProject-Final/proto/gen/orchestrator"
+
+	"github.com/expr-lang/expr/ast"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestOrchestratorServer_GetTaskAST_FinishedTask(t *testing.T) {
+	server, mockTaskRepo, _ := setupOrchestratorServerTest(t)
+	userID, taskID := uuid.New(), uuid.New()
+	sum, product := 5.0, 20.0
+
+	mockTaskRepo.On("GetTaskByID", mock.Anything, taskID).Return(&repository.Task{
+		ID: taskID, UserID: userID, Expression: "(2+3)*4", Status: repository.StatusCompleted, Result: &product,
+	}, nil).Once()
+	mockTaskRepo.On("GetLatestOperationsByTaskID", mock.Anything, taskID).Return([]repository.TaskOperation{
+		{AttemptNumber: 1, NodePath: "0.0", Symbol: "+", Result: &sum, Duration: 3 * time.Millisecond},
+		{AttemptNumber: 1, NodePath: "0", Symbol: "*", Result: &product, Duration: 2 * time.Millisecond},
+	}, nil).Once()
+
+	res, err := server.GetTaskAST(context.Background(), &pb.TaskASTRequest{TaskId: taskID.String(), UserId: userID.String()})
+
+	require.NoError(t, err)
+	assert.False(t, res.GetLive())
+	assert.Equal(t, int32(1), res.GetAttemptNumber())
+	root := res.GetRoot()
+	assert.Equal(t, "*", root.GetOperator())
+	assert.Equal(t, repository.StatusCompleted, root.GetStatus())
+	assert.Equal(t, 20.0, root.GetValue())
+	assert.Equal(t, 5.0, root.GetCriticalPathMs())
+	require.Len(t, root.GetChildren(), 2)
+	assert.Equal(t, "+", root.GetChildren()[0].GetOperator())
+	assert.Equal(t, 5.0, root.GetChildren()[0].GetValue())
+	assert.Equal(t, "number", root.GetChildren()[1].GetKind())
+	assert.True(t, root.GetChildren()[1].GetHasValue())
+}
+
+func TestOrchestratorServer_GetTaskAST_LiveEvaluation(t *testing.T) {
+	server, mockTaskRepo, _ := setupOrchestratorServerTest(t)
+	userID, taskID := uuid.New(), uuid.New()
+
+	mockTaskRepo.On("GetTaskByID", mock.Anything, taskID).Return(&repository.Task{
+		ID: taskID, UserID: userID, Expression: "2+3", Status: repository.StatusProcessing,
+	}, nil).Once()
+	server.evaluations.Track(service.ActiveEvaluation{TaskID: taskID, AttemptNumber: 2}, uuid.New())
+	_, started := server.evaluations.Begin(context.Background(), taskID, service.NewTraceRecorder(nil))
+	require.True(t, started)
+	defer server.evaluations.Finish(taskID)
+
+	res, err := server.GetTaskAST(context.Background(), &pb.TaskASTRequest{TaskId: taskID.String(), UserId: userID.String()})
+
+	require.NoError(t, err)
+	assert.True(t, res.GetLive())
+	assert.Equal(t, int32(2), res.GetAttemptNumber())
+	assert.Equal(t, repository.StatusPending, res.GetRoot().GetStatus())
+	assert.False(t, res.GetRoot().GetHasValue())
+}
+
+func TestBuildAnnotatedAST_FailedTask(t *testing.T) {
+	node := &ast.BinaryNode{
+		Operator: "+",
+		Left:     &ast.BinaryNode{Operator: "/", Left: &ast.IntegerNode{Value: 1}, Right: &ast.IntegerNode{Value: 0}},
+		Right:    &ast.BinaryNode{Operator: "*", Left: &ast.IntegerNode{Value: 2}, Right: &ast.IntegerNode{Value: 3}},
+	}
+	errMsg := "деление на ноль"
+	startedAt := time.Now()
+	annotations := &astAnnotations{
+		taskStatus: repository.StatusProcessing,
+		operations: map[string]repository.TaskOperation{
+			"0.0": {NodePath: "0.0", ErrorMessage: &errMsg, Duration: time.Millisecond},
+		},
+		inflight: map[string]time.Time{"0.1": startedAt},
+		now:      startedAt.Add(4 * time.Millisecond),
+	}
+
+	root := buildAnnotatedAST(node, service.RootNodePath, annotations)
+
+	assert.Equal(t, repository.StatusPending, root.GetStatus())
+	assert.Equal(t, repository.StatusFailed, root.GetChildren()[0].GetStatus())
+	assert.Equal(t, errMsg, root.GetChildren()[0].GetErrorMessage())
+	assert.Equal(t, repository.StatusProcessing, root.GetChildren()[1].GetStatus())
+	assert.Equal(t, 4.0, root.GetChildren()[1].GetDurationMs())
+	assert.Equal(t, 4.0, root.GetCriticalPathMs())
+
+	annotations.taskStatus, annotations.inflight = repository.StatusFailed, nil
+	root = buildAnnotatedAST(node, service.RootNodePath, annotations)
+	assert.Equal(t, nodeStatusSkipped, root.GetStatus())
+	assert.Equal(t, nodeStatusSkipped, root.GetChildren()[1].GetStatus())
+}
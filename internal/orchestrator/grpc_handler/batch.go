@@ -0,0 +1,147 @@
+package grpc_handler
+
+import (
+	"context"
+
+	"github.com/Qu1nel/YaLyceum-GoProject-Final/internal/orchestrator/repository"
+	"github.com/Qu1nel/YaLyceum-GoProject-Final/internal/orchestrator/service"
+	"github.com/Qu1nel/YaLyceum-GoProject-Final/internal/pkg/requestid"
+	pb "github.com/Qu1nel/YaLyceum-GoProject-Final/proto/gen/orchestrator"
+
+	"github.com/expr-lang/expr/ast"
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// SubmitExpressions создает задачи для пакета выражений. Каждое выражение проверяется отдельно:
+// отклоненные получают текст ошибки, остальные вставляются в БД одной транзакцией и ставятся в очередь.
+func (s *OrchestratorServer) SubmitExpressions(ctx context.Context, req *pb.BatchExpressionRequest) (*pb.BatchExpressionResponse, error) {
+	userIDStr := req.GetUserId()
+	items := req.GetItems()
+
+	s.logFor(ctx).Info("Получен gRPC запрос SubmitExpressions",
+		zap.String("userID", userIDStr),
+		zap.Int("count", len(items)),
+	)
+
+	userID, err := uuid.Parse(userIDStr)
+	if err != nil {
+		s.logFor(ctx).Warn("Невалидный формат UserID", zap.String("userID", userIDStr), zap.Error(err))
+		return nil, status.Errorf(codes.InvalidArgument, "невалидный формат userID: %v", err)
+	}
+	if len(items) == 0 {
+		return nil, status.Error(codes.InvalidArgument, "пакет не содержит выражений")
+	}
+
+	var requestID *string
+	if id := requestid.FromContext(ctx); id != "" {
+		requestID = &id
+	}
+
+	response := &pb.BatchExpressionResponse{Results: make([]*pb.BatchExpressionResult, len(items))}
+	var (
+		newTasks []repository.NewTask
+		roots    []ast.Node
+		indexes  []int
+		demands  []repository.Usage
+		demand   repository.Usage
+	)
+	for i, item := range items {
+		response.Results[i] = &pb.BatchExpressionResult{Index: int32(i)}
+		newTask, root, reason := s.validateBatchItem(item)
+		if reason != "" {
+			response.Results[i].Error = reason
+			continue
+		}
+		newTask.UserID, newTask.RequestID = userID, requestID
+		newTasks = append(newTasks, newTask)
+		roots = append(roots, root)
+		indexes = append(indexes, i)
+		demands = append(demands, service.QuotaDemand(1, s.estimateCost(root)))
+		demand = demand.Add(demands[len(demands)-1])
+	}
+
+	if len(newTasks) > 0 {
+		// Пакет целиком отклоняется, если очередь не вместит все выражения: иначе часть задач сразу станет failed.
+		if free := s.freeQueueSlots(); len(newTasks) > free {
+			s.logFor(ctx).Warn("Очередь вычислений не вмещает пакет, выражения отклонены",
+				zap.String("userID", userIDStr),
+				zap.Int("valid", len(newTasks)),
+				zap.Int("free", free),
+			)
+			return nil, status.Error(codes.ResourceExhausted, "очередь вычислений переполнена, повторите попытку позже")
+		}
+		// Квота, как и очередь, резервируется для пакета целиком, а затем делится между задачами.
+		reservation, err := s.reserveQuota(ctx, userID, demand)
+		if err != nil {
+			return nil, err
+		}
+
+		taskIDs, err := s.taskRepo.CreateTasks(ctx, newTasks)
+		if err != nil {
+			s.logFor(ctx).Error("Ошибка при создании пакета задач в репозитории", zap.Error(err))
+			s.releaseQuota(ctx, reservation)
+			return nil, status.Error(codes.Internal, "внутренняя ошибка сервера при создании задач")
+		}
+
+		for j, taskID := range taskIDs {
+			result := response.Results[indexes[j]]
+			taskReservation := quotaReservation{userID: userID, day: reservation.day, usage: demands[j]}
+			_, position, err := s.scheduleEvaluation(ctx, taskID, userID, newTasks[j].Expression, roots[j], taskReservation, s.discardRejectedTask)
+			if err != nil {
+				result.Error = status.Convert(err).Message()
+				continue
+			}
+			result.TaskId = taskID.String()
+			result.QueuePosition = int32(position)
+		}
+	}
+
+	for _, result := range response.Results {
+		if result.Error == "" {
+			response.Accepted++
+		} else {
+			response.Rejected++
+		}
+	}
+	s.logFor(ctx).Info("Пакет выражений обработан",
+		zap.String("userID", userIDStr),
+		zap.Int32("accepted", response.Accepted),
+		zap.Int32("rejected", response.Rejected),
+	)
+	return response, nil
+}
+
+// validateBatchItem проверяет выражение пакета так же, как SubmitExpression, и возвращает причину отказа вместо ошибки.
+func (s *OrchestratorServer) validateBatchItem(item *pb.BatchExpressionItem) (repository.NewTask, ast.Node, string) {
+	expression := item.GetExpression()
+	if expression == "" {
+		return repository.NewTask{}, nil, "expression не может быть пустым"
+	}
+	if violation := s.limits.checkSource(expression); violation != nil {
+		return repository.NewTask{}, nil, violation.message
+	}
+	newTask := repository.NewTask{Expression: expression}
+	if raw := item.GetCallbackUrl(); raw != "" {
+		if err := validateWebhookURL(raw); err != nil {
+			return repository.NewTask{}, nil, "невалидный callback_url: " + err.Error()
+		}
+		newTask.CallbackURL = &raw
+	}
+	program, err := compileExpression(expression)
+	if err != nil {
+		return repository.NewTask{}, nil, "ошибка в выражении: " + err.Error()
+	}
+	if violation := s.limits.checkTree(program.Node()); violation != nil {
+		return repository.NewTask{}, nil, violation.message
+	}
+	return newTask, program.Node(), ""
+}
+
+// freeQueueSlots - сколько вычислений еще можно запустить или поставить в очередь.
+func (s *OrchestratorServer) freeQueueSlots() int {
+	stats := s.queue.Stats()
+	return max(stats.MaxConcurrent-stats.Running, 0) + max(stats.MaxQueued-stats.Waiting, 0)
+}
@@ -0,0 +1,111 @@
+package grpc_handler
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/Qu1nel/YaLyceum-GoProject-Final/internal/orchestrator/repository"
+	pb "github.com/Qu1nel/YaLyceum-GoProject-Final/proto/gen/orchestrator"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestOrchestratorServer_SubmitExpressions_ValidatesEachItem(t *testing.T) {
+	server, mockTaskRepo, mockEvaluator := setupOrchestratorServerTest(t)
+	ctx := context.Background()
+	userID := uuid.New()
+	taskID := uuid.New()
+	attemptID := uuid.New()
+
+	mockTaskRepo.On("CreateTasks", mock.Anything, mock.MatchedBy(func(tasks []repository.NewTask) bool {
+		return len(tasks) == 1 && tasks[0].Expression == "2*3" && tasks[0].UserID == userID
+	})).Return([]uuid.UUID{taskID}, nil).Once()
+	mockTaskRepo.On("CreateAttempt", mock.Anything, taskID).Return(&repository.TaskAttempt{
+		ID: attemptID, TaskID: taskID, AttemptNumber: 1, Status: repository.StatusPending,
+	}, nil).Once()
+
+	done := make(chan struct{})
+	mockTaskRepo.On("UpdateTaskStatus", mock.Anything, taskID, repository.StatusProcessing).Return(nil).Once()
+	mockTaskRepo.On("StartAttempt", mock.Anything, attemptID).Return(nil).Once()
+	mockEvaluator.On("Evaluate", mock.Anything, mock.Anything).Return(6.0, nil).Once()
+	mockTaskRepo.On("SetTaskResult", mock.Anything, taskID, 6.0).Return(nil).Once()
+	mockTaskRepo.On("FinishAttempt", mock.Anything, attemptID, mock.Anything, (*string)(nil)).
+		Run(func(mock.Arguments) { close(done) }).Return(nil).Once()
+
+	res, err := server.SubmitExpressions(ctx, &pb.BatchExpressionRequest{
+		UserId: userID.String(),
+		Items: []*pb.BatchExpressionItem{
+			{Expression: "2+"},
+			{Expression: "2*3"},
+			{Expression: ""},
+			{Expression: "1+1", CallbackUrl: "ftp://example.com"},
+		},
+	})
+	require.NoError(t, err)
+	require.Len(t, res.Results, 4)
+	assert.Equal(t, int32(1), res.Accepted)
+	assert.Equal(t, int32(3), res.Rejected)
+
+	assert.Empty(t, res.Results[0].TaskId)
+	assert.Contains(t, res.Results[0].Error, "ошибка в выражении")
+	assert.Equal(t, taskID.String(), res.Results[1].TaskId)
+	assert.Empty(t, res.Results[1].Error)
+	assert.Equal(t, "expression не может быть пустым", res.Results[2].Error)
+	assert.Contains(t, res.Results[3].Error, "невалидный callback_url")
+	for i, result := range res.Results {
+		assert.Equal(t, int32(i), result.Index)
+	}
+
+	select {
+	case <-done:
+	case <-time.After(time.Second):
+		t.Fatal("Вычисление задачи из пакета не завершилось")
+	}
+}
+
+func TestOrchestratorServer_SubmitExpressions_QueueCannotFitBatch(t *testing.T) {
+	server, _, _ := setupOrchestratorServerTest(t)
+
+	// Очередь теста вмещает одно запущенное и одно ожидающее вычисление.
+	_, err := server.SubmitExpressions(context.Background(), &pb.BatchExpressionRequest{
+		UserId: uuid.New().String(),
+		Items:  []*pb.BatchExpressionItem{{Expression: "1+1"}, {Expression: "2+2"}, {Expression: "3+3"}},
+	})
+	require.Error(t, err)
+	assert.Equal(t, codes.ResourceExhausted, status.Code(err))
+}
+
+func TestOrchestratorServer_SubmitExpressions_NotQueuedTaskDeleted(t *testing.T) {
+	server, mockTaskRepo, _ := setupOrchestratorServerTest(t)
+	taskID := uuid.New()
+
+	mockTaskRepo.On("CreateTasks", mock.Anything, mock.Anything).Return([]uuid.UUID{taskID}, nil).Once()
+	mockTaskRepo.On("CreateAttempt", mock.Anything, taskID).Return(nil, repository.ErrDatabase).Once()
+	mockTaskRepo.On("DeleteRejectedTask", mock.Anything, taskID).Return(nil).Once()
+
+	res, err := server.SubmitExpressions(context.Background(), &pb.BatchExpressionRequest{
+		UserId: uuid.New().String(),
+		Items:  []*pb.BatchExpressionItem{{Expression: "1+1"}},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, int32(1), res.Rejected)
+	assert.Empty(t, res.Results[0].TaskId, "удаленная задача не должна попасть в ответ")
+	assert.NotEmpty(t, res.Results[0].Error)
+}
+
+func TestOrchestratorServer_SubmitExpressions_AllInvalidSkipsDatabase(t *testing.T) {
+	server, _, _ := setupOrchestratorServerTest(t)
+
+	res, err := server.SubmitExpressions(context.Background(), &pb.BatchExpressionRequest{
+		UserId: uuid.New().String(),
+		Items:  []*pb.BatchExpressionItem{{Expression: "("}, {Expression: ""}},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, int32(0), res.Accepted)
+	assert.Equal(t, int32(2), res.Rejected)
+}
@@ -0,0 +1,32 @@
+package grpc_handler
+
+import (
+	"maps"
+	"slices"
+	"time"
+
+	"github.com/Qu1nel/YaLyceum-GoProject-Final/internal/orchestrator/service"
+	pb "github.com/Qu1nel/YaLyceum-GoProject-Final/proto/gen/orchestrator"
+
+	"github.com/expr-lang/expr/ast"
+)
+
+// estimateCost оценивает вычисление по свободным сейчас слотам планировщика вызовов Воркера.
+func (s *OrchestratorServer) estimateCost(root ast.Node) service.CostEstimate {
+	stats := s.scheduler.Stats()
+	return service.EstimateCost(root, s.operationCosts, stats.MaxInflight-stats.Inflight)
+}
+
+func costEstimateToProto(estimate service.CostEstimate) *pb.CostEstimate {
+	pbEstimate := &pb.CostEstimate{
+		CriticalPathOperations: int32(estimate.CriticalPathOperations),
+		CriticalPathMs:         float64(estimate.CriticalPath) / float64(time.Millisecond),
+		TotalWorkMs:            float64(estimate.TotalWork) / float64(time.Millisecond),
+		Parallelism:            int32(estimate.Parallelism),
+		EstimatedDurationMs:    float64(estimate.Duration) / float64(time.Millisecond),
+	}
+	for _, symbol := range slices.Sorted(maps.Keys(estimate.Operations)) {
+		pbEstimate.Operations = append(pbEstimate.Operations, &pb.OperatorCount{Symbol: symbol, Count: int32(estimate.Operations[symbol])})
+	}
+	return pbEstimate
+}
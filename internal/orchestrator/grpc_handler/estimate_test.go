@@ -0,0 +1,45 @@
+package grpc_handler
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/Qu1nel/YaLyceum-GoProject-Final/internal/orchestrator/service"
+	pb "github.com/Qu1nel/YaLyceum-GoProject-Final/proto/gen/orchestrator"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestOrchestratorServer_SubmitExpression_EstimateExceedsLimit(t *testing.T) {
+	server, _, _ := setupOrchestratorServerTest(t)
+	server.operationCosts = service.OperationCosts{"+": time.Second, "*": 2 * time.Second}
+
+	_, err := server.SubmitExpression(context.Background(), &pb.ExpressionRequest{
+		UserId:                 uuid.New().String(),
+		Expression:             "(1+2)*3",
+		MaxEstimatedDurationMs: 2500,
+	})
+	require.Error(t, err)
+	assert.Equal(t, codes.FailedPrecondition, status.Code(err))
+	assert.Contains(t, status.Convert(err).Message(), "3s")
+}
+
+func TestOrchestratorServer_ValidateExpression_Estimate(t *testing.T) {
+	server, _, _ := setupOrchestratorServerTest(t)
+	server.operationCosts = service.OperationCosts{"+": 100 * time.Millisecond, "neg": 50 * time.Millisecond}
+
+	res, err := server.ValidateExpression(context.Background(), &pb.ValidateExpressionRequest{Expression: "-(1+2)+(3+4)"})
+	require.NoError(t, err)
+	require.NotNil(t, res.Estimate)
+	assert.Equal(t, []*pb.OperatorCount{{Symbol: "+", Count: 3}, {Symbol: "neg", Count: 1}}, res.Estimate.Operations)
+	assert.Equal(t, int32(3), res.Estimate.CriticalPathOperations)
+	assert.Equal(t, 250.0, res.Estimate.CriticalPathMs)
+	assert.Equal(t, 350.0, res.Estimate.TotalWorkMs)
+	// Планировщик теста пропускает один вызов Воркера за раз.
+	assert.Equal(t, int32(1), res.Estimate.Parallelism)
+	assert.Equal(t, 350.0, res.Estimate.EstimatedDurationMs)
+}
@@ -0,0 +1,65 @@
+package grpc_handler
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"time"
+
+	"github.com/Qu1nel/YaLyceum-GoProject-Final/internal/orchestrator/repository"
+	pb "github.com/Qu1nel/YaLyceum-GoProject-Final/proto/gen/orchestrator"
+
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+const maxIdempotencyKeyLength = 255
+
+// submitRequestHash - отпечаток тела запроса, по которому повтор с тем же ключом отличается от другого запроса.
+func submitRequestHash(expression, callbackURL string) string {
+	sum := sha256.Sum256([]byte(expression + "\x00" + callbackURL))
+	return hex.EncodeToString(sum[:])
+}
+
+// replayIdempotentSubmit возвращает ответ исходного запроса, если у пользователя есть задача с этим ключом,
+// созданная не раньше idempotencyKeyTTL назад. Ключ просроченной задачи освобождается, и возвращается nil.
+// Возвращаемая ошибка уже является gRPC статусом.
+func (s *OrchestratorServer) replayIdempotentSubmit(ctx context.Context, userID uuid.UUID, key, requestHash string) (*pb.ExpressionResponse, error) {
+	record, err := s.taskRepo.GetIdempotencyRecord(ctx, userID, key)
+	if err != nil {
+		if errors.Is(err, repository.ErrTaskNotFound) {
+			return nil, nil
+		}
+		s.logFor(ctx).Error("Ошибка поиска задачи по ключу идемпотентности", zap.Stringer("userID", userID), zap.Error(err))
+		return nil, status.Error(codes.Internal, "внутренняя ошибка сервера")
+	}
+
+	if time.Since(record.CreatedAt) > s.idempotencyKeyTTL {
+		s.logFor(ctx).Info("Ключ идемпотентности просрочен, создается новая задача",
+			zap.Stringer("previousTaskID", record.TaskID),
+			zap.String("idempotencyKey", key),
+		)
+		if err := s.taskRepo.ReleaseIdempotencyKey(ctx, record.TaskID); err != nil {
+			return nil, status.Error(codes.Internal, "внутренняя ошибка сервера")
+		}
+		return nil, nil
+	}
+
+	if record.RequestHash != requestHash {
+		s.logFor(ctx).Warn("Ключ идемпотентности повторно использован с другим телом запроса",
+			zap.Stringer("taskID", record.TaskID),
+			zap.String("idempotencyKey", key),
+		)
+		return nil, status.Error(codes.AlreadyExists, "ключ идемпотентности уже использован для другого запроса")
+	}
+
+	s.logFor(ctx).Info("Повтор запроса с ключом идемпотентности, возвращается исходная задача",
+		zap.Stringer("taskID", record.TaskID),
+		zap.String("idempotencyKey", key),
+	)
+	position, _ := s.queue.Position(record.TaskID)
+	return &pb.ExpressionResponse{TaskId: record.TaskID.String(), QueuePosition: int32(position), IdempotentReplay: true}, nil
+}
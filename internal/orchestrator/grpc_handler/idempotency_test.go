@@ -0,0 +1,96 @@
+package grpc_handler
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/Qu1nel/YaLyceum-GoProject-Final/internal/orchestrator/repository"
+	pb "github.com/Qu1nel/YaLyceum-GoProject-Final/proto/gen/orchestrator"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestOrchestratorServer_SubmitExpression_IdempotentReplay(t *testing.T) {
+	server, mockTaskRepo, _ := setupOrchestratorServerTest(t)
+	userID := uuid.New()
+	taskID := uuid.New()
+
+	mockTaskRepo.On("GetIdempotencyRecord", mock.Anything, userID, "key-1").Return(&repository.IdempotencyRecord{
+		TaskID:      taskID,
+		RequestHash: submitRequestHash("2+2", ""),
+		CreatedAt:   time.Now().Add(-time.Hour),
+	}, nil).Once()
+
+	res, err := server.SubmitExpression(context.Background(), &pb.ExpressionRequest{
+		UserId: userID.String(), Expression: "2+2", IdempotencyKey: "key-1",
+	})
+	require.NoError(t, err)
+	assert.Equal(t, taskID.String(), res.TaskId)
+	assert.True(t, res.IdempotentReplay)
+	mockTaskRepo.AssertNotCalled(t, "CreateIdempotentTask", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestOrchestratorServer_SubmitExpression_IdempotencyKeyDifferentBody(t *testing.T) {
+	server, mockTaskRepo, _ := setupOrchestratorServerTest(t)
+	userID := uuid.New()
+
+	mockTaskRepo.On("GetIdempotencyRecord", mock.Anything, userID, "key-1").Return(&repository.IdempotencyRecord{
+		TaskID:      uuid.New(),
+		RequestHash: submitRequestHash("2+2", ""),
+		CreatedAt:   time.Now(),
+	}, nil).Once()
+
+	_, err := server.SubmitExpression(context.Background(), &pb.ExpressionRequest{
+		UserId: userID.String(), Expression: "3+3", IdempotencyKey: "key-1",
+	})
+	require.Error(t, err)
+	assert.Equal(t, codes.AlreadyExists, status.Code(err))
+}
+
+func TestOrchestratorServer_SubmitExpression_ExpiredIdempotencyKeyReleased(t *testing.T) {
+	server, mockTaskRepo, _ := setupOrchestratorServerTest(t)
+	userID := uuid.New()
+	previousTaskID := uuid.New()
+	requestHash := submitRequestHash("2+2", "")
+
+	mockTaskRepo.On("GetIdempotencyRecord", mock.Anything, userID, "key-1").Return(&repository.IdempotencyRecord{
+		TaskID:      previousTaskID,
+		RequestHash: requestHash,
+		CreatedAt:   time.Now().Add(-25 * time.Hour),
+	}, nil).Once()
+	mockTaskRepo.On("ReleaseIdempotencyKey", mock.Anything, previousTaskID).Return(nil).Once()
+	mockTaskRepo.On("CreateIdempotentTask", mock.Anything, mock.Anything, "key-1", requestHash).
+		Return(uuid.Nil, repository.ErrDatabase).Once()
+
+	_, err := server.SubmitExpression(context.Background(), &pb.ExpressionRequest{
+		UserId: userID.String(), Expression: "2+2", IdempotencyKey: "key-1",
+	})
+	require.Error(t, err)
+	assert.Equal(t, codes.Internal, status.Code(err))
+}
+
+func TestOrchestratorServer_SubmitExpression_ConcurrentIdempotentRequest(t *testing.T) {
+	server, mockTaskRepo, _ := setupOrchestratorServerTest(t)
+	userID := uuid.New()
+	winnerTaskID := uuid.New()
+	requestHash := submitRequestHash("2+2", "")
+
+	mockTaskRepo.On("GetIdempotencyRecord", mock.Anything, userID, "key-1").Return(nil, repository.ErrTaskNotFound).Once()
+	mockTaskRepo.On("CreateIdempotentTask", mock.Anything, mock.Anything, "key-1", requestHash).
+		Return(uuid.Nil, repository.ErrIdempotencyKeyTaken).Once()
+	mockTaskRepo.On("GetIdempotencyRecord", mock.Anything, userID, "key-1").Return(&repository.IdempotencyRecord{
+		TaskID: winnerTaskID, RequestHash: requestHash, CreatedAt: time.Now(),
+	}, nil).Once()
+
+	res, err := server.SubmitExpression(context.Background(), &pb.ExpressionRequest{
+		UserId: userID.String(), Expression: "2+2", IdempotencyKey: "key-1",
+	})
+	require.NoError(t, err)
+	assert.Equal(t, winnerTaskID.String(), res.TaskId)
+	assert.True(t, res.IdempotentReplay)
+}
@@ -0,0 +1,104 @@
+package grpc_handler
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+
+	"github.com/Qu1nel/YaLyceum-GoProject-Final/internal/orchestrator/config"
+
+	"github.com/expr-lang/expr/ast"
+	"github.com/expr-lang/expr/file"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// expressionLimits ограничивает сложность выражения: каждый узел дерева - это горутина и, возможно, вызов Воркера.
+type expressionLimits struct {
+	maxBytes   int
+	maxNodes   int
+	maxDepth   int
+	maxLiteral float64
+}
+
+// Причины отказа в ErrorInfo: по ним Агент отличает ошибку разбора от нарушенного лимита, не разбирая текст.
+const (
+	errorInfoDomain               = "orchestrator"
+	reasonExpressionParseError    = "EXPRESSION_PARSE_ERROR"
+	reasonExpressionLimitExceeded = "EXPRESSION_LIMIT_EXCEEDED"
+)
+
+// limitViolation - нарушенный лимит; location указывает на число, нарушившее EXPR_MAX_LITERAL.
+type limitViolation struct {
+	limit    string
+	message  string
+	location file.Location
+}
+
+func (v *limitViolation) status() error {
+	return expressionRejected(reasonExpressionLimitExceeded, v.message, map[string]string{"limit": v.limit})
+}
+
+// expressionRejected - InvalidArgument с ErrorInfo, описывающим причину отказа в выражении.
+func expressionRejected(reason, message string, metadata map[string]string) error {
+	st, err := status.New(codes.InvalidArgument, message).WithDetails(&errdetails.ErrorInfo{
+		Reason:   reason,
+		Domain:   errorInfoDomain,
+		Metadata: metadata,
+	})
+	if err != nil {
+		return status.Error(codes.InvalidArgument, message)
+	}
+	return st.Err()
+}
+
+func newExpressionLimits(cfg config.ExpressionLimitsConfig) expressionLimits {
+	return expressionLimits{maxBytes: cfg.MaxBytes, maxNodes: cfg.MaxNodes, maxDepth: cfg.MaxDepth, maxLiteral: cfg.MaxLiteral}
+}
+
+// checkSource проверяется до разбора выражения, чтобы не компилировать заведомо слишком длинный текст.
+func (l expressionLimits) checkSource(expression string) *limitViolation {
+	if len(expression) > l.maxBytes {
+		return &limitViolation{limit: "EXPR_MAX_BYTES", message: fmt.Sprintf("превышен лимит EXPR_MAX_BYTES: длина выражения %d байт, максимум %d", len(expression), l.maxBytes)}
+	}
+	return nil
+}
+
+func (l expressionLimits) checkTree(root ast.Node) *limitViolation {
+	if nodes := countNodes(root); nodes > l.maxNodes {
+		return &limitViolation{limit: "EXPR_MAX_NODES", message: fmt.Sprintf("превышен лимит EXPR_MAX_NODES: %d узлов в дереве выражения, максимум %d", nodes, l.maxNodes)}
+	}
+	if depth := treeDepth(root); depth > l.maxDepth {
+		return &limitViolation{limit: "EXPR_MAX_DEPTH", message: fmt.Sprintf("превышен лимит EXPR_MAX_DEPTH: глубина дерева выражения %d, максимум %d", depth, l.maxDepth)}
+	}
+	return l.checkLiterals(root)
+}
+
+func (l expressionLimits) checkLiterals(node ast.Node) *limitViolation {
+	var value float64
+	switch n := node.(type) {
+	case *ast.IntegerNode:
+		value = float64(n.Value)
+	case *ast.FloatNode:
+		value = n.Value
+	case *ast.UnaryNode:
+		return l.checkLiterals(n.Node)
+	case *ast.BinaryNode:
+		if violation := l.checkLiterals(n.Left); violation != nil {
+			return violation
+		}
+		return l.checkLiterals(n.Right)
+	default:
+		return nil
+	}
+	if math.Abs(value) > l.maxLiteral {
+		return &limitViolation{
+			limit: "EXPR_MAX_LITERAL",
+			message: fmt.Sprintf("превышен лимит EXPR_MAX_LITERAL: модуль числа %s больше %s",
+				strconv.FormatFloat(value, 'g', -1, 64), strconv.FormatFloat(l.maxLiteral, 'g', -1, 64)),
+			location: node.Location(),
+		}
+	}
+	return nil
+}
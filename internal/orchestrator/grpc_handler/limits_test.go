@@ -0,0 +1,98 @@
+package grpc_handler
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	pb "github.com/Qu1nel/YaLyceum-GoProject-Final/proto/gen/orchestrator"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestOrchestratorServer_SubmitExpression_ComplexityLimits(t *testing.T) {
+	// Лимиты теста: 64 байта, 15 узлов, глубина 5, модуль числа 1e6.
+	tests := []struct {
+		name       string
+		expression string
+		limit      string
+	}{
+		{name: "длина", expression: "1" + strings.Repeat(" + 1", 20), limit: "EXPR_MAX_BYTES"},
+		{name: "число узлов", expression: "(1+1)*(1+1)+(1+1)*(1+1)+(1+1)", limit: "EXPR_MAX_NODES"},
+		{name: "глубина", expression: "1+1+1+1+1+1", limit: "EXPR_MAX_DEPTH"},
+		{name: "модуль числа", expression: "2 * -2000000", limit: "EXPR_MAX_LITERAL"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server, _, _ := setupOrchestratorServerTest(t)
+
+			_, err := server.SubmitExpression(context.Background(), &pb.ExpressionRequest{
+				UserId: uuid.New().String(), Expression: tt.expression,
+			})
+			require.Error(t, err)
+			assert.Equal(t, codes.InvalidArgument, status.Code(err))
+			assert.Contains(t, status.Convert(err).Message(), tt.limit)
+			info := errorInfo(t, err)
+			assert.Equal(t, reasonExpressionLimitExceeded, info.GetReason())
+			assert.Equal(t, tt.limit, info.GetMetadata()["limit"])
+		})
+	}
+}
+
+// Лишние скобки не добавляют узлов, поэтому не увеличивают глубину дерева.
+func TestExpressionLimits_RedundantParenthesesWithinDepth(t *testing.T) {
+	server, _, _ := setupOrchestratorServerTest(t)
+
+	program, err := compileExpression("((((((((1+1))))))))")
+	require.NoError(t, err)
+	assert.Nil(t, server.limits.checkTree(program.Node()))
+}
+
+func TestOrchestratorServer_SubmitExpression_ParseErrorReason(t *testing.T) {
+	server, _, _ := setupOrchestratorServerTest(t)
+
+	_, err := server.SubmitExpression(context.Background(), &pb.ExpressionRequest{UserId: uuid.New().String(), Expression: "(1+2"})
+	require.Error(t, err)
+	assert.Equal(t, codes.InvalidArgument, status.Code(err))
+	assert.Equal(t, reasonExpressionParseError, errorInfo(t, err).GetReason())
+}
+
+func errorInfo(t *testing.T, err error) *errdetails.ErrorInfo {
+	t.Helper()
+	for _, detail := range status.Convert(err).Details() {
+		if info, ok := detail.(*errdetails.ErrorInfo); ok {
+			return info
+		}
+	}
+	require.Fail(t, "в статусе нет ErrorInfo")
+	return nil
+}
+
+func TestOrchestratorServer_ValidateExpression_LiteralLimitPosition(t *testing.T) {
+	server, _, _ := setupOrchestratorServerTest(t)
+
+	res, err := server.ValidateExpression(context.Background(), &pb.ValidateExpressionRequest{Expression: "1 + 2.5e7"})
+	require.NoError(t, err)
+	assert.False(t, res.Valid)
+	require.Len(t, res.Errors, 1)
+	assert.Contains(t, res.Errors[0].Message, "EXPR_MAX_LITERAL")
+	assert.Equal(t, int32(5), res.Errors[0].Column)
+	assert.Equal(t, "2.5e7", res.Errors[0].Token)
+	assert.Nil(t, res.Estimate)
+}
+
+func TestOrchestratorServer_SubmitExpressions_ComplexityLimitPerItem(t *testing.T) {
+	server, _, _ := setupOrchestratorServerTest(t)
+
+	res, err := server.SubmitExpressions(context.Background(), &pb.BatchExpressionRequest{
+		UserId: uuid.New().String(),
+		Items:  []*pb.BatchExpressionItem{{Expression: "1+1+1+1+1+1"}, {Expression: "(1+2"}},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, int32(2), res.Rejected)
+	assert.Contains(t, res.Results[0].Error, "EXPR_MAX_DEPTH")
+}
@@ -5,76 +5,202 @@ import (
 	"errors"
 	"fmt"
 	"math"
+	"sync"
 	"time"
 
+	"github.com/Qu1nel/YaLyceum-GoProject-Final/internal/orchestrator/config"
 	"github.com/Qu1nel/YaLyceum-GoProject-Final/internal/orchestrator/repository"
 	"github.com/Qu1nel/YaLyceum-GoProject-Final/internal/orchestrator/service"
+	"github.com/Qu1nel/YaLyceum-GoProject-Final/internal/pkg/logger"
+	"github.com/Qu1nel/YaLyceum-GoProject-Final/internal/pkg/requestid"
 	pb "github.com/Qu1nel/YaLyceum-GoProject-Final/proto/gen/orchestrator"
 
 	"github.com/expr-lang/expr"
 	"github.com/expr-lang/expr/ast"
+	"github.com/expr-lang/expr/vm"
 
 	"github.com/google/uuid"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	otelcodes "go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
 	"go.uber.org/zap"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
 	"google.golang.org/protobuf/types/known/timestamppb"
 )
 
+const tracerName = "github.com/Qu1nel/YaLyceum-GoProject-Final/internal/orchestrator/grpc_handler"
+
 type OrchestratorServer struct {
 	pb.UnimplementedOrchestratorServiceServer
 	log       *zap.Logger
 	taskRepo  repository.TaskRepository
 	evaluator service.Evaluator
+	queue     service.EvaluationQueue
+	events    service.TaskEventBroker
+
+	scheduler   service.OperationScheduler
+	evaluations service.EvaluationRegistry
+
+	webhookRepo repository.WebhookRepository
+	webhooks    service.WebhookNotifier
+
+	usageRepo repository.UsageRepository
+	quotas    service.Quotas
+
+	idempotencyKeyTTL time.Duration
+	operationCosts    service.OperationCosts
+	limits            expressionLimits
+
+	watchersDone      chan struct{}
+	closeWatchersOnce sync.Once
 }
 
 func NewOrchestratorServer(
 	log *zap.Logger,
 	taskRepo repository.TaskRepository,
 	evaluator service.Evaluator,
+	queue service.EvaluationQueue,
+	events service.TaskEventBroker,
+	scheduler service.OperationScheduler,
+	evaluations service.EvaluationRegistry,
+	webhookRepo repository.WebhookRepository,
+	webhooks service.WebhookNotifier,
+	usageRepo repository.UsageRepository,
+	cfg *config.Config,
 ) *OrchestratorServer {
 	return &OrchestratorServer{
 		log:       log,
 		taskRepo:  taskRepo,
 		evaluator: evaluator,
+		queue:     queue,
+		events:    events,
+
+		scheduler:   scheduler,
+		evaluations: evaluations,
+
+		webhookRepo: webhookRepo,
+		webhooks:    webhooks,
+
+		usageRepo: usageRepo,
+		quotas:    service.NewQuotas(cfg.Quota),
+
+		idempotencyKeyTTL: cfg.Idempotency.KeyTTL,
+		operationCosts:    service.NewOperationCosts(cfg.OperationTime),
+		limits:            newExpressionLimits(cfg.Limits),
+
+		watchersDone: make(chan struct{}),
 	}
 }
 
+// logFor возвращает логгер вызова с полем request_id, который кладет в контекст перехватчик requestid.
+func (s *OrchestratorServer) logFor(ctx context.Context) *zap.Logger {
+	return logger.FromContext(ctx, s.log)
+}
+
 func (s *OrchestratorServer) SubmitExpression(ctx context.Context, req *pb.ExpressionRequest) (*pb.ExpressionResponse, error) {
 	userIDStr := req.GetUserId()
 	expression := req.GetExpression()
 
-	s.log.Info("Получен gRPC запрос SubmitExpression",
+	s.logFor(ctx).Info("Получен gRPC запрос SubmitExpression",
 		zap.String("userID", userIDStr),
 		zap.String("expression", expression),
 	)
 
 	userID, err := uuid.Parse(userIDStr)
 	if err != nil {
-		s.log.Warn("Невалидный формат UserID", zap.String("userID", userIDStr), zap.Error(err))
+		s.logFor(ctx).Warn("Невалидный формат UserID", zap.String("userID", userIDStr), zap.Error(err))
 		return nil, status.Errorf(codes.InvalidArgument, "невалидный формат userID: %v", err)
 	}
 	if expression == "" {
-		s.log.Warn("Пустое выражение", zap.String("userID", userIDStr))
+		s.logFor(ctx).Warn("Пустое выражение", zap.String("userID", userIDStr))
 		return nil, status.Error(codes.InvalidArgument, "expression не может быть пустым")
 	}
+	if violation := s.limits.checkSource(expression); violation != nil {
+		s.logFor(ctx).Warn("Выражение нарушает лимит сложности", zap.String("userID", userIDStr), zap.String("violation", violation.message))
+		return nil, violation.status()
+	}
+	var callbackURL *string
+	if raw := req.GetCallbackUrl(); raw != "" {
+		if err := validateWebhookURL(raw); err != nil {
+			return nil, status.Errorf(codes.InvalidArgument, "невалидный callback_url: %v", err)
+		}
+		callbackURL = &raw
+	}
+	idempotencyKey := req.GetIdempotencyKey()
+	requestHash := submitRequestHash(expression, req.GetCallbackUrl())
+	if len(idempotencyKey) > maxIdempotencyKeyLength {
+		return nil, status.Errorf(codes.InvalidArgument, "idempotency_key длиннее %d символов", maxIdempotencyKeyLength)
+	}
+	if req.GetMaxEstimatedDurationMs() < 0 {
+		return nil, status.Error(codes.InvalidArgument, "max_estimated_duration_ms не может быть отрицательным")
+	}
+	if idempotencyKey != "" {
+		if replay, err := s.replayIdempotentSubmit(ctx, userID, idempotencyKey, requestHash); replay != nil || err != nil {
+			return replay, err
+		}
+	}
 
-	program, compileErr := expr.Compile(expression)
+	program, compileErr := compileExpression(expression)
 	if compileErr != nil {
 
-		s.log.Warn("Ошибка компиляции/парсинга выражения (expr)",
+		s.logFor(ctx).Warn("Ошибка компиляции/парсинга выражения (expr)",
 			zap.String("expression", expression),
 			zap.Error(compileErr),
 		)
 
-		return nil, status.Errorf(codes.InvalidArgument, "ошибка в выражении: %s", compileErr.Error())
+		return nil, expressionRejected(reasonExpressionParseError, "ошибка в выражении: "+compileErr.Error(), nil)
 	}
-	s.log.Info("Выражение успешно скомпилировано и распарсено в AST (expr)", zap.String("expression", expression))
+	s.logFor(ctx).Info("Выражение успешно скомпилировано и распарсено в AST (expr)", zap.String("expression", expression))
 	astRootNode := program.Node()
+	if violation := s.limits.checkTree(astRootNode); violation != nil {
+		s.logFor(ctx).Warn("Выражение нарушает лимит сложности", zap.String("userID", userIDStr), zap.String("violation", violation.message))
+		return nil, violation.status()
+	}
 
-	taskID, err := s.taskRepo.CreateTask(ctx, userID, expression)
+	estimate := s.estimateCost(astRootNode)
+	if limit := time.Duration(req.GetMaxEstimatedDurationMs()) * time.Millisecond; limit > 0 && estimate.Duration > limit {
+		s.logFor(ctx).Info("Оценка времени вычисления превышает лимит клиента, выражение отклонено",
+			zap.String("userID", userIDStr),
+			zap.Duration("estimate", estimate.Duration),
+			zap.Duration("limit", limit),
+		)
+		return nil, status.Errorf(codes.FailedPrecondition, "оценка времени вычисления %s превышает max_estimated_duration %s", estimate.Duration, limit)
+	}
+	if s.queue.Full() {
+		s.logFor(ctx).Warn("Очередь вычислений переполнена, выражение отклонено", zap.String("userID", userIDStr))
+		return nil, status.Error(codes.ResourceExhausted, "очередь вычислений переполнена, повторите попытку позже")
+	}
+	reservation, err := s.reserveQuota(ctx, userID, service.QuotaDemand(1, estimate))
 	if err != nil {
-		s.log.Error("Ошибка при создании задачи в репозитории", zap.Error(err))
+		return nil, err
+	}
+
+	var requestID *string
+	if id := requestid.FromContext(ctx); id != "" {
+		requestID = &id
+	}
+	newTask := repository.NewTask{UserID: userID, Expression: expression, CallbackURL: callbackURL, RequestID: requestID}
+	var taskID uuid.UUID
+	if idempotencyKey != "" {
+		taskID, err = s.taskRepo.CreateIdempotentTask(ctx, newTask, idempotencyKey, requestHash)
+	} else {
+		taskID, err = s.taskRepo.CreateTask(ctx, newTask)
+	}
+	if err != nil {
+		s.releaseQuota(ctx, reservation)
+	}
+	if errors.Is(err, repository.ErrIdempotencyKeyTaken) {
+		// Параллельный запрос с тем же ключом успел создать задачу первым.
+		replay, replayErr := s.replayIdempotentSubmit(ctx, userID, idempotencyKey, requestHash)
+		if replay != nil || replayErr != nil {
+			return replay, replayErr
+		}
+		return nil, status.Error(codes.Aborted, "конфликт ключа идемпотентности, повторите запрос")
+	}
+	if err != nil {
+		s.logFor(ctx).Error("Ошибка при создании задачи в репозитории", zap.Error(err))
 
 		if errors.Is(err, repository.ErrDatabase) {
 			return nil, status.Error(codes.Internal, "внутренняя ошибка сервера при создании задачи")
@@ -82,24 +208,327 @@ func (s *OrchestratorServer) SubmitExpression(ctx context.Context, req *pb.Expre
 
 		return nil, status.Errorf(codes.Unknown, "неизвестная ошибка при создании задачи: %v", err)
 	}
-	s.log.Info("Задача успешно создана", zap.String("taskID", taskID.String()))
+	s.logFor(ctx).Info("Задача успешно создана", zap.String("taskID", taskID.String()))
+
+	_, position, err := s.scheduleEvaluation(ctx, taskID, userID, expression, astRootNode, reservation, s.discardRejectedTask)
+	if err != nil {
+		return nil, err
+	}
+
+	return &pb.ExpressionResponse{TaskId: taskID.String(), QueuePosition: int32(position), Estimate: costEstimateToProto(estimate)}, nil
+}
+
+func (s *OrchestratorServer) RetryTask(ctx context.Context, req *pb.RetryTaskRequest) (*pb.RetryTaskResponse, error) {
+	taskIDStr := req.GetTaskId()
+	requestingUserIDStr := req.GetUserId()
+
+	s.logFor(ctx).Info("Получен gRPC запрос RetryTask",
+		zap.String("taskID", taskIDStr),
+		zap.String("requestingUserID", requestingUserIDStr),
+	)
+
+	task, err := s.getOwnedTask(ctx, taskIDStr, requestingUserIDStr, "RetryTask")
+	if err != nil {
+		return nil, err
+	}
+	taskID := task.ID
+
+	if task.DeletedAt != nil {
+		return nil, status.Error(codes.FailedPrecondition, "задача находится в корзине, сначала восстановите ее")
+	}
+	if task.Status != repository.StatusCompleted && task.Status != repository.StatusFailed {
+		return nil, status.Errorf(codes.FailedPrecondition, "задача в статусе '%s' не может быть перезапущена", task.Status)
+	}
+
+	program, compileErr := compileExpression(task.Expression)
+	if compileErr != nil {
+		s.logFor(ctx).Warn("Ошибка компиляции сохраненного выражения при перезапуске",
+			zap.Stringer("taskID", taskID),
+			zap.Error(compileErr),
+		)
+		return nil, status.Errorf(codes.FailedPrecondition, "ошибка в выражении: %s", compileErr.Error())
+	}
+	if s.queue.Full() {
+		s.logFor(ctx).Warn("Очередь вычислений переполнена, перезапуск отклонен", zap.Stringer("taskID", taskID))
+		return nil, status.Error(codes.ResourceExhausted, "очередь вычислений переполнена, повторите попытку позже")
+	}
+	reservation, err := s.reserveQuota(ctx, task.UserID, service.QuotaDemand(0, s.estimateCost(program.Node())))
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.taskRepo.ResetTaskForRetry(ctx, taskID); err != nil {
+		s.releaseQuota(ctx, reservation)
+		if errors.Is(err, repository.ErrTaskNotRetryable) {
+			return nil, status.Error(codes.FailedPrecondition, "задача уже выполняется и не может быть перезапущена")
+		}
+		s.logFor(ctx).Error("Ошибка сброса задачи для перезапуска", zap.Stringer("taskID", taskID), zap.Error(err))
+		return nil, status.Error(codes.Internal, "внутренняя ошибка сервера при перезапуске задачи")
+	}
+
+	attemptNumber, position, err := s.scheduleEvaluation(ctx, taskID, task.UserID, task.Expression, program.Node(), reservation, s.revertRejectedRetry(*task))
+	if err != nil {
+		return nil, err
+	}
+
+	return &pb.RetryTaskResponse{
+		TaskId:        taskID.String(),
+		AttemptNumber: int32(attemptNumber),
+		QueuePosition: int32(position),
+	}, nil
+}
+
+func (s *OrchestratorServer) DeleteTask(ctx context.Context, req *pb.DeleteTaskRequest) (*pb.DeleteTaskResponse, error) {
+	s.logFor(ctx).Info("Получен gRPC запрос DeleteTask",
+		zap.String("taskID", req.GetTaskId()),
+		zap.String("requestingUserID", req.GetUserId()),
+		zap.Bool("purge", req.GetPurge()),
+	)
+
+	task, err := s.getOwnedTask(ctx, req.GetTaskId(), req.GetUserId(), "DeleteTask")
+	if err != nil {
+		return nil, err
+	}
+	if task.Status != repository.StatusCompleted && task.Status != repository.StatusFailed {
+		return nil, status.Errorf(codes.FailedPrecondition, "задача в статусе '%s' не может быть удалена", task.Status)
+	}
+
+	if req.GetPurge() {
+		err = s.taskRepo.DeleteTask(ctx, task.ID)
+	} else {
+		if task.DeletedAt != nil {
+			return nil, status.Error(codes.FailedPrecondition, "задача уже находится в корзине")
+		}
+		err = s.taskRepo.SoftDeleteTask(ctx, task.ID)
+	}
+	if err != nil {
+		if errors.Is(err, repository.ErrTaskNotFound) {
+			return nil, status.Errorf(codes.NotFound, "задача с ID %s не найдена", task.ID)
+		}
+		s.logFor(ctx).Error("Ошибка удаления задачи", zap.Stringer("taskID", task.ID), zap.Error(err))
+		return nil, status.Error(codes.Internal, "внутренняя ошибка сервера при удалении задачи")
+	}
+
+	return &pb.DeleteTaskResponse{}, nil
+}
+
+func (s *OrchestratorServer) RestoreTask(ctx context.Context, req *pb.RestoreTaskRequest) (*pb.RestoreTaskResponse, error) {
+	s.logFor(ctx).Info("Получен gRPC запрос RestoreTask",
+		zap.String("taskID", req.GetTaskId()),
+		zap.String("requestingUserID", req.GetUserId()),
+	)
+
+	task, err := s.getOwnedTask(ctx, req.GetTaskId(), req.GetUserId(), "RestoreTask")
+	if err != nil {
+		return nil, err
+	}
+	if task.DeletedAt == nil {
+		return nil, status.Error(codes.FailedPrecondition, "задача не находится в корзине")
+	}
+
+	if err := s.taskRepo.RestoreTask(ctx, task.ID); err != nil {
+		if errors.Is(err, repository.ErrTaskNotFound) {
+			return nil, status.Error(codes.FailedPrecondition, "задача не находится в корзине")
+		}
+		s.logFor(ctx).Error("Ошибка восстановления задачи", zap.Stringer("taskID", task.ID), zap.Error(err))
+		return nil, status.Error(codes.Internal, "внутренняя ошибка сервера при восстановлении задачи")
+	}
+
+	return &pb.RestoreTaskResponse{}, nil
+}
+
+// getOwnedTask загружает задачу и проверяет, что она принадлежит пользователю.
+// Чужая задача неотличима от несуществующей. Возвращаемая ошибка уже является gRPC статусом.
+func (s *OrchestratorServer) getOwnedTask(ctx context.Context, taskIDStr, requestingUserIDStr, method string) (*repository.Task, error) {
+	taskID, err := uuid.Parse(taskIDStr)
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "невалидный формат taskID: %v", err)
+	}
+	requestingUserID, err := uuid.Parse(requestingUserIDStr)
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "невалидный формат userID в запросе: %v", err)
+	}
+
+	task, err := s.taskRepo.GetTaskByID(ctx, taskID)
+	if err != nil {
+		if errors.Is(err, repository.ErrTaskNotFound) {
+			s.logFor(ctx).Warn("Задача не найдена", zap.String("method", method), zap.Stringer("taskID", taskID))
+			return nil, status.Errorf(codes.NotFound, "задача с ID %s не найдена", taskIDStr)
+		}
+		s.logFor(ctx).Error("Ошибка получения задачи из репозитория", zap.String("method", method), zap.Stringer("taskID", taskID), zap.Error(err))
+		return nil, status.Error(codes.Internal, "внутренняя ошибка сервера")
+	}
 
-	s.log.Info("Планируется запуск асинхронного вычисления",
+	if task.UserID != requestingUserID {
+		s.logFor(ctx).Warn("Попытка доступа к чужой задаче",
+			zap.String("method", method),
+			zap.Stringer("taskID", taskID),
+			zap.Stringer("taskOwnerUserID", task.UserID),
+			zap.Stringer("requestingUserID", requestingUserID),
+		)
+		return nil, status.Errorf(codes.NotFound, "задача с ID %s не найдена (или нет прав доступа)", taskIDStr)
+	}
+
+	return task, nil
+}
+
+// rejectedEvaluation решает судьбу задачи, вычисление которой не удалось поставить в очередь.
+// attempt равен nil, если попытку не удалось даже создать.
+type rejectedEvaluation func(ctx context.Context, taskID uuid.UUID, attempt *repository.TaskAttempt, errMsg string)
+
+// scheduleEvaluation заводит новую попытку вычисления и ставит ее в очередь. Резерв квоты переходит к вычислению,
+// а если задачу не удалось поставить в очередь, возвращается, и задача передается onReject.
+// Возвращаемая ошибка уже является gRPC статусом.
+func (s *OrchestratorServer) scheduleEvaluation(ctx context.Context, taskID, userID uuid.UUID, expression string, rootNode ast.Node, reservation quotaReservation, onReject rejectedEvaluation) (int, int, error) {
+	s.logFor(ctx).Info("Планируется постановка задачи в очередь вычислений",
 		zap.String("taskID", taskID.String()),
-		zap.Any("ast_root_type", fmt.Sprintf("%T", astRootNode)),
+		zap.Any("ast_root_type", fmt.Sprintf("%T", rootNode)),
 	)
 
-	go s.startEvaluation(taskID, userID, expression, astRootNode)
+	// Отмена запроса не должна оставить задачу наполовину поставленной в очередь.
+	dbCtx, dbCancel := context.WithTimeout(context.WithoutCancel(ctx), 5*time.Second)
+	defer dbCancel()
 
-	return &pb.ExpressionResponse{TaskId: taskID.String()}, nil
+	attempt, err := s.taskRepo.CreateAttempt(dbCtx, taskID)
+	if err != nil {
+		s.logFor(ctx).Error("Не удалось создать попытку вычисления", zap.Stringer("taskID", taskID), zap.Error(err))
+		s.releaseQuota(dbCtx, reservation)
+		onReject(dbCtx, taskID, nil, err.Error())
+		return 0, 0, status.Error(codes.Internal, "внутренняя ошибка сервера при создании попытки вычисления")
+	}
+
+	requestLink := trace.LinkFromContext(ctx, attribute.String("link.reason", "async_evaluation"))
+	requestID := requestid.FromContext(ctx)
+	operationsTotal := service.CountOperations(rootNode)
+	s.evaluations.Track(service.ActiveEvaluation{
+		TaskID:          taskID,
+		UserID:          userID,
+		Expression:      expression,
+		AttemptNumber:   attempt.AttemptNumber,
+		OperationsTotal: operationsTotal,
+	}, attempt.ID)
+	// Вычисление может стартовать прямо внутри Enqueue, поэтому оно ждет публикации pending:
+	// иначе подписчик мог бы получить pending после processing или даже после терминального события.
+	pendingPublished := make(chan struct{})
+	position, err := s.queue.Enqueue(taskID, func() {
+		<-pendingPublished
+		s.startEvaluation(requestLink, requestID, taskID, attempt.ID, attempt.AttemptNumber, userID, expression, rootNode, reservation)
+	})
+	if err != nil {
+		s.logFor(ctx).Warn("Не удалось поставить задачу в очередь вычислений", zap.Stringer("taskID", taskID), zap.Error(err))
+		s.evaluations.Finish(taskID)
+		s.releaseQuota(dbCtx, reservation)
+		onReject(dbCtx, taskID, attempt, err.Error())
+
+		if errors.Is(err, service.ErrQueueFull) {
+			return 0, 0, status.Error(codes.ResourceExhausted, "очередь вычислений переполнена, повторите попытку позже")
+		}
+		return 0, 0, status.Error(codes.Internal, "внутренняя ошибка сервера при постановке задачи в очередь")
+	}
+
+	service.ObserveTaskStatus(repository.StatusPending)
+	s.events.Publish(service.TaskEvent{
+		TaskID:          taskID,
+		Status:          repository.StatusPending,
+		AttemptNumber:   attempt.AttemptNumber,
+		QueuePosition:   position,
+		OperationsTotal: operationsTotal,
+	})
+	close(pendingPublished)
+
+	return attempt.AttemptNumber, position, nil
+}
+
+// failRejectedTask помечает задачу, которую не удалось поставить в очередь, как failed.
+func (s *OrchestratorServer) failRejectedTask(ctx context.Context, taskID uuid.UUID, attempt *repository.TaskAttempt, errMsg string) {
+	errCode := repository.ErrorCodeInternal
+	event := service.TaskEvent{TaskID: taskID, Status: repository.StatusFailed, ErrorMessage: &errMsg, ErrorCode: &errCode}
+	if attempt != nil {
+		event.AttemptNumber = attempt.AttemptNumber
+		if err := s.taskRepo.FinishAttempt(ctx, attempt.ID, nil, &errMsg); err != nil {
+			s.logFor(ctx).Error("Не удалось завершить отклоненную попытку", zap.Stringer("attemptID", attempt.ID), zap.Error(err))
+		}
+	}
+	if err := s.taskRepo.SetTaskError(ctx, taskID, errCode, errMsg); err != nil {
+		s.logFor(ctx).Error("Не удалось пометить отклоненную задачу как failed", zap.Stringer("taskID", taskID), zap.Error(err))
+	}
+	service.ObserveTaskStatus(repository.StatusFailed)
+	s.events.Publish(event)
+}
+
+// discardRejectedTask удаляет новую задачу, которую не удалось поставить в очередь: клиент получает ошибку
+// вместо ID, поэтому задача не должна оставаться в его списке. Если удалить не вышло, задача помечается failed.
+func (s *OrchestratorServer) discardRejectedTask(ctx context.Context, taskID uuid.UUID, attempt *repository.TaskAttempt, errMsg string) {
+	err := s.taskRepo.DeleteRejectedTask(ctx, taskID)
+	if err == nil {
+		return
+	}
+	s.logFor(ctx).Error("Не удалось удалить задачу, не поставленную в очередь", zap.Stringer("taskID", taskID), zap.Error(err))
+	s.failRejectedTask(ctx, taskID, attempt, errMsg)
+}
+
+// revertRejectedRetry возвращает задаче результат до перезапуска: отклоненный перезапуск не должен его стирать.
+func (s *OrchestratorServer) revertRejectedRetry(previous repository.Task) rejectedEvaluation {
+	return func(ctx context.Context, taskID uuid.UUID, attempt *repository.TaskAttempt, _ string) {
+		attemptID := uuid.Nil
+		if attempt != nil {
+			attemptID = attempt.ID
+		}
+		if err := s.taskRepo.RevertTaskRetry(ctx, previous, attemptID); err != nil {
+			s.logFor(ctx).Error("Не удалось восстановить задачу после отклоненного перезапуска", zap.Stringer("taskID", taskID), zap.Error(err))
+		}
+	}
 }
 
-func (s *OrchestratorServer) startEvaluation(taskID uuid.UUID, userID uuid.UUID, originalExpr string, rootNode ast.Node) {
+// startEvaluation выполняется в отдельной горутине очереди, уже после ответа на запрос.
+// Спан вычисления продолжает трассу запроса и ссылается на его спан через requestLink,
+// поэтому вызовы Воркера и запросы к БД видны в той же трассе, что и исходный HTTP запрос.
+// requestID запроса, поставившего задачу в очередь, попадает в логи вычисления и передается Воркеру.
+// Резерв квоты по оценке заменяется фактическим потреблением, как бы ни завершилось вычисление.
+func (s *OrchestratorServer) startEvaluation(requestLink trace.Link, requestID string, taskID, attemptID uuid.UUID, attemptNumber int, userID uuid.UUID, originalExpr string, rootNode ast.Node, reservation quotaReservation) {
+	startedAt := time.Now()
+
+	baseCtx := trace.ContextWithSpanContext(context.Background(), requestLink.SpanContext)
+	if requestID != "" {
+		baseCtx = logger.WithContext(requestid.NewContext(baseCtx, requestID), s.log.With(requestid.Field(requestID)))
+	}
+	spanCtx, span := otel.Tracer(tracerName).Start(
+		baseCtx,
+		"orchestrator.evaluate",
+		trace.WithLinks(requestLink),
+		trace.WithAttributes(
+			attribute.String("task.id", taskID.String()),
+			attribute.Int("task.attempt", attemptNumber),
+		),
+	)
+	defer span.End()
+	log := s.logFor(spanCtx)
+
+	progress := service.TaskEvent{
+		TaskID:          taskID,
+		Status:          repository.StatusProcessing,
+		AttemptNumber:   attemptNumber,
+		OperationsTotal: service.CountOperations(rootNode),
+	}
+	recorder := service.NewTraceRecorder(func(recorded int) {
+		event := progress
+		event.OperationsDone = recorded
+		s.events.Publish(event)
+	})
+
+	trackedCtx, started := s.evaluations.Begin(spanCtx, taskID, recorder)
+	if !started {
+		log.Info("Задача принудительно завершена до запуска вычисления", zap.Stringer("taskID", taskID))
+		s.settleQuota(spanCtx, reservation, repository.Usage{})
+		span.SetStatus(otelcodes.Error, service.ErrTaskForceFailed.Error())
+		return
+	}
+	defer s.evaluations.Finish(taskID)
 
-	evalCtx, cancel := context.WithTimeout(context.Background(), 1*time.Minute)
+	evalCtx, cancel := context.WithTimeout(service.ContextWithTraceRecorder(service.ContextWithUserID(trackedCtx, userID), recorder), 1*time.Minute)
 	defer cancel()
 
-	s.log.Info("Запуск асинхронного вычисления задачи",
+	log.Info("Запуск асинхронного вычисления задачи",
 		zap.Stringer("taskID", taskID),
 		zap.Stringer("userID", userID),
 		zap.String("expression", originalExpr),
@@ -107,73 +536,124 @@ func (s *OrchestratorServer) startEvaluation(taskID uuid.UUID, userID uuid.UUID,
 
 	err := s.taskRepo.UpdateTaskStatus(evalCtx, taskID, repository.StatusProcessing)
 	if err != nil {
-		s.log.Error("Не удалось обновить статус задачи на processing",
+		log.Error("Не удалось обновить статус задачи на processing",
 			zap.Stringer("taskID", taskID),
 			zap.Error(err),
 		)
 
-		_ = s.taskRepo.SetTaskError(context.Background(), taskID, fmt.Sprintf("Внутренняя ошибка: не удалось начать обработку: %v", err))
+		errMsg, errCode := fmt.Sprintf("Внутренняя ошибка: не удалось начать обработку: %v", err), repository.ErrorCodeInternal
+		_ = s.taskRepo.SetTaskError(spanCtx, taskID, errCode, errMsg)
+		_ = s.taskRepo.FinishAttempt(spanCtx, attemptID, nil, &errMsg)
+		s.settleQuota(spanCtx, reservation, repository.Usage{})
+		finished := progress
+		finished.Status, finished.ErrorMessage, finished.ErrorCode = repository.StatusFailed, &errMsg, &errCode
+		span.SetStatus(otelcodes.Error, errMsg)
+		service.ObserveTaskEvaluation(finished.Status, time.Since(startedAt))
+		s.events.Publish(finished)
+		s.webhooks.TaskFinished(taskID)
 		return
 	}
+	if err := s.taskRepo.StartAttempt(evalCtx, attemptID); err != nil {
+		log.Warn("Не удалось отметить начало попытки вычисления", zap.Stringer("attemptID", attemptID), zap.Error(err))
+	}
+	service.ObserveTaskStatus(repository.StatusProcessing)
+	s.events.Publish(progress)
 
-	s.log.Debug("Начало рекурсивного вычисления AST", zap.Stringer("taskID", taskID))
+	log.Debug("Начало рекурсивного вычисления AST", zap.Stringer("taskID", taskID))
 	result, evalErr := s.evaluator.Evaluate(evalCtx, rootNode)
-	s.log.Debug("Рекурсивное вычисление AST завершено", zap.Stringer("taskID", taskID), zap.Float64("result_before_check", result), zap.Error(evalErr))
+	log.Debug("Рекурсивное вычисление AST завершено", zap.Stringer("taskID", taskID), zap.Float64("result_before_check", result), zap.Error(evalErr))
 
 	if evalErr == nil {
 		if math.IsInf(result, 0) || math.IsNaN(result) {
-			errorMsg := "ошибка вычисления: результат является бесконечностью или не числом (возможно, деление на ноль)"
-			if math.IsInf(result, 1) {
-				errorMsg = "ошибка вычисления: результат +бесконечность (вероятно, деление на ноль)"
-			} else if math.IsInf(result, -1) {
+			errorMsg, errorCode := "ошибка вычисления: результат +бесконечность", repository.ErrorCodeOverflow
+			if math.IsInf(result, -1) {
 				errorMsg = "ошибка вычисления: результат -бесконечность"
 			} else if math.IsNaN(result) {
-				errorMsg = "ошибка вычисления: результат не является числом (NaN)"
+				errorMsg, errorCode = "ошибка вычисления: результат не является числом (NaN)", repository.ErrorCodeDomainError
 			}
-			s.log.Warn("Результат вычисления является Inf или NaN",
+			log.Warn("Результат вычисления является Inf или NaN",
 				zap.Stringer("taskID", taskID),
 				zap.Float64("result", result),
 			)
-			evalErr = errors.New(errorMsg)
+			evalErr = service.NewEvaluationError(errorCode, errors.New(errorMsg))
 		}
 	}
+	if forceErr := service.ForceFailedCause(evalCtx); forceErr != nil {
+		log.Warn("Вычисление задачи прервано администратором", zap.Stringer("taskID", taskID), zap.Error(forceErr))
+		evalErr = forceErr
+	}
+
+	operations := recorder.Operations()
+	traceCtx, traceCancel := context.WithTimeout(spanCtx, 5*time.Second)
+	if len(operations) > 0 {
+		if saveErr := s.taskRepo.SaveOperations(traceCtx, taskID, attemptID, operations); saveErr != nil {
+			log.Error("Не удалось сохранить трассировку операций",
+				zap.Stringer("taskID", taskID),
+				zap.Stringer("attemptID", attemptID),
+				zap.Error(saveErr),
+			)
+		}
+	}
+	s.settleQuota(traceCtx, reservation, operationsUsage(operations))
+	traceCancel()
+
+	finished := progress
+	finished.OperationsDone = len(operations)
+	defer func() {
+		span.SetAttributes(attribute.String("task.status", finished.Status), attribute.Int("task.operations", finished.OperationsDone))
+		if finished.ErrorMessage != nil {
+			span.SetStatus(otelcodes.Error, *finished.ErrorMessage)
+		}
+		service.ObserveTaskEvaluation(finished.Status, time.Since(startedAt))
+		s.events.Publish(finished)
+		s.webhooks.TaskFinished(taskID)
+	}()
 
 	if evalErr != nil {
-		s.log.Warn("Ошибка вычисления выражения для задачи",
+		log.Warn("Ошибка вычисления выражения для задачи",
 			zap.Stringer("taskID", taskID),
 			zap.Error(evalErr),
 		)
 
-		dbUpdateCtx, dbCancel := context.WithTimeout(context.Background(), 5*time.Second)
+		dbUpdateCtx, dbCancel := context.WithTimeout(spanCtx, 5*time.Second)
 		defer dbCancel()
-		if updateErr := s.taskRepo.SetTaskError(dbUpdateCtx, taskID, evalErr.Error()); updateErr != nil {
-			s.log.Error("Не удалось обновить задачу с ошибкой вычисления",
+		errMsg, errCode := evalErr.Error(), service.FailureCode(evalErr)
+		if updateErr := s.taskRepo.SetTaskError(dbUpdateCtx, taskID, errCode, errMsg); updateErr != nil {
+			log.Error("Не удалось обновить задачу с ошибкой вычисления",
 				zap.Stringer("taskID", taskID),
 				zap.Error(updateErr),
 			)
 		}
+		finished.Status, finished.ErrorMessage, finished.ErrorCode = repository.StatusFailed, &errMsg, &errCode
+		if updateErr := s.taskRepo.FinishAttempt(dbUpdateCtx, attemptID, nil, &errMsg); updateErr != nil {
+			log.Error("Не удалось завершить попытку с ошибкой", zap.Stringer("attemptID", attemptID), zap.Error(updateErr))
+		}
 	} else {
-		s.log.Info("Выражение успешно вычислено для задачи",
+		log.Info("Выражение успешно вычислено для задачи",
 			zap.Stringer("taskID", taskID),
 			zap.Float64("result", result),
 		)
-		dbUpdateCtx, dbCancel := context.WithTimeout(context.Background(), 5*time.Second)
+		dbUpdateCtx, dbCancel := context.WithTimeout(spanCtx, 5*time.Second)
 		defer dbCancel()
 		if updateErr := s.taskRepo.SetTaskResult(dbUpdateCtx, taskID, result); updateErr != nil {
-			s.log.Error("Не удалось обновить задачу с результатом вычисления",
+			log.Error("Не удалось обновить задачу с результатом вычисления",
 				zap.Stringer("taskID", taskID),
 				zap.Error(updateErr),
 			)
 		}
+		finished.Status, finished.Result = repository.StatusCompleted, &result
+		if updateErr := s.taskRepo.FinishAttempt(dbUpdateCtx, attemptID, &result, nil); updateErr != nil {
+			log.Error("Не удалось завершить попытку с результатом", zap.Stringer("attemptID", attemptID), zap.Error(updateErr))
+		}
 	}
-	s.log.Info("Асинхронное вычисление задачи завершено", zap.Stringer("taskID", taskID))
+	log.Info("Асинхронное вычисление задачи завершено", zap.Stringer("taskID", taskID))
 }
 
 func (s *OrchestratorServer) GetTaskDetails(ctx context.Context, req *pb.TaskDetailsRequest) (*pb.TaskDetailsResponse, error) {
 	taskIDStr := req.GetTaskId()
 	requestingUserIDStr := req.GetUserId()
 
-	s.log.Info("Получен gRPC запрос GetTaskDetails",
+	s.logFor(ctx).Info("Получен gRPC запрос GetTaskDetails",
 		zap.String("taskID", taskIDStr),
 		zap.String("requestingUserID", requestingUserIDStr),
 	)
@@ -190,15 +670,15 @@ func (s *OrchestratorServer) GetTaskDetails(ctx context.Context, req *pb.TaskDet
 	task, err := s.taskRepo.GetTaskByID(ctx, taskID)
 	if err != nil {
 		if errors.Is(err, repository.ErrTaskNotFound) {
-			s.log.Warn("Задача не найдена для GetTaskDetails", zap.Stringer("taskID", taskID))
+			s.logFor(ctx).Warn("Задача не найдена для GetTaskDetails", zap.Stringer("taskID", taskID))
 			return nil, status.Errorf(codes.NotFound, "задача с ID %s не найдена", taskIDStr)
 		}
-		s.log.Error("Ошибка получения задачи из репозитория для GetTaskDetails", zap.Stringer("taskID", taskID), zap.Error(err))
+		s.logFor(ctx).Error("Ошибка получения задачи из репозитория для GetTaskDetails", zap.Stringer("taskID", taskID), zap.Error(err))
 		return nil, status.Error(codes.Internal, "внутренняя ошибка сервера")
 	}
 
 	if task.UserID != requestingUserID {
-		s.log.Warn("Попытка доступа к чужой задаче",
+		s.logFor(ctx).Warn("Попытка доступа к чужой задаче",
 			zap.Stringer("taskID", taskID),
 			zap.Stringer("taskOwnerUserID", task.UserID),
 			zap.Stringer("requestingUserID", requestingUserID),
@@ -220,34 +700,179 @@ func (s *OrchestratorServer) GetTaskDetails(ctx context.Context, req *pb.TaskDet
 	if task.ErrorMessage != nil {
 		response.ErrorMessage = *task.ErrorMessage
 	}
+	if task.ErrorCode != nil {
+		response.ErrorCode = *task.ErrorCode
+	}
+	if task.DeletedAt != nil {
+		response.DeletedAt = task.DeletedAt.Format(time.RFC3339Nano)
+	}
+	if task.RequestID != nil {
+		response.RequestId = *task.RequestID
+	}
+	if task.Status == repository.StatusPending {
+		if position, queued := s.queue.Position(task.ID); queued {
+			response.QueuePosition = int32(position)
+		}
+	}
+
+	attempts, err := s.taskRepo.GetAttemptsByTaskID(ctx, taskID)
+	if err != nil {
+		s.logFor(ctx).Error("Ошибка получения попыток задачи для GetTaskDetails", zap.Stringer("taskID", taskID), zap.Error(err))
+		return nil, status.Error(codes.Internal, "внутренняя ошибка сервера")
+	}
+	for _, a := range attempts {
+		pbAttempt := &pb.TaskAttempt{
+			Number:    int32(a.AttemptNumber),
+			Status:    a.Status,
+			CreatedAt: a.CreatedAt.Format(time.RFC3339Nano),
+		}
+		if a.Result != nil {
+			pbAttempt.Result = *a.Result
+		}
+		if a.ErrorMessage != nil {
+			pbAttempt.ErrorMessage = *a.ErrorMessage
+		}
+		if a.StartedAt != nil {
+			pbAttempt.StartedAt = a.StartedAt.Format(time.RFC3339Nano)
+		}
+		if a.FinishedAt != nil {
+			pbAttempt.FinishedAt = a.FinishedAt.Format(time.RFC3339Nano)
+		}
+		response.Attempts = append(response.Attempts, pbAttempt)
+	}
 
 	return response, nil
 }
 
 func (s *OrchestratorServer) ListUserTasks(ctx context.Context, req *pb.UserTasksRequest) (*pb.UserTasksResponse, error) {
 	userIDStr := req.GetUserId()
-	s.log.Info("Получен gRPC запрос ListUserTasks", zap.String("userID", userIDStr))
+	s.logFor(ctx).Info("Получен gRPC запрос ListUserTasks",
+		zap.String("userID", userIDStr),
+		zap.Int32("pageSize", req.GetPageSize()),
+		zap.Strings("statuses", req.GetStatuses()),
+		zap.String("sortOrder", req.GetSortOrder()),
+		zap.String("search", req.GetSearch()),
+	)
 
 	userID, err := uuid.Parse(userIDStr)
 	if err != nil {
 		return nil, status.Errorf(codes.InvalidArgument, "невалидный формат userID: %v", err)
 	}
 
-	tasks, err := s.taskRepo.GetTasksByUserID(ctx, userID)
+	filter, pageSize, err := buildTaskListFilter(req)
+	if err != nil {
+		s.logFor(ctx).Warn("Невалидные параметры запроса списка задач", zap.String("userID", userIDStr), zap.Error(err))
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	tasks, err := s.taskRepo.GetTasksByUserID(ctx, userID, filter)
 	if err != nil {
-		s.log.Error("Ошибка получения списка задач из репозитория для ListUserTasks", zap.Stringer("userID", userID), zap.Error(err))
+		s.logFor(ctx).Error("Ошибка получения списка задач из репозитория для ListUserTasks", zap.Stringer("userID", userID), zap.Error(err))
 		return nil, status.Error(codes.Internal, "внутренняя ошибка сервера")
 	}
 
-	pbTasks := make([]*pb.TaskBrief, 0, len(tasks))
+	response := &pb.UserTasksResponse{}
+	if len(tasks) > pageSize {
+		tasks = tasks[:pageSize]
+		last := tasks[len(tasks)-1]
+		response.NextPageToken = encodePageToken(repository.TaskCursor{CreatedAt: last.CreatedAt, ID: last.ID})
+	}
+
+	response.Tasks = make([]*pb.TaskBrief, 0, len(tasks))
 	for _, task := range tasks {
-		pbTasks = append(pbTasks, &pb.TaskBrief{
+		brief := &pb.TaskBrief{
 			Id:         task.ID.String(),
 			Expression: task.Expression,
 			Status:     task.Status,
 			CreatedAt:  timestamppb.New(task.CreatedAt).AsTime().Format(time.RFC3339Nano),
-		})
+		}
+		if task.Result != nil {
+			brief.Result = *task.Result
+		}
+		if task.ErrorMessage != nil {
+			brief.ErrorMessage = *task.ErrorMessage
+		}
+		if task.ErrorCode != nil {
+			brief.ErrorCode = *task.ErrorCode
+		}
+		if task.DeletedAt != nil {
+			brief.DeletedAt = task.DeletedAt.Format(time.RFC3339Nano)
+		}
+		response.Tasks = append(response.Tasks, brief)
+	}
+
+	return response, nil
+}
+
+// buildTaskListFilter проверяет параметры запроса списка задач. Лимит в фильтре
+// на единицу больше размера страницы, чтобы понять, есть ли следующая страница.
+func buildTaskListFilter(req *pb.UserTasksRequest) (repository.TaskListFilter, int, error) {
+	filter := repository.TaskListFilter{Deleted: req.GetDeleted()}
+
+	pageSize := int(req.GetPageSize())
+	switch {
+	case pageSize < 0:
+		return filter, 0, errors.New("page_size не может быть отрицательным")
+	case pageSize == 0:
+		pageSize = defaultTasksPageSize
+	case pageSize > maxTasksPageSize:
+		pageSize = maxTasksPageSize
+	}
+	filter.Limit = pageSize + 1
+
+	if len([]rune(req.GetSearch())) > maxTasksSearchLength {
+		return filter, 0, fmt.Errorf("строка поиска длиннее %d символов", maxTasksSearchLength)
+	}
+	filter.Search = req.GetSearch()
+
+	for _, st := range req.GetStatuses() {
+		switch st {
+		case repository.StatusPending, repository.StatusProcessing, repository.StatusCompleted, repository.StatusFailed:
+			filter.Statuses = append(filter.Statuses, st)
+		default:
+			return filter, 0, fmt.Errorf("неизвестный статус задачи: '%s'", st)
+		}
+	}
+
+	if v := req.GetCreatedFrom(); v != "" {
+		createdFrom, err := time.Parse(time.RFC3339Nano, v)
+		if err != nil {
+			return filter, 0, fmt.Errorf("невалидный формат created_from: %v", err)
+		}
+		filter.CreatedFrom = &createdFrom
 	}
+	if v := req.GetCreatedTo(); v != "" {
+		createdTo, err := time.Parse(time.RFC3339Nano, v)
+		if err != nil {
+			return filter, 0, fmt.Errorf("невалидный формат created_to: %v", err)
+		}
+		filter.CreatedTo = &createdTo
+	}
+
+	switch req.GetSortOrder() {
+	case "", "desc":
+	case "asc":
+		filter.Ascending = true
+	default:
+		return filter, 0, fmt.Errorf("неизвестный порядок сортировки: '%s'", req.GetSortOrder())
+	}
+
+	if token := req.GetPageToken(); token != "" {
+		cursor, err := decodePageToken(token)
+		if err != nil {
+			return filter, 0, err
+		}
+		filter.After = cursor
+	}
+
+	return filter, pageSize, nil
+}
 
-	return &pb.UserTasksResponse{Tasks: pbTasks}, nil
+// compileExpression компилирует выражение без оптимизаций expr. Один и тот же AST используется для вычисления,
+// проверки лимитов, оценки стоимости, трассировки и /ast, поэтому все они видят выражение в том виде, как его прислали.
+// Оптимизатор expr сворачивает подвыражения из констант еще при компиляции: тогда выражение из одних чисел
+// вычислялось бы без единого вызова Воркера, "1 / 0" превращалось бы в +Inf вместо ошибки деления на ноль,
+// а трассировка и оценка не совпадали бы с фактическими операциями.
+func compileExpression(expression string) (*vm.Program, error) {
+	return expr.Compile(expression, expr.Optimize(false))
 }
@@ -3,19 +3,32 @@ package grpc_handler
 import (
 	"context"
 	"errors"
+	"fmt"
+	"math"
+	"sync"
+	"sync/atomic"
 	"testing"
 	"time"
 
+	"github.com/Qu1nel/YaLyceum-GoProject-Final/internal/orchestrator/config"
 	"github.com/Qu1nel/YaLyceum-GoProject-Final/internal/orchestrator/repository"
 	repo_mocks "github.com/Qu1nel/YaLyceum-GoProject-Final/internal/orchestrator/repository/mocks"
+	"github.com/Qu1nel/YaLyceum-GoProject-Final/internal/orchestrator/service"
 	service_mocks "github.com/Qu1nel/YaLyceum-GoProject-Final/internal/orchestrator/service/mocks"
+	"github.com/Qu1nel/YaLyceum-GoProject-Final/internal/pkg/requestid"
 	pb "github.com/Qu1nel/YaLyceum-GoProject-Final/proto/gen/orchestrator"
 
+	"github.com/expr-lang/expr/ast"
 	"github.com/google/uuid"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	"go.opentelemetry.io/otel/trace"
 	"go.uber.org/zap"
+	"google.golang.org/grpc"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
 )
@@ -24,10 +37,70 @@ func setupOrchestratorServerTest(t *testing.T) (*OrchestratorServer, *repo_mocks
 	logger := zap.NewNop()
 	mockTaskRepo := repo_mocks.NewTaskRepositoryMock(t)
 	mockEvaluator := service_mocks.NewExpressionEvaluatorMock(t)
-	server := NewOrchestratorServer(logger, mockTaskRepo, mockEvaluator)
+	queue := service.NewEvaluationQueue(logger, &config.Config{
+		Evaluation: config.EvaluationConfig{MaxConcurrent: 1, QueueSize: 1},
+	})
+	scheduler := service.NewFairOperationScheduler(logger, &config.Config{
+		Scheduler: config.SchedulerConfig{MaxInflight: 1, DefaultWeight: 1},
+	})
+	server := NewOrchestratorServer(logger, mockTaskRepo, mockEvaluator, queue, service.NewTaskEventBroker(logger),
+		scheduler, service.NewEvaluationRegistry(), repo_mocks.NewWebhookRepositoryMock(t), &fakeWebhookNotifier{}, &fakeUsageRepository{},
+		&config.Config{
+			Idempotency: config.IdempotencyConfig{KeyTTL: 24 * time.Hour},
+			Limits:      config.ExpressionLimitsConfig{MaxBytes: 64, MaxNodes: 15, MaxDepth: 5, MaxLiteral: 1e6},
+		})
 	return server, mockTaskRepo, mockEvaluator
 }
 
+type fakeWebhookNotifier struct {
+	woken atomic.Int32
+}
+
+func (n *fakeWebhookNotifier) TaskFinished(uuid.UUID) {}
+func (n *fakeWebhookNotifier) Wake()                  { n.woken.Add(1) }
+
+// fakeUsageRepository копит потребление в памяти без разбиения по дням: все записи попадают в текущие сутки.
+type fakeUsageRepository struct {
+	mu    sync.Mutex
+	usage map[uuid.UUID]repository.Usage
+}
+
+func (r *fakeUsageRepository) AddUsage(_ context.Context, userID uuid.UUID, _ time.Time, delta repository.Usage) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.usage == nil {
+		r.usage = make(map[uuid.UUID]repository.Usage)
+	}
+	r.usage[userID] = r.usage[userID].Add(delta)
+	return nil
+}
+
+func (r *fakeUsageRepository) ReserveUsage(_ context.Context, userID uuid.UUID, _ time.Time, delta, dailyLimit, monthlyLimit repository.Usage) (bool, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	total := r.usage[userID].Add(delta)
+	if !withinUsageLimit(total, dailyLimit) || !withinUsageLimit(total, monthlyLimit) {
+		return false, nil
+	}
+	if r.usage == nil {
+		r.usage = make(map[uuid.UUID]repository.Usage)
+	}
+	r.usage[userID] = total
+	return true, nil
+}
+
+func withinUsageLimit(usage, limit repository.Usage) bool {
+	return (limit.TasksSubmitted == 0 || usage.TasksSubmitted <= limit.TasksSubmitted) &&
+		(limit.Operations == 0 || usage.Operations <= limit.Operations) &&
+		(limit.ComputeTime == 0 || usage.ComputeTime <= limit.ComputeTime)
+}
+
+func (r *fakeUsageRepository) GetUsage(_ context.Context, userID uuid.UUID, _ time.Time) (*repository.UsageSummary, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return &repository.UsageSummary{Day: r.usage[userID], Month: r.usage[userID]}, nil
+}
+
 func TestOrchestratorServer_GetTaskDetails_Success(t *testing.T) {
 	server, mockTaskRepo, _ := setupOrchestratorServerTest(t)
 	ctx := context.Background()
@@ -51,6 +124,9 @@ func TestOrchestratorServer_GetTaskDetails_Success(t *testing.T) {
 	}
 
 	mockTaskRepo.On("GetTaskByID", mock.Anything, taskID).Return(mockRepoTask, nil).Once()
+	mockTaskRepo.On("GetAttemptsByTaskID", mock.Anything, taskID).Return([]repository.TaskAttempt{
+		{TaskID: taskID, AttemptNumber: 1, Status: repository.StatusCompleted, Result: &resultVal, CreatedAt: createdAt, StartedAt: &createdAt, FinishedAt: &updatedAt},
+	}, nil).Once()
 
 	req := &pb.TaskDetailsRequest{TaskId: taskID.String(), UserId: userID.String()}
 	res, err := server.GetTaskDetails(ctx, req)
@@ -65,6 +141,9 @@ func TestOrchestratorServer_GetTaskDetails_Success(t *testing.T) {
 
 	assert.Equal(t, createdAt.Format(time.RFC3339Nano), res.CreatedAt)
 	assert.Equal(t, updatedAt.Format(time.RFC3339Nano), res.UpdatedAt)
+	require.Len(t, res.Attempts, 1)
+	assert.Equal(t, int32(1), res.Attempts[0].Number)
+	assert.Equal(t, updatedAt.Format(time.RFC3339Nano), res.Attempts[0].FinishedAt)
 	mockTaskRepo.AssertExpectations(t)
 }
 
@@ -117,12 +196,13 @@ func TestOrchestratorServer_ListUserTasks_Success(t *testing.T) {
 
 	createdAt1 := time.Now().Add(-2 * time.Hour).UTC().Truncate(time.Microsecond)
 	createdAt2 := time.Now().Add(-1 * time.Hour).UTC().Truncate(time.Microsecond)
+	resultVal := 2.0
 
 	mockRepoTasks := []repository.Task{
-		{ID: uuid.New(), UserID: userID, Expression: "1+1", Status: repository.StatusCompleted, CreatedAt: createdAt1, UpdatedAt: createdAt1},
+		{ID: uuid.New(), UserID: userID, Expression: "1+1", Status: repository.StatusCompleted, Result: &resultVal, CreatedAt: createdAt1, UpdatedAt: createdAt1},
 		{ID: uuid.New(), UserID: userID, Expression: "2*2", Status: repository.StatusPending, CreatedAt: createdAt2, UpdatedAt: createdAt2},
 	}
-	mockTaskRepo.On("GetTasksByUserID", mock.Anything, userID).Return(mockRepoTasks, nil).Once()
+	mockTaskRepo.On("GetTasksByUserID", mock.Anything, userID, repository.TaskListFilter{Limit: defaultTasksPageSize + 1}).Return(mockRepoTasks, nil).Once()
 
 	req := &pb.UserTasksRequest{UserId: userID.String()}
 	res, err := server.ListUserTasks(ctx, req)
@@ -134,11 +214,13 @@ func TestOrchestratorServer_ListUserTasks_Success(t *testing.T) {
 	assert.Equal(t, mockRepoTasks[0].Expression, res.Tasks[0].Expression)
 	assert.Equal(t, mockRepoTasks[0].Status, res.Tasks[0].Status)
 	assert.Equal(t, createdAt1.Format(time.RFC3339Nano), res.Tasks[0].CreatedAt)
+	assert.InDelta(t, 2.0, res.Tasks[0].Result, 0.00001)
 
 	assert.Equal(t, mockRepoTasks[1].ID.String(), res.Tasks[1].Id)
 	assert.Equal(t, mockRepoTasks[1].Expression, res.Tasks[1].Expression)
 	assert.Equal(t, mockRepoTasks[1].Status, res.Tasks[1].Status)
 	assert.Equal(t, createdAt2.Format(time.RFC3339Nano), res.Tasks[1].CreatedAt)
+	assert.Empty(t, res.NextPageToken)
 
 	mockTaskRepo.AssertExpectations(t)
 }
@@ -149,7 +231,7 @@ func TestOrchestratorServer_ListUserTasks_RepoError(t *testing.T) {
 	userID := uuid.New()
 	repoErr := errors.New("ошибка бд при получении списка")
 
-	mockTaskRepo.On("GetTasksByUserID", mock.Anything, userID).Return(nil, repoErr).Once()
+	mockTaskRepo.On("GetTasksByUserID", mock.Anything, userID, mock.Anything).Return(nil, repoErr).Once()
 
 	req := &pb.UserTasksRequest{UserId: userID.String()}
 	_, err := server.ListUserTasks(ctx, req)
@@ -160,3 +242,664 @@ func TestOrchestratorServer_ListUserTasks_RepoError(t *testing.T) {
 	assert.Equal(t, codes.Internal, st.Code())
 	mockTaskRepo.AssertExpectations(t)
 }
+
+func TestOrchestratorServer_ListUserTasks_Paginated(t *testing.T) {
+	server, mockTaskRepo, _ := setupOrchestratorServerTest(t)
+	ctx := context.Background()
+	userID := uuid.New()
+
+	base := time.Now().UTC().Truncate(time.Microsecond)
+	mockRepoTasks := []repository.Task{
+		{ID: uuid.New(), UserID: userID, Expression: "1", Status: repository.StatusFailed, CreatedAt: base.Add(-time.Minute)},
+		{ID: uuid.New(), UserID: userID, Expression: "2", Status: repository.StatusFailed, CreatedAt: base.Add(-2 * time.Minute)},
+		{ID: uuid.New(), UserID: userID, Expression: "3", Status: repository.StatusFailed, CreatedAt: base.Add(-3 * time.Minute)},
+	}
+	mockTaskRepo.On("GetTasksByUserID", mock.Anything, userID, mock.MatchedBy(func(f repository.TaskListFilter) bool {
+		return f.Limit == 3 && len(f.Statuses) == 1 && f.Statuses[0] == repository.StatusFailed && f.After == nil
+	})).Return(mockRepoTasks, nil).Once()
+
+	res, err := server.ListUserTasks(ctx, &pb.UserTasksRequest{
+		UserId:   userID.String(),
+		PageSize: 2,
+		Statuses: []string{repository.StatusFailed},
+	})
+	require.NoError(t, err)
+	require.Len(t, res.Tasks, 2)
+	require.NotEmpty(t, res.NextPageToken)
+
+	cursor, err := decodePageToken(res.NextPageToken)
+	require.NoError(t, err)
+	assert.Equal(t, mockRepoTasks[1].ID, cursor.ID)
+	assert.True(t, mockRepoTasks[1].CreatedAt.Equal(cursor.CreatedAt))
+
+	mockTaskRepo.On("GetTasksByUserID", mock.Anything, userID, mock.MatchedBy(func(f repository.TaskListFilter) bool {
+		return f.After != nil && f.After.ID == mockRepoTasks[1].ID
+	})).Return(mockRepoTasks[2:], nil).Once()
+
+	res, err = server.ListUserTasks(ctx, &pb.UserTasksRequest{
+		UserId:    userID.String(),
+		PageSize:  2,
+		Statuses:  []string{repository.StatusFailed},
+		PageToken: res.NextPageToken,
+	})
+	require.NoError(t, err)
+	require.Len(t, res.Tasks, 1)
+	assert.Empty(t, res.NextPageToken)
+}
+
+func TestOrchestratorServer_ListUserTasks_InvalidArgs(t *testing.T) {
+	server, _, _ := setupOrchestratorServerTest(t)
+	ctx := context.Background()
+	userID := uuid.New().String()
+
+	cases := map[string]*pb.UserTasksRequest{
+		"unknown status": {UserId: userID, Statuses: []string{"done"}},
+		"bad sort order": {UserId: userID, SortOrder: "sideways"},
+		"bad created_at": {UserId: userID, CreatedFrom: "yesterday"},
+		"bad page token": {UserId: userID, PageToken: "!!!"},
+	}
+	for name, req := range cases {
+		t.Run(name, func(t *testing.T) {
+			_, err := server.ListUserTasks(ctx, req)
+			require.Error(t, err)
+			st, ok := status.FromError(err)
+			require.True(t, ok)
+			assert.Equal(t, codes.InvalidArgument, st.Code())
+		})
+	}
+}
+
+func TestOrchestratorServer_SubmitExpression_QueueFull(t *testing.T) {
+	server, mockTaskRepo, _ := setupOrchestratorServerTest(t)
+	ctx := context.Background()
+	userID := uuid.New()
+
+	release := make(chan struct{})
+	defer close(release)
+	for i := 0; i < 2; i++ {
+		_, err := server.queue.Enqueue(uuid.New(), func() { <-release })
+		require.NoError(t, err)
+	}
+
+	req := &pb.ExpressionRequest{UserId: userID.String(), Expression: "2+2"}
+	_, err := server.SubmitExpression(ctx, req)
+
+	require.Error(t, err)
+	st, ok := status.FromError(err)
+	require.True(t, ok)
+	assert.Equal(t, codes.ResourceExhausted, st.Code())
+	mockTaskRepo.AssertNotCalled(t, "CreateTask", mock.Anything, mock.Anything)
+}
+
+func TestOrchestratorServer_SubmitExpression_NotQueuedTaskDeleted(t *testing.T) {
+	server, mockTaskRepo, _ := setupOrchestratorServerTest(t)
+	userID, taskID, attemptID := uuid.New(), uuid.New(), uuid.New()
+
+	release := make(chan struct{})
+	defer close(release)
+	mockTaskRepo.On("CreateTask", mock.Anything, mock.Anything).Return(taskID, nil).Once()
+	// Очередь заполняется другими задачами между проверкой Full и постановкой новой задачи.
+	mockTaskRepo.On("CreateAttempt", mock.Anything, taskID).Run(func(mock.Arguments) {
+		for i := 0; i < 2; i++ {
+			_, err := server.queue.Enqueue(uuid.New(), func() { <-release })
+			require.NoError(t, err)
+		}
+	}).Return(&repository.TaskAttempt{ID: attemptID, TaskID: taskID, AttemptNumber: 1, Status: repository.StatusPending}, nil).Once()
+	mockTaskRepo.On("DeleteRejectedTask", mock.Anything, taskID).Return(nil).Once()
+
+	_, err := server.SubmitExpression(context.Background(), &pb.ExpressionRequest{UserId: userID.String(), Expression: "2+2"})
+
+	require.Error(t, err)
+	assert.Equal(t, codes.ResourceExhausted, status.Code(err))
+	mockTaskRepo.AssertNotCalled(t, "SetTaskError", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+}
+
+// slowPendingBroker задерживает публикацию pending, чтобы вычисление успело стартовать раньше нее, если его не ждут.
+type slowPendingBroker struct {
+	service.TaskEventBroker
+	mu       sync.Mutex
+	statuses []string
+}
+
+func (b *slowPendingBroker) Publish(event service.TaskEvent) {
+	if event.Status == repository.StatusPending {
+		time.Sleep(50 * time.Millisecond)
+	}
+	b.mu.Lock()
+	b.statuses = append(b.statuses, event.Status)
+	b.mu.Unlock()
+	b.TaskEventBroker.Publish(event)
+}
+
+func TestOrchestratorServer_SubmitExpression_PendingPublishedBeforeEvaluation(t *testing.T) {
+	server, mockTaskRepo, mockEvaluator := setupOrchestratorServerTest(t)
+	broker := &slowPendingBroker{TaskEventBroker: server.events}
+	server.events = broker
+	userID, taskID, attemptID := uuid.New(), uuid.New(), uuid.New()
+
+	done := make(chan struct{})
+	mockTaskRepo.On("CreateTask", mock.Anything, mock.Anything).Return(taskID, nil).Once()
+	mockTaskRepo.On("CreateAttempt", mock.Anything, taskID).Return(&repository.TaskAttempt{
+		ID: attemptID, TaskID: taskID, AttemptNumber: 1, Status: repository.StatusPending,
+	}, nil).Once()
+	mockTaskRepo.On("UpdateTaskStatus", mock.Anything, taskID, repository.StatusProcessing).Return(nil).Once()
+	mockTaskRepo.On("StartAttempt", mock.Anything, attemptID).Return(nil).Once()
+	mockEvaluator.On("Evaluate", mock.Anything, mock.Anything).Return(4.0, nil).Once()
+	mockTaskRepo.On("SetTaskResult", mock.Anything, taskID, 4.0).Return(nil).Once()
+	mockTaskRepo.On("FinishAttempt", mock.Anything, attemptID, mock.Anything, (*string)(nil)).
+		Run(func(mock.Arguments) { close(done) }).Return(nil).Once()
+
+	_, err := server.SubmitExpression(context.Background(), &pb.ExpressionRequest{UserId: userID.String(), Expression: "2+2"})
+	require.NoError(t, err)
+
+	select {
+	case <-done:
+	case <-time.After(time.Second):
+		t.Fatal("Вычисление не завершилось")
+	}
+	require.Eventually(t, func() bool {
+		broker.mu.Lock()
+		defer broker.mu.Unlock()
+		return len(broker.statuses) > 0 && broker.statuses[len(broker.statuses)-1] == repository.StatusCompleted
+	}, time.Second, 10*time.Millisecond)
+	broker.mu.Lock()
+	defer broker.mu.Unlock()
+	assert.Equal(t, []string{repository.StatusPending, repository.StatusProcessing}, broker.statuses[:2])
+}
+
+func TestOrchestratorServer_SubmitExpression_StoresRequestID(t *testing.T) {
+	server, mockTaskRepo, _ := setupOrchestratorServerTest(t)
+	ctx := requestid.NewContext(context.Background(), "req-42")
+	userID := uuid.New()
+
+	mockTaskRepo.On("CreateTask", mock.Anything, mock.MatchedBy(func(task repository.NewTask) bool {
+		return task.RequestID != nil && *task.RequestID == "req-42"
+	})).Return(uuid.Nil, repository.ErrDatabase).Once()
+
+	_, err := server.SubmitExpression(ctx, &pb.ExpressionRequest{UserId: userID.String(), Expression: "2+2"})
+
+	require.Error(t, err)
+	assert.Equal(t, codes.Internal, status.Code(err))
+}
+
+func TestOrchestratorServer_GetTaskDetails_QueuePosition(t *testing.T) {
+	server, mockTaskRepo, _ := setupOrchestratorServerTest(t)
+	ctx := context.Background()
+	userID := uuid.New()
+	taskID := uuid.New()
+
+	release := make(chan struct{})
+	defer close(release)
+	_, err := server.queue.Enqueue(uuid.New(), func() { <-release })
+	require.NoError(t, err)
+	_, err = server.queue.Enqueue(taskID, func() {})
+	require.NoError(t, err)
+
+	mockTaskRepo.On("GetTaskByID", mock.Anything, taskID).Return(&repository.Task{
+		ID:         taskID,
+		UserID:     userID,
+		Expression: "2+2",
+		Status:     repository.StatusPending,
+	}, nil).Once()
+	mockTaskRepo.On("GetAttemptsByTaskID", mock.Anything, taskID).Return(nil, nil).Once()
+
+	res, err := server.GetTaskDetails(ctx, &pb.TaskDetailsRequest{TaskId: taskID.String(), UserId: userID.String()})
+	require.NoError(t, err)
+	assert.Equal(t, int32(1), res.QueuePosition)
+}
+
+func TestOrchestratorServer_RetryTask_Success(t *testing.T) {
+	server, mockTaskRepo, mockEvaluator := setupOrchestratorServerTest(t)
+	ctx := context.Background()
+	userID := uuid.New()
+	taskID := uuid.New()
+	attemptID := uuid.New()
+
+	mockTaskRepo.On("GetTaskByID", mock.Anything, taskID).Return(&repository.Task{
+		ID:         taskID,
+		UserID:     userID,
+		Expression: "2+2",
+		Status:     repository.StatusFailed,
+	}, nil).Once()
+	mockTaskRepo.On("ResetTaskForRetry", mock.Anything, taskID).Return(nil).Once()
+	mockTaskRepo.On("CreateAttempt", mock.Anything, taskID).Return(&repository.TaskAttempt{
+		ID:            attemptID,
+		TaskID:        taskID,
+		AttemptNumber: 2,
+		Status:        repository.StatusPending,
+	}, nil).Once()
+
+	done := make(chan struct{})
+	mockTaskRepo.On("UpdateTaskStatus", mock.Anything, taskID, repository.StatusProcessing).Return(nil).Once()
+	mockTaskRepo.On("StartAttempt", mock.Anything, attemptID).Return(nil).Once()
+	mockEvaluator.On("Evaluate", mock.Anything, mock.Anything).Return(4.0, nil).Once()
+	mockTaskRepo.On("SetTaskResult", mock.Anything, taskID, 4.0).Return(nil).Once()
+	mockTaskRepo.On("FinishAttempt", mock.Anything, attemptID, mock.Anything, (*string)(nil)).
+		Run(func(mock.Arguments) { close(done) }).Return(nil).Once()
+
+	res, err := server.RetryTask(ctx, &pb.RetryTaskRequest{TaskId: taskID.String(), UserId: userID.String()})
+	require.NoError(t, err)
+	assert.Equal(t, taskID.String(), res.TaskId)
+	assert.Equal(t, int32(2), res.AttemptNumber)
+
+	select {
+	case <-done:
+	case <-time.After(time.Second):
+		t.Fatal("Повторное вычисление не завершилось")
+	}
+}
+
+func TestOrchestratorServer_RetryTask_QueueFullKeepsPreviousResult(t *testing.T) {
+	server, mockTaskRepo, _ := setupOrchestratorServerTest(t)
+	ctx := context.Background()
+	userID := uuid.New()
+	taskID := uuid.New()
+	attemptID := uuid.New()
+	result := 4.0
+	previous := repository.Task{
+		ID:         taskID,
+		UserID:     userID,
+		Expression: "2+2",
+		Status:     repository.StatusCompleted,
+		Result:     &result,
+	}
+
+	release := make(chan struct{})
+	defer close(release)
+	taskCopy := previous
+	mockTaskRepo.On("GetTaskByID", mock.Anything, taskID).Return(&taskCopy, nil).Once()
+	mockTaskRepo.On("ResetTaskForRetry", mock.Anything, taskID).Return(nil).Once()
+	// Очередь заполняется другими задачами между проверкой Full и постановкой перезапуска.
+	mockTaskRepo.On("CreateAttempt", mock.Anything, taskID).Run(func(mock.Arguments) {
+		for i := 0; i < 2; i++ {
+			_, err := server.queue.Enqueue(uuid.New(), func() { <-release })
+			require.NoError(t, err)
+		}
+	}).Return(&repository.TaskAttempt{ID: attemptID, TaskID: taskID, AttemptNumber: 2, Status: repository.StatusPending}, nil).Once()
+	mockTaskRepo.On("RevertTaskRetry", mock.Anything, previous, attemptID).Return(nil).Once()
+
+	_, err := server.RetryTask(ctx, &pb.RetryTaskRequest{TaskId: taskID.String(), UserId: userID.String()})
+
+	require.Error(t, err)
+	assert.Equal(t, codes.ResourceExhausted, status.Code(err))
+	mockTaskRepo.AssertNotCalled(t, "SetTaskError", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+	_, tracked := server.evaluations.Snapshot(taskID)
+	assert.False(t, tracked)
+}
+
+func TestOrchestratorServer_RetryTask_EvaluationSpanLinkedToRequest(t *testing.T) {
+	recorder := tracetest.NewSpanRecorder()
+	previous := otel.GetTracerProvider()
+	otel.SetTracerProvider(sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder)))
+	t.Cleanup(func() { otel.SetTracerProvider(previous) })
+
+	server, mockTaskRepo, mockEvaluator := setupOrchestratorServerTest(t)
+	ctx, requestSpan := otel.Tracer("test").Start(context.Background(), "request")
+	userID := uuid.New()
+	taskID := uuid.New()
+	attemptID := uuid.New()
+
+	mockTaskRepo.On("GetTaskByID", mock.Anything, taskID).Return(&repository.Task{
+		ID: taskID, UserID: userID, Expression: "2+2", Status: repository.StatusFailed,
+	}, nil).Once()
+	mockTaskRepo.On("ResetTaskForRetry", mock.Anything, taskID).Return(nil).Once()
+	mockTaskRepo.On("CreateAttempt", mock.Anything, taskID).Return(&repository.TaskAttempt{
+		ID: attemptID, TaskID: taskID, AttemptNumber: 2, Status: repository.StatusPending,
+	}, nil).Once()
+	mockTaskRepo.On("UpdateTaskStatus", mock.Anything, taskID, repository.StatusProcessing).Return(nil).Once()
+	mockTaskRepo.On("StartAttempt", mock.Anything, attemptID).Return(nil).Once()
+	var evalTraceID trace.TraceID
+	mockEvaluator.On("Evaluate", mock.Anything, mock.Anything).Run(func(args mock.Arguments) {
+		evalTraceID = trace.SpanFromContext(args.Get(0).(context.Context)).SpanContext().TraceID()
+	}).Return(4.0, nil).Once()
+	mockTaskRepo.On("SetTaskResult", mock.Anything, taskID, 4.0).Return(nil).Once()
+	mockTaskRepo.On("FinishAttempt", mock.Anything, attemptID, mock.Anything, (*string)(nil)).Return(nil).Once()
+
+	_, err := server.RetryTask(ctx, &pb.RetryTaskRequest{TaskId: taskID.String(), UserId: userID.String()})
+	require.NoError(t, err)
+	requestSpan.End()
+
+	var evalSpan sdktrace.ReadOnlySpan
+	require.Eventually(t, func() bool {
+		for _, span := range recorder.Ended() {
+			if span.Name() == "orchestrator.evaluate" {
+				evalSpan = span
+				return true
+			}
+		}
+		return false
+	}, time.Second, 10*time.Millisecond)
+
+	requestSpanCtx := requestSpan.SpanContext()
+	assert.Equal(t, requestSpanCtx.TraceID(), evalSpan.SpanContext().TraceID())
+	assert.Equal(t, requestSpanCtx.TraceID(), evalTraceID)
+	require.Len(t, evalSpan.Links(), 1)
+	assert.Equal(t, requestSpanCtx.SpanID(), evalSpan.Links()[0].SpanContext.SpanID())
+}
+
+func TestOrchestratorServer_StartEvaluation_StoresErrorCode(t *testing.T) {
+	testCases := []struct {
+		name     string
+		result   float64
+		evalErr  error
+		wantCode string
+	}{
+		{"Ошибка от Воркера", 0, fmt.Errorf("левый операнд для '+': %w",
+			service.NewEvaluationError(repository.ErrorCodeDivisionByZero, errors.New("деление на ноль"))), repository.ErrorCodeDivisionByZero},
+		{"Таймаут", 0, fmt.Errorf("таймаут/отмена операции '+': %w", service.ErrEvaluationTimeout), repository.ErrorCodeTimeout},
+		{"Бесконечный результат", math.Inf(1), nil, repository.ErrorCodeOverflow},
+		{"Результат NaN", math.NaN(), nil, repository.ErrorCodeDomainError},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			server, mockTaskRepo, mockEvaluator := setupOrchestratorServerTest(t)
+			taskID, attemptID := uuid.New(), uuid.New()
+			events, _, cancel := server.events.Subscribe(taskID)
+			defer cancel()
+
+			mockTaskRepo.On("UpdateTaskStatus", mock.Anything, taskID, repository.StatusProcessing).Return(nil).Once()
+			mockTaskRepo.On("StartAttempt", mock.Anything, attemptID).Return(nil).Once()
+			mockEvaluator.On("Evaluate", mock.Anything, mock.Anything).Return(tc.result, tc.evalErr).Once()
+			mockTaskRepo.On("SetTaskError", mock.Anything, taskID, tc.wantCode, mock.Anything).Return(nil).Once()
+			mockTaskRepo.On("FinishAttempt", mock.Anything, attemptID, (*float64)(nil), mock.Anything).Return(nil).Once()
+
+			server.startEvaluation(trace.Link{}, "", taskID, attemptID, 1, uuid.New(), "2+2", &ast.IntegerNode{Value: 4}, quotaReservation{})
+
+			var final service.TaskEvent
+			for event := range events {
+				final = event
+			}
+			assert.Equal(t, repository.StatusFailed, final.Status)
+			require.NotNil(t, final.ErrorCode)
+			assert.Equal(t, tc.wantCode, *final.ErrorCode)
+		})
+	}
+}
+
+func TestOrchestratorServer_RetryTask_StillRunning(t *testing.T) {
+	server, mockTaskRepo, _ := setupOrchestratorServerTest(t)
+	ctx := context.Background()
+	userID := uuid.New()
+	taskID := uuid.New()
+
+	mockTaskRepo.On("GetTaskByID", mock.Anything, taskID).Return(&repository.Task{
+		ID:         taskID,
+		UserID:     userID,
+		Expression: "2+2",
+		Status:     repository.StatusProcessing,
+	}, nil).Once()
+
+	_, err := server.RetryTask(ctx, &pb.RetryTaskRequest{TaskId: taskID.String(), UserId: userID.String()})
+	require.Error(t, err)
+	st, ok := status.FromError(err)
+	require.True(t, ok)
+	assert.Equal(t, codes.FailedPrecondition, st.Code())
+	mockTaskRepo.AssertNotCalled(t, "ResetTaskForRetry", mock.Anything, mock.Anything)
+}
+
+func TestOrchestratorServer_RetryTask_Forbidden(t *testing.T) {
+	server, mockTaskRepo, _ := setupOrchestratorServerTest(t)
+	ctx := context.Background()
+	taskID := uuid.New()
+
+	mockTaskRepo.On("GetTaskByID", mock.Anything, taskID).Return(&repository.Task{
+		ID:     taskID,
+		UserID: uuid.New(),
+		Status: repository.StatusCompleted,
+	}, nil).Once()
+
+	_, err := server.RetryTask(ctx, &pb.RetryTaskRequest{TaskId: taskID.String(), UserId: uuid.New().String()})
+	require.Error(t, err)
+	st, ok := status.FromError(err)
+	require.True(t, ok)
+	assert.Equal(t, codes.NotFound, st.Code())
+}
+
+func TestOrchestratorServer_DeleteTask_MovesToTrash(t *testing.T) {
+	server, mockTaskRepo, _ := setupOrchestratorServerTest(t)
+	ctx := context.Background()
+	userID := uuid.New()
+	taskID := uuid.New()
+
+	mockTaskRepo.On("GetTaskByID", mock.Anything, taskID).Return(&repository.Task{
+		ID: taskID, UserID: userID, Status: repository.StatusCompleted,
+	}, nil).Once()
+	mockTaskRepo.On("SoftDeleteTask", mock.Anything, taskID).Return(nil).Once()
+
+	_, err := server.DeleteTask(ctx, &pb.DeleteTaskRequest{TaskId: taskID.String(), UserId: userID.String()})
+	require.NoError(t, err)
+	mockTaskRepo.AssertNotCalled(t, "DeleteTask", mock.Anything, mock.Anything)
+}
+
+func TestOrchestratorServer_DeleteTask_PurgeFromTrash(t *testing.T) {
+	server, mockTaskRepo, _ := setupOrchestratorServerTest(t)
+	ctx := context.Background()
+	userID := uuid.New()
+	taskID := uuid.New()
+	deletedAt := time.Now()
+
+	mockTaskRepo.On("GetTaskByID", mock.Anything, taskID).Return(&repository.Task{
+		ID: taskID, UserID: userID, Status: repository.StatusFailed, DeletedAt: &deletedAt,
+	}, nil).Once()
+	mockTaskRepo.On("DeleteTask", mock.Anything, taskID).Return(nil).Once()
+
+	_, err := server.DeleteTask(ctx, &pb.DeleteTaskRequest{TaskId: taskID.String(), UserId: userID.String(), Purge: true})
+	require.NoError(t, err)
+}
+
+func TestOrchestratorServer_DeleteTask_StillRunning(t *testing.T) {
+	server, mockTaskRepo, _ := setupOrchestratorServerTest(t)
+	ctx := context.Background()
+	userID := uuid.New()
+	taskID := uuid.New()
+
+	mockTaskRepo.On("GetTaskByID", mock.Anything, taskID).Return(&repository.Task{
+		ID: taskID, UserID: userID, Status: repository.StatusProcessing,
+	}, nil).Once()
+
+	_, err := server.DeleteTask(ctx, &pb.DeleteTaskRequest{TaskId: taskID.String(), UserId: userID.String()})
+	require.Error(t, err)
+	st, ok := status.FromError(err)
+	require.True(t, ok)
+	assert.Equal(t, codes.FailedPrecondition, st.Code())
+}
+
+func TestOrchestratorServer_RestoreTask(t *testing.T) {
+	server, mockTaskRepo, _ := setupOrchestratorServerTest(t)
+	ctx := context.Background()
+	userID := uuid.New()
+	taskID := uuid.New()
+	deletedAt := time.Now()
+
+	mockTaskRepo.On("GetTaskByID", mock.Anything, taskID).Return(&repository.Task{
+		ID: taskID, UserID: userID, Status: repository.StatusCompleted, DeletedAt: &deletedAt,
+	}, nil).Once()
+	mockTaskRepo.On("RestoreTask", mock.Anything, taskID).Return(nil).Once()
+
+	_, err := server.RestoreTask(ctx, &pb.RestoreTaskRequest{TaskId: taskID.String(), UserId: userID.String()})
+	require.NoError(t, err)
+}
+
+func TestOrchestratorServer_RestoreTask_NotInTrash(t *testing.T) {
+	server, mockTaskRepo, _ := setupOrchestratorServerTest(t)
+	ctx := context.Background()
+	userID := uuid.New()
+	taskID := uuid.New()
+
+	mockTaskRepo.On("GetTaskByID", mock.Anything, taskID).Return(&repository.Task{
+		ID: taskID, UserID: userID, Status: repository.StatusCompleted,
+	}, nil).Once()
+
+	_, err := server.RestoreTask(ctx, &pb.RestoreTaskRequest{TaskId: taskID.String(), UserId: userID.String()})
+	require.Error(t, err)
+	st, ok := status.FromError(err)
+	require.True(t, ok)
+	assert.Equal(t, codes.FailedPrecondition, st.Code())
+}
+
+func TestOrchestratorServer_GetTaskTrace(t *testing.T) {
+	server, mockTaskRepo, _ := setupOrchestratorServerTest(t)
+	ctx := context.Background()
+	userID := uuid.New()
+	taskID := uuid.New()
+	result := 5.0
+
+	mockTaskRepo.On("GetTaskByID", mock.Anything, taskID).Return(&repository.Task{
+		ID: taskID, UserID: userID, Expression: "2+3", Status: repository.StatusCompleted,
+	}, nil).Once()
+	mockTaskRepo.On("GetLatestOperationsByTaskID", mock.Anything, taskID).Return([]repository.TaskOperation{
+		{TaskID: taskID, AttemptNumber: 2, Seq: 1, OperationID: "op-1", NodePath: service.RootNodePath, Symbol: "+",
+			OperandA: 2, OperandB: 3, Result: &result, WorkerAddress: "10.0.0.2:50052", StartedAt: time.Now(), Duration: 2 * time.Millisecond},
+	}, nil).Once()
+
+	res, err := server.GetTaskTrace(ctx, &pb.TaskTraceRequest{TaskId: taskID.String(), UserId: userID.String()})
+	require.NoError(t, err)
+	assert.Equal(t, int32(2), res.GetAttemptNumber())
+	require.Len(t, res.GetOperations(), 1)
+	assert.Equal(t, "10.0.0.2:50052", res.GetOperations()[0].GetWorkerAddress())
+	assert.Equal(t, 2.0, res.GetOperations()[0].GetDurationMs())
+	require.NotNil(t, res.GetTree())
+	assert.Equal(t, service.RootNodePath, res.GetTree().GetNodePath())
+}
+
+func TestOrchestratorServer_GetTaskTrace_Forbidden(t *testing.T) {
+	server, mockTaskRepo, _ := setupOrchestratorServerTest(t)
+	taskID := uuid.New()
+
+	mockTaskRepo.On("GetTaskByID", mock.Anything, taskID).Return(&repository.Task{
+		ID: taskID, UserID: uuid.New(), Expression: "2+3", Status: repository.StatusCompleted,
+	}, nil).Once()
+
+	_, err := server.GetTaskTrace(context.Background(), &pb.TaskTraceRequest{TaskId: taskID.String(), UserId: uuid.NewString()})
+	require.Error(t, err)
+	st, ok := status.FromError(err)
+	require.True(t, ok)
+	assert.Equal(t, codes.NotFound, st.Code())
+}
+
+func TestBuildTraceTree(t *testing.T) {
+	node := &ast.BinaryNode{
+		Operator: "*",
+		Left:     &ast.UnaryNode{Operator: "-", Node: &ast.IntegerNode{Value: 2}},
+		Right:    &ast.FloatNode{Value: 1.5},
+	}
+	byPath := map[string]*pb.TaskOperation{
+		"0":   {Seq: 2, Symbol: "*"},
+		"0.0": {Seq: 1, Symbol: "neg"},
+	}
+
+	tree := buildTraceTree(node, service.RootNodePath, byPath)
+	assert.Equal(t, "binary", tree.GetKind())
+	assert.Equal(t, int32(2), tree.GetOperation().GetSeq())
+	require.Len(t, tree.GetChildren(), 2)
+
+	unary := tree.GetChildren()[0]
+	assert.Equal(t, "0.0", unary.GetNodePath())
+	assert.Equal(t, "unary", unary.GetKind())
+	assert.Equal(t, "neg", unary.GetOperation().GetSymbol())
+	require.Len(t, unary.GetChildren(), 1)
+	assert.Equal(t, "0.0.0", unary.GetChildren()[0].GetNodePath())
+	assert.Equal(t, 2.0, unary.GetChildren()[0].GetValue())
+
+	number := tree.GetChildren()[1]
+	assert.Equal(t, "0.1", number.GetNodePath())
+	assert.Equal(t, "number", number.GetKind())
+	assert.Equal(t, "1.5", number.GetSymbol())
+	assert.Nil(t, number.GetOperation())
+}
+
+type fakeWatchTaskStream struct {
+	grpc.ServerStream
+	ctx  context.Context
+	sent chan *pb.TaskEvent
+}
+
+func (f *fakeWatchTaskStream) Context() context.Context { return f.ctx }
+
+func (f *fakeWatchTaskStream) Send(event *pb.TaskEvent) error {
+	f.sent <- event
+	return nil
+}
+
+func TestOrchestratorServer_WatchTask_TerminalTask(t *testing.T) {
+	server, mockTaskRepo, _ := setupOrchestratorServerTest(t)
+	userID := uuid.New()
+	taskID := uuid.New()
+	result := 5.0
+
+	mockTaskRepo.On("GetTaskByID", mock.Anything, taskID).Return(&repository.Task{
+		ID: taskID, UserID: userID, Expression: "2+3", Status: repository.StatusCompleted, Result: &result, UpdatedAt: time.Now(),
+	}, nil).Once()
+
+	stream := &fakeWatchTaskStream{ctx: context.Background(), sent: make(chan *pb.TaskEvent, 4)}
+	err := server.WatchTask(&pb.WatchTaskRequest{TaskId: taskID.String(), UserId: userID.String()}, stream)
+	require.NoError(t, err)
+	require.Len(t, stream.sent, 1)
+	event := <-stream.sent
+	assert.Equal(t, repository.StatusCompleted, event.GetStatus())
+	assert.Equal(t, 5.0, event.GetResult())
+}
+
+func TestOrchestratorServer_WatchTask_StreamsUntilTerminal(t *testing.T) {
+	server, mockTaskRepo, _ := setupOrchestratorServerTest(t)
+	userID := uuid.New()
+	taskID := uuid.New()
+
+	mockTaskRepo.On("GetTaskByID", mock.Anything, taskID).Return(&repository.Task{
+		ID: taskID, UserID: userID, Expression: "2+3", Status: repository.StatusPending,
+	}, nil).Once()
+
+	stream := &fakeWatchTaskStream{ctx: context.Background(), sent: make(chan *pb.TaskEvent, 4)}
+	done := make(chan error, 1)
+	go func() {
+		done <- server.WatchTask(&pb.WatchTaskRequest{TaskId: taskID.String(), UserId: userID.String()}, stream)
+	}()
+
+	snapshot := <-stream.sent
+	assert.Equal(t, repository.StatusPending, snapshot.GetStatus())
+
+	server.events.Publish(service.TaskEvent{TaskID: taskID, Status: repository.StatusProcessing, OperationsTotal: 1})
+	errMsg, errCode := "деление на ноль", repository.ErrorCodeDivisionByZero
+	server.events.Publish(service.TaskEvent{TaskID: taskID, Status: repository.StatusFailed, OperationsDone: 1, OperationsTotal: 1, ErrorMessage: &errMsg, ErrorCode: &errCode})
+
+	select {
+	case err := <-done:
+		require.NoError(t, err)
+	case <-time.After(time.Second):
+		t.Fatal("WatchTask не завершился после терминального события")
+	}
+	require.Len(t, stream.sent, 2)
+	assert.Equal(t, repository.StatusProcessing, (<-stream.sent).GetStatus())
+	final := <-stream.sent
+	assert.Equal(t, repository.StatusFailed, final.GetStatus())
+	assert.Equal(t, errMsg, final.GetErrorMessage())
+	assert.Equal(t, errCode, final.GetErrorCode())
+}
+
+func TestOrchestratorServer_WatchTask_ClosedOnShutdown(t *testing.T) {
+	server, mockTaskRepo, _ := setupOrchestratorServerTest(t)
+	userID := uuid.New()
+	taskID := uuid.New()
+
+	mockTaskRepo.On("GetTaskByID", mock.Anything, taskID).Return(&repository.Task{
+		ID: taskID, UserID: userID, Expression: "2+3", Status: repository.StatusProcessing,
+	}, nil).Once()
+
+	stream := &fakeWatchTaskStream{ctx: context.Background(), sent: make(chan *pb.TaskEvent, 4)}
+	done := make(chan error, 1)
+	go func() {
+		done <- server.WatchTask(&pb.WatchTaskRequest{TaskId: taskID.String(), UserId: userID.String()}, stream)
+	}()
+	<-stream.sent
+
+	server.CloseWatchers()
+	select {
+	case err := <-done:
+		st, ok := status.FromError(err)
+		require.True(t, ok)
+		assert.Equal(t, codes.Unavailable, st.Code())
+	case <-time.After(time.Second):
+		t.Fatal("WatchTask не завершился при остановке сервера")
+	}
+}
@@ -0,0 +1,44 @@
+package grpc_handler
+
+import (
+	"encoding/base64"
+	"errors"
+	"strings"
+	"time"
+
+	"github.com/Qu1nel/YaLyceum-GoProject-Final/internal/orchestrator/repository"
+	"github.com/google/uuid"
+)
+
+const (
+	defaultTasksPageSize = 50
+	maxTasksPageSize     = 200
+	maxTasksSearchLength = 200
+)
+
+var errInvalidPageToken = errors.New("невалидный page_token")
+
+func encodePageToken(cursor repository.TaskCursor) string {
+	raw := cursor.CreatedAt.UTC().Format(time.RFC3339Nano) + "|" + cursor.ID.String()
+	return base64.RawURLEncoding.EncodeToString([]byte(raw))
+}
+
+func decodePageToken(token string) (*repository.TaskCursor, error) {
+	raw, err := base64.RawURLEncoding.DecodeString(token)
+	if err != nil {
+		return nil, errInvalidPageToken
+	}
+	createdAtStr, idStr, ok := strings.Cut(string(raw), "|")
+	if !ok {
+		return nil, errInvalidPageToken
+	}
+	createdAt, err := time.Parse(time.RFC3339Nano, createdAtStr)
+	if err != nil {
+		return nil, errInvalidPageToken
+	}
+	id, err := uuid.Parse(idStr)
+	if err != nil {
+		return nil, errInvalidPageToken
+	}
+	return &repository.TaskCursor{CreatedAt: createdAt, ID: id}, nil
+}
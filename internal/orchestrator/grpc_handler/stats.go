@@ -0,0 +1,58 @@
+package grpc_handler
+
+import (
+	"context"
+	"sort"
+
+	pb "github.com/Qu1nel/YaLyceum-GoProject-Final/proto/gen/orchestrator"
+
+	"go.uber.org/zap"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func (s *OrchestratorServer) GetUserStats(ctx context.Context, req *pb.UserStatsRequest) (*pb.UserStatsResponse, error) {
+	s.logFor(ctx).Info("Получен gRPC запрос GetUserStats", zap.String("userID", req.GetUserId()))
+
+	userID, err := parseUserID(req.GetUserId())
+	if err != nil {
+		return nil, err
+	}
+
+	stats, err := s.taskRepo.GetUserStats(ctx, userID)
+	if err != nil {
+		s.logFor(ctx).Error("Ошибка получения статистики пользователя", zap.Stringer("userID", userID), zap.Error(err))
+		return nil, status.Error(codes.Internal, "внутренняя ошибка сервера")
+	}
+
+	response := &pb.UserStatsResponse{
+		Total:             stats.Total,
+		CompletedMeasured: stats.CompletedMeasured,
+	}
+	statuses := make([]string, 0, len(stats.StatusCounts))
+	for st := range stats.StatusCounts {
+		statuses = append(statuses, st)
+	}
+	sort.Strings(statuses)
+	for _, st := range statuses {
+		response.StatusCounts = append(response.StatusCounts, &pb.StatusCount{Status: st, Count: stats.StatusCounts[st]})
+	}
+	if stats.SuccessRate != nil {
+		response.SuccessRate = *stats.SuccessRate
+	}
+	if stats.AvgCompletionSeconds != nil {
+		response.AvgCompletionSeconds = *stats.AvgCompletionSeconds
+	}
+	if stats.P95CompletionSeconds != nil {
+		response.P95CompletionSeconds = *stats.P95CompletionSeconds
+	}
+	for _, op := range stats.Operators {
+		response.Operators = append(response.Operators, &pb.OperatorUsage{
+			Symbol:        op.Symbol,
+			Count:         op.Count,
+			Failed:        op.Failed,
+			AvgDurationMs: op.AvgDurationMs,
+		})
+	}
+	return response, nil
+}
@@ -0,0 +1,107 @@
+package grpc_handler
+
+import (
+	"context"
+	"strconv"
+	"time"
+
+	"github.com/Qu1nel/YaLyceum-GoProject-Final/internal/orchestrator/repository"
+	"github.com/Qu1nel/YaLyceum-GoProject-Final/internal/orchestrator/service"
+	pb "github.com/Qu1nel/YaLyceum-GoProject-Final/proto/gen/orchestrator"
+
+	"github.com/expr-lang/expr/ast"
+	"go.uber.org/zap"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func (s *OrchestratorServer) GetTaskTrace(ctx context.Context, req *pb.TaskTraceRequest) (*pb.TaskTraceResponse, error) {
+	s.logFor(ctx).Info("Получен gRPC запрос GetTaskTrace",
+		zap.String("taskID", req.GetTaskId()),
+		zap.String("requestingUserID", req.GetUserId()),
+	)
+
+	task, err := s.getOwnedTask(ctx, req.GetTaskId(), req.GetUserId(), "GetTaskTrace")
+	if err != nil {
+		return nil, err
+	}
+
+	operations, err := s.taskRepo.GetLatestOperationsByTaskID(ctx, task.ID)
+	if err != nil {
+		s.logFor(ctx).Error("Ошибка получения трассировки задачи", zap.Stringer("taskID", task.ID), zap.Error(err))
+		return nil, status.Error(codes.Internal, "внутренняя ошибка сервера")
+	}
+
+	response := &pb.TaskTraceResponse{TaskId: task.ID.String()}
+	byPath := make(map[string]*pb.TaskOperation, len(operations))
+	for _, op := range operations {
+		pbOp := toPBOperation(op)
+		response.AttemptNumber = int32(op.AttemptNumber)
+		response.Operations = append(response.Operations, pbOp)
+		byPath[op.NodePath] = pbOp
+	}
+
+	program, compileErr := compileExpression(task.Expression)
+	if compileErr != nil {
+		s.logFor(ctx).Warn("Не удалось построить дерево трассировки: ошибка компиляции выражения",
+			zap.Stringer("taskID", task.ID),
+			zap.Error(compileErr),
+		)
+		return response, nil
+	}
+	response.Tree = buildTraceTree(program.Node(), service.RootNodePath, byPath)
+
+	return response, nil
+}
+
+func toPBOperation(op repository.TaskOperation) *pb.TaskOperation {
+	pbOp := &pb.TaskOperation{
+		Seq:           int32(op.Seq),
+		OperationId:   op.OperationID,
+		NodePath:      op.NodePath,
+		Symbol:        op.Symbol,
+		OperandA:      op.OperandA,
+		OperandB:      op.OperandB,
+		WorkerAddress: op.WorkerAddress,
+		StartedAt:     op.StartedAt.Format(time.RFC3339Nano),
+		DurationMs:    float64(op.Duration) / float64(time.Millisecond),
+	}
+	if op.Result != nil {
+		pbOp.Result = *op.Result
+	}
+	if op.ErrorMessage != nil {
+		pbOp.ErrorMessage = *op.ErrorMessage
+	}
+	return pbOp
+}
+
+// buildTraceTree повторяет обход ExpressionEvaluator.Evaluate, чтобы пути узлов совпадали с NodePath операций.
+func buildTraceTree(node ast.Node, path string, byPath map[string]*pb.TaskOperation) *pb.TraceNode {
+	traceNode := &pb.TraceNode{NodePath: path, Operation: byPath[path]}
+	child := func(index int, n ast.Node) *pb.TraceNode {
+		return buildTraceTree(n, path+"."+strconv.Itoa(index), byPath)
+	}
+
+	switch n := node.(type) {
+	case *ast.IntegerNode:
+		traceNode.Kind = "number"
+		traceNode.Symbol = strconv.Itoa(n.Value)
+		traceNode.Value = float64(n.Value)
+	case *ast.FloatNode:
+		traceNode.Kind = "number"
+		traceNode.Symbol = strconv.FormatFloat(n.Value, 'g', -1, 64)
+		traceNode.Value = n.Value
+	case *ast.UnaryNode:
+		traceNode.Kind = "unary"
+		traceNode.Symbol = n.Operator
+		traceNode.Children = []*pb.TraceNode{child(0, n.Node)}
+	case *ast.BinaryNode:
+		traceNode.Kind = "binary"
+		traceNode.Symbol = n.Operator
+		traceNode.Children = []*pb.TraceNode{child(0, n.Left), child(1, n.Right)}
+	default:
+		traceNode.Kind = "unsupported"
+		traceNode.Symbol = node.String()
+	}
+	return traceNode
+}
@@ -0,0 +1,160 @@
+package grpc_handler
+
+import (
+	"context"
+	"time"
+
+	"github.com/Qu1nel/YaLyceum-GoProject-Final/internal/orchestrator/repository"
+	"github.com/Qu1nel/YaLyceum-GoProject-Final/internal/orchestrator/service"
+	pb "github.com/Qu1nel/YaLyceum-GoProject-Final/proto/gen/orchestrator"
+
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/durationpb"
+)
+
+func (s *OrchestratorServer) GetUsage(ctx context.Context, req *pb.UsageRequest) (*pb.UsageResponse, error) {
+	s.logFor(ctx).Info("Получен gRPC запрос GetUsage", zap.String("userID", req.GetUserId()))
+
+	userID, err := parseUserID(req.GetUserId())
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	usage, err := s.usageRepo.GetUsage(ctx, userID, now.UTC())
+	if err != nil {
+		s.logFor(ctx).Error("Ошибка получения потребления пользователя", zap.Stringer("userID", userID), zap.Error(err))
+		return nil, status.Error(codes.Internal, "внутренняя ошибка сервера")
+	}
+	return &pb.UsageResponse{
+		Day:   usagePeriodToProto(service.QuotaPeriodDay, s.quotas.Daily, usage.Day, now),
+		Month: usagePeriodToProto(service.QuotaPeriodMonth, s.quotas.Monthly, usage.Month, now),
+	}, nil
+}
+
+func usagePeriodToProto(period string, quota service.Quota, usage repository.Usage, now time.Time) *pb.UsagePeriod {
+	start, resetAt := service.QuotaPeriodBounds(period, now)
+	pbPeriod := &pb.UsagePeriod{Start: start.Format(time.RFC3339Nano), ResetAt: resetAt.Format(time.RFC3339Nano)}
+	for _, counter := range quota.Counters(usage) {
+		pbCounter := &pb.UsageCounter{Used: counter.Used, Limit: counter.Limit, Remaining: counter.Remaining()}
+		switch counter.Resource {
+		case service.QuotaResourceTasks:
+			pbPeriod.Tasks = pbCounter
+		case service.QuotaResourceOperations:
+			pbPeriod.Operations = pbCounter
+		case service.QuotaResourceComputeMs:
+			pbPeriod.ComputeMs = pbCounter
+		}
+	}
+	return pbPeriod
+}
+
+// quotaReservation - потребление, списанное с квоты пользователя по оценке стоимости выражения до постановки в очередь.
+// После вычисления оценка заменяется фактическим потреблением (settleQuota), при отказе резерв возвращается (releaseQuota).
+// Резерв относится к суткам, в которые был сделан, даже если вычисление закончится на следующие.
+type quotaReservation struct {
+	userID uuid.UUID
+	day    time.Time
+	usage  repository.Usage
+}
+
+// reserveQuota списывает demand с остатка квот одним условным обновлением в БД, поэтому параллельные запросы
+// пользователя не могут вместе превысить квоту. Без ограничений резерв только учитывает потребление.
+// Возвращаемая ошибка уже является gRPC статусом: ResourceExhausted с QuotaFailure и RetryInfo до обновления квоты,
+// чтобы Агент отличал ее от переполненной очереди.
+func (s *OrchestratorServer) reserveQuota(ctx context.Context, userID uuid.UUID, demand repository.Usage) (quotaReservation, error) {
+	now := time.Now()
+	reservation := quotaReservation{userID: userID, day: now.UTC(), usage: demand}
+	reserved, err := s.usageRepo.ReserveUsage(ctx, userID, reservation.day, demand, s.quotas.Daily.Limits(), s.quotas.Monthly.Limits())
+	if err != nil {
+		s.logFor(ctx).Error("Ошибка резервирования квоты пользователя", zap.Stringer("userID", userID), zap.Error(err))
+		return quotaReservation{}, status.Error(codes.Internal, "внутренняя ошибка сервера при проверке квоты")
+	}
+	if !reserved {
+		return quotaReservation{}, s.quotaExceeded(ctx, userID, demand, now)
+	}
+	return reservation, nil
+}
+
+// quotaExceeded описывает, какая квота не вместила demand. Потребление могло измениться после отказа в резерве,
+// поэтому без найденного нарушения возвращается общее сообщение.
+func (s *OrchestratorServer) quotaExceeded(ctx context.Context, userID uuid.UUID, demand repository.Usage, now time.Time) error {
+	usage, err := s.usageRepo.GetUsage(ctx, userID, now.UTC())
+	if err != nil {
+		s.logFor(ctx).Error("Ошибка получения потребления для описания квоты", zap.Stringer("userID", userID), zap.Error(err))
+		return status.Error(codes.ResourceExhausted, "квота пользователя исчерпана")
+	}
+	violation := s.quotas.Check(*usage, demand, now)
+	if violation == nil {
+		s.logFor(ctx).Info("Квота пользователя исчерпана параллельными запросами, запрос отклонен", zap.Stringer("userID", userID))
+		return status.Error(codes.ResourceExhausted, "квота пользователя исчерпана параллельными запросами, повторите попытку позже")
+	}
+
+	s.logFor(ctx).Info("Квота пользователя исчерпана, запрос отклонен",
+		zap.Stringer("userID", userID),
+		zap.String("period", violation.Period),
+		zap.String("resource", violation.Resource),
+		zap.Int64("used", violation.Used),
+		zap.Int64("requested", violation.Requested),
+		zap.Int64("limit", violation.Limit),
+	)
+	st, err := status.New(codes.ResourceExhausted, violation.Message()).WithDetails(
+		&errdetails.QuotaFailure{Violations: []*errdetails.QuotaFailure_Violation{{
+			Subject:     violation.Period + ":" + violation.Resource,
+			Description: violation.Message(),
+		}}},
+		&errdetails.RetryInfo{RetryDelay: durationpb.New(violation.ResetAt.Sub(now))},
+	)
+	if err != nil {
+		s.logFor(ctx).Error("Не удалось добавить детали к ошибке квоты", zap.Error(err))
+		return status.Error(codes.ResourceExhausted, violation.Message())
+	}
+	return st.Err()
+}
+
+// releaseQuota возвращает резерв задачи, которая так и не была поставлена в очередь.
+func (s *OrchestratorServer) releaseQuota(ctx context.Context, reservation quotaReservation) {
+	s.addUsage(ctx, reservation.userID, reservation.day, repository.Usage{
+		TasksSubmitted: -reservation.usage.TasksSubmitted,
+		Operations:     -reservation.usage.Operations,
+		ComputeTime:    -reservation.usage.ComputeTime,
+	})
+}
+
+// settleQuota заменяет оценку операций и времени вычислений в резерве фактическим потреблением.
+// Отправленная задача остается учтенной.
+func (s *OrchestratorServer) settleQuota(ctx context.Context, reservation quotaReservation, actual repository.Usage) {
+	delta := repository.Usage{
+		Operations:  actual.Operations - reservation.usage.Operations,
+		ComputeTime: actual.ComputeTime - reservation.usage.ComputeTime,
+	}
+	if delta != (repository.Usage{}) {
+		s.addUsage(ctx, reservation.userID, reservation.day, delta)
+	}
+}
+
+// addUsage корректирует потребление пользователя. Ошибка учета не должна влиять на задачу, поэтому только логируется.
+func (s *OrchestratorServer) addUsage(ctx context.Context, userID uuid.UUID, day time.Time, delta repository.Usage) {
+	if err := s.usageRepo.AddUsage(ctx, userID, day, delta); err != nil {
+		s.logFor(ctx).Error("Не удалось учесть потребление пользователя",
+			zap.Stringer("userID", userID),
+			zap.Int64("tasks", delta.TasksSubmitted),
+			zap.Int64("operations", delta.Operations),
+			zap.Duration("computeTime", delta.ComputeTime),
+			zap.Error(err),
+		)
+	}
+}
+
+// operationsUsage - потребление по выполненным вызовам Воркера, включая завершившиеся ошибкой.
+func operationsUsage(operations []repository.TaskOperation) repository.Usage {
+	usage := repository.Usage{Operations: int64(len(operations))}
+	for _, op := range operations {
+		usage.ComputeTime += op.Duration
+	}
+	return usage
+}
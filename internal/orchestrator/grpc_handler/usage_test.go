@@ -0,0 +1,165 @@
+package grpc_handler
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/Qu1nel/YaLyceum-GoProject-Final/internal/orchestrator/repository"
+	"github.com/Qu1nel/YaLyceum-GoProject-Final/internal/orchestrator/service"
+	pb "github.com/Qu1nel/YaLyceum-GoProject-Final/proto/gen/orchestrator"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestOrchestratorServer_SubmitExpression_QuotaExceeded(t *testing.T) {
+	server, mockTaskRepo, _ := setupOrchestratorServerTest(t)
+	server.quotas = service.Quotas{Daily: service.Quota{Operations: 10}}
+	userID := uuid.New()
+	usage := &fakeUsageRepository{}
+	server.usageRepo = usage
+	require.NoError(t, usage.AddUsage(context.Background(), userID, time.Now(), repository.Usage{Operations: 9}))
+
+	_, err := server.SubmitExpression(context.Background(), &pb.ExpressionRequest{UserId: userID.String(), Expression: "(1+2)*3"})
+
+	require.Error(t, err)
+	st := status.Convert(err)
+	assert.Equal(t, codes.ResourceExhausted, st.Code())
+	assert.Contains(t, st.Message(), "суточная квота операций: использовано 9, требуется 2, лимит 10")
+	var quotaFailure *errdetails.QuotaFailure
+	var retryInfo *errdetails.RetryInfo
+	for _, detail := range st.Details() {
+		switch d := detail.(type) {
+		case *errdetails.QuotaFailure:
+			quotaFailure = d
+		case *errdetails.RetryInfo:
+			retryInfo = d
+		}
+	}
+	require.NotNil(t, quotaFailure)
+	assert.Equal(t, "day:operations", quotaFailure.Violations[0].Subject)
+	require.NotNil(t, retryInfo)
+	assert.LessOrEqual(t, retryInfo.RetryDelay.AsDuration(), 24*time.Hour)
+	mockTaskRepo.AssertNotCalled(t, "CreateTask", mock.Anything, mock.Anything)
+}
+
+func TestOrchestratorServer_SubmitExpression_ReleasesReservationWhenNotQueued(t *testing.T) {
+	server, mockTaskRepo, _ := setupOrchestratorServerTest(t)
+	server.quotas = service.Quotas{Monthly: service.Quota{Tasks: 5}}
+	usage := &fakeUsageRepository{}
+	server.usageRepo = usage
+	userID, taskID := uuid.New(), uuid.New()
+
+	mockTaskRepo.On("CreateTask", mock.Anything, mock.Anything).Return(taskID, nil).Once()
+	mockTaskRepo.On("CreateAttempt", mock.Anything, taskID).Return(nil, errors.New("db down")).Once()
+	mockTaskRepo.On("DeleteRejectedTask", mock.Anything, taskID).Return(nil).Once()
+
+	_, err := server.SubmitExpression(context.Background(), &pb.ExpressionRequest{UserId: userID.String(), Expression: "2+2"})
+
+	require.Error(t, err)
+	assert.Equal(t, repository.Usage{}, usage.usage[userID])
+}
+
+// Резерв делается до создания задачи, поэтому параллельные запросы не проходят проверку квоты все разом.
+func TestOrchestratorServer_SubmitExpression_ConcurrentSubmitsRespectQuota(t *testing.T) {
+	server, mockTaskRepo, _ := setupOrchestratorServerTest(t)
+	server.quotas = service.Quotas{Daily: service.Quota{Tasks: 3}}
+	usage := &fakeUsageRepository{}
+	server.usageRepo = usage
+	userID := uuid.New()
+	const submits = 10
+
+	var quotaRejected atomic.Int32
+	allRejected := make(chan struct{})
+	// Задачи, получившие резерв, ждут в CreateTask, пока остальные запросы не получат отказ по квоте.
+	mockTaskRepo.On("CreateTask", mock.Anything, mock.Anything).Run(func(mock.Arguments) {
+		<-allRejected
+	}).Return(uuid.Nil, repository.ErrDatabase)
+
+	var wg sync.WaitGroup
+	codesCh := make(chan codes.Code, submits)
+	for range submits {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			_, err := server.SubmitExpression(context.Background(), &pb.ExpressionRequest{UserId: userID.String(), Expression: "2+2"})
+			code := status.Code(err)
+			if code == codes.ResourceExhausted && quotaRejected.Add(1) == submits-3 {
+				close(allRejected)
+			}
+			codesCh <- code
+		}()
+	}
+	wg.Wait()
+	close(codesCh)
+
+	counts := map[codes.Code]int{}
+	for code := range codesCh {
+		counts[code]++
+	}
+	assert.Equal(t, map[codes.Code]int{codes.ResourceExhausted: submits - 3, codes.Internal: 3}, counts)
+	mockTaskRepo.AssertNumberOfCalls(t, "CreateTask", 3)
+	assert.Equal(t, repository.Usage{}, usage.usage[userID], "резервы неудавшихся задач возвращены")
+}
+
+func TestOrchestratorServer_SettleQuota(t *testing.T) {
+	server, _, _ := setupOrchestratorServerTest(t)
+	usage := &fakeUsageRepository{}
+	server.usageRepo = usage
+	userID := uuid.New()
+
+	reservation, err := server.reserveQuota(context.Background(), userID, repository.Usage{TasksSubmitted: 1, Operations: 5, ComputeTime: 2 * time.Second})
+	require.NoError(t, err)
+	server.settleQuota(context.Background(), reservation, repository.Usage{Operations: 2, ComputeTime: 300 * time.Millisecond})
+
+	assert.Equal(t, repository.Usage{TasksSubmitted: 1, Operations: 2, ComputeTime: 300 * time.Millisecond}, usage.usage[userID])
+}
+
+func TestOrchestratorServer_GetUsage(t *testing.T) {
+	server, _, _ := setupOrchestratorServerTest(t)
+	server.quotas = service.Quotas{
+		Daily:   service.Quota{Tasks: 10, ComputeTime: time.Second},
+		Monthly: service.Quota{Operations: 100},
+	}
+	usage := &fakeUsageRepository{}
+	server.usageRepo = usage
+	userID := uuid.New()
+	require.NoError(t, usage.AddUsage(context.Background(), userID, time.Now(), repository.Usage{
+		TasksSubmitted: 3, Operations: 40, ComputeTime: 1500 * time.Millisecond,
+	}))
+
+	res, err := server.GetUsage(context.Background(), &pb.UsageRequest{UserId: userID.String()})
+
+	require.NoError(t, err)
+	assert.Equal(t, &pb.UsageCounter{Used: 3, Limit: 10, Remaining: 7}, res.Day.Tasks)
+	assert.Equal(t, &pb.UsageCounter{Used: 40}, res.Day.Operations)
+	assert.Equal(t, &pb.UsageCounter{Used: 1500, Limit: 1000, Remaining: 0}, res.Day.ComputeMs)
+	assert.Equal(t, &pb.UsageCounter{Used: 40, Limit: 100, Remaining: 60}, res.Month.Operations)
+
+	assert.Equal(t, 24*time.Hour, mustParseTime(t, res.Day.ResetAt).Sub(mustParseTime(t, res.Day.Start)))
+	assert.Equal(t, 1, mustParseTime(t, res.Month.Start).Day())
+}
+
+func TestOperationsUsage(t *testing.T) {
+	errMsg := "деление на ноль"
+	usage := operationsUsage([]repository.TaskOperation{
+		{Duration: 200 * time.Millisecond},
+		{Duration: 300 * time.Millisecond, ErrorMessage: &errMsg},
+	})
+	assert.Equal(t, repository.Usage{Operations: 2, ComputeTime: 500 * time.Millisecond}, usage)
+}
+
+func mustParseTime(t *testing.T, value string) time.Time {
+	t.Helper()
+	parsed, err := time.Parse(time.RFC3339Nano, value)
+	require.NoError(t, err)
+	return parsed
+}
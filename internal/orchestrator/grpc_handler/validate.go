@@ -0,0 +1,130 @@
+package grpc_handler
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/Qu1nel/YaLyceum-GoProject-Final/internal/orchestrator/service"
+	pb "github.com/Qu1nel/YaLyceum-GoProject-Final/proto/gen/orchestrator"
+
+	"github.com/expr-lang/expr/ast"
+	"github.com/expr-lang/expr/file"
+	"go.uber.org/zap"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// supportedBinaryOperators - бинарные операторы, которые Воркер умеет вычислять ("**" передается как "^").
+var supportedBinaryOperators = map[string]bool{"+": true, "-": true, "*": true, "/": true, "^": true, "**": true}
+
+// ValidateExpression разбирает выражение так же, как SubmitExpression, но ничего не сохраняет и не вычисляет.
+func (s *OrchestratorServer) ValidateExpression(ctx context.Context, req *pb.ValidateExpressionRequest) (*pb.ValidateExpressionResponse, error) {
+	expression := req.GetExpression()
+	s.logFor(ctx).Info("Получен gRPC запрос ValidateExpression",
+		zap.String("userID", req.GetUserId()),
+		zap.String("expression", expression),
+	)
+
+	if expression == "" {
+		return nil, status.Error(codes.InvalidArgument, "expression не может быть пустым")
+	}
+
+	source := []rune(expression)
+	if violation := s.limits.checkSource(expression); violation != nil {
+		return &pb.ValidateExpressionResponse{Errors: []*pb.ExpressionError{expressionError(source, violation.location, violation.message)}}, nil
+	}
+	program, compileErr := compileExpression(expression)
+	if compileErr != nil {
+		var fileErr *file.Error
+		if !errors.As(compileErr, &fileErr) {
+			s.logFor(ctx).Warn("Ошибка компиляции выражения без позиции", zap.Error(compileErr))
+			return &pb.ValidateExpressionResponse{Errors: []*pb.ExpressionError{{Message: compileErr.Error(), Line: 1, Column: 1}}}, nil
+		}
+		location := fileErr.Location
+		// Для неожиданного конца выражения expr указывает на последний токен, а ошибка находится после него.
+		if strings.HasSuffix(fileErr.Message, "token EOF") {
+			location = file.Location{From: len(source), To: len(source)}
+		}
+		return &pb.ValidateExpressionResponse{Errors: []*pb.ExpressionError{expressionError(source, location, fileErr.Message)}}, nil
+	}
+
+	root := program.Node()
+	response := &pb.ValidateExpressionResponse{
+		Errors:         unsupportedNodeErrors(source, root),
+		Normalized:     root.String(),
+		NodeCount:      int32(countNodes(root)),
+		OperationCount: int32(service.CountOperations(root)),
+		Depth:          int32(treeDepth(root)),
+	}
+	if violation := s.limits.checkTree(root); violation != nil {
+		response.Errors = append([]*pb.ExpressionError{expressionError(source, violation.location, violation.message)}, response.Errors...)
+	}
+	response.Valid = len(response.Errors) == 0
+	if response.Valid {
+		response.Estimate = costEstimateToProto(s.estimateCost(root))
+	}
+	return response, nil
+}
+
+// unsupportedNodeErrors собирает узлы, которые выражение допускает синтаксически, но ExpressionEvaluator вычислить не сможет.
+func unsupportedNodeErrors(source []rune, node ast.Node) []*pb.ExpressionError {
+	switch n := node.(type) {
+	case *ast.IntegerNode, *ast.FloatNode:
+		return nil
+	case *ast.UnaryNode:
+		if n.Operator != "-" {
+			return []*pb.ExpressionError{expressionError(source, n.Location(), fmt.Sprintf("неподдерживаемый унарный оператор '%s'", n.Operator))}
+		}
+		return unsupportedNodeErrors(source, n.Node)
+	case *ast.BinaryNode:
+		if !supportedBinaryOperators[n.Operator] {
+			return []*pb.ExpressionError{expressionError(source, n.Location(), fmt.Sprintf("неподдерживаемый оператор '%s'", n.Operator))}
+		}
+		return append(unsupportedNodeErrors(source, n.Left), unsupportedNodeErrors(source, n.Right)...)
+	case *ast.IdentifierNode:
+		return []*pb.ExpressionError{expressionError(source, n.Location(), fmt.Sprintf("переменные не поддерживаются: '%s'", n.Value))}
+	case *ast.CallNode, *ast.BuiltinNode:
+		return []*pb.ExpressionError{expressionError(source, n.Location(), "вызовы функций не поддерживаются")}
+	default:
+		return []*pb.ExpressionError{expressionError(source, n.Location(), "неподдерживаемый элемент выражения: "+n.String())}
+	}
+}
+
+// expressionError переводит смещение в символах в строку и столбец, считая с 1.
+func expressionError(source []rune, location file.Location, message string) *pb.ExpressionError {
+	from := min(max(location.From, 0), len(source))
+	to := min(max(location.To, from), len(source))
+	line, column := 1, 1
+	for _, r := range source[:from] {
+		if r == '\n' {
+			line, column = line+1, 1
+		} else {
+			column++
+		}
+	}
+	return &pb.ExpressionError{Message: message, Line: int32(line), Column: int32(column), Token: string(source[from:to])}
+}
+
+func countNodes(node ast.Node) int {
+	switch n := node.(type) {
+	case *ast.UnaryNode:
+		return 1 + countNodes(n.Node)
+	case *ast.BinaryNode:
+		return 1 + countNodes(n.Left) + countNodes(n.Right)
+	default:
+		return 1
+	}
+}
+
+func treeDepth(node ast.Node) int {
+	switch n := node.(type) {
+	case *ast.UnaryNode:
+		return 1 + treeDepth(n.Node)
+	case *ast.BinaryNode:
+		return 1 + max(treeDepth(n.Left), treeDepth(n.Right))
+	default:
+		return 1
+	}
+}
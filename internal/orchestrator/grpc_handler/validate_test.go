@@ -0,0 +1,102 @@
+package grpc_handler
+
+import (
+	"context"
+	"testing"
+
+	"github.com/Qu1nel/YaLyceum-GoProject-Final/internal/orchestrator/service"
+	pb "github.com/Qu1nel/YaLyceum-GoProject-Final/proto/gen/orchestrator"
+	"github.com/expr-lang/expr/ast"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestOrchestratorServer_ValidateExpression_Valid(t *testing.T) {
+	server, _, _ := setupOrchestratorServerTest(t)
+
+	res, err := server.ValidateExpression(context.Background(), &pb.ValidateExpressionRequest{Expression: "-(2+3)*4"})
+	require.NoError(t, err)
+	assert.True(t, res.Valid)
+	assert.Empty(t, res.Errors)
+	assert.Equal(t, "-(2 + 3) * 4", res.Normalized)
+	assert.Equal(t, int32(6), res.NodeCount)
+	assert.Equal(t, int32(3), res.OperationCount)
+	assert.Equal(t, int32(4), res.Depth)
+}
+
+func TestOrchestratorServer_ValidateExpression_SyntaxErrorPosition(t *testing.T) {
+	server, _, _ := setupOrchestratorServerTest(t)
+
+	res, err := server.ValidateExpression(context.Background(), &pb.ValidateExpressionRequest{Expression: "1 +\n2 * )"})
+	require.NoError(t, err)
+	assert.False(t, res.Valid)
+	require.Len(t, res.Errors, 1)
+	assert.Equal(t, int32(2), res.Errors[0].Line)
+	assert.Equal(t, int32(5), res.Errors[0].Column)
+	assert.Equal(t, ")", res.Errors[0].Token)
+	assert.Empty(t, res.Normalized)
+}
+
+func TestOrchestratorServer_ValidateExpression_UnexpectedEnd(t *testing.T) {
+	server, _, _ := setupOrchestratorServerTest(t)
+
+	res, err := server.ValidateExpression(context.Background(), &pb.ValidateExpressionRequest{Expression: "2+"})
+	require.NoError(t, err)
+	require.Len(t, res.Errors, 1)
+	assert.Equal(t, int32(1), res.Errors[0].Line)
+	assert.Equal(t, int32(3), res.Errors[0].Column)
+	assert.Empty(t, res.Errors[0].Token)
+}
+
+func TestOrchestratorServer_ValidateExpression_UnsupportedNodes(t *testing.T) {
+	server, _, _ := setupOrchestratorServerTest(t)
+
+	res, err := server.ValidateExpression(context.Background(), &pb.ValidateExpressionRequest{Expression: "x + abs(2) * 3"})
+	require.NoError(t, err)
+	assert.False(t, res.Valid)
+	require.Len(t, res.Errors, 2)
+	assert.Equal(t, "x", res.Errors[0].Token)
+	assert.Equal(t, int32(1), res.Errors[0].Column)
+	assert.Equal(t, "abs", res.Errors[1].Token)
+	assert.Equal(t, int32(5), res.Errors[1].Column)
+	assert.Equal(t, "x + abs(2) * 3", res.Normalized)
+}
+
+func TestOrchestratorServer_ValidateExpression_Empty(t *testing.T) {
+	server, _, _ := setupOrchestratorServerTest(t)
+
+	_, err := server.ValidateExpression(context.Background(), &pb.ValidateExpressionRequest{})
+	require.Error(t, err)
+	assert.Equal(t, codes.InvalidArgument, status.Code(err))
+}
+
+// Вычисляемое дерево не сворачивается оптимизатором: каждая операция из выражения уходит Воркеру.
+func TestCompileExpression_KeepsConstantOperations(t *testing.T) {
+	tests := []struct {
+		expression string
+		operator   string
+		operations int
+	}{
+		{expression: "1 / 0", operator: "/", operations: 1},
+		{expression: "(2+3)*4", operator: "*", operations: 2},
+		{expression: "-(2+3)", operator: "-", operations: 2},
+	}
+	for _, tt := range tests {
+		t.Run(tt.expression, func(t *testing.T) {
+			program, err := compileExpression(tt.expression)
+			require.NoError(t, err)
+
+			switch root := program.Node().(type) {
+			case *ast.BinaryNode:
+				assert.Equal(t, tt.operator, root.Operator)
+			case *ast.UnaryNode:
+				assert.Equal(t, tt.operator, root.Operator)
+			default:
+				t.Fatalf("выражение свернуто в %T", root)
+			}
+			assert.Equal(t, tt.operations, service.CountOperations(program.Node()))
+		})
+	}
+}
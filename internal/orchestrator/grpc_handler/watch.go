@@ -0,0 +1,119 @@
+package grpc_handler
+
+import (
+	"time"
+
+	"github.com/Qu1nel/YaLyceum-GoProject-Final/internal/orchestrator/repository"
+	"github.com/Qu1nel/YaLyceum-GoProject-Final/internal/orchestrator/service"
+	pb "github.com/Qu1nel/YaLyceum-GoProject-Final/proto/gen/orchestrator"
+
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// CloseWatchers завершает все открытые потоки WatchTask, чтобы GracefulStop не ждал их до таймаута.
+func (s *OrchestratorServer) CloseWatchers() {
+	s.closeWatchersOnce.Do(func() { close(s.watchersDone) })
+}
+
+func (s *OrchestratorServer) WatchTask(req *pb.WatchTaskRequest, stream grpc.ServerStreamingServer[pb.TaskEvent]) error {
+	ctx := stream.Context()
+	s.logFor(stream.Context()).Info("Получен gRPC запрос WatchTask",
+		zap.String("taskID", req.GetTaskId()),
+		zap.String("requestingUserID", req.GetUserId()),
+	)
+
+	taskID, err := uuid.Parse(req.GetTaskId())
+	if err != nil {
+		return status.Errorf(codes.InvalidArgument, "невалидный формат taskID: %v", err)
+	}
+
+	// Подписываемся до чтения задачи из БД, чтобы не пропустить переход, случившийся между ними.
+	events, last, cancel := s.events.Subscribe(taskID)
+	defer cancel()
+
+	task, err := s.getOwnedTask(ctx, req.GetTaskId(), req.GetUserId(), "WatchTask")
+	if err != nil {
+		return err
+	}
+
+	snapshot := s.taskSnapshot(task)
+	if last != nil && !snapshot.Terminal() {
+		snapshot = *last
+	}
+	if err := stream.Send(toPBTaskEvent(snapshot)); err != nil {
+		return err
+	}
+	if snapshot.Terminal() {
+		return nil
+	}
+
+	for {
+		select {
+		case <-ctx.Done():
+			s.logFor(stream.Context()).Debug("Подписчик WatchTask отключился", zap.Stringer("taskID", taskID))
+			return nil
+		case <-s.watchersDone:
+			return status.Error(codes.Unavailable, "Оркестратор останавливается, переподключитесь позже")
+		case event, ok := <-events:
+			if !ok {
+				return nil
+			}
+			if err := stream.Send(toPBTaskEvent(event)); err != nil {
+				return err
+			}
+			if event.Terminal() {
+				return nil
+			}
+		}
+	}
+}
+
+// taskSnapshot строит событие из сохраненного состояния задачи. Прогресс незавершенного вычисления в БД не хранится.
+func (s *OrchestratorServer) taskSnapshot(task *repository.Task) service.TaskEvent {
+	event := service.TaskEvent{
+		TaskID:       task.ID,
+		Status:       task.Status,
+		Result:       task.Result,
+		ErrorMessage: task.ErrorMessage,
+		ErrorCode:    task.ErrorCode,
+		At:           task.UpdatedAt,
+	}
+	if program, err := compileExpression(task.Expression); err == nil {
+		event.OperationsTotal = service.CountOperations(program.Node())
+	}
+	if task.Status == repository.StatusCompleted {
+		event.OperationsDone = event.OperationsTotal
+	}
+	if task.Status == repository.StatusPending {
+		if position, queued := s.queue.Position(task.ID); queued {
+			event.QueuePosition = position
+		}
+	}
+	return event
+}
+
+func toPBTaskEvent(event service.TaskEvent) *pb.TaskEvent {
+	pbEvent := &pb.TaskEvent{
+		TaskId:          event.TaskID.String(),
+		Status:          event.Status,
+		AttemptNumber:   int32(event.AttemptNumber),
+		QueuePosition:   int32(event.QueuePosition),
+		OperationsDone:  int32(event.OperationsDone),
+		OperationsTotal: int32(event.OperationsTotal),
+		Timestamp:       event.At.Format(time.RFC3339Nano),
+	}
+	if event.Result != nil {
+		pbEvent.Result = *event.Result
+	}
+	if event.ErrorMessage != nil {
+		pbEvent.ErrorMessage = *event.ErrorMessage
+	}
+	if event.ErrorCode != nil {
+		pbEvent.ErrorCode = *event.ErrorCode
+	}
+	return pbEvent
+}
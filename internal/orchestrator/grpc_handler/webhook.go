@@ -0,0 +1,223 @@
+package grpc_handler
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/url"
+	"time"
+
+	"github.com/Qu1nel/YaLyceum-GoProject-Final/internal/orchestrator/repository"
+	"github.com/Qu1nel/YaLyceum-GoProject-Final/internal/orchestrator/service"
+	"github.com/Qu1nel/YaLyceum-GoProject-Final/internal/pkg/netguard"
+	pb "github.com/Qu1nel/YaLyceum-GoProject-Final/proto/gen/orchestrator"
+
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+const (
+	defaultWebhookDeliveriesLimit = 50
+	maxWebhookDeliveriesLimit     = 200
+)
+
+// validateWebhookURL проверяет, что URL абсолютный, использует http или https и не указывает во внутреннюю сеть.
+// Имена хостов дополнительно проверяются при каждом соединении диспетчера webhook.
+func validateWebhookURL(raw string) error {
+	u, err := url.Parse(raw)
+	if err != nil {
+		return err
+	}
+	if u.Scheme != "http" && u.Scheme != "https" {
+		return fmt.Errorf("поддерживаются только схемы http и https")
+	}
+	if u.Host == "" {
+		return fmt.Errorf("не указан хост")
+	}
+	return netguard.CheckHost(u.Hostname())
+}
+
+func parseUserID(userIDStr string) (uuid.UUID, error) {
+	userID, err := uuid.Parse(userIDStr)
+	if err != nil {
+		return uuid.Nil, status.Errorf(codes.InvalidArgument, "невалидный формат userID: %v", err)
+	}
+	return userID, nil
+}
+
+func (s *OrchestratorServer) CreateWebhookEndpoint(ctx context.Context, req *pb.CreateWebhookEndpointRequest) (*pb.WebhookEndpoint, error) {
+	s.logFor(ctx).Info("Получен gRPC запрос CreateWebhookEndpoint", zap.String("userID", req.GetUserId()), zap.String("url", req.GetUrl()))
+
+	userID, err := parseUserID(req.GetUserId())
+	if err != nil {
+		return nil, err
+	}
+	if err := validateWebhookURL(req.GetUrl()); err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "невалидный url: %v", err)
+	}
+
+	endpoint, err := s.webhookRepo.CreateEndpoint(ctx, userID, req.GetUrl())
+	if err != nil {
+		if errors.Is(err, repository.ErrWebhookEndpointExists) {
+			return nil, status.Error(codes.AlreadyExists, "webhook с таким URL уже зарегистрирован")
+		}
+		return nil, status.Error(codes.Internal, "внутренняя ошибка сервера при создании webhook")
+	}
+	return toPBWebhookEndpoint(*endpoint), nil
+}
+
+func (s *OrchestratorServer) ListWebhookEndpoints(ctx context.Context, req *pb.ListWebhookEndpointsRequest) (*pb.ListWebhookEndpointsResponse, error) {
+	userID, err := parseUserID(req.GetUserId())
+	if err != nil {
+		return nil, err
+	}
+
+	endpoints, err := s.webhookRepo.ListEndpoints(ctx, userID)
+	if err != nil {
+		return nil, status.Error(codes.Internal, "внутренняя ошибка сервера")
+	}
+	response := &pb.ListWebhookEndpointsResponse{}
+	for _, e := range endpoints {
+		response.Endpoints = append(response.Endpoints, toPBWebhookEndpoint(e))
+	}
+	return response, nil
+}
+
+func (s *OrchestratorServer) DeleteWebhookEndpoint(ctx context.Context, req *pb.DeleteWebhookEndpointRequest) (*pb.DeleteWebhookEndpointResponse, error) {
+	s.logFor(ctx).Info("Получен gRPC запрос DeleteWebhookEndpoint", zap.String("userID", req.GetUserId()), zap.String("endpointID", req.GetEndpointId()))
+
+	userID, err := parseUserID(req.GetUserId())
+	if err != nil {
+		return nil, err
+	}
+	endpointID, err := uuid.Parse(req.GetEndpointId())
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "невалидный формат endpointID: %v", err)
+	}
+
+	if err := s.webhookRepo.DeleteEndpoint(ctx, userID, endpointID); err != nil {
+		if errors.Is(err, repository.ErrWebhookNotFound) {
+			return nil, status.Errorf(codes.NotFound, "webhook с ID %s не найден", endpointID)
+		}
+		return nil, status.Error(codes.Internal, "внутренняя ошибка сервера при удалении webhook")
+	}
+	return &pb.DeleteWebhookEndpointResponse{}, nil
+}
+
+func (s *OrchestratorServer) GetWebhookSecret(ctx context.Context, req *pb.WebhookSecretRequest) (*pb.WebhookSecretResponse, error) {
+	s.logFor(ctx).Info("Получен gRPC запрос GetWebhookSecret", zap.String("userID", req.GetUserId()), zap.Bool("rotate", req.GetRotate()))
+
+	userID, err := parseUserID(req.GetUserId())
+	if err != nil {
+		return nil, err
+	}
+
+	secret, err := service.NewWebhookSecret()
+	if err != nil {
+		s.logFor(ctx).Error("Ошибка генерации секрета webhook", zap.Error(err))
+		return nil, status.Error(codes.Internal, "внутренняя ошибка сервера")
+	}
+	if req.GetRotate() {
+		err = s.webhookRepo.RotateSecret(ctx, userID, secret)
+	} else {
+		secret, err = s.webhookRepo.GetOrCreateSecret(ctx, userID, secret)
+	}
+	if err != nil {
+		return nil, status.Error(codes.Internal, "внутренняя ошибка сервера")
+	}
+	return &pb.WebhookSecretResponse{Secret: secret}, nil
+}
+
+func (s *OrchestratorServer) ListWebhookDeliveries(ctx context.Context, req *pb.ListWebhookDeliveriesRequest) (*pb.ListWebhookDeliveriesResponse, error) {
+	userID, err := parseUserID(req.GetUserId())
+	if err != nil {
+		return nil, err
+	}
+	var taskID *uuid.UUID
+	if req.GetTaskId() != "" {
+		id, err := uuid.Parse(req.GetTaskId())
+		if err != nil {
+			return nil, status.Errorf(codes.InvalidArgument, "невалидный формат taskID: %v", err)
+		}
+		taskID = &id
+	}
+	limit := int(req.GetLimit())
+	if limit < 0 || limit > maxWebhookDeliveriesLimit {
+		return nil, status.Errorf(codes.InvalidArgument, "limit должен быть от 1 до %d", maxWebhookDeliveriesLimit)
+	}
+	if limit == 0 {
+		limit = defaultWebhookDeliveriesLimit
+	}
+
+	deliveries, err := s.webhookRepo.ListDeliveries(ctx, userID, taskID, limit)
+	if err != nil {
+		return nil, status.Error(codes.Internal, "внутренняя ошибка сервера")
+	}
+	response := &pb.ListWebhookDeliveriesResponse{}
+	for _, d := range deliveries {
+		response.Deliveries = append(response.Deliveries, toPBWebhookDelivery(d))
+	}
+	return response, nil
+}
+
+func (s *OrchestratorServer) RedeliverWebhook(ctx context.Context, req *pb.RedeliverWebhookRequest) (*pb.RedeliverWebhookResponse, error) {
+	s.logFor(ctx).Info("Получен gRPC запрос RedeliverWebhook", zap.String("userID", req.GetUserId()), zap.String("deliveryID", req.GetDeliveryId()))
+
+	userID, err := parseUserID(req.GetUserId())
+	if err != nil {
+		return nil, err
+	}
+	deliveryID, err := uuid.Parse(req.GetDeliveryId())
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "невалидный формат deliveryID: %v", err)
+	}
+
+	if err := s.webhookRepo.ResetDelivery(ctx, userID, deliveryID); err != nil {
+		if errors.Is(err, repository.ErrWebhookNotFound) {
+			return nil, status.Errorf(codes.NotFound, "доставка с ID %s не найдена", deliveryID)
+		}
+		return nil, status.Error(codes.Internal, "внутренняя ошибка сервера при повторной доставке")
+	}
+	s.webhooks.Wake()
+	return &pb.RedeliverWebhookResponse{}, nil
+}
+
+func toPBWebhookEndpoint(e repository.WebhookEndpoint) *pb.WebhookEndpoint {
+	return &pb.WebhookEndpoint{
+		Id:        e.ID.String(),
+		Url:       e.URL,
+		CreatedAt: e.CreatedAt.Format(time.RFC3339Nano),
+	}
+}
+
+func toPBWebhookDelivery(d repository.WebhookDelivery) *pb.WebhookDelivery {
+	pbDelivery := &pb.WebhookDelivery{
+		Id:        d.ID.String(),
+		Url:       d.URL,
+		Event:     d.Event,
+		Status:    d.Status,
+		Attempts:  int32(d.Attempts),
+		CreatedAt: d.CreatedAt.Format(time.RFC3339Nano),
+	}
+	if d.TaskID != nil {
+		pbDelivery.TaskId = d.TaskID.String()
+	}
+	if d.EndpointID != nil {
+		pbDelivery.EndpointId = d.EndpointID.String()
+	}
+	if d.LastStatusCode != nil {
+		pbDelivery.LastStatusCode = int32(*d.LastStatusCode)
+	}
+	if d.LastError != nil {
+		pbDelivery.LastError = *d.LastError
+	}
+	if d.NextAttemptAt != nil && d.Status == repository.DeliveryStatusPending {
+		pbDelivery.NextAttemptAt = d.NextAttemptAt.Format(time.RFC3339Nano)
+	}
+	if d.DeliveredAt != nil {
+		pbDelivery.DeliveredAt = d.DeliveredAt.Format(time.RFC3339Nano)
+	}
+	return pbDelivery
+}
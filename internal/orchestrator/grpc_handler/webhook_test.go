@@ -0,0 +1,80 @@
+package grpc_handler
+
+import (
+	"context"
+	"testing"
+
+	"github.com/Qu1nel/YaLyceum-GoProject-Final/internal/orchestrator/repository"
+	repo_mocks "github.com/Qu1nel/YaLyceum-GoProject-Final/internal/orchestrator/repository/mocks"
+	pb "github.com/Qu1nel/YaLyceum-GoProject-Final/proto/gen/orchestrator"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestOrchestratorServer_SubmitExpression_InvalidCallbackURL(t *testing.T) {
+	server, _, _ := setupOrchestratorServerTest(t)
+
+	_, err := server.SubmitExpression(context.Background(), &pb.ExpressionRequest{
+		UserId:      uuid.New().String(),
+		Expression:  "2+2",
+		CallbackUrl: "ftp://example.com/hook",
+	})
+
+	require.Error(t, err)
+	assert.Equal(t, codes.InvalidArgument, status.Code(err))
+}
+
+func TestOrchestratorServer_CreateWebhookEndpoint_InternalAddress(t *testing.T) {
+	server, _, _ := setupOrchestratorServerTest(t)
+
+	for _, url := range []string{"http://127.0.0.1:8080/hook", "http://169.254.169.254/latest/meta-data/", "http://localhost:5432", "http://[::1]/"} {
+		_, err := server.CreateWebhookEndpoint(context.Background(), &pb.CreateWebhookEndpointRequest{UserId: uuid.New().String(), Url: url})
+
+		require.Error(t, err, url)
+		assert.Equal(t, codes.InvalidArgument, status.Code(err), url)
+	}
+}
+
+func TestOrchestratorServer_CreateWebhookEndpoint_AlreadyExists(t *testing.T) {
+	server, _, _ := setupOrchestratorServerTest(t)
+	webhookRepo := server.webhookRepo.(*repo_mocks.WebhookRepositoryMock)
+	userID := uuid.New()
+
+	webhookRepo.On("CreateEndpoint", mock.Anything, userID, "https://example.com/hook").
+		Return(nil, repository.ErrWebhookEndpointExists).Once()
+
+	_, err := server.CreateWebhookEndpoint(context.Background(), &pb.CreateWebhookEndpointRequest{
+		UserId: userID.String(),
+		Url:    "https://example.com/hook",
+	})
+
+	require.Error(t, err)
+	assert.Equal(t, codes.AlreadyExists, status.Code(err))
+}
+
+func TestOrchestratorServer_RedeliverWebhook(t *testing.T) {
+	server, _, _ := setupOrchestratorServerTest(t)
+	webhookRepo := server.webhookRepo.(*repo_mocks.WebhookRepositoryMock)
+	notifier := server.webhooks.(*fakeWebhookNotifier)
+	userID, deliveryID, foreignDeliveryID := uuid.New(), uuid.New(), uuid.New()
+
+	webhookRepo.On("ResetDelivery", mock.Anything, userID, deliveryID).Return(nil).Once()
+	webhookRepo.On("ResetDelivery", mock.Anything, userID, foreignDeliveryID).Return(repository.ErrWebhookNotFound).Once()
+
+	_, err := server.RedeliverWebhook(context.Background(), &pb.RedeliverWebhookRequest{
+		UserId: userID.String(), DeliveryId: deliveryID.String(),
+	})
+	require.NoError(t, err)
+	assert.Equal(t, int32(1), notifier.woken.Load())
+
+	_, err = server.RedeliverWebhook(context.Background(), &pb.RedeliverWebhookRequest{
+		UserId: userID.String(), DeliveryId: foreignDeliveryID.String(),
+	})
+	assert.Equal(t, codes.NotFound, status.Code(err))
+	assert.Equal(t, int32(1), notifier.woken.Load())
+}
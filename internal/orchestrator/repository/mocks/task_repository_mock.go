@@ -15,9 +15,69 @@ type TaskRepositoryMock struct {
 	mock.Mock
 }
 
-// CreateTask provides a mock function with given fields: ctx, userID, expression
-func (_m *TaskRepositoryMock) CreateTask(ctx context.Context, userID uuid.UUID, expression string) (uuid.UUID, error) {
-	ret := _m.Called(ctx, userID, expression)
+// CreateAttempt provides a mock function with given fields: ctx, taskID
+func (_m *TaskRepositoryMock) CreateAttempt(ctx context.Context, taskID uuid.UUID) (*repository.TaskAttempt, error) {
+	ret := _m.Called(ctx, taskID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CreateAttempt")
+	}
+
+	var r0 *repository.TaskAttempt
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID) (*repository.TaskAttempt, error)); ok {
+		return rf(ctx, taskID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID) *repository.TaskAttempt); ok {
+		r0 = rf(ctx, taskID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*repository.TaskAttempt)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, uuid.UUID) error); ok {
+		r1 = rf(ctx, taskID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// CreateIdempotentTask provides a mock function with given fields: ctx, task, key, requestHash
+func (_m *TaskRepositoryMock) CreateIdempotentTask(ctx context.Context, task repository.NewTask, key string, requestHash string) (uuid.UUID, error) {
+	ret := _m.Called(ctx, task, key, requestHash)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CreateIdempotentTask")
+	}
+
+	var r0 uuid.UUID
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, repository.NewTask, string, string) (uuid.UUID, error)); ok {
+		return rf(ctx, task, key, requestHash)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, repository.NewTask, string, string) uuid.UUID); ok {
+		r0 = rf(ctx, task, key, requestHash)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(uuid.UUID)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, repository.NewTask, string, string) error); ok {
+		r1 = rf(ctx, task, key, requestHash)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// CreateTask provides a mock function with given fields: ctx, task
+func (_m *TaskRepositoryMock) CreateTask(ctx context.Context, task repository.NewTask) (uuid.UUID, error) {
+	ret := _m.Called(ctx, task)
 
 	if len(ret) == 0 {
 		panic("no return value specified for CreateTask")
@@ -25,19 +85,241 @@ func (_m *TaskRepositoryMock) CreateTask(ctx context.Context, userID uuid.UUID,
 
 	var r0 uuid.UUID
 	var r1 error
-	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID, string) (uuid.UUID, error)); ok {
-		return rf(ctx, userID, expression)
+	if rf, ok := ret.Get(0).(func(context.Context, repository.NewTask) (uuid.UUID, error)); ok {
+		return rf(ctx, task)
 	}
-	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID, string) uuid.UUID); ok {
-		r0 = rf(ctx, userID, expression)
+	if rf, ok := ret.Get(0).(func(context.Context, repository.NewTask) uuid.UUID); ok {
+		r0 = rf(ctx, task)
 	} else {
 		if ret.Get(0) != nil {
 			r0 = ret.Get(0).(uuid.UUID)
 		}
 	}
 
+	if rf, ok := ret.Get(1).(func(context.Context, repository.NewTask) error); ok {
+		r1 = rf(ctx, task)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// CreateTasks provides a mock function with given fields: ctx, tasks
+func (_m *TaskRepositoryMock) CreateTasks(ctx context.Context, tasks []repository.NewTask) ([]uuid.UUID, error) {
+	ret := _m.Called(ctx, tasks)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CreateTasks")
+	}
+
+	var r0 []uuid.UUID
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, []repository.NewTask) ([]uuid.UUID, error)); ok {
+		return rf(ctx, tasks)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, []repository.NewTask) []uuid.UUID); ok {
+		r0 = rf(ctx, tasks)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]uuid.UUID)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, []repository.NewTask) error); ok {
+		r1 = rf(ctx, tasks)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// DeleteRejectedTask provides a mock function with given fields: ctx, taskID
+func (_m *TaskRepositoryMock) DeleteRejectedTask(ctx context.Context, taskID uuid.UUID) error {
+	ret := _m.Called(ctx, taskID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DeleteRejectedTask")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID) error); ok {
+		r0 = rf(ctx, taskID)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// DeleteTask provides a mock function with given fields: ctx, taskID
+func (_m *TaskRepositoryMock) DeleteTask(ctx context.Context, taskID uuid.UUID) error {
+	ret := _m.Called(ctx, taskID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DeleteTask")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID) error); ok {
+		r0 = rf(ctx, taskID)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// FailUnfinishedTask provides a mock function with given fields: ctx, taskID, errorCode, errorMessage
+func (_m *TaskRepositoryMock) FailUnfinishedTask(ctx context.Context, taskID uuid.UUID, errorCode string, errorMessage string) error {
+	ret := _m.Called(ctx, taskID, errorCode, errorMessage)
+
+	if len(ret) == 0 {
+		panic("no return value specified for FailUnfinishedTask")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID, string, string) error); ok {
+		r0 = rf(ctx, taskID, errorCode, errorMessage)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// FinishAttempt provides a mock function with given fields: ctx, attemptID, result, errorMessage
+func (_m *TaskRepositoryMock) FinishAttempt(ctx context.Context, attemptID uuid.UUID, result *float64, errorMessage *string) error {
+	ret := _m.Called(ctx, attemptID, result, errorMessage)
+
+	if len(ret) == 0 {
+		panic("no return value specified for FinishAttempt")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID, *float64, *string) error); ok {
+		r0 = rf(ctx, attemptID, result, errorMessage)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// GetAttemptsByTaskID provides a mock function with given fields: ctx, taskID
+func (_m *TaskRepositoryMock) GetAttemptsByTaskID(ctx context.Context, taskID uuid.UUID) ([]repository.TaskAttempt, error) {
+	ret := _m.Called(ctx, taskID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetAttemptsByTaskID")
+	}
+
+	var r0 []repository.TaskAttempt
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID) ([]repository.TaskAttempt, error)); ok {
+		return rf(ctx, taskID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID) []repository.TaskAttempt); ok {
+		r0 = rf(ctx, taskID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]repository.TaskAttempt)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, uuid.UUID) error); ok {
+		r1 = rf(ctx, taskID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetIdempotencyRecord provides a mock function with given fields: ctx, userID, key
+func (_m *TaskRepositoryMock) GetIdempotencyRecord(ctx context.Context, userID uuid.UUID, key string) (*repository.IdempotencyRecord, error) {
+	ret := _m.Called(ctx, userID, key)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetIdempotencyRecord")
+	}
+
+	var r0 *repository.IdempotencyRecord
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID, string) (*repository.IdempotencyRecord, error)); ok {
+		return rf(ctx, userID, key)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID, string) *repository.IdempotencyRecord); ok {
+		r0 = rf(ctx, userID, key)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*repository.IdempotencyRecord)
+		}
+	}
+
 	if rf, ok := ret.Get(1).(func(context.Context, uuid.UUID, string) error); ok {
-		r1 = rf(ctx, userID, expression)
+		r1 = rf(ctx, userID, key)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetLatestOperationsByTaskID provides a mock function with given fields: ctx, taskID
+func (_m *TaskRepositoryMock) GetLatestOperationsByTaskID(ctx context.Context, taskID uuid.UUID) ([]repository.TaskOperation, error) {
+	ret := _m.Called(ctx, taskID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetLatestOperationsByTaskID")
+	}
+
+	var r0 []repository.TaskOperation
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID) ([]repository.TaskOperation, error)); ok {
+		return rf(ctx, taskID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID) []repository.TaskOperation); ok {
+		r0 = rf(ctx, taskID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]repository.TaskOperation)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, uuid.UUID) error); ok {
+		r1 = rf(ctx, taskID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetSystemTaskCounts provides a mock function with given fields: ctx
+func (_m *TaskRepositoryMock) GetSystemTaskCounts(ctx context.Context) (*repository.SystemTaskCounts, error) {
+	ret := _m.Called(ctx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetSystemTaskCounts")
+	}
+
+	var r0 *repository.SystemTaskCounts
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context) (*repository.SystemTaskCounts, error)); ok {
+		return rf(ctx)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context) *repository.SystemTaskCounts); ok {
+		r0 = rf(ctx)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*repository.SystemTaskCounts)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = rf(ctx)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -75,9 +357,9 @@ func (_m *TaskRepositoryMock) GetTaskByID(ctx context.Context, taskID uuid.UUID)
 	return r0, r1
 }
 
-// GetTasksByUserID provides a mock function with given fields: ctx, userID
-func (_m *TaskRepositoryMock) GetTasksByUserID(ctx context.Context, userID uuid.UUID) ([]repository.Task, error) {
-	ret := _m.Called(ctx, userID)
+// GetTasksByUserID provides a mock function with given fields: ctx, userID, filter
+func (_m *TaskRepositoryMock) GetTasksByUserID(ctx context.Context, userID uuid.UUID, filter repository.TaskListFilter) ([]repository.Task, error) {
+	ret := _m.Called(ctx, userID, filter)
 
 	if len(ret) == 0 {
 		panic("no return value specified for GetTasksByUserID")
@@ -85,14 +367,44 @@ func (_m *TaskRepositoryMock) GetTasksByUserID(ctx context.Context, userID uuid.
 
 	var r0 []repository.Task
 	var r1 error
-	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID) ([]repository.Task, error)); ok {
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID, repository.TaskListFilter) ([]repository.Task, error)); ok {
+		return rf(ctx, userID, filter)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID, repository.TaskListFilter) []repository.Task); ok {
+		r0 = rf(ctx, userID, filter)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]repository.Task)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, uuid.UUID, repository.TaskListFilter) error); ok {
+		r1 = rf(ctx, userID, filter)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetUserStats provides a mock function with given fields: ctx, userID
+func (_m *TaskRepositoryMock) GetUserStats(ctx context.Context, userID uuid.UUID) (*repository.UserStats, error) {
+	ret := _m.Called(ctx, userID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetUserStats")
+	}
+
+	var r0 *repository.UserStats
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID) (*repository.UserStats, error)); ok {
 		return rf(ctx, userID)
 	}
-	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID) []repository.Task); ok {
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID) *repository.UserStats); ok {
 		r0 = rf(ctx, userID)
 	} else {
 		if ret.Get(0) != nil {
-			r0 = ret.Get(0).([]repository.Task)
+			r0 = ret.Get(0).(*repository.UserStats)
 		}
 	}
 
@@ -105,17 +417,135 @@ func (_m *TaskRepositoryMock) GetTasksByUserID(ctx context.Context, userID uuid.
 	return r0, r1
 }
 
-// SetTaskError provides a mock function with given fields: ctx, taskID, errorMessage
-func (_m *TaskRepositoryMock) SetTaskError(ctx context.Context, taskID uuid.UUID, errorMessage string) error {
-	ret := _m.Called(ctx, taskID, errorMessage)
+// PurgeTasks provides a mock function with given fields: ctx, criteria
+func (_m *TaskRepositoryMock) PurgeTasks(ctx context.Context, criteria repository.PurgeCriteria) (int64, error) {
+	ret := _m.Called(ctx, criteria)
+
+	if len(ret) == 0 {
+		panic("no return value specified for PurgeTasks")
+	}
+
+	var r0 int64
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, repository.PurgeCriteria) (int64, error)); ok {
+		return rf(ctx, criteria)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, repository.PurgeCriteria) int64); ok {
+		r0 = rf(ctx, criteria)
+	} else {
+		r0 = ret.Get(0).(int64)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, repository.PurgeCriteria) error); ok {
+		r1 = rf(ctx, criteria)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ReleaseIdempotencyKey provides a mock function with given fields: ctx, taskID
+func (_m *TaskRepositoryMock) ReleaseIdempotencyKey(ctx context.Context, taskID uuid.UUID) error {
+	ret := _m.Called(ctx, taskID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ReleaseIdempotencyKey")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID) error); ok {
+		r0 = rf(ctx, taskID)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// ResetTaskForRetry provides a mock function with given fields: ctx, taskID
+func (_m *TaskRepositoryMock) ResetTaskForRetry(ctx context.Context, taskID uuid.UUID) error {
+	ret := _m.Called(ctx, taskID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ResetTaskForRetry")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID) error); ok {
+		r0 = rf(ctx, taskID)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// RestoreTask provides a mock function with given fields: ctx, taskID
+func (_m *TaskRepositoryMock) RestoreTask(ctx context.Context, taskID uuid.UUID) error {
+	ret := _m.Called(ctx, taskID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for RestoreTask")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID) error); ok {
+		r0 = rf(ctx, taskID)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// RevertTaskRetry provides a mock function with given fields: ctx, previous, attemptID
+func (_m *TaskRepositoryMock) RevertTaskRetry(ctx context.Context, previous repository.Task, attemptID uuid.UUID) error {
+	ret := _m.Called(ctx, previous, attemptID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for RevertTaskRetry")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, repository.Task, uuid.UUID) error); ok {
+		r0 = rf(ctx, previous, attemptID)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// SaveOperations provides a mock function with given fields: ctx, taskID, attemptID, operations
+func (_m *TaskRepositoryMock) SaveOperations(ctx context.Context, taskID uuid.UUID, attemptID uuid.UUID, operations []repository.TaskOperation) error {
+	ret := _m.Called(ctx, taskID, attemptID, operations)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SaveOperations")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID, uuid.UUID, []repository.TaskOperation) error); ok {
+		r0 = rf(ctx, taskID, attemptID, operations)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// SetTaskError provides a mock function with given fields: ctx, taskID, errorCode, errorMessage
+func (_m *TaskRepositoryMock) SetTaskError(ctx context.Context, taskID uuid.UUID, errorCode string, errorMessage string) error {
+	ret := _m.Called(ctx, taskID, errorCode, errorMessage)
 
 	if len(ret) == 0 {
 		panic("no return value specified for SetTaskError")
 	}
 
 	var r0 error
-	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID, string) error); ok {
-		r0 = rf(ctx, taskID, errorMessage)
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID, string, string) error); ok {
+		r0 = rf(ctx, taskID, errorCode, errorMessage)
 	} else {
 		r0 = ret.Error(0)
 	}
@@ -141,6 +571,42 @@ func (_m *TaskRepositoryMock) SetTaskResult(ctx context.Context, taskID uuid.UUI
 	return r0
 }
 
+// SoftDeleteTask provides a mock function with given fields: ctx, taskID
+func (_m *TaskRepositoryMock) SoftDeleteTask(ctx context.Context, taskID uuid.UUID) error {
+	ret := _m.Called(ctx, taskID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SoftDeleteTask")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID) error); ok {
+		r0 = rf(ctx, taskID)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// StartAttempt provides a mock function with given fields: ctx, attemptID
+func (_m *TaskRepositoryMock) StartAttempt(ctx context.Context, attemptID uuid.UUID) error {
+	ret := _m.Called(ctx, attemptID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for StartAttempt")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID) error); ok {
+		r0 = rf(ctx, attemptID)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // UpdateTaskStatus provides a mock function with given fields: ctx, taskID, status
 func (_m *TaskRepositoryMock) UpdateTaskStatus(ctx context.Context, taskID uuid.UUID, status string) error {
 	ret := _m.Called(ctx, taskID, status)
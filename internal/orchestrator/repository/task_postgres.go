@@ -4,6 +4,9 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
 	"time"
 
 	"github.com/google/uuid"
@@ -19,6 +22,17 @@ const (
 	StatusFailed     = "failed"
 )
 
+// Типы ошибок задачи в статусе failed.
+const (
+	ErrorCodeDivisionByZero    = "DIVISION_BY_ZERO"
+	ErrorCodeDomainError       = "DOMAIN_ERROR"
+	ErrorCodeOverflow          = "OVERFLOW"
+	ErrorCodeTimeout           = "TIMEOUT"
+	ErrorCodeWorkerUnavailable = "WORKER_UNAVAILABLE"
+	ErrorCodeCancelled         = "CANCELLED"
+	ErrorCodeInternal          = "INTERNAL"
+)
+
 type Task struct {
 	ID           uuid.UUID
 	UserID       uuid.UUID
@@ -26,22 +40,136 @@ type Task struct {
 	Status       string
 	Result       *float64
 	ErrorMessage *string
+	ErrorCode    *string
 	CreatedAt    time.Time
 	UpdatedAt    time.Time
+	// CompletedAt - время перехода в completed или failed. В отличие от updated_at не меняется
+	// при перемещении в корзину и обратно или освобождении ключа идемпотентности.
+	CompletedAt *time.Time
+	DeletedAt   *time.Time
+	CallbackURL *string
+	RequestID   *string
+}
+
+// NewTask - параметры создаваемой задачи.
+type NewTask struct {
+	UserID      uuid.UUID
+	Expression  string
+	CallbackURL *string
+	RequestID   *string // ID HTTP запроса Агента, создавшего задачу
+}
+
+// IdempotencyRecord - задача, созданная запросом с ключом идемпотентности.
+type IdempotencyRecord struct {
+	TaskID      uuid.UUID
+	RequestHash string
+	CreatedAt   time.Time
+}
+
+type TaskAttempt struct {
+	ID            uuid.UUID
+	TaskID        uuid.UUID
+	AttemptNumber int
+	Status        string
+	Result        *float64
+	ErrorMessage  *string
+	StartedAt     *time.Time
+	FinishedAt    *time.Time
+	CreatedAt     time.Time
+}
+
+// TaskOperation - запись об одном вызове Воркера при вычислении задачи.
+// NodePath - путь узла в AST от корня ("0", "0.1", "0.1.0", ...), 0 - левый/единственный операнд, 1 - правый.
+type TaskOperation struct {
+	TaskID        uuid.UUID
+	AttemptID     uuid.UUID
+	AttemptNumber int
+	Seq           int
+	OperationID   string
+	NodePath      string
+	Symbol        string
+	OperandA      float64
+	OperandB      float64
+	Result        *float64
+	ErrorMessage  *string
+	WorkerAddress string
+	StartedAt     time.Time
+	Duration      time.Duration
+}
+
+// TaskCursor - позиция последней выданной задачи для keyset-пагинации.
+type TaskCursor struct {
+	CreatedAt time.Time
+	ID        uuid.UUID
+}
+
+type TaskListFilter struct {
+	Deleted     bool
+	Search      string
+	Statuses    []string
+	CreatedFrom *time.Time
+	CreatedTo   *time.Time
+	Ascending   bool
+	After       *TaskCursor
+	Limit       int
+}
+
+// PurgeCriteria описывает одну выборку задач для окончательного удаления.
+// Nil-граница означает, что задачи этой категории не удаляются.
+type PurgeCriteria struct {
+	UserIDs         []uuid.UUID
+	ExcludeUserIDs  []uuid.UUID
+	CompletedBefore *time.Time
+	FailedBefore    *time.Time
+	DeletedBefore   *time.Time
+	Limit           int
 }
 
 var (
-	ErrTaskNotFound = errors.New("задача не найдена")
-	ErrDatabase     = errors.New("ошибка базы данных")
+	ErrTaskNotFound     = errors.New("задача не найдена")
+	ErrTaskNotRetryable = errors.New("задача еще выполняется и не может быть перезапущена")
+	// ErrTaskStateConflict - задача уже завершилась, пока ее пытались завершить принудительно.
+	ErrTaskStateConflict = errors.New("задача уже завершена")
+	ErrDatabase          = errors.New("ошибка базы данных")
+	// ErrIdempotencyKeyTaken - у пользователя уже есть задача с этим ключом идемпотентности.
+	ErrIdempotencyKeyTaken = errors.New("ключ идемпотентности уже использован")
 )
 
 type TaskRepository interface {
-	CreateTask(ctx context.Context, userID uuid.UUID, expression string) (uuid.UUID, error)
+	CreateTask(ctx context.Context, task NewTask) (uuid.UUID, error)
+	// CreateTasks создает задачи в одной транзакции: либо все, либо ни одной. ID возвращаются в порядке tasks.
+	CreateTasks(ctx context.Context, tasks []NewTask) ([]uuid.UUID, error)
+	// CreateIdempotentTask создает задачу с ключом идемпотентности или возвращает ErrIdempotencyKeyTaken.
+	CreateIdempotentTask(ctx context.Context, task NewTask, key, requestHash string) (uuid.UUID, error)
+	GetIdempotencyRecord(ctx context.Context, userID uuid.UUID, key string) (*IdempotencyRecord, error)
+	// ReleaseIdempotencyKey отвязывает ключ от задачи, чтобы его можно было использовать снова.
+	ReleaseIdempotencyKey(ctx context.Context, taskID uuid.UUID) error
 	GetTaskByID(ctx context.Context, taskID uuid.UUID) (*Task, error)
-	GetTasksByUserID(ctx context.Context, userID uuid.UUID) ([]Task, error)
+	GetTasksByUserID(ctx context.Context, userID uuid.UUID, filter TaskListFilter) ([]Task, error)
 	UpdateTaskStatus(ctx context.Context, taskID uuid.UUID, status string) error
 	SetTaskResult(ctx context.Context, taskID uuid.UUID, result float64) error
-	SetTaskError(ctx context.Context, taskID uuid.UUID, errorMessage string) error
+	SetTaskError(ctx context.Context, taskID uuid.UUID, errorCode, errorMessage string) error
+	// FailUnfinishedTask помечает задачу failed, только если она еще pending или processing, иначе ErrTaskStateConflict.
+	FailUnfinishedTask(ctx context.Context, taskID uuid.UUID, errorCode, errorMessage string) error
+	ResetTaskForRetry(ctx context.Context, taskID uuid.UUID) error
+	// RevertTaskRetry возвращает сброшенной задаче результат previous и удаляет попытку attemptID (uuid.Nil - без попытки),
+	// если перезапуск не удалось поставить в очередь. ErrTaskStateConflict - задача уже не в статусе pending.
+	RevertTaskRetry(ctx context.Context, previous Task, attemptID uuid.UUID) error
+	CreateAttempt(ctx context.Context, taskID uuid.UUID) (*TaskAttempt, error)
+	StartAttempt(ctx context.Context, attemptID uuid.UUID) error
+	FinishAttempt(ctx context.Context, attemptID uuid.UUID, result *float64, errorMessage *string) error
+	GetAttemptsByTaskID(ctx context.Context, taskID uuid.UUID) ([]TaskAttempt, error)
+	SaveOperations(ctx context.Context, taskID, attemptID uuid.UUID, operations []TaskOperation) error
+	GetLatestOperationsByTaskID(ctx context.Context, taskID uuid.UUID) ([]TaskOperation, error)
+	SoftDeleteTask(ctx context.Context, taskID uuid.UUID) error
+	RestoreTask(ctx context.Context, taskID uuid.UUID) error
+	DeleteTask(ctx context.Context, taskID uuid.UUID) error
+	// DeleteRejectedTask удаляет только что созданную задачу, которую не удалось поставить в очередь.
+	// ErrTaskStateConflict - задача уже не в статусе pending.
+	DeleteRejectedTask(ctx context.Context, taskID uuid.UUID) error
+	PurgeTasks(ctx context.Context, criteria PurgeCriteria) (int64, error)
+	GetUserStats(ctx context.Context, userID uuid.UUID) (*UserStats, error)
+	GetSystemTaskCounts(ctx context.Context) (*SystemTaskCounts, error)
 }
 
 type pgxTaskRepository struct {
@@ -53,18 +181,19 @@ func NewPgxTaskRepository(db DBPoolIface, log *zap.Logger) TaskRepository {
 	return &pgxTaskRepository{db: db, log: log}
 }
 
-func (r *pgxTaskRepository) CreateTask(ctx context.Context, userID uuid.UUID, expression string) (uuid.UUID, error) {
+func (r *pgxTaskRepository) CreateTask(ctx context.Context, task NewTask) (uuid.UUID, error) {
 	query := `
-        INSERT INTO tasks (user_id, expression, status)
-        VALUES ($1, $2, $3)
+        INSERT INTO tasks (user_id, expression, status, callback_url, request_id)
+        VALUES ($1, $2, $3, $4, $5)
         RETURNING id
     `
+	userID := task.UserID
 	var taskID uuid.UUID
-	err := r.db.QueryRow(ctx, query, userID, expression, StatusPending).Scan(&taskID)
+	err := r.db.QueryRow(ctx, query, userID, task.Expression, StatusPending, task.CallbackURL, task.RequestID).Scan(&taskID)
 	if err != nil {
 		r.log.Error("Не удалось создать задачу в БД",
 			zap.Stringer("userID", userID),
-			zap.String("expression", expression),
+			zap.String("expression", task.Expression),
 			zap.Error(err),
 		)
 		return uuid.Nil, fmt.Errorf("%w: не удалось вставить задачу: %v", ErrDatabase, err)
@@ -76,16 +205,112 @@ func (r *pgxTaskRepository) CreateTask(ctx context.Context, userID uuid.UUID, ex
 	return taskID, nil
 }
 
+func (r *pgxTaskRepository) CreateTasks(ctx context.Context, tasks []NewTask) ([]uuid.UUID, error) {
+	query := `
+        INSERT INTO tasks (user_id, expression, status, callback_url, request_id)
+        VALUES ($1, $2, $3, $4, $5)
+        RETURNING id
+    `
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		r.log.Error("Не удалось начать транзакцию создания задач", zap.Error(err))
+		return nil, fmt.Errorf("%w: не удалось начать транзакцию: %v", ErrDatabase, err)
+	}
+	// После Commit откат ничего не делает, поэтому его ошибка не важна.
+	defer func() { _ = tx.Rollback(ctx) }()
+
+	// Вставки уходят одним пакетом, без отдельного круга до БД на каждую задачу.
+	batch := &pgx.Batch{}
+	for _, task := range tasks {
+		batch.Queue(query, task.UserID, task.Expression, StatusPending, task.CallbackURL, task.RequestID)
+	}
+	results := tx.SendBatch(ctx, batch)
+	taskIDs := make([]uuid.UUID, len(tasks))
+	for i := range tasks {
+		if err := results.QueryRow().Scan(&taskIDs[i]); err != nil {
+			results.Close()
+			r.log.Error("Не удалось вставить задачу пакета", zap.Int("index", i), zap.Error(err))
+			return nil, fmt.Errorf("%w: не удалось вставить задачу %d пакета: %v", ErrDatabase, i, err)
+		}
+	}
+	if err := results.Close(); err != nil {
+		r.log.Error("Ошибка завершения пакетной вставки задач", zap.Error(err))
+		return nil, fmt.Errorf("%w: ошибка пакетной вставки: %v", ErrDatabase, err)
+	}
+	if err := tx.Commit(ctx); err != nil {
+		r.log.Error("Не удалось зафиксировать транзакцию создания задач", zap.Error(err))
+		return nil, fmt.Errorf("%w: не удалось зафиксировать транзакцию: %v", ErrDatabase, err)
+	}
+
+	r.log.Info("Пакет задач успешно создан в БД", zap.Int("count", len(taskIDs)))
+	return taskIDs, nil
+}
+
+func (r *pgxTaskRepository) CreateIdempotentTask(ctx context.Context, task NewTask, key, requestHash string) (uuid.UUID, error) {
+	query := `
+        INSERT INTO tasks (user_id, expression, status, callback_url, request_id, idempotency_key, request_hash)
+        VALUES ($1, $2, $3, $4, $5, $6, $7)
+        ON CONFLICT (user_id, idempotency_key) WHERE idempotency_key IS NOT NULL DO NOTHING
+        RETURNING id
+    `
+	var taskID uuid.UUID
+	err := r.db.QueryRow(ctx, query, task.UserID, task.Expression, StatusPending, task.CallbackURL, task.RequestID, key, requestHash).Scan(&taskID)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return uuid.Nil, ErrIdempotencyKeyTaken
+		}
+		r.log.Error("Не удалось создать задачу с ключом идемпотентности в БД",
+			zap.Stringer("userID", task.UserID),
+			zap.String("idempotencyKey", key),
+			zap.Error(err),
+		)
+		return uuid.Nil, fmt.Errorf("%w: не удалось вставить задачу: %v", ErrDatabase, err)
+	}
+	r.log.Info("Задача с ключом идемпотентности создана в БД",
+		zap.Stringer("taskID", taskID),
+		zap.Stringer("userID", task.UserID),
+	)
+	return taskID, nil
+}
+
+// GetIdempotencyRecord возвращает ErrTaskNotFound, если у пользователя нет задачи с ключом key.
+func (r *pgxTaskRepository) GetIdempotencyRecord(ctx context.Context, userID uuid.UUID, key string) (*IdempotencyRecord, error) {
+	query := `
+        SELECT id, COALESCE(request_hash, ''), created_at
+        FROM tasks
+        WHERE user_id = $1 AND idempotency_key = $2
+    `
+	var record IdempotencyRecord
+	err := r.db.QueryRow(ctx, query, userID, key).Scan(&record.TaskID, &record.RequestHash, &record.CreatedAt)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, ErrTaskNotFound
+		}
+		r.log.Error("Ошибка поиска задачи по ключу идемпотентности", zap.Stringer("userID", userID), zap.Error(err))
+		return nil, fmt.Errorf("%w: %v", ErrDatabase, err)
+	}
+	return &record, nil
+}
+
+func (r *pgxTaskRepository) ReleaseIdempotencyKey(ctx context.Context, taskID uuid.UUID) error {
+	query := `UPDATE tasks SET idempotency_key = NULL, request_hash = NULL WHERE id = $1`
+	if _, err := r.db.Exec(ctx, query, taskID); err != nil {
+		r.log.Error("Не удалось освободить ключ идемпотентности", zap.Stringer("taskID", taskID), zap.Error(err))
+		return fmt.Errorf("%w: %v", ErrDatabase, err)
+	}
+	return nil
+}
+
 func (r *pgxTaskRepository) GetTaskByID(ctx context.Context, taskID uuid.UUID) (*Task, error) {
 	query := `
-        SELECT id, user_id, expression, status, result, error_message, created_at, updated_at
+        SELECT id, user_id, expression, status, result, error_message, error_code, created_at, updated_at, completed_at, deleted_at, callback_url, request_id
         FROM tasks
         WHERE id = $1
     `
 	var t Task
 	err := r.db.QueryRow(ctx, query, taskID).Scan(
 		&t.ID, &t.UserID, &t.Expression, &t.Status,
-		&t.Result, &t.ErrorMessage, &t.CreatedAt, &t.UpdatedAt,
+		&t.Result, &t.ErrorMessage, &t.ErrorCode, &t.CreatedAt, &t.UpdatedAt, &t.CompletedAt, &t.DeletedAt, &t.CallbackURL, &t.RequestID,
 	)
 	if err != nil {
 		if errors.Is(err, pgx.ErrNoRows) {
@@ -97,14 +322,52 @@ func (r *pgxTaskRepository) GetTaskByID(ctx context.Context, taskID uuid.UUID) (
 	return &t, nil
 }
 
-func (r *pgxTaskRepository) GetTasksByUserID(ctx context.Context, userID uuid.UUID) ([]Task, error) {
+func (r *pgxTaskRepository) GetTasksByUserID(ctx context.Context, userID uuid.UUID, filter TaskListFilter) ([]Task, error) {
+	conditions := []string{"user_id = $1", "deleted_at IS NULL"}
+	if filter.Deleted {
+		conditions[1] = "deleted_at IS NOT NULL"
+	}
+	args := []any{userID}
+	addArg := func(v any) string {
+		args = append(args, v)
+		return fmt.Sprintf("$%d", len(args))
+	}
+
+	if search := strings.TrimSpace(filter.Search); search != "" {
+		if isNumericLiteral(search) {
+			conditions = append(conditions, "expression ~ "+addArg(numericLiteralPattern(search)))
+		} else {
+			conditions = append(conditions, "expression ILIKE "+addArg("%"+escapeLikePattern(search)+"%"))
+		}
+	}
+	if len(filter.Statuses) > 0 {
+		conditions = append(conditions, "status = ANY("+addArg(filter.Statuses)+")")
+	}
+	if filter.CreatedFrom != nil {
+		conditions = append(conditions, "created_at >= "+addArg(*filter.CreatedFrom))
+	}
+	if filter.CreatedTo != nil {
+		conditions = append(conditions, "created_at < "+addArg(*filter.CreatedTo))
+	}
+
+	direction, cmp := "DESC", "<"
+	if filter.Ascending {
+		direction, cmp = "ASC", ">"
+	}
+	if filter.After != nil {
+		conditions = append(conditions, fmt.Sprintf("(created_at, id) %s (%s, %s)", cmp, addArg(filter.After.CreatedAt), addArg(filter.After.ID)))
+	}
+
 	query := `
-        SELECT id, user_id, expression, status, result, error_message, created_at, updated_at
+        SELECT id, user_id, expression, status, result, error_message, error_code, created_at, updated_at, deleted_at
         FROM tasks
-        WHERE user_id = $1
-        ORDER BY created_at DESC
-    `
-	rows, err := r.db.Query(ctx, query, userID)
+        WHERE ` + strings.Join(conditions, " AND ") + `
+        ORDER BY created_at ` + direction + `, id ` + direction
+	if filter.Limit > 0 {
+		query += " LIMIT " + addArg(filter.Limit)
+	}
+
+	rows, err := r.db.Query(ctx, query, args...)
 	if err != nil {
 		r.log.Error("Ошибка получения задач по UserID из БД", zap.Stringer("userID", userID), zap.Error(err))
 		return nil, fmt.Errorf("%w: %v", ErrDatabase, err)
@@ -116,7 +379,7 @@ func (r *pgxTaskRepository) GetTasksByUserID(ctx context.Context, userID uuid.UU
 		var t Task
 		if err := rows.Scan(
 			&t.ID, &t.UserID, &t.Expression, &t.Status,
-			&t.Result, &t.ErrorMessage, &t.CreatedAt, &t.UpdatedAt,
+			&t.Result, &t.ErrorMessage, &t.ErrorCode, &t.CreatedAt, &t.UpdatedAt, &t.DeletedAt,
 		); err != nil {
 			r.log.Error("Ошибка сканирования строки задачи", zap.Stringer("userID", userID), zap.Error(err))
 			return nil, fmt.Errorf("%w: ошибка сканирования: %v", ErrDatabase, err)
@@ -132,6 +395,22 @@ func (r *pgxTaskRepository) GetTasksByUserID(ctx context.Context, userID uuid.UU
 	return tasks, nil
 }
 
+var likeEscaper = strings.NewReplacer(`\`, `\\`, `%`, `\%`, `_`, `\_`)
+
+func escapeLikePattern(s string) string {
+	return likeEscaper.Replace(s)
+}
+
+func isNumericLiteral(s string) bool {
+	_, err := strconv.ParseFloat(s, 64)
+	return err == nil && strings.Trim(s, "0123456789.") == ""
+}
+
+// numericLiteralPattern ищет число целиком, чтобы "1.08" не находило "11.085".
+func numericLiteralPattern(literal string) string {
+	return `(^|[^0-9.])` + regexp.QuoteMeta(literal) + `($|[^0-9.])`
+}
+
 func (r *pgxTaskRepository) UpdateTaskStatus(ctx context.Context, taskID uuid.UUID, status string) error {
 	query := `UPDATE tasks SET status = $1, updated_at = NOW() WHERE id = $2`
 	commandTag, err := r.db.Exec(ctx, query, status, taskID)
@@ -147,7 +426,7 @@ func (r *pgxTaskRepository) UpdateTaskStatus(ctx context.Context, taskID uuid.UU
 }
 
 func (r *pgxTaskRepository) SetTaskResult(ctx context.Context, taskID uuid.UUID, result float64) error {
-	query := `UPDATE tasks SET status = $1, result = $2, error_message = NULL, updated_at = NOW() WHERE id = $3`
+	query := `UPDATE tasks SET status = $1, result = $2, error_message = NULL, error_code = NULL, completed_at = NOW(), updated_at = NOW() WHERE id = $3`
 	commandTag, err := r.db.Exec(ctx, query, StatusCompleted, result, taskID)
 	if err != nil {
 		r.log.Error("Ошибка установки результата задачи", zap.Stringer("taskID", taskID), zap.Float64("result", result), zap.Error(err))
@@ -160,16 +439,336 @@ func (r *pgxTaskRepository) SetTaskResult(ctx context.Context, taskID uuid.UUID,
 	return nil
 }
 
-func (r *pgxTaskRepository) SetTaskError(ctx context.Context, taskID uuid.UUID, errorMessage string) error {
-	query := `UPDATE tasks SET status = $1, error_message = $2, result = NULL, updated_at = NOW() WHERE id = $3`
-	commandTag, err := r.db.Exec(ctx, query, StatusFailed, errorMessage, taskID)
+func (r *pgxTaskRepository) SetTaskError(ctx context.Context, taskID uuid.UUID, errorCode, errorMessage string) error {
+	query := `UPDATE tasks SET status = $1, error_message = $2, error_code = $3, result = NULL, completed_at = NOW(), updated_at = NOW() WHERE id = $4`
+	commandTag, err := r.db.Exec(ctx, query, StatusFailed, errorMessage, errorCode, taskID)
 	if err != nil {
-		r.log.Error("Ошибка установки ошибки задачи", zap.Stringer("taskID", taskID), zap.String("errorMessage", errorMessage), zap.Error(err))
+		r.log.Error("Ошибка установки ошибки задачи", zap.Stringer("taskID", taskID), zap.String("errorCode", errorCode), zap.String("errorMessage", errorMessage), zap.Error(err))
 		return fmt.Errorf("%w: %v", ErrDatabase, err)
 	}
 	if commandTag.RowsAffected() == 0 {
 		return ErrTaskNotFound
 	}
-	r.log.Info("Ошибка задачи установлена", zap.Stringer("taskID", taskID), zap.String("errorMessage", errorMessage))
+	r.log.Info("Ошибка задачи установлена", zap.Stringer("taskID", taskID), zap.String("errorCode", errorCode), zap.String("errorMessage", errorMessage))
 	return nil
 }
+
+func (r *pgxTaskRepository) FailUnfinishedTask(ctx context.Context, taskID uuid.UUID, errorCode, errorMessage string) error {
+	query := `
+        UPDATE tasks SET status = $1, error_message = $2, error_code = $3, result = NULL, completed_at = NOW(), updated_at = NOW()
+        WHERE id = $4 AND status IN ($5, $6)
+    `
+	commandTag, err := r.db.Exec(ctx, query, StatusFailed, errorMessage, errorCode, taskID, StatusPending, StatusProcessing)
+	if err != nil {
+		r.log.Error("Ошибка принудительного завершения задачи", zap.Stringer("taskID", taskID), zap.String("errorCode", errorCode), zap.Error(err))
+		return fmt.Errorf("%w: %v", ErrDatabase, err)
+	}
+	if commandTag.RowsAffected() == 0 {
+		return ErrTaskStateConflict
+	}
+	r.log.Info("Задача принудительно завершена", zap.Stringer("taskID", taskID), zap.String("errorCode", errorCode), zap.String("errorMessage", errorMessage))
+	return nil
+}
+
+func (r *pgxTaskRepository) ResetTaskForRetry(ctx context.Context, taskID uuid.UUID) error {
+	query := `
+        UPDATE tasks SET status = $1, result = NULL, error_message = NULL, error_code = NULL, completed_at = NULL, updated_at = NOW()
+        WHERE id = $2 AND status IN ($3, $4) AND deleted_at IS NULL
+    `
+	commandTag, err := r.db.Exec(ctx, query, StatusPending, taskID, StatusCompleted, StatusFailed)
+	if err != nil {
+		r.log.Error("Ошибка сброса задачи для повторного вычисления", zap.Stringer("taskID", taskID), zap.Error(err))
+		return fmt.Errorf("%w: %v", ErrDatabase, err)
+	}
+	if commandTag.RowsAffected() == 0 {
+		return ErrTaskNotRetryable
+	}
+	r.log.Info("Задача сброшена для повторного вычисления", zap.Stringer("taskID", taskID))
+	return nil
+}
+
+func (r *pgxTaskRepository) RevertTaskRetry(ctx context.Context, previous Task, attemptID uuid.UUID) error {
+	query := `
+        WITH removed_attempt AS (
+            DELETE FROM task_attempts WHERE id = $6 AND task_id = $5
+        )
+        UPDATE tasks SET status = $1, result = $2, error_message = $3, error_code = $4, completed_at = $8, updated_at = NOW()
+        WHERE id = $5 AND status = $7
+    `
+	commandTag, err := r.db.Exec(ctx, query,
+		previous.Status, previous.Result, previous.ErrorMessage, previous.ErrorCode, previous.ID, attemptID, StatusPending, previous.CompletedAt)
+	if err != nil {
+		r.log.Error("Ошибка отмены перезапуска задачи", zap.Stringer("taskID", previous.ID), zap.Error(err))
+		return fmt.Errorf("%w: %v", ErrDatabase, err)
+	}
+	if commandTag.RowsAffected() == 0 {
+		return ErrTaskStateConflict
+	}
+	r.log.Info("Перезапуск задачи отменен, прежний результат восстановлен", zap.Stringer("taskID", previous.ID), zap.String("status", previous.Status))
+	return nil
+}
+
+func (r *pgxTaskRepository) CreateAttempt(ctx context.Context, taskID uuid.UUID) (*TaskAttempt, error) {
+	query := `
+        INSERT INTO task_attempts (task_id, attempt_number, status)
+        SELECT $1, COALESCE(MAX(attempt_number), 0) + 1, $2 FROM task_attempts WHERE task_id = $1
+        RETURNING id, attempt_number, created_at
+    `
+	attempt := TaskAttempt{TaskID: taskID, Status: StatusPending}
+	err := r.db.QueryRow(ctx, query, taskID, StatusPending).Scan(&attempt.ID, &attempt.AttemptNumber, &attempt.CreatedAt)
+	if err != nil {
+		r.log.Error("Не удалось создать попытку вычисления задачи", zap.Stringer("taskID", taskID), zap.Error(err))
+		return nil, fmt.Errorf("%w: не удалось вставить попытку: %v", ErrDatabase, err)
+	}
+	r.log.Info("Попытка вычисления задачи создана",
+		zap.Stringer("taskID", taskID),
+		zap.Stringer("attemptID", attempt.ID),
+		zap.Int("attemptNumber", attempt.AttemptNumber),
+	)
+	return &attempt, nil
+}
+
+func (r *pgxTaskRepository) StartAttempt(ctx context.Context, attemptID uuid.UUID) error {
+	query := `UPDATE task_attempts SET status = $1, started_at = NOW() WHERE id = $2`
+	commandTag, err := r.db.Exec(ctx, query, StatusProcessing, attemptID)
+	if err != nil {
+		r.log.Error("Ошибка отметки начала попытки", zap.Stringer("attemptID", attemptID), zap.Error(err))
+		return fmt.Errorf("%w: %v", ErrDatabase, err)
+	}
+	if commandTag.RowsAffected() == 0 {
+		return ErrTaskNotFound
+	}
+	return nil
+}
+
+func (r *pgxTaskRepository) FinishAttempt(ctx context.Context, attemptID uuid.UUID, result *float64, errorMessage *string) error {
+	status := StatusCompleted
+	if errorMessage != nil {
+		status = StatusFailed
+		result = nil
+	}
+	query := `UPDATE task_attempts SET status = $1, result = $2, error_message = $3, finished_at = NOW() WHERE id = $4`
+	commandTag, err := r.db.Exec(ctx, query, status, result, errorMessage, attemptID)
+	if err != nil {
+		r.log.Error("Ошибка завершения попытки", zap.Stringer("attemptID", attemptID), zap.Error(err))
+		return fmt.Errorf("%w: %v", ErrDatabase, err)
+	}
+	if commandTag.RowsAffected() == 0 {
+		return ErrTaskNotFound
+	}
+	r.log.Info("Попытка вычисления завершена", zap.Stringer("attemptID", attemptID), zap.String("status", status))
+	return nil
+}
+
+func (r *pgxTaskRepository) GetAttemptsByTaskID(ctx context.Context, taskID uuid.UUID) ([]TaskAttempt, error) {
+	query := `
+        SELECT id, task_id, attempt_number, status, result, error_message, started_at, finished_at, created_at
+        FROM task_attempts
+        WHERE task_id = $1
+        ORDER BY attempt_number
+    `
+	rows, err := r.db.Query(ctx, query, taskID)
+	if err != nil {
+		r.log.Error("Ошибка получения попыток задачи из БД", zap.Stringer("taskID", taskID), zap.Error(err))
+		return nil, fmt.Errorf("%w: %v", ErrDatabase, err)
+	}
+	defer rows.Close()
+
+	var attempts []TaskAttempt
+	for rows.Next() {
+		var a TaskAttempt
+		if err := rows.Scan(
+			&a.ID, &a.TaskID, &a.AttemptNumber, &a.Status, &a.Result,
+			&a.ErrorMessage, &a.StartedAt, &a.FinishedAt, &a.CreatedAt,
+		); err != nil {
+			r.log.Error("Ошибка сканирования строки попытки", zap.Stringer("taskID", taskID), zap.Error(err))
+			return nil, fmt.Errorf("%w: ошибка сканирования: %v", ErrDatabase, err)
+		}
+		attempts = append(attempts, a)
+	}
+
+	if err = rows.Err(); err != nil {
+		r.log.Error("Ошибка после итерации по строкам попыток", zap.Stringer("taskID", taskID), zap.Error(err))
+		return nil, fmt.Errorf("%w: ошибка итерации: %v", ErrDatabase, err)
+	}
+
+	return attempts, nil
+}
+
+const operationsInsertBatchSize = 500
+
+func (r *pgxTaskRepository) SaveOperations(ctx context.Context, taskID, attemptID uuid.UUID, operations []TaskOperation) error {
+	for start := 0; start < len(operations); start += operationsInsertBatchSize {
+		end := min(start+operationsInsertBatchSize, len(operations))
+		batch := operations[start:end]
+
+		var sb strings.Builder
+		sb.WriteString(`INSERT INTO task_operations (task_id, attempt_id, seq, operation_id, node_path, symbol, operand_a, operand_b, result, error_message, worker_address, started_at, duration_ms) VALUES `)
+		args := make([]any, 0, len(batch)*13)
+		for i, op := range batch {
+			if i > 0 {
+				sb.WriteString(", ")
+			}
+			n := len(args)
+			fmt.Fprintf(&sb, "($%d, $%d, $%d, $%d, $%d, $%d, $%d, $%d, $%d, $%d, $%d, $%d, $%d)",
+				n+1, n+2, n+3, n+4, n+5, n+6, n+7, n+8, n+9, n+10, n+11, n+12, n+13)
+			args = append(args, taskID, attemptID, op.Seq, op.OperationID, op.NodePath, op.Symbol,
+				op.OperandA, op.OperandB, op.Result, op.ErrorMessage, op.WorkerAddress, op.StartedAt,
+				float64(op.Duration)/float64(time.Millisecond))
+		}
+
+		if _, err := r.db.Exec(ctx, sb.String(), args...); err != nil {
+			r.log.Error("Ошибка сохранения трассировки операций",
+				zap.Stringer("taskID", taskID),
+				zap.Stringer("attemptID", attemptID),
+				zap.Error(err),
+			)
+			return fmt.Errorf("%w: %v", ErrDatabase, err)
+		}
+	}
+	r.log.Debug("Трассировка операций сохранена",
+		zap.Stringer("taskID", taskID),
+		zap.Stringer("attemptID", attemptID),
+		zap.Int("count", len(operations)),
+	)
+	return nil
+}
+
+// GetLatestOperationsByTaskID возвращает операции последней попытки задачи в порядке их запуска.
+func (r *pgxTaskRepository) GetLatestOperationsByTaskID(ctx context.Context, taskID uuid.UUID) ([]TaskOperation, error) {
+	query := `
+        SELECT o.task_id, o.attempt_id, a.attempt_number, o.seq, o.operation_id, o.node_path, o.symbol,
+               o.operand_a, o.operand_b, o.result, o.error_message, COALESCE(o.worker_address, ''), o.started_at, o.duration_ms
+        FROM task_operations o
+        JOIN task_attempts a ON a.id = o.attempt_id
+        WHERE o.task_id = $1
+          AND a.attempt_number = (SELECT MAX(attempt_number) FROM task_attempts WHERE task_id = $1)
+        ORDER BY o.seq
+    `
+	rows, err := r.db.Query(ctx, query, taskID)
+	if err != nil {
+		r.log.Error("Ошибка получения трассировки операций из БД", zap.Stringer("taskID", taskID), zap.Error(err))
+		return nil, fmt.Errorf("%w: %v", ErrDatabase, err)
+	}
+	defer rows.Close()
+
+	var operations []TaskOperation
+	for rows.Next() {
+		var op TaskOperation
+		var durationMs float64
+		if err := rows.Scan(
+			&op.TaskID, &op.AttemptID, &op.AttemptNumber, &op.Seq, &op.OperationID, &op.NodePath, &op.Symbol,
+			&op.OperandA, &op.OperandB, &op.Result, &op.ErrorMessage, &op.WorkerAddress, &op.StartedAt, &durationMs,
+		); err != nil {
+			r.log.Error("Ошибка сканирования строки операции", zap.Stringer("taskID", taskID), zap.Error(err))
+			return nil, fmt.Errorf("%w: ошибка сканирования: %v", ErrDatabase, err)
+		}
+		op.Duration = time.Duration(durationMs * float64(time.Millisecond))
+		operations = append(operations, op)
+	}
+
+	if err = rows.Err(); err != nil {
+		r.log.Error("Ошибка после итерации по строкам операций", zap.Stringer("taskID", taskID), zap.Error(err))
+		return nil, fmt.Errorf("%w: ошибка итерации: %v", ErrDatabase, err)
+	}
+
+	return operations, nil
+}
+
+func (r *pgxTaskRepository) SoftDeleteTask(ctx context.Context, taskID uuid.UUID) error {
+	query := `UPDATE tasks SET deleted_at = NOW() WHERE id = $1 AND deleted_at IS NULL AND status IN ($2, $3)`
+	commandTag, err := r.db.Exec(ctx, query, taskID, StatusCompleted, StatusFailed)
+	if err != nil {
+		r.log.Error("Ошибка перемещения задачи в корзину", zap.Stringer("taskID", taskID), zap.Error(err))
+		return fmt.Errorf("%w: %v", ErrDatabase, err)
+	}
+	if commandTag.RowsAffected() == 0 {
+		return ErrTaskNotFound
+	}
+	r.log.Info("Задача перемещена в корзину", zap.Stringer("taskID", taskID))
+	return nil
+}
+
+func (r *pgxTaskRepository) RestoreTask(ctx context.Context, taskID uuid.UUID) error {
+	query := `UPDATE tasks SET deleted_at = NULL WHERE id = $1 AND deleted_at IS NOT NULL`
+	commandTag, err := r.db.Exec(ctx, query, taskID)
+	if err != nil {
+		r.log.Error("Ошибка восстановления задачи из корзины", zap.Stringer("taskID", taskID), zap.Error(err))
+		return fmt.Errorf("%w: %v", ErrDatabase, err)
+	}
+	if commandTag.RowsAffected() == 0 {
+		return ErrTaskNotFound
+	}
+	r.log.Info("Задача восстановлена из корзины", zap.Stringer("taskID", taskID))
+	return nil
+}
+
+func (r *pgxTaskRepository) DeleteTask(ctx context.Context, taskID uuid.UUID) error {
+	query := `DELETE FROM tasks WHERE id = $1 AND status IN ($2, $3)`
+	commandTag, err := r.db.Exec(ctx, query, taskID, StatusCompleted, StatusFailed)
+	if err != nil {
+		r.log.Error("Ошибка удаления задачи", zap.Stringer("taskID", taskID), zap.Error(err))
+		return fmt.Errorf("%w: %v", ErrDatabase, err)
+	}
+	if commandTag.RowsAffected() == 0 {
+		return ErrTaskNotFound
+	}
+	r.log.Info("Задача удалена окончательно", zap.Stringer("taskID", taskID))
+	return nil
+}
+
+func (r *pgxTaskRepository) DeleteRejectedTask(ctx context.Context, taskID uuid.UUID) error {
+	query := `DELETE FROM tasks WHERE id = $1 AND status = $2`
+	commandTag, err := r.db.Exec(ctx, query, taskID, StatusPending)
+	if err != nil {
+		r.log.Error("Ошибка удаления отклоненной задачи", zap.Stringer("taskID", taskID), zap.Error(err))
+		return fmt.Errorf("%w: %v", ErrDatabase, err)
+	}
+	if commandTag.RowsAffected() == 0 {
+		return ErrTaskStateConflict
+	}
+	r.log.Info("Задача, не поставленная в очередь, удалена", zap.Stringer("taskID", taskID))
+	return nil
+}
+
+func (r *pgxTaskRepository) PurgeTasks(ctx context.Context, criteria PurgeCriteria) (int64, error) {
+	var args []any
+	addArg := func(v any) string {
+		args = append(args, v)
+		return fmt.Sprintf("$%d", len(args))
+	}
+
+	var expired []string
+	if criteria.CompletedBefore != nil {
+		expired = append(expired, fmt.Sprintf("(status = %s AND completed_at < %s)", addArg(StatusCompleted), addArg(*criteria.CompletedBefore)))
+	}
+	if criteria.FailedBefore != nil {
+		expired = append(expired, fmt.Sprintf("(status = %s AND completed_at < %s)", addArg(StatusFailed), addArg(*criteria.FailedBefore)))
+	}
+	if criteria.DeletedBefore != nil {
+		expired = append(expired, "deleted_at < "+addArg(*criteria.DeletedBefore))
+	}
+	if len(expired) == 0 {
+		return 0, nil
+	}
+
+	conditions := []string{"(" + strings.Join(expired, " OR ") + ")"}
+	if len(criteria.UserIDs) > 0 {
+		conditions = append(conditions, "user_id = ANY("+addArg(criteria.UserIDs)+")")
+	}
+	if len(criteria.ExcludeUserIDs) > 0 {
+		conditions = append(conditions, "NOT (user_id = ANY("+addArg(criteria.ExcludeUserIDs)+"))")
+	}
+
+	query := `
+        DELETE FROM tasks WHERE id IN (
+            SELECT id FROM tasks WHERE ` + strings.Join(conditions, " AND ") + `
+            LIMIT ` + addArg(criteria.Limit) + `
+        )`
+	commandTag, err := r.db.Exec(ctx, query, args...)
+	if err != nil {
+		r.log.Error("Ошибка очистки устаревших задач", zap.Error(err))
+		return 0, fmt.Errorf("%w: %v", ErrDatabase, err)
+	}
+	return commandTag.RowsAffected(), nil
+}
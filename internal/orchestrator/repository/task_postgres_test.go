@@ -25,21 +25,131 @@ func TestPgxTaskRepository_CreateTask(t *testing.T) {
 
 	userID := uuid.New()
 	expression := "2+2"
+	requestID := "req-123"
 	expectedTaskID := uuid.New()
 
-	mock.ExpectQuery(regexp.QuoteMeta(`INSERT INTO tasks (user_id, expression, status)
-            VALUES ($1, $2, $3)
+	mock.ExpectQuery(regexp.QuoteMeta(`INSERT INTO tasks (user_id, expression, status, callback_url, request_id)
+            VALUES ($1, $2, $3, $4, $5)
             RETURNING id`)).
-		WithArgs(userID, expression, StatusPending).
+		WithArgs(userID, expression, StatusPending, (*string)(nil), &requestID).
 		WillReturnRows(pgxmock.NewRows([]string{"id"}).AddRow(expectedTaskID))
 
-	taskID, err := repo.CreateTask(context.Background(), userID, expression)
+	taskID, err := repo.CreateTask(context.Background(), NewTask{UserID: userID, Expression: expression, RequestID: &requestID})
 
 	require.NoError(t, err, "CreateTask не должен возвращать ошибку")
 	assert.Equal(t, expectedTaskID, taskID, "Возвращенный taskID не совпадает с ожидаемым")
 	assert.NoError(t, mock.ExpectationsWereMet(), "Не все ожидания мок-пула были выполнены")
 }
 
+func TestPgxTaskRepository_CreateTasks(t *testing.T) {
+	mock, err := pgxmock.NewPool()
+	require.NoError(t, err)
+	defer mock.Close()
+
+	repo := NewPgxTaskRepository(mock, zap.NewNop())
+
+	userID := uuid.New()
+	callbackURL := "https://example.com/hook"
+	firstID, secondID := uuid.New(), uuid.New()
+	insertQuery := regexp.QuoteMeta(`INSERT INTO tasks (user_id, expression, status, callback_url, request_id)
+            VALUES ($1, $2, $3, $4, $5)
+            RETURNING id`)
+
+	mock.ExpectBegin()
+	batch := mock.ExpectBatch()
+	batch.ExpectQuery(insertQuery).
+		WithArgs(userID, "1+1", StatusPending, (*string)(nil), (*string)(nil)).
+		WillReturnRows(pgxmock.NewRows([]string{"id"}).AddRow(firstID))
+	batch.ExpectQuery(insertQuery).
+		WithArgs(userID, "2*3", StatusPending, &callbackURL, (*string)(nil)).
+		WillReturnRows(pgxmock.NewRows([]string{"id"}).AddRow(secondID))
+	mock.ExpectCommit()
+
+	taskIDs, err := repo.CreateTasks(context.Background(), []NewTask{
+		{UserID: userID, Expression: "1+1"},
+		{UserID: userID, Expression: "2*3", CallbackURL: &callbackURL},
+	})
+
+	require.NoError(t, err)
+	assert.Equal(t, []uuid.UUID{firstID, secondID}, taskIDs)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestPgxTaskRepository_CreateTasks_RollbackOnError(t *testing.T) {
+	mock, err := pgxmock.NewPool()
+	require.NoError(t, err)
+	defer mock.Close()
+
+	repo := NewPgxTaskRepository(mock, zap.NewNop())
+
+	userID := uuid.New()
+	dbError := errors.New("нарушение ограничения")
+	insertQuery := regexp.QuoteMeta(`INSERT INTO tasks (user_id, expression, status, callback_url, request_id)`)
+
+	mock.ExpectBegin()
+	batch := mock.ExpectBatch()
+	batch.ExpectQuery(insertQuery).
+		WithArgs(userID, "1+1", StatusPending, (*string)(nil), (*string)(nil)).
+		WillReturnRows(pgxmock.NewRows([]string{"id"}).AddRow(uuid.New()))
+	batch.ExpectQuery(insertQuery).
+		WithArgs(userID, "2*3", StatusPending, (*string)(nil), (*string)(nil)).
+		WillReturnError(dbError)
+	mock.ExpectRollback()
+
+	taskIDs, err := repo.CreateTasks(context.Background(), []NewTask{
+		{UserID: userID, Expression: "1+1"},
+		{UserID: userID, Expression: "2*3"},
+	})
+
+	require.Error(t, err)
+	assert.ErrorIs(t, err, ErrDatabase)
+	assert.Nil(t, taskIDs)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestPgxTaskRepository_CreateIdempotentTask_KeyTaken(t *testing.T) {
+	mock, err := pgxmock.NewPool()
+	require.NoError(t, err)
+	defer mock.Close()
+
+	repo := NewPgxTaskRepository(mock, zap.NewNop())
+	userID := uuid.New()
+
+	mock.ExpectQuery(regexp.QuoteMeta(`ON CONFLICT (user_id, idempotency_key) WHERE idempotency_key IS NOT NULL DO NOTHING`)).
+		WithArgs(userID, "2+2", StatusPending, (*string)(nil), (*string)(nil), "key-1", "hash").
+		WillReturnRows(pgxmock.NewRows([]string{"id"}))
+
+	taskID, err := repo.CreateIdempotentTask(context.Background(), NewTask{UserID: userID, Expression: "2+2"}, "key-1", "hash")
+
+	assert.ErrorIs(t, err, ErrIdempotencyKeyTaken)
+	assert.Equal(t, uuid.Nil, taskID)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestPgxTaskRepository_GetIdempotencyRecord(t *testing.T) {
+	mock, err := pgxmock.NewPool()
+	require.NoError(t, err)
+	defer mock.Close()
+
+	repo := NewPgxTaskRepository(mock, zap.NewNop())
+	userID := uuid.New()
+	taskID := uuid.New()
+	createdAt := time.Now().UTC().Truncate(time.Microsecond)
+	query := regexp.QuoteMeta(`WHERE user_id = $1 AND idempotency_key = $2`)
+
+	mock.ExpectQuery(query).WithArgs(userID, "key-1").
+		WillReturnRows(pgxmock.NewRows([]string{"id", "request_hash", "created_at"}).AddRow(taskID, "hash", createdAt))
+	mock.ExpectQuery(query).WithArgs(userID, "key-2").WillReturnError(pgx.ErrNoRows)
+
+	record, err := repo.GetIdempotencyRecord(context.Background(), userID, "key-1")
+	require.NoError(t, err)
+	assert.Equal(t, &IdempotencyRecord{TaskID: taskID, RequestHash: "hash", CreatedAt: createdAt}, record)
+
+	_, err = repo.GetIdempotencyRecord(context.Background(), userID, "key-2")
+	assert.ErrorIs(t, err, ErrTaskNotFound)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
 func TestPgxTaskRepository_CreateTask_DBError(t *testing.T) {
 	mock, err := pgxmock.NewPool()
 	require.NoError(t, err)
@@ -52,13 +162,13 @@ func TestPgxTaskRepository_CreateTask_DBError(t *testing.T) {
 	expression := "3*3"
 	dbError := errors.New("какая-то ошибка бд")
 
-	mock.ExpectQuery(regexp.QuoteMeta(`INSERT INTO tasks (user_id, expression, status)
-            VALUES ($1, $2, $3)
+	mock.ExpectQuery(regexp.QuoteMeta(`INSERT INTO tasks (user_id, expression, status, callback_url, request_id)
+            VALUES ($1, $2, $3, $4, $5)
             RETURNING id`)).
-		WithArgs(userID, expression, StatusPending).
+		WithArgs(userID, expression, StatusPending, (*string)(nil), (*string)(nil)).
 		WillReturnError(dbError)
 
-	taskID, err := repo.CreateTask(context.Background(), userID, expression)
+	taskID, err := repo.CreateTask(context.Background(), NewTask{UserID: userID, Expression: expression})
 
 	require.Error(t, err, "CreateTask должен вернуть ошибку")
 	assert.True(t, errors.Is(err, ErrDatabase), "Ошибка должна быть обернута в ErrDatabase")
@@ -85,13 +195,15 @@ func TestPgxTaskRepository_GetTaskByID(t *testing.T) {
 		ErrorMessage: nil,
 		CreatedAt:    now.Add(-time.Hour),
 		UpdatedAt:    now,
+		CompletedAt:  &now,
+		RequestID:    strPtr("req-456"),
 	}
 
-	rows := pgxmock.NewRows([]string{"id", "user_id", "expression", "status", "result", "error_message", "created_at", "updated_at"}).
+	rows := pgxmock.NewRows([]string{"id", "user_id", "expression", "status", "result", "error_message", "error_code", "created_at", "updated_at", "completed_at", "deleted_at", "callback_url", "request_id"}).
 		AddRow(expectedTask.ID, expectedTask.UserID, expectedTask.Expression, expectedTask.Status,
-			expectedTask.Result, expectedTask.ErrorMessage, expectedTask.CreatedAt, expectedTask.UpdatedAt)
+			expectedTask.Result, expectedTask.ErrorMessage, nil, expectedTask.CreatedAt, expectedTask.UpdatedAt, expectedTask.CompletedAt, nil, nil, expectedTask.RequestID)
 
-	mock.ExpectQuery(regexp.QuoteMeta(`SELECT id, user_id, expression, status, result, error_message, created_at, updated_at
+	mock.ExpectQuery(regexp.QuoteMeta(`SELECT id, user_id, expression, status, result, error_message, error_code, created_at, updated_at, completed_at, deleted_at, callback_url, request_id
         FROM tasks
         WHERE id = $1`)).
 		WithArgs(taskID).
@@ -106,9 +218,13 @@ func TestPgxTaskRepository_GetTaskByID(t *testing.T) {
 	assert.Equal(t, expectedTask.Status, task.Status)
 	assert.EqualValues(t, expectedTask.Result, task.Result)
 	assert.EqualValues(t, expectedTask.ErrorMessage, task.ErrorMessage)
+	assert.Nil(t, task.ErrorCode)
+	assert.Equal(t, expectedTask.RequestID, task.RequestID)
 
 	assert.WithinDuration(t, expectedTask.CreatedAt, task.CreatedAt, time.Second, "CreatedAt не совпадает")
 	assert.WithinDuration(t, expectedTask.UpdatedAt, task.UpdatedAt, time.Second, "UpdatedAt не совпадает")
+	require.NotNil(t, task.CompletedAt)
+	assert.WithinDuration(t, *expectedTask.CompletedAt, *task.CompletedAt, time.Second, "CompletedAt не совпадает")
 
 	assert.NoError(t, mock.ExpectationsWereMet())
 }
@@ -119,7 +235,7 @@ func TestPgxTaskRepository_GetTaskByID_NotFound(t *testing.T) {
 	repo := NewPgxTaskRepository(mock, zap.NewNop())
 	taskID := uuid.New()
 
-	mock.ExpectQuery(regexp.QuoteMeta(`SELECT id, user_id, expression, status, result, error_message, created_at, updated_at
+	mock.ExpectQuery(regexp.QuoteMeta(`SELECT id, user_id, expression, status, result, error_message, error_code, created_at, updated_at, completed_at, deleted_at, callback_url, request_id
         FROM tasks
         WHERE id = $1`)).
 		WithArgs(taskID).
@@ -145,31 +261,97 @@ func TestPgxTaskRepository_GetTasksByUserID(t *testing.T) {
 	expectedTasks := []Task{
 		{ID: uuid.New(), UserID: userID, Expression: "1+1", Status: StatusCompleted, Result: floatPtr(2.0), CreatedAt: ts1, UpdatedAt: ts1},
 		{ID: uuid.New(), UserID: userID, Expression: "2*2", Status: StatusProcessing, CreatedAt: ts2, UpdatedAt: ts2},
+		{ID: uuid.New(), UserID: userID, Expression: "1/0", Status: StatusFailed, ErrorMessage: strPtr("деление на ноль"), ErrorCode: strPtr(ErrorCodeDivisionByZero), CreatedAt: ts2, UpdatedAt: ts2},
 	}
 
-	rows := pgxmock.NewRows([]string{"id", "user_id", "expression", "status", "result", "error_message", "created_at", "updated_at"})
+	rows := pgxmock.NewRows([]string{"id", "user_id", "expression", "status", "result", "error_message", "error_code", "created_at", "updated_at", "deleted_at"})
 	for _, taskData := range expectedTasks {
-		rows.AddRow(taskData.ID, taskData.UserID, taskData.Expression, taskData.Status, taskData.Result, taskData.ErrorMessage, taskData.CreatedAt, taskData.UpdatedAt)
+		rows.AddRow(taskData.ID, taskData.UserID, taskData.Expression, taskData.Status, taskData.Result, taskData.ErrorMessage, taskData.ErrorCode, taskData.CreatedAt, taskData.UpdatedAt, nil)
 	}
 
-	mock.ExpectQuery(regexp.QuoteMeta(`SELECT id, user_id, expression, status, result, error_message, created_at, updated_at
+	mock.ExpectQuery(regexp.QuoteMeta(`SELECT id, user_id, expression, status, result, error_message, error_code, created_at, updated_at, deleted_at
         FROM tasks
-        WHERE user_id = $1
-        ORDER BY created_at DESC`)).
+        WHERE user_id = $1 AND deleted_at IS NULL
+        ORDER BY created_at DESC, id DESC`)).
 		WithArgs(userID).
 		WillReturnRows(rows)
 
-	tasks, err := repo.GetTasksByUserID(context.Background(), userID)
+	tasks, err := repo.GetTasksByUserID(context.Background(), userID, TaskListFilter{})
 	require.NoError(t, err)
 	require.Len(t, tasks, len(expectedTasks))
 	for i := range tasks {
 		assert.Equal(t, expectedTasks[i].ID, tasks[i].ID)
 		assert.Equal(t, expectedTasks[i].Expression, tasks[i].Expression)
+		assert.Equal(t, expectedTasks[i].ErrorCode, tasks[i].ErrorCode)
 
 	}
 	assert.NoError(t, mock.ExpectationsWereMet())
 }
 
+func TestPgxTaskRepository_GetTasksByUserID_FilteredPage(t *testing.T) {
+	mock, _ := pgxmock.NewPool()
+	defer mock.Close()
+	repo := NewPgxTaskRepository(mock, zap.NewNop())
+
+	userID := uuid.New()
+	from := time.Now().Add(-24 * time.Hour).Truncate(time.Microsecond)
+	cursor := TaskCursor{CreatedAt: time.Now().Add(-time.Hour).Truncate(time.Microsecond), ID: uuid.New()}
+	statuses := []string{StatusCompleted, StatusFailed}
+
+	rows := pgxmock.NewRows([]string{"id", "user_id", "expression", "status", "result", "error_message", "error_code", "created_at", "updated_at", "deleted_at"}).
+		AddRow(uuid.New(), userID, "1+1", StatusCompleted, floatPtr(2.0), nil, nil, cursor.CreatedAt.Add(time.Minute), cursor.CreatedAt, nil)
+
+	mock.ExpectQuery(regexp.QuoteMeta(`WHERE user_id = $1 AND deleted_at IS NULL AND status = ANY($2) AND created_at >= $3 AND (created_at, id) > ($4, $5)
+        ORDER BY created_at ASC, id ASC LIMIT $6`)).
+		WithArgs(userID, statuses, from, cursor.CreatedAt, cursor.ID, 11).
+		WillReturnRows(rows)
+
+	tasks, err := repo.GetTasksByUserID(context.Background(), userID, TaskListFilter{
+		Statuses:    statuses,
+		CreatedFrom: &from,
+		Ascending:   true,
+		After:       &cursor,
+		Limit:       11,
+	})
+	require.NoError(t, err)
+	require.Len(t, tasks, 1)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestPgxTaskRepository_GetTasksByUserID_Search(t *testing.T) {
+	userID := uuid.New()
+	columns := []string{"id", "user_id", "expression", "status", "result", "error_message", "error_code", "created_at", "updated_at", "deleted_at"}
+
+	cases := []struct {
+		name        string
+		search      string
+		condition   string
+		expectedArg string
+	}{
+		{name: "numeric literal", search: "1.0825", condition: "expression ~ $2", expectedArg: `(^|[^0-9.])1\.0825($|[^0-9.])`},
+		{name: "operator", search: "^", condition: "expression ILIKE $2", expectedArg: "%^%"},
+		{name: "substring with wildcard", search: "50%_", condition: "expression ILIKE $2", expectedArg: `%50\%\_%`},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			mock, _ := pgxmock.NewPool()
+			defer mock.Close()
+			repo := NewPgxTaskRepository(mock, zap.NewNop())
+
+			mock.ExpectQuery(regexp.QuoteMeta(`WHERE user_id = $1 AND deleted_at IS NULL AND `+tc.condition+` AND status = ANY($3)`)).
+				WithArgs(userID, tc.expectedArg, []string{StatusCompleted}).
+				WillReturnRows(pgxmock.NewRows(columns))
+
+			_, err := repo.GetTasksByUserID(context.Background(), userID, TaskListFilter{
+				Search:   " " + tc.search + " ",
+				Statuses: []string{StatusCompleted},
+			})
+			require.NoError(t, err)
+			assert.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
+
 func TestPgxTaskRepository_UpdateTaskStatus(t *testing.T) {
 	mock, _ := pgxmock.NewPool()
 	defer mock.Close()
@@ -213,7 +395,7 @@ func TestPgxTaskRepository_SetTaskResult(t *testing.T) {
 	resultVal := 42.0
 
 	mock.ExpectExec(regexp.QuoteMeta(
-		`UPDATE tasks SET status = $1, result = $2, error_message = NULL, updated_at = NOW() WHERE id = $3`)).
+		`UPDATE tasks SET status = $1, result = $2, error_message = NULL, error_code = NULL, completed_at = NOW(), updated_at = NOW() WHERE id = $3`)).
 		WithArgs(StatusCompleted, resultVal, taskID).
 		WillReturnResult(pgxmock.NewResult("UPDATE", 1))
 
@@ -230,15 +412,298 @@ func TestPgxTaskRepository_SetTaskError(t *testing.T) {
 	errMsg := "division by zero"
 
 	mock.ExpectExec(regexp.QuoteMeta(
-		`UPDATE tasks SET status = $1, error_message = $2, result = NULL, updated_at = NOW() WHERE id = $3`)).
-		WithArgs(StatusFailed, errMsg, taskID).
+		`UPDATE tasks SET status = $1, error_message = $2, error_code = $3, result = NULL, completed_at = NOW(), updated_at = NOW() WHERE id = $4`)).
+		WithArgs(StatusFailed, errMsg, ErrorCodeDivisionByZero, taskID).
+		WillReturnResult(pgxmock.NewResult("UPDATE", 1))
+
+	err := repo.SetTaskError(context.Background(), taskID, ErrorCodeDivisionByZero, errMsg)
+	require.NoError(t, err)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestPgxTaskRepository_FailUnfinishedTask(t *testing.T) {
+	taskID := uuid.New()
+	errMsg := "задача принудительно завершена"
+	tests := []struct {
+		name    string
+		rows    int64
+		wantErr error
+	}{
+		{name: "незавершенная задача", rows: 1},
+		{name: "задача уже завершена", rows: 0, wantErr: ErrTaskStateConflict},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mock, _ := pgxmock.NewPool()
+			defer mock.Close()
+			repo := NewPgxTaskRepository(mock, zap.NewNop())
+
+			mock.ExpectExec(regexp.QuoteMeta(`WHERE id = $4 AND status IN ($5, $6)`)).
+				WithArgs(StatusFailed, errMsg, ErrorCodeCancelled, taskID, StatusPending, StatusProcessing).
+				WillReturnResult(pgxmock.NewResult("UPDATE", tt.rows))
+
+			err := repo.FailUnfinishedTask(context.Background(), taskID, ErrorCodeCancelled, errMsg)
+			if tt.wantErr != nil {
+				assert.ErrorIs(t, err, tt.wantErr)
+			} else {
+				assert.NoError(t, err)
+			}
+			assert.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
+
+func TestPgxTaskRepository_ResetTaskForRetry(t *testing.T) {
+	mock, _ := pgxmock.NewPool()
+	defer mock.Close()
+	repo := NewPgxTaskRepository(mock, zap.NewNop())
+	taskID := uuid.New()
+
+	mock.ExpectExec(regexp.QuoteMeta(
+		`UPDATE tasks SET status = $1, result = NULL, error_message = NULL, error_code = NULL, completed_at = NULL, updated_at = NOW()`)).
+		WithArgs(StatusPending, taskID, StatusCompleted, StatusFailed).
+		WillReturnResult(pgxmock.NewResult("UPDATE", 1))
+
+	err := repo.ResetTaskForRetry(context.Background(), taskID)
+	require.NoError(t, err)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestPgxTaskRepository_ResetTaskForRetry_NotRetryable(t *testing.T) {
+	mock, _ := pgxmock.NewPool()
+	defer mock.Close()
+	repo := NewPgxTaskRepository(mock, zap.NewNop())
+	taskID := uuid.New()
+
+	mock.ExpectExec(regexp.QuoteMeta(
+		`UPDATE tasks SET status = $1, result = NULL, error_message = NULL, error_code = NULL, completed_at = NULL, updated_at = NOW()`)).
+		WithArgs(StatusPending, taskID, StatusCompleted, StatusFailed).
+		WillReturnResult(pgxmock.NewResult("UPDATE", 0))
+
+	err := repo.ResetTaskForRetry(context.Background(), taskID)
+	require.Error(t, err)
+	assert.ErrorIs(t, err, ErrTaskNotRetryable)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestPgxTaskRepository_RevertTaskRetry(t *testing.T) {
+	mock, _ := pgxmock.NewPool()
+	defer mock.Close()
+	repo := NewPgxTaskRepository(mock, zap.NewNop())
+	taskID, attemptID := uuid.New(), uuid.New()
+	completedAt := time.Now().Add(-time.Hour)
+	previous := Task{ID: taskID, Status: StatusCompleted, Result: floatPtr(4), CompletedAt: &completedAt}
+
+	mock.ExpectExec(regexp.QuoteMeta(`DELETE FROM task_attempts WHERE id = $6 AND task_id = $5`)).
+		WithArgs(StatusCompleted, floatPtr(4), (*string)(nil), (*string)(nil), taskID, attemptID, StatusPending, &completedAt).
+		WillReturnResult(pgxmock.NewResult("UPDATE", 1))
+
+	err := repo.RevertTaskRetry(context.Background(), previous, attemptID)
+	require.NoError(t, err)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestPgxTaskRepository_DeleteRejectedTask_NotPending(t *testing.T) {
+	mock, _ := pgxmock.NewPool()
+	defer mock.Close()
+	repo := NewPgxTaskRepository(mock, zap.NewNop())
+	taskID := uuid.New()
+
+	mock.ExpectExec(regexp.QuoteMeta(`DELETE FROM tasks WHERE id = $1 AND status = $2`)).
+		WithArgs(taskID, StatusPending).
+		WillReturnResult(pgxmock.NewResult("DELETE", 0))
+
+	err := repo.DeleteRejectedTask(context.Background(), taskID)
+	assert.ErrorIs(t, err, ErrTaskStateConflict)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestPgxTaskRepository_CreateAttempt(t *testing.T) {
+	mock, _ := pgxmock.NewPool()
+	defer mock.Close()
+	repo := NewPgxTaskRepository(mock, zap.NewNop())
+	taskID := uuid.New()
+	attemptID := uuid.New()
+	now := time.Now().Truncate(time.Microsecond)
+
+	mock.ExpectQuery(regexp.QuoteMeta(`INSERT INTO task_attempts (task_id, attempt_number, status)`)).
+		WithArgs(taskID, StatusPending).
+		WillReturnRows(pgxmock.NewRows([]string{"id", "attempt_number", "created_at"}).AddRow(attemptID, 2, now))
+
+	attempt, err := repo.CreateAttempt(context.Background(), taskID)
+	require.NoError(t, err)
+	assert.Equal(t, attemptID, attempt.ID)
+	assert.Equal(t, 2, attempt.AttemptNumber)
+	assert.Equal(t, StatusPending, attempt.Status)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestPgxTaskRepository_FinishAttempt_Failed(t *testing.T) {
+	mock, _ := pgxmock.NewPool()
+	defer mock.Close()
+	repo := NewPgxTaskRepository(mock, zap.NewNop())
+	attemptID := uuid.New()
+	errMsg := "division by zero"
+
+	mock.ExpectExec(regexp.QuoteMeta(
+		`UPDATE task_attempts SET status = $1, result = $2, error_message = $3, finished_at = NOW() WHERE id = $4`)).
+		WithArgs(StatusFailed, (*float64)(nil), &errMsg, attemptID).
 		WillReturnResult(pgxmock.NewResult("UPDATE", 1))
 
-	err := repo.SetTaskError(context.Background(), taskID, errMsg)
+	err := repo.FinishAttempt(context.Background(), attemptID, floatPtr(1), &errMsg)
+	require.NoError(t, err)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestPgxTaskRepository_GetAttemptsByTaskID(t *testing.T) {
+	mock, _ := pgxmock.NewPool()
+	defer mock.Close()
+	repo := NewPgxTaskRepository(mock, zap.NewNop())
+	taskID := uuid.New()
+	now := time.Now().Truncate(time.Microsecond)
+	errMsg := "division by zero"
+
+	rows := pgxmock.NewRows([]string{"id", "task_id", "attempt_number", "status", "result", "error_message", "started_at", "finished_at", "created_at"}).
+		AddRow(uuid.New(), taskID, 1, StatusFailed, nil, &errMsg, &now, &now, now).
+		AddRow(uuid.New(), taskID, 2, StatusCompleted, floatPtr(4), nil, &now, &now, now)
+	mock.ExpectQuery(regexp.QuoteMeta(`FROM task_attempts`)).
+		WithArgs(taskID).
+		WillReturnRows(rows)
+
+	attempts, err := repo.GetAttemptsByTaskID(context.Background(), taskID)
+	require.NoError(t, err)
+	require.Len(t, attempts, 2)
+	assert.Equal(t, 1, attempts[0].AttemptNumber)
+	require.NotNil(t, attempts[0].ErrorMessage)
+	assert.Equal(t, errMsg, *attempts[0].ErrorMessage)
+	require.NotNil(t, attempts[1].Result)
+	assert.Equal(t, 4.0, *attempts[1].Result)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestPgxTaskRepository_SaveOperations(t *testing.T) {
+	mock, _ := pgxmock.NewPool()
+	defer mock.Close()
+	repo := NewPgxTaskRepository(mock, zap.NewNop())
+	taskID, attemptID := uuid.New(), uuid.New()
+	now := time.Now()
+	errMsg := "деление на ноль"
+
+	operations := []TaskOperation{
+		{Seq: 1, OperationID: "op-1", NodePath: "0.0", Symbol: "+", OperandA: 2, OperandB: 3, Result: floatPtr(5), WorkerAddress: "10.0.0.2:50052", StartedAt: now, Duration: 1500 * time.Microsecond},
+		{Seq: 2, OperationID: "op-2", NodePath: "0", Symbol: "/", OperandA: 5, OperandB: 0, ErrorMessage: &errMsg, StartedAt: now, Duration: time.Millisecond},
+	}
+	mock.ExpectExec(regexp.QuoteMeta(`INSERT INTO task_operations`)).
+		WithArgs(
+			taskID, attemptID, 1, "op-1", "0.0", "+", 2.0, 3.0, floatPtr(5), (*string)(nil), "10.0.0.2:50052", now, 1.5,
+			taskID, attemptID, 2, "op-2", "0", "/", 5.0, 0.0, (*float64)(nil), &errMsg, "", now, 1.0,
+		).
+		WillReturnResult(pgxmock.NewResult("INSERT", 2))
+
+	err := repo.SaveOperations(context.Background(), taskID, attemptID, operations)
 	require.NoError(t, err)
 	assert.NoError(t, mock.ExpectationsWereMet())
 }
 
+func TestPgxTaskRepository_GetLatestOperationsByTaskID(t *testing.T) {
+	mock, _ := pgxmock.NewPool()
+	defer mock.Close()
+	repo := NewPgxTaskRepository(mock, zap.NewNop())
+	taskID, attemptID := uuid.New(), uuid.New()
+	now := time.Now().Truncate(time.Microsecond)
+
+	rows := pgxmock.NewRows([]string{"task_id", "attempt_id", "attempt_number", "seq", "operation_id", "node_path", "symbol",
+		"operand_a", "operand_b", "result", "error_message", "worker_address", "started_at", "duration_ms"}).
+		AddRow(taskID, attemptID, 2, 1, "op-1", "0", "neg", 5.0, 0.0, floatPtr(-5), nil, "10.0.0.2:50052", now, 2.5)
+	mock.ExpectQuery(regexp.QuoteMeta(`(SELECT MAX(attempt_number) FROM task_attempts WHERE task_id = $1)`)).
+		WithArgs(taskID).
+		WillReturnRows(rows)
+
+	operations, err := repo.GetLatestOperationsByTaskID(context.Background(), taskID)
+	require.NoError(t, err)
+	require.Len(t, operations, 1)
+	assert.Equal(t, 2, operations[0].AttemptNumber)
+	assert.Equal(t, "neg", operations[0].Symbol)
+	assert.Equal(t, 2500*time.Microsecond, operations[0].Duration)
+	require.NotNil(t, operations[0].Result)
+	assert.Equal(t, -5.0, *operations[0].Result)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestPgxTaskRepository_SoftDeleteTask(t *testing.T) {
+	mock, _ := pgxmock.NewPool()
+	defer mock.Close()
+	repo := NewPgxTaskRepository(mock, zap.NewNop())
+	taskID := uuid.New()
+
+	mock.ExpectExec(regexp.QuoteMeta(
+		`UPDATE tasks SET deleted_at = NOW() WHERE id = $1 AND deleted_at IS NULL AND status IN ($2, $3)`)).
+		WithArgs(taskID, StatusCompleted, StatusFailed).
+		WillReturnResult(pgxmock.NewResult("UPDATE", 1))
+
+	err := repo.SoftDeleteTask(context.Background(), taskID)
+	require.NoError(t, err)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestPgxTaskRepository_RestoreTask_NotInTrash(t *testing.T) {
+	mock, _ := pgxmock.NewPool()
+	defer mock.Close()
+	repo := NewPgxTaskRepository(mock, zap.NewNop())
+	taskID := uuid.New()
+
+	mock.ExpectExec(regexp.QuoteMeta(
+		`UPDATE tasks SET deleted_at = NULL WHERE id = $1 AND deleted_at IS NOT NULL`)).
+		WithArgs(taskID).
+		WillReturnResult(pgxmock.NewResult("UPDATE", 0))
+
+	err := repo.RestoreTask(context.Background(), taskID)
+	require.Error(t, err)
+	assert.ErrorIs(t, err, ErrTaskNotFound)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestPgxTaskRepository_PurgeTasks(t *testing.T) {
+	mock, _ := pgxmock.NewPool()
+	defer mock.Close()
+	repo := NewPgxTaskRepository(mock, zap.NewNop())
+
+	completedBefore := time.Now().AddDate(0, 0, -30)
+	failedBefore := time.Now().AddDate(0, 0, -14)
+	excluded := []uuid.UUID{uuid.New()}
+
+	mock.ExpectExec(regexp.QuoteMeta(
+		`SELECT id FROM tasks WHERE ((status = $1 AND completed_at < $2) OR (status = $3 AND completed_at < $4)) AND NOT (user_id = ANY($5))
+            LIMIT $6`)).
+		WithArgs(StatusCompleted, completedBefore, StatusFailed, failedBefore, excluded, 100).
+		WillReturnResult(pgxmock.NewResult("DELETE", 42))
+
+	deleted, err := repo.PurgeTasks(context.Background(), PurgeCriteria{
+		ExcludeUserIDs:  excluded,
+		CompletedBefore: &completedBefore,
+		FailedBefore:    &failedBefore,
+		Limit:           100,
+	})
+	require.NoError(t, err)
+	assert.Equal(t, int64(42), deleted)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestPgxTaskRepository_PurgeTasks_NothingToPurge(t *testing.T) {
+	mock, _ := pgxmock.NewPool()
+	defer mock.Close()
+	repo := NewPgxTaskRepository(mock, zap.NewNop())
+
+	deleted, err := repo.PurgeTasks(context.Background(), PurgeCriteria{UserIDs: []uuid.UUID{uuid.New()}, Limit: 100})
+	require.NoError(t, err)
+	assert.Zero(t, deleted)
+	assert.NoError(t, mock.ExpectationsWereMet(), "Без границ хранения запрос к БД не выполняется")
+}
+
 func floatPtr(f float64) *float64 {
 	return &f
 }
+
+func strPtr(s string) *string {
+	return &s
+}
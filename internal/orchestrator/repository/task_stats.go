@@ -0,0 +1,161 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+)
+
+// UserStats - сводка по задачам пользователя вне корзины.
+type UserStats struct {
+	Total        int64
+	StatusCounts map[string]int64
+	// SuccessRate - доля completed среди завершенных задач, nil если завершенных задач нет.
+	SuccessRate *float64
+	// Время от постановки последней попытки в очередь до завершения completed задач в секундах, nil если таких задач нет.
+	AvgCompletionSeconds *float64
+	P95CompletionSeconds *float64
+	CompletedMeasured    int64
+	Operators            []OperatorUsage
+}
+
+type OperatorUsage struct {
+	Symbol        string
+	Count         int64
+	Failed        int64
+	AvgDurationMs float64
+}
+
+func (r *pgxTaskRepository) GetUserStats(ctx context.Context, userID uuid.UUID) (*UserStats, error) {
+	stats := &UserStats{StatusCounts: make(map[string]int64)}
+
+	countsQuery := `
+        SELECT status, COUNT(*)
+        FROM tasks
+        WHERE user_id = $1 AND deleted_at IS NULL
+        GROUP BY status
+    `
+	rows, err := r.db.Query(ctx, countsQuery, userID)
+	if err != nil {
+		r.log.Error("Ошибка подсчета задач пользователя по статусам", zap.Stringer("userID", userID), zap.Error(err))
+		return nil, fmt.Errorf("%w: %v", ErrDatabase, err)
+	}
+	for rows.Next() {
+		var status string
+		var count int64
+		if err := rows.Scan(&status, &count); err != nil {
+			rows.Close()
+			r.log.Error("Ошибка сканирования числа задач по статусу", zap.Stringer("userID", userID), zap.Error(err))
+			return nil, fmt.Errorf("%w: ошибка сканирования: %v", ErrDatabase, err)
+		}
+		stats.StatusCounts[status] = count
+		stats.Total += count
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		r.log.Error("Ошибка после итерации по статусам задач", zap.Stringer("userID", userID), zap.Error(err))
+		return nil, fmt.Errorf("%w: ошибка итерации: %v", ErrDatabase, err)
+	}
+
+	if finished := stats.StatusCounts[StatusCompleted] + stats.StatusCounts[StatusFailed]; finished > 0 {
+		rate := float64(stats.StatusCounts[StatusCompleted]) / float64(finished)
+		stats.SuccessRate = &rate
+	}
+
+	// Длительность считается от создания последней попытки, чтобы время до перезапуска не попадало в нее.
+	// Задачи без попыток (созданные до их учета) измеряются от создания задачи.
+	durationQuery := `
+        WITH durations AS (
+            SELECT EXTRACT(EPOCH FROM t.completed_at - COALESCE(a.created_at, t.created_at)) AS seconds
+            FROM tasks t
+            LEFT JOIN LATERAL (
+                SELECT created_at FROM task_attempts WHERE task_id = t.id ORDER BY attempt_number DESC LIMIT 1
+            ) a ON TRUE
+            WHERE t.user_id = $1 AND t.status = $2 AND t.deleted_at IS NULL AND t.completed_at IS NOT NULL
+        )
+        SELECT COUNT(*), AVG(seconds), percentile_cont(0.95) WITHIN GROUP (ORDER BY seconds)
+        FROM durations
+    `
+	err = r.db.QueryRow(ctx, durationQuery, userID, StatusCompleted).Scan(
+		&stats.CompletedMeasured, &stats.AvgCompletionSeconds, &stats.P95CompletionSeconds,
+	)
+	if err != nil {
+		r.log.Error("Ошибка расчета времени выполнения задач пользователя", zap.Stringer("userID", userID), zap.Error(err))
+		return nil, fmt.Errorf("%w: %v", ErrDatabase, err)
+	}
+
+	operatorsQuery := `
+        SELECT o.symbol, COUNT(*), COUNT(*) FILTER (WHERE o.error_message IS NOT NULL), AVG(o.duration_ms)
+        FROM task_operations o
+        JOIN tasks t ON t.id = o.task_id
+        WHERE t.user_id = $1 AND t.deleted_at IS NULL
+        GROUP BY o.symbol
+        ORDER BY COUNT(*) DESC, o.symbol
+    `
+	rows, err = r.db.Query(ctx, operatorsQuery, userID)
+	if err != nil {
+		r.log.Error("Ошибка подсчета операторов пользователя", zap.Stringer("userID", userID), zap.Error(err))
+		return nil, fmt.Errorf("%w: %v", ErrDatabase, err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var op OperatorUsage
+		if err := rows.Scan(&op.Symbol, &op.Count, &op.Failed, &op.AvgDurationMs); err != nil {
+			r.log.Error("Ошибка сканирования статистики оператора", zap.Stringer("userID", userID), zap.Error(err))
+			return nil, fmt.Errorf("%w: ошибка сканирования: %v", ErrDatabase, err)
+		}
+		stats.Operators = append(stats.Operators, op)
+	}
+	if err := rows.Err(); err != nil {
+		r.log.Error("Ошибка после итерации по операторам", zap.Stringer("userID", userID), zap.Error(err))
+		return nil, fmt.Errorf("%w: ошибка итерации: %v", ErrDatabase, err)
+	}
+
+	return stats, nil
+}
+
+// SystemTaskCounts - число задач всех пользователей по статусам.
+type SystemTaskCounts struct {
+	Total        int64
+	StatusCounts map[string]int64
+	// Trashed - задачи в корзине, в StatusCounts и Total не входят.
+	Trashed int64
+}
+
+func (r *pgxTaskRepository) GetSystemTaskCounts(ctx context.Context) (*SystemTaskCounts, error) {
+	query := `
+        SELECT status, COUNT(*) FILTER (WHERE deleted_at IS NULL), COUNT(*) FILTER (WHERE deleted_at IS NOT NULL)
+        FROM tasks
+        GROUP BY status
+    `
+	rows, err := r.db.Query(ctx, query)
+	if err != nil {
+		r.log.Error("Ошибка подсчета задач по статусам", zap.Error(err))
+		return nil, fmt.Errorf("%w: %v", ErrDatabase, err)
+	}
+	defer rows.Close()
+
+	counts := &SystemTaskCounts{StatusCounts: make(map[string]int64)}
+	for rows.Next() {
+		var status string
+		var active, trashed int64
+		if err := rows.Scan(&status, &active, &trashed); err != nil {
+			r.log.Error("Ошибка сканирования числа задач по статусу", zap.Error(err))
+			return nil, fmt.Errorf("%w: ошибка сканирования: %v", ErrDatabase, err)
+		}
+		if active > 0 {
+			counts.StatusCounts[status] = active
+		}
+		counts.Total += active
+		counts.Trashed += trashed
+	}
+	if err := rows.Err(); err != nil {
+		r.log.Error("Ошибка после итерации по статусам задач", zap.Error(err))
+		return nil, fmt.Errorf("%w: ошибка итерации: %v", ErrDatabase, err)
+	}
+
+	return counts, nil
+}
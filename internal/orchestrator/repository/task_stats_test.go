@@ -0,0 +1,102 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"regexp"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/pashagolub/pgxmock/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+func TestPgxTaskRepository_GetUserStats(t *testing.T) {
+	mock, err := pgxmock.NewPool()
+	require.NoError(t, err)
+	defer mock.Close()
+	repo := NewPgxTaskRepository(mock, zap.NewNop())
+	userID := uuid.New()
+
+	mock.ExpectQuery(regexp.QuoteMeta(`SELECT status, COUNT(*)
+        FROM tasks
+        WHERE user_id = $1 AND deleted_at IS NULL
+        GROUP BY status`)).
+		WithArgs(userID).
+		WillReturnRows(pgxmock.NewRows([]string{"status", "count"}).
+			AddRow(StatusCompleted, int64(3)).
+			AddRow(StatusFailed, int64(1)).
+			AddRow(StatusPending, int64(2)))
+	mock.ExpectQuery(regexp.QuoteMeta(`percentile_cont(0.95)`)).
+		WithArgs(userID, StatusCompleted).
+		WillReturnRows(pgxmock.NewRows([]string{"count", "avg", "p95"}).
+			AddRow(int64(3), floatPtr(1.5), floatPtr(2.8)))
+	mock.ExpectQuery(regexp.QuoteMeta(`FROM task_operations o
+        JOIN tasks t ON t.id = o.task_id`)).
+		WithArgs(userID).
+		WillReturnRows(pgxmock.NewRows([]string{"symbol", "count", "failed", "avg"}).
+			AddRow("+", int64(10), int64(0), 200.0).
+			AddRow("/", int64(4), int64(1), 400.0))
+
+	stats, err := repo.GetUserStats(context.Background(), userID)
+
+	require.NoError(t, err)
+	assert.Equal(t, int64(6), stats.Total)
+	assert.Equal(t, map[string]int64{StatusCompleted: 3, StatusFailed: 1, StatusPending: 2}, stats.StatusCounts)
+	require.NotNil(t, stats.SuccessRate)
+	assert.InDelta(t, 0.75, *stats.SuccessRate, 1e-9)
+	assert.Equal(t, int64(3), stats.CompletedMeasured)
+	assert.Equal(t, floatPtr(1.5), stats.AvgCompletionSeconds)
+	assert.Equal(t, floatPtr(2.8), stats.P95CompletionSeconds)
+	assert.Equal(t, []OperatorUsage{
+		{Symbol: "+", Count: 10, Failed: 0, AvgDurationMs: 200},
+		{Symbol: "/", Count: 4, Failed: 1, AvgDurationMs: 400},
+	}, stats.Operators)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestPgxTaskRepository_GetUserStats_NoTasks(t *testing.T) {
+	mock, err := pgxmock.NewPool()
+	require.NoError(t, err)
+	defer mock.Close()
+	repo := NewPgxTaskRepository(mock, zap.NewNop())
+	userID := uuid.New()
+
+	mock.ExpectQuery(regexp.QuoteMeta(`GROUP BY status`)).
+		WithArgs(userID).
+		WillReturnRows(pgxmock.NewRows([]string{"status", "count"}))
+	mock.ExpectQuery(regexp.QuoteMeta(`percentile_cont(0.95)`)).
+		WithArgs(userID, StatusCompleted).
+		WillReturnRows(pgxmock.NewRows([]string{"count", "avg", "p95"}).AddRow(int64(0), nil, nil))
+	mock.ExpectQuery(regexp.QuoteMeta(`FROM task_operations o`)).
+		WithArgs(userID).
+		WillReturnRows(pgxmock.NewRows([]string{"symbol", "count", "failed", "avg"}))
+
+	stats, err := repo.GetUserStats(context.Background(), userID)
+
+	require.NoError(t, err)
+	assert.Zero(t, stats.Total)
+	assert.Nil(t, stats.SuccessRate)
+	assert.Nil(t, stats.AvgCompletionSeconds)
+	assert.Empty(t, stats.Operators)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestPgxTaskRepository_GetUserStats_DBError(t *testing.T) {
+	mock, err := pgxmock.NewPool()
+	require.NoError(t, err)
+	defer mock.Close()
+	repo := NewPgxTaskRepository(mock, zap.NewNop())
+	userID := uuid.New()
+
+	mock.ExpectQuery(regexp.QuoteMeta(`GROUP BY status`)).
+		WithArgs(userID).
+		WillReturnError(errors.New("connection reset"))
+
+	stats, err := repo.GetUserStats(context.Background(), userID)
+
+	assert.ErrorIs(t, err, ErrDatabase)
+	assert.Nil(t, stats)
+}
@@ -0,0 +1,137 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+
+	"go.uber.org/zap"
+)
+
+// Usage - потребление пользователя: отправленные задачи, выполненные вызовы Воркера и их суммарное время.
+type Usage struct {
+	TasksSubmitted int64
+	Operations     int64
+	ComputeTime    time.Duration
+}
+
+func (u Usage) Add(other Usage) Usage {
+	return Usage{
+		TasksSubmitted: u.TasksSubmitted + other.TasksSubmitted,
+		Operations:     u.Operations + other.Operations,
+		ComputeTime:    u.ComputeTime + other.ComputeTime,
+	}
+}
+
+// UsageSummary - потребление за сутки и за календарный месяц, которому они принадлежат.
+type UsageSummary struct {
+	Day   Usage
+	Month Usage
+}
+
+type UsageRepository interface {
+	// AddUsage прибавляет delta к счетчикам пользователя за сутки day (учитывается только дата).
+	AddUsage(ctx context.Context, userID uuid.UUID, day time.Time, delta Usage) error
+	// ReserveUsage атомарно прибавляет delta к счетчикам за сутки day, только если потребление за эти сутки
+	// и за их месяц останется в пределах dailyLimit и monthlyLimit (нулевое поле - без ограничения).
+	// false - delta не уложилась в лимиты, счетчики не изменены.
+	ReserveUsage(ctx context.Context, userID uuid.UUID, day time.Time, delta, dailyLimit, monthlyLimit Usage) (bool, error)
+	GetUsage(ctx context.Context, userID uuid.UUID, day time.Time) (*UsageSummary, error)
+}
+
+type pgxUsageRepository struct {
+	db  DBPoolIface
+	log *zap.Logger
+}
+
+func NewPgxUsageRepository(db DBPoolIface, log *zap.Logger) UsageRepository {
+	return &pgxUsageRepository{db: db, log: log}
+}
+
+func (r *pgxUsageRepository) AddUsage(ctx context.Context, userID uuid.UUID, day time.Time, delta Usage) error {
+	query := `
+        INSERT INTO user_usage (user_id, day, tasks_submitted, operations, compute_ms)
+        VALUES ($1, $2::date, $3, $4, $5)
+        ON CONFLICT (user_id, day) DO UPDATE SET
+            tasks_submitted = user_usage.tasks_submitted + EXCLUDED.tasks_submitted,
+            operations = user_usage.operations + EXCLUDED.operations,
+            compute_ms = user_usage.compute_ms + EXCLUDED.compute_ms
+    `
+	_, err := r.db.Exec(ctx, query, userID, day, delta.TasksSubmitted, delta.Operations, delta.ComputeTime.Milliseconds())
+	if err != nil {
+		r.log.Error("Ошибка учета потребления пользователя", zap.Stringer("userID", userID), zap.Error(err))
+		return fmt.Errorf("%w: %v", ErrDatabase, err)
+	}
+	return nil
+}
+
+// Потребление за прошлые дни месяца уже не меняется, а строку текущих суток конкурирующие резервы обновляют
+// под блокировкой ON CONFLICT, поэтому условие в DO UPDATE проверяется по актуальным счетчикам.
+// Условие в SELECT отсекает резерв, который не уложится в лимиты даже при пустых сутках.
+func (r *pgxUsageRepository) ReserveUsage(ctx context.Context, userID uuid.UUID, day time.Time, delta, dailyLimit, monthlyLimit Usage) (bool, error) {
+	query := `
+        WITH month AS (
+            SELECT
+                COALESCE(SUM(tasks_submitted), 0)::bigint AS tasks_submitted,
+                COALESCE(SUM(operations), 0)::bigint AS operations,
+                COALESCE(SUM(compute_ms), 0)::bigint AS compute_ms
+            FROM user_usage
+            WHERE user_id = $1 AND day >= date_trunc('month', $2::date)::date AND day < $2::date
+        )
+        INSERT INTO user_usage (user_id, day, tasks_submitted, operations, compute_ms)
+        SELECT $1, $2::date, $3::bigint, $4::bigint, $5::bigint FROM month
+        WHERE ($6::bigint = 0 OR $3 <= $6) AND ($7::bigint = 0 OR $4 <= $7) AND ($8::bigint = 0 OR $5 <= $8)
+            AND ($9::bigint = 0 OR month.tasks_submitted + $3 <= $9)
+            AND ($10::bigint = 0 OR month.operations + $4 <= $10)
+            AND ($11::bigint = 0 OR month.compute_ms + $5 <= $11)
+        ON CONFLICT (user_id, day) DO UPDATE SET
+            tasks_submitted = user_usage.tasks_submitted + EXCLUDED.tasks_submitted,
+            operations = user_usage.operations + EXCLUDED.operations,
+            compute_ms = user_usage.compute_ms + EXCLUDED.compute_ms
+        WHERE ($6 = 0 OR user_usage.tasks_submitted + EXCLUDED.tasks_submitted <= $6)
+            AND ($7 = 0 OR user_usage.operations + EXCLUDED.operations <= $7)
+            AND ($8 = 0 OR user_usage.compute_ms + EXCLUDED.compute_ms <= $8)
+            AND ($9 = 0 OR (SELECT tasks_submitted FROM month) + user_usage.tasks_submitted + EXCLUDED.tasks_submitted <= $9)
+            AND ($10 = 0 OR (SELECT operations FROM month) + user_usage.operations + EXCLUDED.operations <= $10)
+            AND ($11 = 0 OR (SELECT compute_ms FROM month) + user_usage.compute_ms + EXCLUDED.compute_ms <= $11)
+    `
+	commandTag, err := r.db.Exec(ctx, query, userID, day,
+		delta.TasksSubmitted, delta.Operations, delta.ComputeTime.Milliseconds(),
+		dailyLimit.TasksSubmitted, dailyLimit.Operations, dailyLimit.ComputeTime.Milliseconds(),
+		monthlyLimit.TasksSubmitted, monthlyLimit.Operations, monthlyLimit.ComputeTime.Milliseconds(),
+	)
+	if err != nil {
+		r.log.Error("Ошибка резервирования квоты пользователя", zap.Stringer("userID", userID), zap.Error(err))
+		return false, fmt.Errorf("%w: %v", ErrDatabase, err)
+	}
+	return commandTag.RowsAffected() > 0, nil
+}
+
+func (r *pgxUsageRepository) GetUsage(ctx context.Context, userID uuid.UUID, day time.Time) (*UsageSummary, error) {
+	query := `
+        SELECT
+            COALESCE(SUM(tasks_submitted) FILTER (WHERE day = $2::date), 0)::bigint,
+            COALESCE(SUM(operations) FILTER (WHERE day = $2::date), 0)::bigint,
+            COALESCE(SUM(compute_ms) FILTER (WHERE day = $2::date), 0)::bigint,
+            COALESCE(SUM(tasks_submitted), 0)::bigint,
+            COALESCE(SUM(operations), 0)::bigint,
+            COALESCE(SUM(compute_ms), 0)::bigint
+        FROM user_usage
+        WHERE user_id = $1 AND day >= date_trunc('month', $2::date)::date AND day <= $2::date
+    `
+	var summary UsageSummary
+	var dayComputeMs, monthComputeMs int64
+	err := r.db.QueryRow(ctx, query, userID, day).Scan(
+		&summary.Day.TasksSubmitted, &summary.Day.Operations, &dayComputeMs,
+		&summary.Month.TasksSubmitted, &summary.Month.Operations, &monthComputeMs,
+	)
+	if err != nil {
+		r.log.Error("Ошибка получения потребления пользователя", zap.Stringer("userID", userID), zap.Error(err))
+		return nil, fmt.Errorf("%w: %v", ErrDatabase, err)
+	}
+	summary.Day.ComputeTime = time.Duration(dayComputeMs) * time.Millisecond
+	summary.Month.ComputeTime = time.Duration(monthComputeMs) * time.Millisecond
+	return &summary, nil
+}
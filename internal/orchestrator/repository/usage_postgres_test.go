@@ -0,0 +1,107 @@
+package repository
+
+import (
+	"context"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/pashagolub/pgxmock/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+func TestPgxUsageRepository_AddUsage(t *testing.T) {
+	mock, err := pgxmock.NewPool()
+	require.NoError(t, err)
+	defer mock.Close()
+
+	repo := NewPgxUsageRepository(mock, zap.NewNop())
+	userID := uuid.New()
+	day := time.Date(2025, 3, 10, 0, 0, 0, 0, time.UTC)
+
+	mock.ExpectExec(regexp.QuoteMeta(`INSERT INTO user_usage (user_id, day, tasks_submitted, operations, compute_ms)`)).
+		WithArgs(userID, day, int64(0), int64(3), int64(750)).
+		WillReturnResult(pgxmock.NewResult("INSERT", 1))
+
+	err = repo.AddUsage(context.Background(), userID, day, Usage{Operations: 3, ComputeTime: 750 * time.Millisecond})
+
+	require.NoError(t, err)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestPgxUsageRepository_ReserveUsage(t *testing.T) {
+	userID := uuid.New()
+	day := time.Date(2025, 3, 10, 0, 0, 0, 0, time.UTC)
+	delta := Usage{TasksSubmitted: 1, Operations: 2, ComputeTime: 400 * time.Millisecond}
+	daily := Usage{TasksSubmitted: 10}
+	monthly := Usage{Operations: 100, ComputeTime: time.Minute}
+
+	for _, tt := range []struct {
+		name     string
+		rows     int64
+		reserved bool
+	}{
+		{name: "укладывается в лимиты", rows: 1, reserved: true},
+		{name: "квота исчерпана", rows: 0, reserved: false},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			mock, err := pgxmock.NewPool()
+			require.NoError(t, err)
+			defer mock.Close()
+			repo := NewPgxUsageRepository(mock, zap.NewNop())
+
+			mock.ExpectExec(regexp.QuoteMeta(`ON CONFLICT (user_id, day) DO UPDATE SET`)).
+				WithArgs(userID, day, int64(1), int64(2), int64(400), int64(10), int64(0), int64(0), int64(0), int64(100), int64(60000)).
+				WillReturnResult(pgxmock.NewResult("INSERT", tt.rows))
+
+			reserved, err := repo.ReserveUsage(context.Background(), userID, day, delta, daily, monthly)
+
+			require.NoError(t, err)
+			assert.Equal(t, tt.reserved, reserved)
+			assert.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
+
+func TestPgxUsageRepository_GetUsage(t *testing.T) {
+	mock, err := pgxmock.NewPool()
+	require.NoError(t, err)
+	defer mock.Close()
+
+	repo := NewPgxUsageRepository(mock, zap.NewNop())
+	userID := uuid.New()
+	day := time.Date(2025, 3, 10, 0, 0, 0, 0, time.UTC)
+
+	mock.ExpectQuery(regexp.QuoteMeta(`FROM user_usage`)).
+		WithArgs(userID, day).
+		WillReturnRows(pgxmock.NewRows([]string{"day_tasks", "day_ops", "day_ms", "month_tasks", "month_ops", "month_ms"}).
+			AddRow(int64(2), int64(7), int64(1400), int64(20), int64(90), int64(18000)))
+
+	summary, err := repo.GetUsage(context.Background(), userID, day)
+
+	require.NoError(t, err)
+	assert.Equal(t, Usage{TasksSubmitted: 2, Operations: 7, ComputeTime: 1400 * time.Millisecond}, summary.Day)
+	assert.Equal(t, Usage{TasksSubmitted: 20, Operations: 90, ComputeTime: 18 * time.Second}, summary.Month)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestPgxUsageRepository_GetUsageError(t *testing.T) {
+	mock, err := pgxmock.NewPool()
+	require.NoError(t, err)
+	defer mock.Close()
+
+	repo := NewPgxUsageRepository(mock, zap.NewNop())
+	userID := uuid.New()
+
+	mock.ExpectQuery(regexp.QuoteMeta(`FROM user_usage`)).
+		WithArgs(userID, pgxmock.AnyArg()).
+		WillReturnError(assert.AnError)
+
+	_, err = repo.GetUsage(context.Background(), userID, time.Now())
+
+	assert.ErrorIs(t, err, ErrDatabase)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
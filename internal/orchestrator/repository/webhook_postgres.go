@@ -0,0 +1,295 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+
+	"go.uber.org/zap"
+)
+
+const (
+	DeliveryStatusPending   = "pending"
+	DeliveryStatusDelivered = "delivered"
+	DeliveryStatusFailed    = "failed"
+)
+
+const pgUniqueViolationCode = "23505"
+
+type WebhookEndpoint struct {
+	ID        uuid.UUID
+	UserID    uuid.UUID
+	URL       string
+	CreatedAt time.Time
+}
+
+// WebhookDelivery - одна доставка события задачи на один URL вместе с историей попыток.
+// EndpointID пуст для доставки на callback_url, переданный при создании задачи.
+type WebhookDelivery struct {
+	ID             uuid.UUID
+	UserID         uuid.UUID
+	TaskID         *uuid.UUID
+	EndpointID     *uuid.UUID
+	URL            string
+	Event          string
+	Payload        []byte
+	Status         string
+	Attempts       int
+	LastStatusCode *int
+	LastError      *string
+	NextAttemptAt  *time.Time
+	DeliveredAt    *time.Time
+	CreatedAt      time.Time
+}
+
+var (
+	ErrWebhookNotFound       = errors.New("webhook не найден")
+	ErrWebhookEndpointExists = errors.New("webhook с таким URL уже зарегистрирован")
+)
+
+type WebhookRepository interface {
+	// GetOrCreateSecret возвращает секрет подписи пользователя, сохраняя candidate, если секрета еще нет.
+	GetOrCreateSecret(ctx context.Context, userID uuid.UUID, candidate string) (string, error)
+	RotateSecret(ctx context.Context, userID uuid.UUID, secret string) error
+	CreateEndpoint(ctx context.Context, userID uuid.UUID, url string) (*WebhookEndpoint, error)
+	ListEndpoints(ctx context.Context, userID uuid.UUID) ([]WebhookEndpoint, error)
+	DeleteEndpoint(ctx context.Context, userID, endpointID uuid.UUID) error
+	CreateDelivery(ctx context.Context, delivery WebhookDelivery) (uuid.UUID, error)
+	// ClaimDueDeliveries выбирает доставки, время попытки которых наступило, и откладывает их на lease,
+	// чтобы параллельный диспетчер не взял их повторно.
+	ClaimDueDeliveries(ctx context.Context, limit int, lease time.Duration) ([]WebhookDelivery, error)
+	MarkDeliverySucceeded(ctx context.Context, deliveryID uuid.UUID, statusCode int) error
+	// MarkDeliveryFailed фиксирует неудачную попытку. Nil nextAttemptAt означает, что попытки исчерпаны.
+	MarkDeliveryFailed(ctx context.Context, deliveryID uuid.UUID, statusCode *int, errorMessage string, nextAttemptAt *time.Time) error
+	ListDeliveries(ctx context.Context, userID uuid.UUID, taskID *uuid.UUID, limit int) ([]WebhookDelivery, error)
+	ResetDelivery(ctx context.Context, userID, deliveryID uuid.UUID) error
+}
+
+type pgxWebhookRepository struct {
+	db  DBPoolIface
+	log *zap.Logger
+}
+
+func NewPgxWebhookRepository(db DBPoolIface, log *zap.Logger) WebhookRepository {
+	return &pgxWebhookRepository{db: db, log: log}
+}
+
+const webhookDeliveryColumns = `id, user_id, task_id, endpoint_id, url, event, payload, status, attempts,
+        last_status_code, last_error, next_attempt_at, delivered_at, created_at`
+
+func (r *pgxWebhookRepository) GetOrCreateSecret(ctx context.Context, userID uuid.UUID, candidate string) (string, error) {
+	query := `
+        INSERT INTO webhook_secrets (user_id, secret)
+        VALUES ($1, $2)
+        ON CONFLICT (user_id) DO UPDATE SET user_id = EXCLUDED.user_id
+        RETURNING secret
+    `
+	var secret string
+	if err := r.db.QueryRow(ctx, query, userID, candidate).Scan(&secret); err != nil {
+		r.log.Error("Ошибка получения секрета webhook", zap.Stringer("userID", userID), zap.Error(err))
+		return "", fmt.Errorf("%w: %v", ErrDatabase, err)
+	}
+	return secret, nil
+}
+
+func (r *pgxWebhookRepository) RotateSecret(ctx context.Context, userID uuid.UUID, secret string) error {
+	query := `
+        INSERT INTO webhook_secrets (user_id, secret)
+        VALUES ($1, $2)
+        ON CONFLICT (user_id) DO UPDATE SET secret = EXCLUDED.secret
+    `
+	if _, err := r.db.Exec(ctx, query, userID, secret); err != nil {
+		r.log.Error("Ошибка смены секрета webhook", zap.Stringer("userID", userID), zap.Error(err))
+		return fmt.Errorf("%w: %v", ErrDatabase, err)
+	}
+	r.log.Info("Секрет webhook изменен", zap.Stringer("userID", userID))
+	return nil
+}
+
+func (r *pgxWebhookRepository) CreateEndpoint(ctx context.Context, userID uuid.UUID, url string) (*WebhookEndpoint, error) {
+	query := `
+        INSERT INTO webhook_endpoints (user_id, url)
+        VALUES ($1, $2)
+        RETURNING id, created_at
+    `
+	endpoint := WebhookEndpoint{UserID: userID, URL: url}
+	if err := r.db.QueryRow(ctx, query, userID, url).Scan(&endpoint.ID, &endpoint.CreatedAt); err != nil {
+		var pgErr *pgconn.PgError
+		if errors.As(err, &pgErr) && pgErr.Code == pgUniqueViolationCode {
+			return nil, ErrWebhookEndpointExists
+		}
+		r.log.Error("Ошибка создания webhook", zap.Stringer("userID", userID), zap.Error(err))
+		return nil, fmt.Errorf("%w: %v", ErrDatabase, err)
+	}
+	r.log.Info("Webhook зарегистрирован", zap.Stringer("userID", userID), zap.Stringer("endpointID", endpoint.ID))
+	return &endpoint, nil
+}
+
+func (r *pgxWebhookRepository) ListEndpoints(ctx context.Context, userID uuid.UUID) ([]WebhookEndpoint, error) {
+	query := `
+        SELECT id, user_id, url, created_at
+        FROM webhook_endpoints
+        WHERE user_id = $1
+        ORDER BY created_at
+    `
+	rows, err := r.db.Query(ctx, query, userID)
+	if err != nil {
+		r.log.Error("Ошибка получения webhook пользователя", zap.Stringer("userID", userID), zap.Error(err))
+		return nil, fmt.Errorf("%w: %v", ErrDatabase, err)
+	}
+	defer rows.Close()
+
+	var endpoints []WebhookEndpoint
+	for rows.Next() {
+		var e WebhookEndpoint
+		if err := rows.Scan(&e.ID, &e.UserID, &e.URL, &e.CreatedAt); err != nil {
+			return nil, fmt.Errorf("%w: ошибка сканирования: %v", ErrDatabase, err)
+		}
+		endpoints = append(endpoints, e)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("%w: ошибка итерации: %v", ErrDatabase, err)
+	}
+	return endpoints, nil
+}
+
+func (r *pgxWebhookRepository) DeleteEndpoint(ctx context.Context, userID, endpointID uuid.UUID) error {
+	query := `DELETE FROM webhook_endpoints WHERE id = $1 AND user_id = $2`
+	commandTag, err := r.db.Exec(ctx, query, endpointID, userID)
+	if err != nil {
+		r.log.Error("Ошибка удаления webhook", zap.Stringer("endpointID", endpointID), zap.Error(err))
+		return fmt.Errorf("%w: %v", ErrDatabase, err)
+	}
+	if commandTag.RowsAffected() == 0 {
+		return ErrWebhookNotFound
+	}
+	r.log.Info("Webhook удален", zap.Stringer("userID", userID), zap.Stringer("endpointID", endpointID))
+	return nil
+}
+
+func (r *pgxWebhookRepository) CreateDelivery(ctx context.Context, d WebhookDelivery) (uuid.UUID, error) {
+	query := `
+        INSERT INTO webhook_deliveries (user_id, task_id, endpoint_id, url, event, payload, status, next_attempt_at)
+        VALUES ($1, $2, $3, $4, $5, $6, $7, NOW())
+        RETURNING id
+    `
+	var id uuid.UUID
+	err := r.db.QueryRow(ctx, query, d.UserID, d.TaskID, d.EndpointID, d.URL, d.Event, d.Payload, DeliveryStatusPending).Scan(&id)
+	if err != nil {
+		r.log.Error("Ошибка создания доставки webhook", zap.Stringer("userID", d.UserID), zap.String("url", d.URL), zap.Error(err))
+		return uuid.Nil, fmt.Errorf("%w: %v", ErrDatabase, err)
+	}
+	return id, nil
+}
+
+func (r *pgxWebhookRepository) ClaimDueDeliveries(ctx context.Context, limit int, lease time.Duration) ([]WebhookDelivery, error) {
+	query := `
+        UPDATE webhook_deliveries
+        SET next_attempt_at = NOW() + make_interval(secs => $3)
+        WHERE id IN (
+            SELECT id FROM webhook_deliveries
+            WHERE status = $1 AND next_attempt_at <= NOW()
+            ORDER BY next_attempt_at
+            LIMIT $2
+            FOR UPDATE SKIP LOCKED
+        )
+        RETURNING ` + webhookDeliveryColumns
+	rows, err := r.db.Query(ctx, query, DeliveryStatusPending, limit, lease.Seconds())
+	if err != nil {
+		r.log.Error("Ошибка выборки доставок webhook", zap.Error(err))
+		return nil, fmt.Errorf("%w: %v", ErrDatabase, err)
+	}
+	return r.scanDeliveries(rows)
+}
+
+func (r *pgxWebhookRepository) MarkDeliverySucceeded(ctx context.Context, deliveryID uuid.UUID, statusCode int) error {
+	query := `
+        UPDATE webhook_deliveries
+        SET status = $2, attempts = attempts + 1, last_status_code = $3, last_error = NULL,
+            next_attempt_at = NULL, delivered_at = NOW()
+        WHERE id = $1
+    `
+	if _, err := r.db.Exec(ctx, query, deliveryID, DeliveryStatusDelivered, statusCode); err != nil {
+		r.log.Error("Ошибка отметки доставки webhook", zap.Stringer("deliveryID", deliveryID), zap.Error(err))
+		return fmt.Errorf("%w: %v", ErrDatabase, err)
+	}
+	return nil
+}
+
+func (r *pgxWebhookRepository) MarkDeliveryFailed(ctx context.Context, deliveryID uuid.UUID, statusCode *int, errorMessage string, nextAttemptAt *time.Time) error {
+	status := DeliveryStatusPending
+	if nextAttemptAt == nil {
+		status = DeliveryStatusFailed
+	}
+	query := `
+        UPDATE webhook_deliveries
+        SET status = $2, attempts = attempts + 1, last_status_code = $3, last_error = $4, next_attempt_at = $5
+        WHERE id = $1
+    `
+	if _, err := r.db.Exec(ctx, query, deliveryID, status, statusCode, errorMessage, nextAttemptAt); err != nil {
+		r.log.Error("Ошибка отметки неудачной доставки webhook", zap.Stringer("deliveryID", deliveryID), zap.Error(err))
+		return fmt.Errorf("%w: %v", ErrDatabase, err)
+	}
+	return nil
+}
+
+func (r *pgxWebhookRepository) ListDeliveries(ctx context.Context, userID uuid.UUID, taskID *uuid.UUID, limit int) ([]WebhookDelivery, error) {
+	query := `
+        SELECT ` + webhookDeliveryColumns + `
+        FROM webhook_deliveries
+        WHERE user_id = $1 AND ($2::uuid IS NULL OR task_id = $2)
+        ORDER BY created_at DESC
+        LIMIT $3
+    `
+	rows, err := r.db.Query(ctx, query, userID, taskID, limit)
+	if err != nil {
+		r.log.Error("Ошибка получения журнала доставок webhook", zap.Stringer("userID", userID), zap.Error(err))
+		return nil, fmt.Errorf("%w: %v", ErrDatabase, err)
+	}
+	return r.scanDeliveries(rows)
+}
+
+func (r *pgxWebhookRepository) ResetDelivery(ctx context.Context, userID, deliveryID uuid.UUID) error {
+	query := `
+        UPDATE webhook_deliveries
+        SET status = $3, attempts = 0, next_attempt_at = NOW(), delivered_at = NULL
+        WHERE id = $1 AND user_id = $2
+    `
+	commandTag, err := r.db.Exec(ctx, query, deliveryID, userID, DeliveryStatusPending)
+	if err != nil {
+		r.log.Error("Ошибка повторной постановки доставки webhook", zap.Stringer("deliveryID", deliveryID), zap.Error(err))
+		return fmt.Errorf("%w: %v", ErrDatabase, err)
+	}
+	if commandTag.RowsAffected() == 0 {
+		return ErrWebhookNotFound
+	}
+	r.log.Info("Доставка webhook поставлена на повтор", zap.Stringer("userID", userID), zap.Stringer("deliveryID", deliveryID))
+	return nil
+}
+
+func (r *pgxWebhookRepository) scanDeliveries(rows pgx.Rows) ([]WebhookDelivery, error) {
+	defer rows.Close()
+
+	var deliveries []WebhookDelivery
+	for rows.Next() {
+		var d WebhookDelivery
+		if err := rows.Scan(
+			&d.ID, &d.UserID, &d.TaskID, &d.EndpointID, &d.URL, &d.Event, &d.Payload, &d.Status, &d.Attempts,
+			&d.LastStatusCode, &d.LastError, &d.NextAttemptAt, &d.DeliveredAt, &d.CreatedAt,
+		); err != nil {
+			r.log.Error("Ошибка сканирования строки доставки webhook", zap.Error(err))
+			return nil, fmt.Errorf("%w: ошибка сканирования: %v", ErrDatabase, err)
+		}
+		deliveries = append(deliveries, d)
+	}
+	if err := rows.Err(); err != nil {
+		r.log.Error("Ошибка после итерации по доставкам webhook", zap.Error(err))
+		return nil, fmt.Errorf("%w: ошибка итерации: %v", ErrDatabase, err)
+	}
+	return deliveries, nil
+}
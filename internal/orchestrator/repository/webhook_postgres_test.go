@@ -0,0 +1,97 @@
+package repository
+
+import (
+	"context"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/pashagolub/pgxmock/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+func TestPgxWebhookRepository_CreateEndpoint_Duplicate(t *testing.T) {
+	mock, err := pgxmock.NewPool()
+	require.NoError(t, err)
+	defer mock.Close()
+
+	repo := NewPgxWebhookRepository(mock, zap.NewNop())
+	userID := uuid.New()
+
+	mock.ExpectQuery(regexp.QuoteMeta(`INSERT INTO webhook_endpoints (user_id, url)`)).
+		WithArgs(userID, "https://example.com/hook").
+		WillReturnError(&pgconn.PgError{Code: pgUniqueViolationCode})
+
+	_, err = repo.CreateEndpoint(context.Background(), userID, "https://example.com/hook")
+
+	assert.ErrorIs(t, err, ErrWebhookEndpointExists)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestPgxWebhookRepository_ClaimDueDeliveries(t *testing.T) {
+	mock, err := pgxmock.NewPool()
+	require.NoError(t, err)
+	defer mock.Close()
+
+	repo := NewPgxWebhookRepository(mock, zap.NewNop())
+	deliveryID, userID, taskID := uuid.New(), uuid.New(), uuid.New()
+	now := time.Now()
+
+	rows := pgxmock.NewRows([]string{"id", "user_id", "task_id", "endpoint_id", "url", "event", "payload", "status", "attempts",
+		"last_status_code", "last_error", "next_attempt_at", "delivered_at", "created_at"}).
+		AddRow(deliveryID, userID, &taskID, nil, "https://example.com/hook", "task.completed", []byte(`{}`), DeliveryStatusPending, 1,
+			nil, nil, &now, nil, now)
+	mock.ExpectQuery(regexp.QuoteMeta(`WHERE status = $1 AND next_attempt_at <= NOW()`)).
+		WithArgs(DeliveryStatusPending, 10, float64(30)).
+		WillReturnRows(rows)
+
+	deliveries, err := repo.ClaimDueDeliveries(context.Background(), 10, 30*time.Second)
+
+	require.NoError(t, err)
+	require.Len(t, deliveries, 1)
+	assert.Equal(t, deliveryID, deliveries[0].ID)
+	assert.Equal(t, taskID, *deliveries[0].TaskID)
+	assert.Nil(t, deliveries[0].EndpointID)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestPgxWebhookRepository_MarkDeliveryFailed_Exhausted(t *testing.T) {
+	mock, err := pgxmock.NewPool()
+	require.NoError(t, err)
+	defer mock.Close()
+
+	repo := NewPgxWebhookRepository(mock, zap.NewNop())
+	deliveryID := uuid.New()
+	statusCode := 500
+
+	mock.ExpectExec(regexp.QuoteMeta(`SET status = $2, attempts = attempts + 1, last_status_code = $3, last_error = $4, next_attempt_at = $5`)).
+		WithArgs(deliveryID, DeliveryStatusFailed, &statusCode, "HTTP 500", (*time.Time)(nil)).
+		WillReturnResult(pgxmock.NewResult("UPDATE", 1))
+
+	err = repo.MarkDeliveryFailed(context.Background(), deliveryID, &statusCode, "HTTP 500", nil)
+
+	require.NoError(t, err)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestPgxWebhookRepository_ResetDelivery_NotFound(t *testing.T) {
+	mock, err := pgxmock.NewPool()
+	require.NoError(t, err)
+	defer mock.Close()
+
+	repo := NewPgxWebhookRepository(mock, zap.NewNop())
+	userID, deliveryID := uuid.New(), uuid.New()
+
+	mock.ExpectExec(regexp.QuoteMeta(`UPDATE webhook_deliveries`)).
+		WithArgs(deliveryID, userID, DeliveryStatusPending).
+		WillReturnResult(pgxmock.NewResult("UPDATE", 0))
+
+	err = repo.ResetDelivery(context.Background(), userID, deliveryID)
+
+	assert.ErrorIs(t, err, ErrWebhookNotFound)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
@@ -0,0 +1,76 @@
+package service
+
+import (
+	"time"
+
+	"github.com/Qu1nel/YaLyceum-GoProject-Final/internal/orchestrator/config"
+	"github.com/expr-lang/expr/ast"
+)
+
+// OperationCosts - время одного вызова Воркера по символу операции ("+", "-", "*", "/", "^", "neg").
+type OperationCosts map[string]time.Duration
+
+func NewOperationCosts(cfg config.OperationTimeConfig) OperationCosts {
+	return OperationCosts{
+		"+":   cfg.Addition,
+		"-":   cfg.Subtraction,
+		"*":   cfg.Multiplication,
+		"/":   cfg.Division,
+		"^":   cfg.Exponentiation,
+		"neg": cfg.Subtraction,
+	}
+}
+
+// CostEstimate - прогноз вычисления выражения без учета сетевых задержек и ожидания в очереди задач.
+type CostEstimate struct {
+	// Operations - число вызовов Воркера по символу операции.
+	Operations map[string]int
+	// CriticalPathOperations и CriticalPath - самая длинная цепочка зависимых вызовов: ее нельзя ускорить параллелизмом.
+	CriticalPathOperations int
+	CriticalPath           time.Duration
+	// TotalWork - сумма времени всех вызовов.
+	TotalWork time.Duration
+	// Parallelism - сколько вызовов Воркера могут идти одновременно.
+	Parallelism int
+	Duration    time.Duration
+}
+
+// EstimateCost оценивает время вычисления узла: не меньше критического пути и не меньше
+// всей работы, поделенной на доступные слоты Воркера.
+func EstimateCost(node ast.Node, costs OperationCosts, parallelism int) CostEstimate {
+	estimate := CostEstimate{Operations: make(map[string]int), Parallelism: max(parallelism, 1)}
+	estimate.CriticalPathOperations, estimate.CriticalPath = estimate.walk(node, costs)
+	estimate.Duration = max(estimate.CriticalPath, estimate.TotalWork/time.Duration(estimate.Parallelism))
+	return estimate
+}
+
+// walk считает операции так же, как их вызывает ExpressionEvaluator: операнды узла вычисляются параллельно.
+func (e *CostEstimate) walk(node ast.Node, costs OperationCosts) (int, time.Duration) {
+	switch n := node.(type) {
+	case *ast.UnaryNode:
+		operations, path := e.walk(n.Node, costs)
+		if n.Operator != "-" {
+			return operations, path
+		}
+		return operations + 1, path + e.record("neg", costs)
+	case *ast.BinaryNode:
+		leftOperations, leftPath := e.walk(n.Left, costs)
+		rightOperations, rightPath := e.walk(n.Right, costs)
+		symbol := n.Operator
+		if symbol == "**" {
+			symbol = "^"
+		}
+		if rightPath > leftPath || (rightPath == leftPath && rightOperations > leftOperations) {
+			leftOperations, leftPath = rightOperations, rightPath
+		}
+		return leftOperations + 1, leftPath + e.record(symbol, costs)
+	default:
+		return 0, 0
+	}
+}
+
+func (e *CostEstimate) record(symbol string, costs OperationCosts) time.Duration {
+	e.Operations[symbol]++
+	e.TotalWork += costs[symbol]
+	return costs[symbol]
+}
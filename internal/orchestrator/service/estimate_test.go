@@ -0,0 +1,57 @@
+package service
+
+import (
+	"testing"
+	"time"
+
+	"github.com/Qu1nel/YaLyceum-GoProject-Final/internal/orchestrator/config"
+	"github.com/expr-lang/expr"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func testOperationCosts() OperationCosts {
+	return NewOperationCosts(config.OperationTimeConfig{
+		Addition:       100 * time.Millisecond,
+		Subtraction:    100 * time.Millisecond,
+		Multiplication: 200 * time.Millisecond,
+		Division:       300 * time.Millisecond,
+		Exponentiation: 400 * time.Millisecond,
+	})
+}
+
+func TestEstimateCost_CriticalPathAndParallelism(t *testing.T) {
+	program, err := expr.Compile("(1+2)*(3+4) + -5 ** 2", expr.Optimize(false))
+	require.NoError(t, err)
+
+	estimate := EstimateCost(program.Node(), testOperationCosts(), 2)
+	assert.Equal(t, map[string]int{"+": 3, "*": 1, "^": 1, "neg": 1}, estimate.Operations)
+	// Ветка (1+2)*(3+4) занимает 100 + 200 мс, ветка -5**2 - 400 + 100 мс, затем корневое сложение.
+	assert.Equal(t, 3, estimate.CriticalPathOperations)
+	assert.Equal(t, 600*time.Millisecond, estimate.CriticalPath)
+	assert.Equal(t, 1000*time.Millisecond, estimate.TotalWork)
+	assert.Equal(t, 600*time.Millisecond, estimate.Duration)
+}
+
+func TestEstimateCost_LimitedByCapacity(t *testing.T) {
+	program, err := expr.Compile("1+2+3+4", expr.Optimize(false))
+	require.NoError(t, err)
+
+	oneSlot := EstimateCost(program.Node(), testOperationCosts(), 0)
+	assert.Equal(t, 1, oneSlot.Parallelism)
+	assert.Equal(t, 300*time.Millisecond, oneSlot.Duration)
+
+	program, err = expr.Compile("(1+2)*(3+4)", expr.Optimize(false))
+	require.NoError(t, err)
+	assert.Equal(t, 400*time.Millisecond, EstimateCost(program.Node(), testOperationCosts(), 1).Duration)
+	assert.Equal(t, 300*time.Millisecond, EstimateCost(program.Node(), testOperationCosts(), 4).Duration)
+}
+
+func TestEstimateCost_Number(t *testing.T) {
+	program, err := expr.Compile("42", expr.Optimize(false))
+	require.NoError(t, err)
+
+	estimate := EstimateCost(program.Node(), testOperationCosts(), 4)
+	assert.Empty(t, estimate.Operations)
+	assert.Zero(t, estimate.Duration)
+}
@@ -0,0 +1,277 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/Qu1nel/YaLyceum-GoProject-Final/internal/orchestrator/repository"
+	"github.com/google/uuid"
+)
+
+var ErrTaskForceFailed = errors.New("задача принудительно завершена администратором")
+
+// ActiveEvaluation - задача, которая сейчас вычисляется в этом процессе Оркестратора.
+type ActiveEvaluation struct {
+	TaskID             uuid.UUID
+	UserID             uuid.UUID
+	Expression         string
+	AttemptNumber      int
+	StartedAt          time.Time
+	InflightOperations int
+	OperationsDone     int
+	OperationsTotal    int
+}
+
+// WorkerActivity - Воркер, к которому обращались вычисления, по адресу из соединения.
+type WorkerActivity struct {
+	Address          string
+	LastSeenAt       time.Time
+	Operations       int64
+	FailedOperations int64
+}
+
+// EvaluationSnapshot - промежуточное состояние идущего вычисления.
+type EvaluationSnapshot struct {
+	AttemptNumber int
+	// Operations - завершенные вызовы Воркера в порядке запуска.
+	Operations []repository.TaskOperation
+	// Inflight - время начала выполняющихся вызовов Воркера по пути узла в AST.
+	Inflight map[string]time.Time
+}
+
+// ForceFailOutcome описывает, кто должен записать статус failed после ForceFail.
+type ForceFailOutcome int
+
+const (
+	// ForceFailUntracked - задача не отслеживается (например, вычисление потеряно при перезапуске), статус записывает вызывающий.
+	ForceFailUntracked ForceFailOutcome = iota
+	// ForceFailQueued - задача снята с ожидания в очереди и не будет запущена, статус записывает вызывающий.
+	ForceFailQueued
+	// ForceFailRunning - вычисление отменено и само запишет статус failed.
+	ForceFailRunning
+)
+
+// EvaluationRegistry отслеживает вычисления от постановки в очередь до завершения
+// и Воркеры, к которым они обращались.
+type EvaluationRegistry interface {
+	// Track регистрирует поставленную в очередь попытку вычисления.
+	Track(evaluation ActiveEvaluation, attemptID uuid.UUID)
+	// Begin отмечает запуск вычисления и возвращает контекст, отменяемый ForceFail.
+	// recorder (может быть nil) - накопитель трассировки вычисления для Snapshot.
+	// false означает, что задача была принудительно завершена, пока ждала в очереди.
+	Begin(ctx context.Context, taskID uuid.UUID, recorder *TraceRecorder) (context.Context, bool)
+	// Finish снимает задачу с учета после завершения вычисления или неудачной постановки в очередь.
+	Finish(taskID uuid.UUID)
+	// ForceFail завершает вычисление задачи с причиной reason. attemptID - попытка из Track.
+	ForceFail(taskID uuid.UUID, reason string) (outcome ForceFailOutcome, attemptID uuid.UUID)
+	Active() []ActiveEvaluation
+	// Snapshot возвращает состояние вычисления задачи, если оно сейчас идет.
+	Snapshot(taskID uuid.UUID) (*EvaluationSnapshot, bool)
+	Workers() []WorkerActivity
+}
+
+type trackedEvaluation struct {
+	info      ActiveEvaluation
+	attemptID uuid.UUID
+	started   bool
+	failed    bool
+	cancel    context.CancelCauseFunc
+	recorder  *TraceRecorder
+	inflight  map[string]time.Time
+}
+
+type inMemoryEvaluationRegistry struct {
+	mu          sync.Mutex
+	now         func() time.Time
+	evaluations map[uuid.UUID]*trackedEvaluation
+	workers     map[string]*WorkerActivity
+}
+
+func NewEvaluationRegistry() EvaluationRegistry {
+	return &inMemoryEvaluationRegistry{
+		now:         time.Now,
+		evaluations: make(map[uuid.UUID]*trackedEvaluation),
+		workers:     make(map[string]*WorkerActivity),
+	}
+}
+
+type trackedEvaluationContextKey struct{}
+
+func (r *inMemoryEvaluationRegistry) Track(evaluation ActiveEvaluation, attemptID uuid.UUID) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.evaluations[evaluation.TaskID] = &trackedEvaluation{info: evaluation, attemptID: attemptID}
+}
+
+func (r *inMemoryEvaluationRegistry) Begin(ctx context.Context, taskID uuid.UUID, recorder *TraceRecorder) (context.Context, bool) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	tracked, ok := r.evaluations[taskID]
+	if !ok {
+		return ctx, true
+	}
+	if tracked.failed {
+		delete(r.evaluations, taskID)
+		return ctx, false
+	}
+	evalCtx, cancel := context.WithCancelCause(ctx)
+	tracked.started = true
+	tracked.cancel = cancel
+	tracked.recorder = recorder
+	tracked.inflight = make(map[string]time.Time)
+	tracked.info.StartedAt = r.now()
+	return context.WithValue(evalCtx, trackedEvaluationContextKey{}, trackedEvaluationRef{registry: r, tracked: tracked}), true
+}
+
+func (r *inMemoryEvaluationRegistry) Finish(taskID uuid.UUID) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if tracked, ok := r.evaluations[taskID]; ok {
+		if tracked.cancel != nil {
+			tracked.cancel(nil)
+		}
+		delete(r.evaluations, taskID)
+	}
+}
+
+func (r *inMemoryEvaluationRegistry) ForceFail(taskID uuid.UUID, reason string) (ForceFailOutcome, uuid.UUID) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	tracked, ok := r.evaluations[taskID]
+	if !ok || tracked.failed {
+		return ForceFailUntracked, uuid.Nil
+	}
+	tracked.failed = true
+	if !tracked.started {
+		return ForceFailQueued, tracked.attemptID
+	}
+	tracked.cancel(NewForceFailedError(reason))
+	return ForceFailRunning, tracked.attemptID
+}
+
+func (r *inMemoryEvaluationRegistry) Active() []ActiveEvaluation {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	active := make([]ActiveEvaluation, 0, len(r.evaluations))
+	for _, tracked := range r.evaluations {
+		if tracked.started {
+			active = append(active, tracked.info)
+		}
+	}
+	sort.Slice(active, func(i, j int) bool {
+		return active[i].StartedAt.Before(active[j].StartedAt)
+	})
+	return active
+}
+
+func (r *inMemoryEvaluationRegistry) Snapshot(taskID uuid.UUID) (*EvaluationSnapshot, bool) {
+	r.mu.Lock()
+	tracked, ok := r.evaluations[taskID]
+	if !ok || !tracked.started {
+		r.mu.Unlock()
+		return nil, false
+	}
+	snapshot := &EvaluationSnapshot{
+		AttemptNumber: tracked.info.AttemptNumber,
+		Inflight:      make(map[string]time.Time, len(tracked.inflight)),
+	}
+	for path, startedAt := range tracked.inflight {
+		snapshot.Inflight[path] = startedAt
+	}
+	recorder := tracked.recorder
+	r.mu.Unlock()
+
+	// Накопитель берет собственную блокировку и вызывает под ней публикацию событий, поэтому читается вне r.mu.
+	if recorder != nil {
+		snapshot.Operations = recorder.Operations()
+	}
+	return snapshot, true
+}
+
+func (r *inMemoryEvaluationRegistry) Workers() []WorkerActivity {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	workers := make([]WorkerActivity, 0, len(r.workers))
+	for _, worker := range r.workers {
+		workers = append(workers, *worker)
+	}
+	sort.Slice(workers, func(i, j int) bool {
+		return workers[i].Address < workers[j].Address
+	})
+	return workers
+}
+
+// trackedEvaluationRef кладется в контекст вычисления, чтобы evaluator отмечал вызовы Воркера.
+type trackedEvaluationRef struct {
+	registry *inMemoryEvaluationRegistry
+	tracked  *trackedEvaluation
+}
+
+func (ref trackedEvaluationRef) operationStarted(nodePath string) {
+	ref.registry.mu.Lock()
+	defer ref.registry.mu.Unlock()
+	ref.tracked.info.InflightOperations++
+	ref.tracked.inflight[nodePath] = ref.registry.now()
+}
+
+// operationFinished учитывает завершенный вызов Воркера. address пуст, если соединение с Воркером не установлено.
+func (ref trackedEvaluationRef) operationFinished(nodePath, address string, err error) {
+	ref.registry.mu.Lock()
+	defer ref.registry.mu.Unlock()
+	ref.tracked.info.InflightOperations--
+	delete(ref.tracked.inflight, nodePath)
+	ref.tracked.info.OperationsDone++
+
+	if address == "" {
+		return
+	}
+	worker, ok := ref.registry.workers[address]
+	if !ok {
+		worker = &WorkerActivity{Address: address}
+		ref.registry.workers[address] = worker
+	}
+	worker.LastSeenAt = ref.registry.now()
+	worker.Operations++
+	if err != nil {
+		worker.FailedOperations++
+	}
+}
+
+func trackedEvaluationFromContext(ctx context.Context) (trackedEvaluationRef, bool) {
+	ref, ok := ctx.Value(trackedEvaluationContextKey{}).(trackedEvaluationRef)
+	return ref, ok
+}
+
+type forceFailedError string
+
+// NewForceFailedError возвращает ошибку принудительного завершения с причиной, указанной администратором.
+func NewForceFailedError(reason string) error {
+	return forceFailedError(reason)
+}
+
+func (e forceFailedError) Error() string {
+	if e == "" {
+		return ErrTaskForceFailed.Error()
+	}
+	return ErrTaskForceFailed.Error() + ": " + string(e)
+}
+
+func (e forceFailedError) Unwrap() error {
+	return ErrTaskForceFailed
+}
+
+// ForceFailedCause возвращает ошибку принудительного завершения, если контекст вычисления отменен через ForceFail.
+func ForceFailedCause(ctx context.Context) error {
+	if cause := context.Cause(ctx); errors.Is(cause, ErrTaskForceFailed) {
+		return cause
+	}
+	return nil
+}
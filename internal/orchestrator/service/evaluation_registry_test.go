@@ -0,0 +1,90 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestEvaluationRegistry_TracksRunningEvaluationAndWorkers(t *testing.T) {
+	registry := NewEvaluationRegistry().(*inMemoryEvaluationRegistry)
+	now := time.Date(2025, 5, 1, 12, 0, 0, 0, time.UTC)
+	registry.now = func() time.Time { return now }
+	taskID := uuid.New()
+
+	registry.Track(ActiveEvaluation{TaskID: taskID, Expression: "1+2*3", AttemptNumber: 1, OperationsTotal: 2}, uuid.New())
+	assert.Empty(t, registry.Active(), "задача в очереди не считается вычисляемой")
+
+	ctx, started := registry.Begin(context.Background(), taskID, nil)
+	require.True(t, started)
+
+	tracked, ok := trackedEvaluationFromContext(ctx)
+	require.True(t, ok)
+	tracked.operationStarted("0.0")
+	tracked.operationStarted("0.1")
+	tracked.operationFinished("0.1", "10.0.0.2:50052", nil)
+
+	active := registry.Active()
+	require.Len(t, active, 1)
+	assert.Equal(t, taskID, active[0].TaskID)
+	assert.Equal(t, now, active[0].StartedAt)
+	assert.Equal(t, 1, active[0].InflightOperations)
+	assert.Equal(t, 1, active[0].OperationsDone)
+
+	snapshot, ok := registry.Snapshot(taskID)
+	require.True(t, ok)
+	assert.Equal(t, map[string]time.Time{"0.0": now}, snapshot.Inflight)
+
+	tracked.operationFinished("0.0", "10.0.0.2:50052", errors.New("деление на ноль"))
+	assert.Equal(t, []WorkerActivity{{Address: "10.0.0.2:50052", LastSeenAt: now, Operations: 2, FailedOperations: 1}}, registry.Workers())
+
+	registry.Finish(taskID)
+	assert.Empty(t, registry.Active())
+	assert.ErrorIs(t, ctx.Err(), context.Canceled)
+	assert.NoError(t, ForceFailedCause(ctx))
+}
+
+func TestEvaluationRegistry_ForceFailRunningCancelsContext(t *testing.T) {
+	registry := NewEvaluationRegistry()
+	taskID, attemptID := uuid.New(), uuid.New()
+	registry.Track(ActiveEvaluation{TaskID: taskID}, attemptID)
+	ctx, started := registry.Begin(context.Background(), taskID, nil)
+	require.True(t, started)
+
+	outcome, gotAttemptID := registry.ForceFail(taskID, "зависло")
+
+	assert.Equal(t, ForceFailRunning, outcome)
+	assert.Equal(t, attemptID, gotAttemptID)
+	<-ctx.Done()
+	cause := ForceFailedCause(ctx)
+	require.ErrorIs(t, cause, ErrTaskForceFailed)
+	assert.Equal(t, "задача принудительно завершена администратором: зависло", cause.Error())
+
+	outcome, _ = registry.ForceFail(taskID, "повторно")
+	assert.Equal(t, ForceFailUntracked, outcome, "повторная отмена не должна отменять вычисление еще раз")
+}
+
+func TestEvaluationRegistry_ForceFailQueuedPreventsStart(t *testing.T) {
+	registry := NewEvaluationRegistry()
+	taskID, attemptID := uuid.New(), uuid.New()
+	registry.Track(ActiveEvaluation{TaskID: taskID}, attemptID)
+
+	outcome, gotAttemptID := registry.ForceFail(taskID, "")
+	assert.Equal(t, ForceFailQueued, outcome)
+	assert.Equal(t, attemptID, gotAttemptID)
+
+	_, started := registry.Begin(context.Background(), taskID, nil)
+	assert.False(t, started)
+	assert.Empty(t, registry.Active())
+}
+
+func TestEvaluationRegistry_ForceFailUntracked(t *testing.T) {
+	outcome, attemptID := NewEvaluationRegistry().ForceFail(uuid.New(), "")
+	assert.Equal(t, ForceFailUntracked, outcome)
+	assert.Equal(t, uuid.Nil, attemptID)
+}
@@ -7,11 +7,15 @@ import (
 	"sync"
 	"time"
 
+	"github.com/Qu1nel/YaLyceum-GoProject-Final/internal/orchestrator/repository"
+	"github.com/Qu1nel/YaLyceum-GoProject-Final/internal/pkg/logger"
 	pb_worker "github.com/Qu1nel/YaLyceum-GoProject-Final/proto/gen/worker"
 	"github.com/expr-lang/expr/ast"
 	"github.com/google/uuid"
 	"go.uber.org/zap"
+	"google.golang.org/grpc"
 	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/peer"
 	"google.golang.org/grpc/status"
 )
 
@@ -27,16 +31,19 @@ type Evaluator interface {
 type ExpressionEvaluator struct {
 	log          *zap.Logger
 	workerClient pb_worker.WorkerServiceClient
+	scheduler    OperationScheduler
 }
 
-func NewExpressionEvaluator(log *zap.Logger, workerClient pb_worker.WorkerServiceClient) Evaluator {
+func NewExpressionEvaluator(log *zap.Logger, workerClient pb_worker.WorkerServiceClient, scheduler OperationScheduler) Evaluator {
 	return &ExpressionEvaluator{
 		log:          log,
 		workerClient: workerClient,
+		scheduler:    scheduler,
 	}
 }
 
 func (e *ExpressionEvaluator) Evaluate(ctx context.Context, node ast.Node) (float64, error) {
+	log := logger.FromContext(ctx, e.log)
 	select {
 	case <-ctx.Done():
 		return 0, fmt.Errorf("вычисление узла отменено перед обработкой: %w", ctx.Err())
@@ -49,14 +56,14 @@ func (e *ExpressionEvaluator) Evaluate(ctx context.Context, node ast.Node) (floa
 	case *ast.FloatNode:
 		return n.Value, nil
 	case *ast.UnaryNode:
-		operandVal, err := e.Evaluate(ctx, n.Node)
+		operandVal, err := e.Evaluate(contextWithChildNode(ctx, 0), n.Node)
 		if err != nil {
 			return 0, fmt.Errorf("ошибка вычисления операнда для унарной операции '%s': %w", n.Operator, err)
 		}
 		if n.Operator == "-" {
 			return e.callWorker(ctx, "neg", operandVal, 0)
 		}
-		e.log.Error("Неподдерживаемый унарный оператор", zap.String("operator", n.Operator))
+		log.Error("Неподдерживаемый унарный оператор", zap.String("operator", n.Operator))
 		return 0, fmt.Errorf("%w: унарный оператор '%s'", ErrUnsupportedNodeType, n.Operator)
 	case *ast.BinaryNode:
 		opSymbol := n.Operator
@@ -73,7 +80,7 @@ func (e *ExpressionEvaluator) Evaluate(ctx context.Context, node ast.Node) (floa
 
 		go func() {
 			defer wg.Done()
-			val, err := e.Evaluate(ctx, n.Left)
+			val, err := e.Evaluate(contextWithChildNode(ctx, 0), n.Left)
 			if err != nil {
 				errChan <- fmt.Errorf("левый операнд для '%s': %w", opSymbol, err)
 				return
@@ -83,7 +90,7 @@ func (e *ExpressionEvaluator) Evaluate(ctx context.Context, node ast.Node) (floa
 
 		go func() {
 			defer wg.Done()
-			val, err := e.Evaluate(ctx, n.Right)
+			val, err := e.Evaluate(contextWithChildNode(ctx, 1), n.Right)
 			if err != nil {
 				errChan <- fmt.Errorf("правый операнд для '%s': %w", opSymbol, err)
 				return
@@ -98,7 +105,7 @@ func (e *ExpressionEvaluator) Evaluate(ctx context.Context, node ast.Node) (floa
 
 		for i := 0; i < 2; i++ {
 			if evalErr := <-errChan; evalErr != nil {
-				e.log.Debug("Ошибка от дочернего узла при вычислении бинарной операции", zap.Error(evalErr))
+				log.Debug("Ошибка от дочернего узла при вычислении бинарной операции", zap.Error(evalErr))
 				return 0, evalErr
 			}
 		}
@@ -106,13 +113,13 @@ func (e *ExpressionEvaluator) Evaluate(ctx context.Context, node ast.Node) (floa
 		leftVal := <-leftChan
 		rightVal := <-rightChan
 
-		e.log.Debug("Вызов callWorker для бинарной операции",
+		log.Debug("Вызов callWorker для бинарной операции",
 			zap.String("operator", opSymbol),
 			zap.Float64("left", leftVal),
 			zap.Float64("right", rightVal),
 		)
 		result, workerErr := e.callWorker(ctx, opSymbol, leftVal, rightVal)
-		e.log.Debug("Результат от callWorker для бинарной операции",
+		log.Debug("Результат от callWorker для бинарной операции",
 			zap.String("operator", opSymbol),
 			zap.Float64("result", result),
 			zap.Error(workerErr),
@@ -123,14 +130,14 @@ func (e *ExpressionEvaluator) Evaluate(ctx context.Context, node ast.Node) (floa
 		funcIdentNode, ok := n.Callee.(*ast.IdentifierNode)
 		if !ok {
 
-			e.log.Error("Узел функции CallNode имеет Callee не типа IdentifierNode",
+			log.Error("Узел функции CallNode имеет Callee не типа IdentifierNode",
 				zap.Any("callee_type", fmt.Sprintf("%T", n.Callee)),
 			)
 			return 0, fmt.Errorf("%w: неподдерживаемый тип вызываемого объекта в CallNode (%T)", ErrUnsupportedNodeType, n.Callee)
 		}
 		funcName := funcIdentNode.Value
 
-		e.log.Info("Обнаружен вызов функции (пока не реализовано)",
+		log.Info("Обнаружен вызов функции (пока не реализовано)",
 			zap.String("function_name", funcName),
 			zap.Int("arg_count", len(n.Arguments)),
 		)
@@ -141,17 +148,31 @@ func (e *ExpressionEvaluator) Evaluate(ctx context.Context, node ast.Node) (floa
 		*ast.MemberNode, *ast.SliceNode, *ast.ArrayNode, *ast.MapNode,
 		*ast.ConditionalNode, *ast.BuiltinNode,
 		*ast.PointerNode, *ast.ConstantNode:
-		e.log.Error("Неподдерживаемый тип узла AST в Evaluate", zap.Any("type", fmt.Sprintf("%T", n)))
+		log.Error("Неподдерживаемый тип узла AST в Evaluate", zap.Any("type", fmt.Sprintf("%T", n)))
 		return 0, fmt.Errorf("%w: %T", ErrUnsupportedNodeType, n)
 	default:
-		e.log.Error("Неизвестный тип узла AST в Evaluate", zap.Any("type", fmt.Sprintf("%T", n)))
+		log.Error("Неизвестный тип узла AST в Evaluate", zap.Any("type", fmt.Sprintf("%T", n)))
 		return 0, fmt.Errorf("%w: неизвестный тип %T", ErrUnsupportedNodeType, n)
 	}
 }
 
-func (e *ExpressionEvaluator) callWorker(ctx context.Context, opSymbol string, a, b float64) (float64, error) {
+func (e *ExpressionEvaluator) callWorker(ctx context.Context, opSymbol string, a, b float64) (result float64, err error) {
+	log := logger.FromContext(ctx, e.log)
 	operationID := uuid.NewString()
-	e.log.Debug("Отправка операции Воркеру",
+	userID := UserIDFromContext(ctx)
+
+	release, acquireErr := e.scheduler.Acquire(ctx, userID)
+	if acquireErr != nil {
+		log.Warn("Не дождались слота Воркера для операции",
+			zap.String("operationID", operationID),
+			zap.Stringer("userID", userID),
+			zap.Error(acquireErr),
+		)
+		return 0, fmt.Errorf("ожидание воркера для операции '%s' прервано: %w: %w", opSymbol, ErrEvaluationTimeout, acquireErr)
+	}
+	defer release()
+
+	log.Debug("Отправка операции Воркеру",
 		zap.String("operationID", operationID),
 		zap.String("symbol", opSymbol),
 		zap.Float64("a", a),
@@ -168,15 +189,29 @@ func (e *ExpressionEvaluator) callWorker(ctx context.Context, opSymbol string, a
 		OperandB:        b,
 	}
 
-	res, grpcErr := e.workerClient.CalculateOperation(opCtx, req)
-	e.log.Debug("Ответ от Воркера (сырой) в callWorker",
+	tracked, isTracked := trackedEvaluationFromContext(ctx)
+	if isTracked {
+		tracked.operationStarted(nodePathFromContext(ctx))
+	}
+
+	var workerPeer peer.Peer
+	startedAt := time.Now()
+	defer func() {
+		e.recordOperation(ctx, req, &workerPeer, startedAt, result, err)
+		if isTracked {
+			tracked.operationFinished(nodePathFromContext(ctx), peerAddress(&workerPeer), err)
+		}
+	}()
+
+	res, grpcErr := e.workerClient.CalculateOperation(opCtx, req, grpc.Peer(&workerPeer))
+	log.Debug("Ответ от Воркера (сырой) в callWorker",
 		zap.String("operationID", req.OperationId),
 		zap.Any("response_body", res),
 		zap.Error(grpcErr),
 	)
 
 	if grpcErr != nil {
-		e.log.Warn("ExpressionEvaluator.callWorker: Ошибка gRPC вызова Воркера",
+		log.Warn("ExpressionEvaluator.callWorker: Ошибка gRPC вызова Воркера",
 			zap.String("operationID", req.OperationId),
 			zap.String("symbol", opSymbol),
 			zap.Error(grpcErr),
@@ -189,28 +224,65 @@ func (e *ExpressionEvaluator) callWorker(ctx context.Context, opSymbol string, a
 			if st.Code() == codes.DeadlineExceeded || errors.Is(opCtx.Err(), context.DeadlineExceeded) || errors.Is(opCtx.Err(), context.Canceled) {
 				return 0, fmt.Errorf("таймаут/отмена операции '%s': %w", opSymbol, ErrEvaluationTimeout)
 			}
+			if st.Code() == codes.Unavailable {
+				return 0, NewEvaluationError(repository.ErrorCodeWorkerUnavailable,
+					fmt.Errorf("воркер недоступен при операции '%s': %w", opSymbol, grpcErr))
+			}
 			return 0, fmt.Errorf("gRPC ошибка от воркера (код %s) при операции '%s': %s", st.Code(), opSymbol, st.Message())
 		}
-		return 0, fmt.Errorf("ошибка связи с воркером при операции '%s': %w", opSymbol, grpcErr)
+		return 0, NewEvaluationError(repository.ErrorCodeWorkerUnavailable,
+			fmt.Errorf("ошибка связи с воркером при операции '%s': %w", opSymbol, grpcErr))
 	}
 
 	if res != nil && res.ErrorMessage != "" {
-		e.log.Warn("Воркер вернул ошибку в теле ответа (gRPC вызов успешен)",
+		log.Warn("Воркер вернул ошибку в теле ответа (gRPC вызов успешен)",
 			zap.String("operationID", req.OperationId),
 			zap.String("symbol", opSymbol),
 			zap.String("workerError", res.ErrorMessage),
+			zap.Stringer("workerErrorCode", res.ErrorCode),
 		)
-		return 0, errors.New(res.ErrorMessage)
+		return 0, NewEvaluationError(workerFailureCode(res.ErrorCode), errors.New(res.ErrorMessage))
 	}
 
 	if res == nil {
-		e.log.Error("Неожиданный nil ответ от воркера без ошибки gRPC", zap.String("operationID", req.OperationId))
+		log.Error("Неожиданный nil ответ от воркера без ошибки gRPC", zap.String("operationID", req.OperationId))
 		return 0, fmt.Errorf("неожиданный пустой ответ от воркера для операции '%s'", opSymbol)
 	}
 
-	e.log.Debug("Операция успешно выполнена Воркером",
+	log.Debug("Операция успешно выполнена Воркером",
 		zap.String("operationID", req.OperationId),
 		zap.Float64("result", res.Result),
 	)
 	return res.Result, nil
 }
+
+func (e *ExpressionEvaluator) recordOperation(ctx context.Context, req *pb_worker.CalculateOperationRequest, workerPeer *peer.Peer, startedAt time.Time, result float64, err error) {
+	recorder := traceRecorderFromContext(ctx)
+	if recorder == nil {
+		return
+	}
+	op := repository.TaskOperation{
+		OperationID: req.OperationId,
+		NodePath:    nodePathFromContext(ctx),
+		Symbol:      req.OperationSymbol,
+		OperandA:    req.OperandA,
+		OperandB:    req.OperandB,
+		StartedAt:   startedAt,
+		Duration:    time.Since(startedAt),
+	}
+	op.WorkerAddress = peerAddress(workerPeer)
+	if err != nil {
+		errMsg := err.Error()
+		op.ErrorMessage = &errMsg
+	} else {
+		op.Result = &result
+	}
+	recorder.record(op)
+}
+
+func peerAddress(p *peer.Peer) string {
+	if p.Addr == nil {
+		return ""
+	}
+	return p.Addr.String()
+}
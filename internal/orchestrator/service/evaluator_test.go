@@ -6,6 +6,8 @@ import (
 	"testing"
 	"time"
 
+	"github.com/Qu1nel/YaLyceum-GoProject-Final/internal/orchestrator/config"
+	"github.com/Qu1nel/YaLyceum-GoProject-Final/internal/orchestrator/repository"
 	"github.com/Qu1nel/YaLyceum-GoProject-Final/internal/orchestrator/service/mocks"
 	pb_worker "github.com/Qu1nel/YaLyceum-GoProject-Final/proto/gen/worker"
 	"github.com/expr-lang/expr/ast"
@@ -17,10 +19,16 @@ import (
 	"google.golang.org/grpc/status"
 )
 
+func testScheduler() OperationScheduler {
+	return NewFairOperationScheduler(zap.NewNop(), &config.Config{
+		Scheduler: config.SchedulerConfig{MaxInflight: 4, DefaultWeight: 1},
+	})
+}
+
 func setupEvaluatorTest(t *testing.T) (Evaluator, *mocks.WorkerServiceClientMock) {
 	logger := zap.NewNop()
 	mockWorkerClient := mocks.NewWorkerServiceClientMock(t)
-	evaluator := NewExpressionEvaluator(logger, mockWorkerClient)
+	evaluator := NewExpressionEvaluator(logger, mockWorkerClient, testScheduler())
 	return evaluator, mockWorkerClient
 }
 
@@ -28,7 +36,7 @@ func TestExpressionEvaluator_callWorker_Success(t *testing.T) {
 
 	logger := zap.NewNop()
 	mockWorkerClient := mocks.NewWorkerServiceClientMock(t)
-	evaluatorImpl := NewExpressionEvaluator(logger, mockWorkerClient).(*ExpressionEvaluator)
+	evaluatorImpl := NewExpressionEvaluator(logger, mockWorkerClient, testScheduler()).(*ExpressionEvaluator)
 
 	ctx := context.Background()
 	opSymbol := "+"
@@ -40,6 +48,7 @@ func TestExpressionEvaluator_callWorker_Success(t *testing.T) {
 		mock.MatchedBy(func(req *pb_worker.CalculateOperationRequest) bool {
 			return req.OperationSymbol == opSymbol && req.OperandA == opA && req.OperandB == opB
 		}),
+		mock.Anything,
 	).Return(&pb_worker.CalculateOperationResponse{Result: expectedResult}, nil).Once()
 
 	result, err := evaluatorImpl.callWorker(ctx, opSymbol, opA, opB)
@@ -51,30 +60,31 @@ func TestExpressionEvaluator_callWorker_Success(t *testing.T) {
 func TestExpressionEvaluator_callWorker_WorkerError(t *testing.T) {
 	logger := zap.NewNop()
 	mockWorkerClient := mocks.NewWorkerServiceClientMock(t)
-	evaluatorImpl := NewExpressionEvaluator(logger, mockWorkerClient).(*ExpressionEvaluator)
+	evaluatorImpl := NewExpressionEvaluator(logger, mockWorkerClient, testScheduler()).(*ExpressionEvaluator)
 
 	ctx := context.Background()
 	workerErrMsg := "деление на ноль от воркера"
 
-	mockWorkerClient.On("CalculateOperation", mock.Anything, mock.AnythingOfType("*worker_grpc.CalculateOperationRequest")).
-		Return(&pb_worker.CalculateOperationResponse{ErrorMessage: workerErrMsg}, nil).Once()
+	mockWorkerClient.On("CalculateOperation", mock.Anything, mock.AnythingOfType("*worker_grpc.CalculateOperationRequest"), mock.Anything).
+		Return(&pb_worker.CalculateOperationResponse{ErrorMessage: workerErrMsg, ErrorCode: pb_worker.ErrorCode_DIVISION_BY_ZERO}, nil).Once()
 
 	_, err := evaluatorImpl.callWorker(ctx, "/", 10.0, 0.0)
 	require.Error(t, err)
 	assert.Contains(t, err.Error(), "деление на ноль от воркера")
 	assert.Contains(t, err.Error(), workerErrMsg)
+	assert.Equal(t, repository.ErrorCodeDivisionByZero, FailureCode(err))
 	mockWorkerClient.AssertExpectations(t)
 }
 
 func TestExpressionEvaluator_callWorker_gRPCError(t *testing.T) {
 	logger := zap.NewNop()
 	mockWorkerClient := mocks.NewWorkerServiceClientMock(t)
-	evaluatorImpl := NewExpressionEvaluator(logger, mockWorkerClient).(*ExpressionEvaluator)
+	evaluatorImpl := NewExpressionEvaluator(logger, mockWorkerClient, testScheduler()).(*ExpressionEvaluator)
 
 	ctx := context.Background()
 	grpcErr := status.Error(codes.Unavailable, "воркер недоступен")
 
-	mockWorkerClient.On("CalculateOperation", mock.Anything, mock.AnythingOfType("*worker_grpc.CalculateOperationRequest")).
+	mockWorkerClient.On("CalculateOperation", mock.Anything, mock.AnythingOfType("*worker_grpc.CalculateOperationRequest"), mock.Anything).
 		Return(nil, grpcErr).Once()
 
 	_, err := evaluatorImpl.callWorker(ctx, "+", 1.0, 2.0)
@@ -82,13 +92,14 @@ func TestExpressionEvaluator_callWorker_gRPCError(t *testing.T) {
 	assert.Contains(t, err.Error(), "воркер недоступен")
 	_, ok := status.FromError(errors.Unwrap(err))
 	require.True(t, ok)
+	assert.Equal(t, repository.ErrorCodeWorkerUnavailable, FailureCode(err))
 	mockWorkerClient.AssertExpectations(t)
 }
 
 func TestExpressionEvaluator_callWorker_Timeout(t *testing.T) {
 	logger := zap.NewNop()
 	mockWorkerClient := mocks.NewWorkerServiceClientMock(t)
-	evaluatorImpl := NewExpressionEvaluator(logger, mockWorkerClient).(*ExpressionEvaluator)
+	evaluatorImpl := NewExpressionEvaluator(logger, mockWorkerClient, testScheduler()).(*ExpressionEvaluator)
 
 	ctx, cancel := context.WithTimeout(context.Background(), 1*time.Millisecond)
 	defer cancel()
@@ -96,6 +107,7 @@ func TestExpressionEvaluator_callWorker_Timeout(t *testing.T) {
 	mockWorkerClient.On("CalculateOperation",
 		mock.Anything,
 		mock.AnythingOfType("*worker_grpc.CalculateOperationRequest"),
+		mock.Anything,
 	).Return(nil, status.Error(codes.DeadlineExceeded, "контекст вызова операции истек")).Maybe()
 
 	time.Sleep(5 * time.Millisecond)
@@ -140,6 +152,7 @@ func TestExpressionEvaluator_Evaluate_UnaryNode_Minus(t *testing.T) {
 		mock.MatchedBy(func(req *pb_worker.CalculateOperationRequest) bool {
 			return req.OperationSymbol == "neg" && req.OperandA == 5.0
 		}),
+		mock.Anything,
 	).Return(&pb_worker.CalculateOperationResponse{Result: expectedWorkerResult}, nil).Once()
 
 	result, err := evaluator.Evaluate(ctx, node)
@@ -159,6 +172,7 @@ func TestExpressionEvaluator_Evaluate_BinaryNode_Simple(t *testing.T) {
 		mock.MatchedBy(func(req *pb_worker.CalculateOperationRequest) bool {
 			return req.OperationSymbol == "+" && req.OperandA == 2.0 && req.OperandB == 3.0
 		}),
+		mock.Anything,
 	).Return(&pb_worker.CalculateOperationResponse{Result: expectedWorkerResult}, nil).Once()
 
 	result, err := evaluator.Evaluate(ctx, node)
@@ -181,6 +195,7 @@ func TestExpressionEvaluator_Evaluate_BinaryNode_Nested(t *testing.T) {
 		mock.MatchedBy(func(req *pb_worker.CalculateOperationRequest) bool {
 			return req.OperationSymbol == "+" && req.OperandA == 2.0 && req.OperandB == 3.0
 		}),
+		mock.Anything,
 	).Return(&pb_worker.CalculateOperationResponse{Result: 5.0}, nil).Once()
 
 	mockWorkerClient.On("CalculateOperation",
@@ -188,6 +203,7 @@ func TestExpressionEvaluator_Evaluate_BinaryNode_Nested(t *testing.T) {
 		mock.MatchedBy(func(req *pb_worker.CalculateOperationRequest) bool {
 			return req.OperationSymbol == "*" && req.OperandA == 5.0 && req.OperandB == 4.0
 		}),
+		mock.Anything,
 	).Return(&pb_worker.CalculateOperationResponse{Result: 20.0}, nil).Once()
 
 	result, err := evaluator.Evaluate(ctx, node)
@@ -237,6 +253,7 @@ func TestExpressionEvaluator_Evaluate_ErrorInRightChild_WorkerCall(t *testing.T)
 		mock.MatchedBy(func(req *pb_worker.CalculateOperationRequest) bool {
 			return req.OperationSymbol == "neg" && req.OperandA == 5.0
 		}),
+		mock.Anything,
 	).Return(nil, grpcErr).Once()
 
 	_, err := evaluator.Evaluate(ctx, node)
@@ -262,6 +279,7 @@ func TestExpressionEvaluator_Evaluate_ContextCancelled(t *testing.T) {
 		mock.MatchedBy(func(req *pb_worker.CalculateOperationRequest) bool {
 			return req.OperationSymbol == "+" && req.OperandA == 2.0 && req.OperandB == 3.0
 		}),
+		mock.Anything,
 	).Run(func(args mock.Arguments) {
 		callCtx := args.Get(0).(context.Context)
 		select {
@@ -282,3 +300,46 @@ func TestExpressionEvaluator_Evaluate_ContextCancelled(t *testing.T) {
 	}
 	assert.True(t, ok, "Ожидалась ошибка context.Canceled, context.DeadlineExceeded или ErrEvaluationTimeout, получено: %v", err)
 }
+
+func TestExpressionEvaluator_Evaluate_RecordsTrace(t *testing.T) {
+	evaluator, mockWorkerClient := setupEvaluatorTest(t)
+	recorder := NewTraceRecorder(nil)
+	ctx := ContextWithTraceRecorder(context.Background(), recorder)
+	node := &ast.BinaryNode{
+		Operator: "/",
+		Left:     &ast.BinaryNode{Operator: "+", Left: &ast.IntegerNode{Value: 2}, Right: &ast.IntegerNode{Value: 3}},
+		Right:    &ast.IntegerNode{Value: 0},
+	}
+
+	mockWorkerClient.On("CalculateOperation",
+		mock.Anything,
+		mock.MatchedBy(func(req *pb_worker.CalculateOperationRequest) bool { return req.OperationSymbol == "+" }),
+		mock.Anything,
+	).Return(&pb_worker.CalculateOperationResponse{Result: 5.0}, nil).Once()
+	mockWorkerClient.On("CalculateOperation",
+		mock.Anything,
+		mock.MatchedBy(func(req *pb_worker.CalculateOperationRequest) bool { return req.OperationSymbol == "/" }),
+		mock.Anything,
+	).Return(&pb_worker.CalculateOperationResponse{ErrorMessage: "деление на ноль"}, nil).Once()
+
+	_, err := evaluator.Evaluate(ctx, node)
+	require.Error(t, err)
+
+	operations := recorder.Operations()
+	require.Len(t, operations, 2)
+
+	assert.Equal(t, 1, operations[0].Seq)
+	assert.Equal(t, "0.0", operations[0].NodePath)
+	assert.Equal(t, "+", operations[0].Symbol)
+	require.NotNil(t, operations[0].Result)
+	assert.Equal(t, 5.0, *operations[0].Result)
+	assert.NotEmpty(t, operations[0].OperationID)
+
+	assert.Equal(t, 2, operations[1].Seq)
+	assert.Equal(t, RootNodePath, operations[1].NodePath)
+	assert.Equal(t, 5.0, operations[1].OperandA)
+	assert.Nil(t, operations[1].Result)
+	require.NotNil(t, operations[1].ErrorMessage)
+	assert.Equal(t, "деление на ноль", *operations[1].ErrorMessage)
+	assert.False(t, operations[1].StartedAt.Before(operations[0].StartedAt))
+}
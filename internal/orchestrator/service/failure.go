@@ -0,0 +1,53 @@
+package service
+
+import (
+	"context"
+	"errors"
+
+	"github.com/Qu1nel/YaLyceum-GoProject-Final/internal/orchestrator/repository"
+	pb_worker "github.com/Qu1nel/YaLyceum-GoProject-Final/proto/gen/worker"
+)
+
+// EvaluationError - ошибка вычисления с известным типом (repository.ErrorCode*).
+type EvaluationError struct {
+	Code string
+	Err  error
+}
+
+func NewEvaluationError(code string, err error) *EvaluationError {
+	return &EvaluationError{Code: code, Err: err}
+}
+
+func (e *EvaluationError) Error() string { return e.Err.Error() }
+
+func (e *EvaluationError) Unwrap() error { return e.Err }
+
+// FailureCode определяет тип ошибки вычисления для поля error_code задачи.
+// Ошибки без известного типа считаются внутренними.
+func FailureCode(err error) string {
+	var evalErr *EvaluationError
+	switch {
+	case errors.Is(err, ErrTaskForceFailed):
+		return repository.ErrorCodeCancelled
+	case errors.As(err, &evalErr):
+		return evalErr.Code
+	case errors.Is(err, ErrEvaluationTimeout), errors.Is(err, context.DeadlineExceeded):
+		return repository.ErrorCodeTimeout
+	case errors.Is(err, context.Canceled):
+		return repository.ErrorCodeCancelled
+	}
+	return repository.ErrorCodeInternal
+}
+
+// workerFailureCode переводит тип ошибки из ответа Воркера. Неизвестный оператор означает ошибку Оркестратора.
+func workerFailureCode(code pb_worker.ErrorCode) string {
+	switch code {
+	case pb_worker.ErrorCode_DIVISION_BY_ZERO:
+		return repository.ErrorCodeDivisionByZero
+	case pb_worker.ErrorCode_DOMAIN_ERROR:
+		return repository.ErrorCodeDomainError
+	case pb_worker.ErrorCode_OVERFLOW:
+		return repository.ErrorCodeOverflow
+	}
+	return repository.ErrorCodeInternal
+}
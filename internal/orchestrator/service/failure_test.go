@@ -0,0 +1,35 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/Qu1nel/YaLyceum-GoProject-Final/internal/orchestrator/repository"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFailureCode(t *testing.T) {
+	divisionByZero := NewEvaluationError(repository.ErrorCodeDivisionByZero, errors.New("деление на ноль"))
+
+	testCases := []struct {
+		name string
+		err  error
+		want string
+	}{
+		{"Ошибка от Воркера", divisionByZero, repository.ErrorCodeDivisionByZero},
+		{"Обернутая ошибка операнда", fmt.Errorf("левый операнд для '+': %w", divisionByZero), repository.ErrorCodeDivisionByZero},
+		{"Таймаут операции", fmt.Errorf("таймаут/отмена операции '+': %w", ErrEvaluationTimeout), repository.ErrorCodeTimeout},
+		{"Таймаут вычисления", fmt.Errorf("вычисление узла отменено перед обработкой: %w", context.DeadlineExceeded), repository.ErrorCodeTimeout},
+		{"Принудительное завершение", ErrTaskForceFailed, repository.ErrorCodeCancelled},
+		{"Отмена контекста", context.Canceled, repository.ErrorCodeCancelled},
+		{"Неизвестная ошибка", errors.New("неожиданная ошибка"), repository.ErrorCodeInternal},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.want, FailureCode(tc.err))
+		})
+	}
+}
@@ -0,0 +1,179 @@
+package service
+
+import (
+	"context"
+	"sync"
+
+	"github.com/Qu1nel/YaLyceum-GoProject-Final/internal/orchestrator/config"
+	"github.com/Qu1nel/YaLyceum-GoProject-Final/internal/pkg/logger"
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+)
+
+type userIDContextKey struct{}
+
+func ContextWithUserID(ctx context.Context, userID uuid.UUID) context.Context {
+	return context.WithValue(ctx, userIDContextKey{}, userID)
+}
+
+func UserIDFromContext(ctx context.Context) uuid.UUID {
+	userID, _ := ctx.Value(userIDContextKey{}).(uuid.UUID)
+	return userID
+}
+
+type SchedulerStats struct {
+	Inflight    int
+	MaxInflight int
+	Waiting     int
+}
+
+type OperationScheduler interface {
+	Acquire(ctx context.Context, userID uuid.UUID) (release func(), err error)
+	Stats() SchedulerStats
+}
+
+type fairWaiter struct {
+	ready    chan struct{}
+	granted  bool
+	canceled bool
+}
+
+type userFlow struct {
+	userID  uuid.UUID
+	waiters []*fairWaiter
+	deficit int
+}
+
+type fairOperationScheduler struct {
+	log           *zap.Logger
+	mu            sync.Mutex
+	maxInflight   int
+	inflight      int
+	defaultWeight int
+	weights       map[uuid.UUID]int
+	flows         map[uuid.UUID]*userFlow
+	active        []*userFlow
+	cursor        int
+}
+
+func NewFairOperationScheduler(log *zap.Logger, cfg *config.Config) OperationScheduler {
+	return &fairOperationScheduler{
+		log:           log,
+		maxInflight:   cfg.Scheduler.MaxInflight,
+		defaultWeight: cfg.Scheduler.DefaultWeight,
+		weights:       cfg.Scheduler.UserWeights,
+		flows:         make(map[uuid.UUID]*userFlow),
+	}
+}
+
+func (s *fairOperationScheduler) Acquire(ctx context.Context, userID uuid.UUID) (func(), error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	w := &fairWaiter{ready: make(chan struct{})}
+
+	s.mu.Lock()
+	flow, ok := s.flows[userID]
+	if !ok {
+		flow = &userFlow{userID: userID}
+		s.flows[userID] = flow
+		s.active = append(s.active, flow)
+	}
+	flow.waiters = append(flow.waiters, w)
+	s.dispatchLocked()
+	s.mu.Unlock()
+
+	select {
+	case <-w.ready:
+		return s.releaseFunc(), nil
+	case <-ctx.Done():
+		s.mu.Lock()
+		if w.granted {
+			s.mu.Unlock()
+			s.release()
+			return nil, ctx.Err()
+		}
+		w.canceled = true
+		s.mu.Unlock()
+		logger.FromContext(ctx, s.log).Debug("Ожидание слота Воркера отменено", zap.Stringer("userID", userID), zap.Error(ctx.Err()))
+		return nil, ctx.Err()
+	}
+}
+
+func (s *fairOperationScheduler) releaseFunc() func() {
+	var once sync.Once
+	return func() {
+		once.Do(s.release)
+	}
+}
+
+func (s *fairOperationScheduler) release() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.inflight--
+	s.dispatchLocked()
+}
+
+func (s *fairOperationScheduler) dispatchLocked() {
+	for s.inflight < s.maxInflight && len(s.active) > 0 {
+		if s.cursor >= len(s.active) {
+			s.cursor = 0
+		}
+		flow := s.active[s.cursor]
+
+		for len(flow.waiters) > 0 && flow.waiters[0].canceled {
+			flow.waiters = flow.waiters[1:]
+		}
+		if len(flow.waiters) == 0 {
+			s.removeActiveLocked(s.cursor)
+			continue
+		}
+
+		if flow.deficit <= 0 {
+			flow.deficit += s.weightOf(flow.userID)
+		}
+
+		w := flow.waiters[0]
+		flow.waiters[0] = nil
+		flow.waiters = flow.waiters[1:]
+		w.granted = true
+		close(w.ready)
+		s.inflight++
+		flow.deficit--
+
+		if len(flow.waiters) == 0 {
+			s.removeActiveLocked(s.cursor)
+		} else if flow.deficit <= 0 {
+			s.cursor++
+		}
+	}
+}
+
+func (s *fairOperationScheduler) removeActiveLocked(i int) {
+	delete(s.flows, s.active[i].userID)
+	s.active = append(s.active[:i], s.active[i+1:]...)
+}
+
+func (s *fairOperationScheduler) weightOf(userID uuid.UUID) int {
+	if weight, ok := s.weights[userID]; ok && weight > 0 {
+		return weight
+	}
+	return s.defaultWeight
+}
+
+func (s *fairOperationScheduler) Stats() SchedulerStats {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	stats := SchedulerStats{Inflight: s.inflight, MaxInflight: s.maxInflight}
+	for _, flow := range s.active {
+		for _, w := range flow.waiters {
+			if !w.canceled {
+				stats.Waiting++
+			}
+		}
+	}
+	return stats
+}
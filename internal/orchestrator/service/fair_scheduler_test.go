@@ -0,0 +1,119 @@
+package service
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/Qu1nel/YaLyceum-GoProject-Final/internal/orchestrator/config"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+type grant struct {
+	label   string
+	release func()
+}
+
+func setupFairSchedulerTest(weights map[uuid.UUID]int) *fairOperationScheduler {
+	return NewFairOperationScheduler(zap.NewNop(), &config.Config{
+		Scheduler: config.SchedulerConfig{MaxInflight: 1, DefaultWeight: 1, UserWeights: weights},
+	}).(*fairOperationScheduler)
+}
+
+func waitingCount(s *fairOperationScheduler) int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	total := 0
+	for _, flow := range s.flows {
+		total += len(flow.waiters)
+	}
+	return total
+}
+
+func enqueueWaiter(t *testing.T, s *fairOperationScheduler, userID uuid.UUID, label string, grants chan<- grant) {
+	before := waitingCount(s)
+	go func() {
+		release, err := s.Acquire(context.Background(), userID)
+		if err != nil {
+			return
+		}
+		grants <- grant{label: label, release: release}
+	}()
+	require.Eventually(t, func() bool { return waitingCount(s) == before+1 }, time.Second, time.Millisecond)
+}
+
+func drainOrder(t *testing.T, grants <-chan grant, n int) []string {
+	order := make([]string, 0, n)
+	for i := 0; i < n; i++ {
+		select {
+		case g := <-grants:
+			order = append(order, g.label)
+			g.release()
+		case <-time.After(time.Second):
+			t.Fatalf("Не дождались выдачи слота, получено: %v", order)
+		}
+	}
+	return order
+}
+
+func TestFairOperationScheduler_EqualWeightsInterleaveUsers(t *testing.T) {
+	s := setupFairSchedulerTest(nil)
+	userA, userB := uuid.New(), uuid.New()
+	grants := make(chan grant, 8)
+
+	hold, err := s.Acquire(context.Background(), uuid.New())
+	require.NoError(t, err)
+
+	for i := 0; i < 4; i++ {
+		enqueueWaiter(t, s, userA, "A", grants)
+	}
+	for i := 0; i < 2; i++ {
+		enqueueWaiter(t, s, userB, "B", grants)
+	}
+
+	hold()
+	order := drainOrder(t, grants, 6)
+	assert.Equal(t, []string{"A", "B", "A", "B", "A", "A"}, order)
+}
+
+func TestFairOperationScheduler_RespectsUserWeights(t *testing.T) {
+	userA, userB := uuid.New(), uuid.New()
+	s := setupFairSchedulerTest(map[uuid.UUID]int{userA: 2})
+	grants := make(chan grant, 8)
+
+	hold, err := s.Acquire(context.Background(), uuid.New())
+	require.NoError(t, err)
+
+	for i := 0; i < 4; i++ {
+		enqueueWaiter(t, s, userA, "A", grants)
+	}
+	for i := 0; i < 2; i++ {
+		enqueueWaiter(t, s, userB, "B", grants)
+	}
+
+	hold()
+	order := drainOrder(t, grants, 6)
+	assert.Equal(t, []string{"A", "A", "B", "A", "A", "B"}, order)
+}
+
+func TestFairOperationScheduler_AcquireCancelled(t *testing.T) {
+	s := setupFairSchedulerTest(nil)
+
+	hold, err := s.Acquire(context.Background(), uuid.New())
+	require.NoError(t, err)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	_, err = s.Acquire(ctx, uuid.New())
+	require.Error(t, err)
+	assert.ErrorIs(t, err, context.DeadlineExceeded)
+
+	hold()
+
+	release, err := s.Acquire(context.Background(), uuid.New())
+	require.NoError(t, err, "Отмененный ожидающий не должен занимать слот")
+	release()
+}
@@ -0,0 +1,60 @@
+package service
+
+import (
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+)
+
+var (
+	taskStatusTransitions = promauto.NewCounterVec(prometheus.CounterOpts{
+		Name: "orchestrator_task_status_transitions_total",
+		Help: "Количество переходов задач в статус (pending при постановке в очередь, далее processing, completed или failed).",
+	}, []string{"status"})
+
+	taskEvaluationDuration = promauto.NewHistogramVec(prometheus.HistogramOpts{
+		Name:    "orchestrator_task_evaluation_duration_seconds",
+		Help:    "Время вычисления задачи от начала обработки до терминального статуса.",
+		Buckets: []float64{0.1, 0.25, 0.5, 1, 2.5, 5, 10, 30, 60},
+	}, []string{"status"})
+)
+
+// ObserveTaskStatus учитывает переход задачи в статус.
+func ObserveTaskStatus(status string) {
+	taskStatusTransitions.WithLabelValues(status).Inc()
+}
+
+// ObserveTaskEvaluation учитывает завершенное вычисление задачи с итоговым статусом.
+func ObserveTaskEvaluation(status string, duration time.Duration) {
+	taskStatusTransitions.WithLabelValues(status).Inc()
+	taskEvaluationDuration.WithLabelValues(status).Observe(duration.Seconds())
+}
+
+// NewQueueCollector публикует текущую загрузку очереди вычислений: число задач в статусе processing и ожидающих запуска.
+func NewQueueCollector(queue EvaluationQueue) prometheus.Collector {
+	return &queueCollector{
+		queue:   queue,
+		running: prometheus.NewDesc("orchestrator_evaluations_running", "Задачи, вычисляемые в данный момент.", nil, nil),
+		waiting: prometheus.NewDesc("orchestrator_evaluations_waiting", "Задачи, ожидающие запуска в очереди.", nil, nil),
+		limit:   prometheus.NewDesc("orchestrator_evaluations_max_concurrent", "Максимальное число одновременных вычислений.", nil, nil),
+	}
+}
+
+type queueCollector struct {
+	queue   EvaluationQueue
+	running *prometheus.Desc
+	waiting *prometheus.Desc
+	limit   *prometheus.Desc
+}
+
+func (c *queueCollector) Describe(ch chan<- *prometheus.Desc) {
+	prometheus.DescribeByCollect(c, ch)
+}
+
+func (c *queueCollector) Collect(ch chan<- prometheus.Metric) {
+	stats := c.queue.Stats()
+	ch <- prometheus.MustNewConstMetric(c.running, prometheus.GaugeValue, float64(stats.Running))
+	ch <- prometheus.MustNewConstMetric(c.waiting, prometheus.GaugeValue, float64(stats.Waiting))
+	ch <- prometheus.MustNewConstMetric(c.limit, prometheus.GaugeValue, float64(stats.MaxConcurrent))
+}
@@ -0,0 +1,136 @@
+package service
+
+import (
+	"errors"
+	"sync"
+
+	"github.com/Qu1nel/YaLyceum-GoProject-Final/internal/orchestrator/config"
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+)
+
+var ErrQueueFull = errors.New("очередь вычислений переполнена")
+
+type EvaluationJob func()
+
+type QueueStats struct {
+	Running       int
+	Waiting       int
+	MaxConcurrent int
+	MaxQueued     int
+}
+
+type EvaluationQueue interface {
+	Enqueue(taskID uuid.UUID, job EvaluationJob) (position int, err error)
+	Position(taskID uuid.UUID) (int, bool)
+	Full() bool
+	Stats() QueueStats
+}
+
+type queuedJob struct {
+	taskID uuid.UUID
+	job    EvaluationJob
+}
+
+type boundedEvaluationQueue struct {
+	log           *zap.Logger
+	mu            sync.Mutex
+	maxConcurrent int
+	maxQueued     int
+	running       int
+	waiting       []queuedJob
+}
+
+func NewEvaluationQueue(log *zap.Logger, cfg *config.Config) EvaluationQueue {
+	return &boundedEvaluationQueue{
+		log:           log,
+		maxConcurrent: cfg.Evaluation.MaxConcurrent,
+		maxQueued:     cfg.Evaluation.QueueSize,
+	}
+}
+
+func (q *boundedEvaluationQueue) Enqueue(taskID uuid.UUID, job EvaluationJob) (int, error) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	if q.running < q.maxConcurrent {
+		q.running++
+		go q.run(job)
+		q.log.Debug("Задача запущена без ожидания в очереди",
+			zap.Stringer("taskID", taskID),
+			zap.Int("running", q.running),
+		)
+		return 0, nil
+	}
+
+	if len(q.waiting) >= q.maxQueued {
+		q.log.Warn("Очередь вычислений переполнена, задача отклонена",
+			zap.Stringer("taskID", taskID),
+			zap.Int("waiting", len(q.waiting)),
+		)
+		return 0, ErrQueueFull
+	}
+
+	q.waiting = append(q.waiting, queuedJob{taskID: taskID, job: job})
+	position := len(q.waiting)
+	q.log.Info("Задача поставлена в очередь вычислений",
+		zap.Stringer("taskID", taskID),
+		zap.Int("position", position),
+	)
+	return position, nil
+}
+
+func (q *boundedEvaluationQueue) run(job EvaluationJob) {
+	defer q.done()
+	job()
+}
+
+func (q *boundedEvaluationQueue) done() {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	if len(q.waiting) == 0 {
+		q.running--
+		return
+	}
+
+	next := q.waiting[0]
+	q.waiting[0] = queuedJob{}
+	q.waiting = q.waiting[1:]
+	q.log.Debug("Задача извлечена из очереди вычислений",
+		zap.Stringer("taskID", next.taskID),
+		zap.Int("waiting", len(q.waiting)),
+	)
+	go q.run(next.job)
+}
+
+func (q *boundedEvaluationQueue) Position(taskID uuid.UUID) (int, bool) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	for i, item := range q.waiting {
+		if item.taskID == taskID {
+			return i + 1, true
+		}
+	}
+	return 0, false
+}
+
+func (q *boundedEvaluationQueue) Full() bool {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	return q.running >= q.maxConcurrent && len(q.waiting) >= q.maxQueued
+}
+
+func (q *boundedEvaluationQueue) Stats() QueueStats {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	return QueueStats{
+		Running:       q.running,
+		Waiting:       len(q.waiting),
+		MaxConcurrent: q.maxConcurrent,
+		MaxQueued:     q.maxQueued,
+	}
+}
@@ -0,0 +1,106 @@
+package service
+
+import (
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/Qu1nel/YaLyceum-GoProject-Final/internal/orchestrator/config"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+func setupQueueTest(maxConcurrent, queueSize int) EvaluationQueue {
+	return NewEvaluationQueue(zap.NewNop(), &config.Config{
+		Evaluation: config.EvaluationConfig{MaxConcurrent: maxConcurrent, QueueSize: queueSize},
+	})
+}
+
+func TestEvaluationQueue_LimitsConcurrencyAndReportsPosition(t *testing.T) {
+	queue := setupQueueTest(1, 2)
+	release := make(chan struct{})
+	started := make(chan uuid.UUID, 3)
+
+	blockingJob := func(id uuid.UUID) EvaluationJob {
+		return func() {
+			started <- id
+			<-release
+		}
+	}
+
+	first, second, third := uuid.New(), uuid.New(), uuid.New()
+
+	pos, err := queue.Enqueue(first, blockingJob(first))
+	require.NoError(t, err)
+	assert.Equal(t, 0, pos, "Первая задача должна запуститься сразу")
+	assert.Equal(t, first, <-started)
+
+	pos, err = queue.Enqueue(second, blockingJob(second))
+	require.NoError(t, err)
+	assert.Equal(t, 1, pos)
+
+	pos, err = queue.Enqueue(third, blockingJob(third))
+	require.NoError(t, err)
+	assert.Equal(t, 2, pos)
+
+	position, queued := queue.Position(third)
+	assert.True(t, queued)
+	assert.Equal(t, 2, position)
+
+	_, queued = queue.Position(first)
+	assert.False(t, queued, "Запущенная задача не должна числиться в очереди")
+
+	stats := queue.Stats()
+	assert.Equal(t, 1, stats.Running)
+	assert.Equal(t, 2, stats.Waiting)
+	assert.True(t, queue.Full())
+
+	release <- struct{}{}
+	assert.Equal(t, second, <-started)
+
+	position, queued = queue.Position(third)
+	assert.True(t, queued)
+	assert.Equal(t, 1, position, "Позиция должна сдвинуться после запуска предыдущей задачи")
+
+	close(release)
+	assert.Equal(t, third, <-started)
+}
+
+func TestEvaluationQueue_RejectsWhenFull(t *testing.T) {
+	queue := setupQueueTest(1, 1)
+	release := make(chan struct{})
+	defer close(release)
+
+	job := func() { <-release }
+
+	_, err := queue.Enqueue(uuid.New(), job)
+	require.NoError(t, err)
+	_, err = queue.Enqueue(uuid.New(), job)
+	require.NoError(t, err)
+
+	_, err = queue.Enqueue(uuid.New(), job)
+	require.Error(t, err)
+	assert.ErrorIs(t, err, ErrQueueFull)
+}
+
+func TestEvaluationQueue_RunsAllJobs(t *testing.T) {
+	queue := setupQueueTest(2, 10)
+	var wg sync.WaitGroup
+
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+		_, err := queue.Enqueue(uuid.New(), func() {
+			defer wg.Done()
+			time.Sleep(time.Millisecond)
+		})
+		require.NoError(t, err)
+	}
+
+	wg.Wait()
+	require.Eventually(t, func() bool {
+		return queue.Stats().Running == 0
+	}, time.Second, 5*time.Millisecond)
+	assert.Equal(t, 0, queue.Stats().Waiting)
+}
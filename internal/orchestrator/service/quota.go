@@ -0,0 +1,138 @@
+package service
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/Qu1nel/YaLyceum-GoProject-Final/internal/orchestrator/config"
+	"github.com/Qu1nel/YaLyceum-GoProject-Final/internal/orchestrator/repository"
+)
+
+const (
+	QuotaPeriodDay   = "day"
+	QuotaPeriodMonth = "month"
+
+	QuotaResourceTasks      = "tasks"
+	QuotaResourceOperations = "operations"
+	QuotaResourceComputeMs  = "compute_ms"
+)
+
+var (
+	quotaPeriodNames   = map[string]string{QuotaPeriodDay: "суточная", QuotaPeriodMonth: "месячная"}
+	quotaResourceNames = map[string]string{
+		QuotaResourceTasks:      "задач",
+		QuotaResourceOperations: "операций",
+		QuotaResourceComputeMs:  "времени вычислений (мс)",
+	}
+)
+
+// Quota - ограничения на один период, 0 - без ограничения.
+type Quota struct {
+	Tasks       int64
+	Operations  int64
+	ComputeTime time.Duration
+}
+
+type Quotas struct {
+	Daily   Quota
+	Monthly Quota
+}
+
+func NewQuotas(cfg config.QuotaConfig) Quotas {
+	return Quotas{
+		Daily:   Quota{Tasks: cfg.DailyTasks, Operations: cfg.DailyOperations, ComputeTime: cfg.DailyCompute},
+		Monthly: Quota{Tasks: cfg.MonthlyTasks, Operations: cfg.MonthlyOperations, ComputeTime: cfg.MonthlyCompute},
+	}
+}
+
+// Limits - ограничения периода в виде потребления для резервирования в репозитории.
+func (q Quota) Limits() repository.Usage {
+	return repository.Usage{TasksSubmitted: q.Tasks, Operations: q.Operations, ComputeTime: q.ComputeTime}
+}
+
+// QuotaCounter - потребление одного ресурса за период. Limit 0 - без ограничения.
+type QuotaCounter struct {
+	Resource string
+	Used     int64
+	Limit    int64
+}
+
+func (c QuotaCounter) Remaining() int64 {
+	if c.Limit == 0 {
+		return 0
+	}
+	return max(c.Limit-c.Used, 0)
+}
+
+// Counters раскладывает потребление за период по ресурсам квоты. Время вычислений считается в миллисекундах.
+func (q Quota) Counters(usage repository.Usage) []QuotaCounter {
+	return []QuotaCounter{
+		{Resource: QuotaResourceTasks, Used: usage.TasksSubmitted, Limit: q.Tasks},
+		{Resource: QuotaResourceOperations, Used: usage.Operations, Limit: q.Operations},
+		{Resource: QuotaResourceComputeMs, Used: usage.ComputeTime.Milliseconds(), Limit: q.ComputeTime.Milliseconds()},
+	}
+}
+
+// QuotaPeriodBounds возвращает начало текущего периода квоты и начало следующего. Периоды считаются по UTC.
+func QuotaPeriodBounds(period string, now time.Time) (time.Time, time.Time) {
+	now = now.UTC()
+	if period == QuotaPeriodMonth {
+		start := time.Date(now.Year(), now.Month(), 1, 0, 0, 0, 0, time.UTC)
+		return start, start.AddDate(0, 1, 0)
+	}
+	start := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, time.UTC)
+	return start, start.AddDate(0, 0, 1)
+}
+
+type QuotaViolation struct {
+	Period    string
+	Resource  string
+	Used      int64
+	Requested int64
+	Limit     int64
+	ResetAt   time.Time
+}
+
+func (v *QuotaViolation) Message() string {
+	return fmt.Sprintf("превышена %s квота %s: использовано %d, требуется %d, лимит %d; квота обновится %s",
+		quotaPeriodNames[v.Period], quotaResourceNames[v.Resource], v.Used, v.Requested, v.Limit, v.ResetAt.Format(time.RFC3339))
+}
+
+// Check проверяет, укладывается ли demand в остаток квот. Месячная квота проверяется первой:
+// при нарушении обеих клиенту важнее дата ее обновления.
+func (q Quotas) Check(usage repository.UsageSummary, demand repository.Usage, now time.Time) *QuotaViolation {
+	periods := []struct {
+		name  string
+		quota Quota
+		used  repository.Usage
+	}{
+		{QuotaPeriodMonth, q.Monthly, usage.Month},
+		{QuotaPeriodDay, q.Daily, usage.Day},
+	}
+	requested := Quota{}.Counters(demand)
+	for _, period := range periods {
+		for i, counter := range period.quota.Counters(period.used) {
+			if counter.Limit > 0 && counter.Used+requested[i].Used > counter.Limit {
+				_, resetAt := QuotaPeriodBounds(period.name, now)
+				return &QuotaViolation{
+					Period:    period.name,
+					Resource:  counter.Resource,
+					Used:      counter.Used,
+					Requested: requested[i].Used,
+					Limit:     counter.Limit,
+					ResetAt:   resetAt,
+				}
+			}
+		}
+	}
+	return nil
+}
+
+// QuotaDemand - сколько ресурсов квоты займет вычисление выражения по его оценке.
+func QuotaDemand(tasks int64, estimate CostEstimate) repository.Usage {
+	demand := repository.Usage{TasksSubmitted: tasks, ComputeTime: estimate.TotalWork}
+	for _, count := range estimate.Operations {
+		demand.Operations += int64(count)
+	}
+	return demand
+}
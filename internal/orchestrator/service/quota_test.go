@@ -0,0 +1,62 @@
+package service
+
+import (
+	"testing"
+	"time"
+
+	"github.com/Qu1nel/YaLyceum-GoProject-Final/internal/orchestrator/repository"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestQuotaPeriodBounds(t *testing.T) {
+	now := time.Date(2025, 12, 31, 23, 30, 0, 0, time.FixedZone("UTC-2", -2*3600))
+
+	start, reset := QuotaPeriodBounds(QuotaPeriodDay, now)
+	assert.Equal(t, time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC), start)
+	assert.Equal(t, time.Date(2026, 1, 2, 0, 0, 0, 0, time.UTC), reset)
+
+	start, reset = QuotaPeriodBounds(QuotaPeriodMonth, time.Date(2025, 1, 31, 12, 0, 0, 0, time.UTC))
+	assert.Equal(t, time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC), start)
+	assert.Equal(t, time.Date(2025, 2, 1, 0, 0, 0, 0, time.UTC), reset)
+}
+
+func TestQuotas_Check(t *testing.T) {
+	now := time.Date(2025, 3, 10, 15, 0, 0, 0, time.UTC)
+	quotas := Quotas{
+		Daily:   Quota{Tasks: 10, ComputeTime: time.Second},
+		Monthly: Quota{Operations: 100},
+	}
+	usage := repository.UsageSummary{
+		Day:   repository.Usage{TasksSubmitted: 9, Operations: 5, ComputeTime: 600 * time.Millisecond},
+		Month: repository.Usage{TasksSubmitted: 50, Operations: 95, ComputeTime: 10 * time.Second},
+	}
+
+	assert.Nil(t, quotas.Check(usage, repository.Usage{TasksSubmitted: 1, Operations: 5, ComputeTime: 400 * time.Millisecond}, now))
+
+	violation := quotas.Check(usage, repository.Usage{TasksSubmitted: 1, Operations: 1, ComputeTime: 500 * time.Millisecond}, now)
+	require.NotNil(t, violation)
+	assert.Equal(t, QuotaPeriodDay, violation.Period)
+	assert.Equal(t, QuotaResourceComputeMs, violation.Resource)
+	assert.Equal(t, int64(600), violation.Used)
+	assert.Equal(t, int64(500), violation.Requested)
+	assert.Equal(t, time.Date(2025, 3, 11, 0, 0, 0, 0, time.UTC), violation.ResetAt)
+
+	// При нарушении обеих квот сообщается месячная.
+	violation = quotas.Check(usage, repository.Usage{TasksSubmitted: 2, Operations: 6}, now)
+	require.NotNil(t, violation)
+	assert.Equal(t, QuotaPeriodMonth, violation.Period)
+	assert.Equal(t, QuotaResourceOperations, violation.Resource)
+	assert.Equal(t, time.Date(2025, 4, 1, 0, 0, 0, 0, time.UTC), violation.ResetAt)
+	assert.Equal(t, "превышена месячная квота операций: использовано 95, требуется 6, лимит 100; квота обновится 2025-04-01T00:00:00Z", violation.Message())
+}
+
+func TestQuota_Limits(t *testing.T) {
+	assert.Equal(t, repository.Usage{}, Quota{}.Limits())
+	assert.Equal(t, repository.Usage{TasksSubmitted: 5, ComputeTime: time.Minute}, Quota{Tasks: 5, ComputeTime: time.Minute}.Limits())
+}
+
+func TestQuotaDemand(t *testing.T) {
+	estimate := CostEstimate{Operations: map[string]int{"+": 2, "*": 1}, TotalWork: 700 * time.Millisecond}
+	assert.Equal(t, repository.Usage{TasksSubmitted: 1, Operations: 3, ComputeTime: 700 * time.Millisecond}, QuotaDemand(1, estimate))
+}
@@ -0,0 +1,103 @@
+package service
+
+import (
+	"context"
+	"time"
+
+	"github.com/Qu1nel/YaLyceum-GoProject-Final/internal/orchestrator/config"
+	"github.com/Qu1nel/YaLyceum-GoProject-Final/internal/orchestrator/repository"
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+)
+
+// RetentionPurger периодически удаляет задачи с истекшим сроком хранения
+// и задачи, пролежавшие в корзине дольше RETENTION_TRASH_DAYS.
+type RetentionPurger struct {
+	log       *zap.Logger
+	taskRepo  repository.TaskRepository
+	cfg       config.RetentionConfig
+	now       func() time.Time
+	batchSize int
+}
+
+func NewRetentionPurger(log *zap.Logger, taskRepo repository.TaskRepository, cfg *config.Config) *RetentionPurger {
+	return &RetentionPurger{
+		log:       log,
+		taskRepo:  taskRepo,
+		cfg:       cfg.Retention,
+		now:       time.Now,
+		batchSize: cfg.Retention.PurgeBatchSize,
+	}
+}
+
+func (p *RetentionPurger) Run(ctx context.Context) {
+	ticker := time.NewTicker(p.cfg.PurgeInterval)
+	defer ticker.Stop()
+
+	for {
+		if _, err := p.PurgeOnce(ctx); err != nil && ctx.Err() == nil {
+			p.log.Error("Ошибка фоновой очистки задач", zap.Error(err))
+		}
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+func (p *RetentionPurger) PurgeOnce(ctx context.Context) (int64, error) {
+	now := p.now()
+	var total int64
+
+	passes := []repository.PurgeCriteria{
+		{DeletedBefore: daysBefore(now, p.cfg.TrashDays)},
+		{
+			ExcludeUserIDs:  overrideUserIDs(p.cfg.UserOverrides),
+			CompletedBefore: daysBefore(now, p.cfg.CompletedDays),
+			FailedBefore:    daysBefore(now, p.cfg.FailedDays),
+		},
+	}
+	for userID, override := range p.cfg.UserOverrides {
+		passes = append(passes, repository.PurgeCriteria{
+			UserIDs:         []uuid.UUID{userID},
+			CompletedBefore: daysBefore(now, override.CompletedDays),
+			FailedBefore:    daysBefore(now, override.FailedDays),
+		})
+	}
+
+	for _, criteria := range passes {
+		criteria.Limit = p.batchSize
+		for {
+			deleted, err := p.taskRepo.PurgeTasks(ctx, criteria)
+			total += deleted
+			if err != nil {
+				return total, err
+			}
+			if deleted < int64(p.batchSize) || ctx.Err() != nil {
+				break
+			}
+		}
+	}
+
+	if total > 0 {
+		p.log.Info("Очистка устаревших задач завершена", zap.Int64("deleted", total))
+	}
+	return total, ctx.Err()
+}
+
+func daysBefore(now time.Time, days int) *time.Time {
+	if days <= 0 {
+		return nil
+	}
+	cutoff := now.AddDate(0, 0, -days)
+	return &cutoff
+}
+
+func overrideUserIDs(overrides map[uuid.UUID]config.RetentionOverride) []uuid.UUID {
+	ids := make([]uuid.UUID, 0, len(overrides))
+	for userID := range overrides {
+		ids = append(ids, userID)
+	}
+	return ids
+}
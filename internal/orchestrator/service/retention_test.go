@@ -0,0 +1,55 @@
+package service
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/Qu1nel/YaLyceum-GoProject-Final/internal/orchestrator/config"
+	"github.com/Qu1nel/YaLyceum-GoProject-Final/internal/orchestrator/repository"
+	repo_mocks "github.com/Qu1nel/YaLyceum-GoProject-Final/internal/orchestrator/repository/mocks"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+func TestRetentionPurger_PurgeOnce(t *testing.T) {
+	mockTaskRepo := repo_mocks.NewTaskRepositoryMock(t)
+	premiumUser := uuid.New()
+	now := time.Date(2025, 6, 1, 12, 0, 0, 0, time.UTC)
+
+	purger := NewRetentionPurger(zap.NewNop(), mockTaskRepo, &config.Config{
+		Retention: config.RetentionConfig{
+			CompletedDays:  30,
+			FailedDays:     14,
+			TrashDays:      7,
+			PurgeBatchSize: 2,
+			UserOverrides:  map[uuid.UUID]config.RetentionOverride{premiumUser: {CompletedDays: 365}},
+		},
+	})
+	purger.now = func() time.Time { return now }
+
+	isTrashPass := func(c repository.PurgeCriteria) bool {
+		return c.DeletedBefore != nil && c.DeletedBefore.Equal(now.AddDate(0, 0, -7)) &&
+			c.CompletedBefore == nil && c.FailedBefore == nil && c.UserIDs == nil
+	}
+	isDefaultPass := func(c repository.PurgeCriteria) bool {
+		return c.DeletedBefore == nil && len(c.ExcludeUserIDs) == 1 && c.ExcludeUserIDs[0] == premiumUser &&
+			c.CompletedBefore.Equal(now.AddDate(0, 0, -30)) && c.FailedBefore.Equal(now.AddDate(0, 0, -14))
+	}
+	isPremiumPass := func(c repository.PurgeCriteria) bool {
+		return len(c.UserIDs) == 1 && c.UserIDs[0] == premiumUser &&
+			c.CompletedBefore.Equal(now.AddDate(0, 0, -365)) && c.FailedBefore == nil
+	}
+
+	mockTaskRepo.On("PurgeTasks", mock.Anything, mock.MatchedBy(isTrashPass)).Return(int64(1), nil).Once()
+	mockTaskRepo.On("PurgeTasks", mock.Anything, mock.MatchedBy(isDefaultPass)).Return(int64(2), nil).Twice()
+	mockTaskRepo.On("PurgeTasks", mock.Anything, mock.MatchedBy(isDefaultPass)).Return(int64(0), nil).Once()
+	mockTaskRepo.On("PurgeTasks", mock.Anything, mock.MatchedBy(isPremiumPass)).Return(int64(1), nil).Once()
+
+	deleted, err := purger.PurgeOnce(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, int64(6), deleted)
+}
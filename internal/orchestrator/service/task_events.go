@@ -0,0 +1,125 @@
+package service
+
+import (
+	"sync"
+	"time"
+
+	"github.com/Qu1nel/YaLyceum-GoProject-Final/internal/orchestrator/repository"
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+)
+
+const taskEventSubscriberBuffer = 16
+
+// TaskEvent - изменение статуса задачи или прогресса ее вычисления.
+type TaskEvent struct {
+	TaskID          uuid.UUID
+	Status          string
+	AttemptNumber   int
+	QueuePosition   int
+	OperationsDone  int
+	OperationsTotal int
+	Result          *float64
+	ErrorMessage    *string
+	ErrorCode       *string
+	At              time.Time
+}
+
+func (e TaskEvent) Terminal() bool {
+	return e.Status == repository.StatusCompleted || e.Status == repository.StatusFailed
+}
+
+// TaskEventBroker раздает события задач подписчикам внутри процесса Оркестратора.
+type TaskEventBroker interface {
+	Publish(event TaskEvent)
+	// Subscribe возвращает канал событий задачи и последнее опубликованное событие, если вычисление еще не завершено.
+	// Канал закрывается после терминального события или вызова cancel.
+	Subscribe(taskID uuid.UUID) (events <-chan TaskEvent, last *TaskEvent, cancel func())
+}
+
+type taskEventSubscriber struct {
+	ch chan TaskEvent
+}
+
+type inMemoryTaskEventBroker struct {
+	log         *zap.Logger
+	mu          sync.Mutex
+	subscribers map[uuid.UUID]map[*taskEventSubscriber]struct{}
+	last        map[uuid.UUID]TaskEvent
+}
+
+func NewTaskEventBroker(log *zap.Logger) TaskEventBroker {
+	return &inMemoryTaskEventBroker{
+		log:         log,
+		subscribers: make(map[uuid.UUID]map[*taskEventSubscriber]struct{}),
+		last:        make(map[uuid.UUID]TaskEvent),
+	}
+}
+
+func (b *inMemoryTaskEventBroker) Publish(event TaskEvent) {
+	if event.At.IsZero() {
+		event.At = time.Now()
+	}
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if event.Terminal() {
+		delete(b.last, event.TaskID)
+	} else {
+		b.last[event.TaskID] = event
+	}
+
+	for sub := range b.subscribers[event.TaskID] {
+		select {
+		case sub.ch <- event:
+		default:
+			// Медленный подписчик: вытесняем самое старое событие, чтобы не блокировать вычисление
+			// и не потерять последнее (в том числе терминальное) состояние.
+			select {
+			case <-sub.ch:
+			default:
+			}
+			sub.ch <- event
+			b.log.Debug("Подписчик не успевает читать события задачи, старое событие отброшено", zap.Stringer("taskID", event.TaskID))
+		}
+		if event.Terminal() {
+			close(sub.ch)
+			delete(b.subscribers[event.TaskID], sub)
+		}
+	}
+	if len(b.subscribers[event.TaskID]) == 0 {
+		delete(b.subscribers, event.TaskID)
+	}
+}
+
+func (b *inMemoryTaskEventBroker) Subscribe(taskID uuid.UUID) (<-chan TaskEvent, *TaskEvent, func()) {
+	sub := &taskEventSubscriber{ch: make(chan TaskEvent, taskEventSubscriberBuffer)}
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if b.subscribers[taskID] == nil {
+		b.subscribers[taskID] = make(map[*taskEventSubscriber]struct{})
+	}
+	b.subscribers[taskID][sub] = struct{}{}
+
+	var last *TaskEvent
+	if event, ok := b.last[taskID]; ok {
+		last = &event
+	}
+
+	cancel := func() {
+		b.mu.Lock()
+		defer b.mu.Unlock()
+		if _, ok := b.subscribers[taskID][sub]; !ok {
+			return
+		}
+		delete(b.subscribers[taskID], sub)
+		if len(b.subscribers[taskID]) == 0 {
+			delete(b.subscribers, taskID)
+		}
+		close(sub.ch)
+	}
+	return sub.ch, last, cancel
+}
@@ -0,0 +1,73 @@
+package service
+
+import (
+	"testing"
+
+	"github.com/Qu1nel/YaLyceum-GoProject-Final/internal/orchestrator/repository"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+func TestTaskEventBroker_DeliversUntilTerminal(t *testing.T) {
+	broker := NewTaskEventBroker(zap.NewNop())
+	taskID := uuid.New()
+
+	events, last, cancel := broker.Subscribe(taskID)
+	defer cancel()
+	assert.Nil(t, last)
+
+	broker.Publish(TaskEvent{TaskID: uuid.New(), Status: repository.StatusProcessing})
+	broker.Publish(TaskEvent{TaskID: taskID, Status: repository.StatusProcessing, OperationsTotal: 2})
+	broker.Publish(TaskEvent{TaskID: taskID, Status: repository.StatusCompleted, OperationsDone: 2, OperationsTotal: 2})
+
+	var received []TaskEvent
+	for event := range events {
+		received = append(received, event)
+	}
+	require.Len(t, received, 2)
+	assert.Equal(t, repository.StatusProcessing, received[0].Status)
+	assert.Equal(t, repository.StatusCompleted, received[1].Status)
+	assert.False(t, received[1].At.IsZero())
+
+	cancel()
+}
+
+func TestTaskEventBroker_LastEventForLateSubscriber(t *testing.T) {
+	broker := NewTaskEventBroker(zap.NewNop())
+	taskID := uuid.New()
+
+	broker.Publish(TaskEvent{TaskID: taskID, Status: repository.StatusProcessing, OperationsDone: 1, OperationsTotal: 3})
+	_, last, cancel := broker.Subscribe(taskID)
+	require.NotNil(t, last)
+	assert.Equal(t, 1, last.OperationsDone)
+	cancel()
+
+	broker.Publish(TaskEvent{TaskID: taskID, Status: repository.StatusFailed})
+	_, last, cancel = broker.Subscribe(taskID)
+	defer cancel()
+	assert.Nil(t, last, "после терминального события состояние не хранится")
+}
+
+func TestTaskEventBroker_SlowSubscriberKeepsTerminalEvent(t *testing.T) {
+	broker := NewTaskEventBroker(zap.NewNop())
+	taskID := uuid.New()
+
+	events, _, cancel := broker.Subscribe(taskID)
+	defer cancel()
+
+	for i := 1; i <= taskEventSubscriberBuffer+5; i++ {
+		broker.Publish(TaskEvent{TaskID: taskID, Status: repository.StatusProcessing, OperationsDone: i})
+	}
+	broker.Publish(TaskEvent{TaskID: taskID, Status: repository.StatusCompleted})
+
+	var lastReceived TaskEvent
+	count := 0
+	for event := range events {
+		lastReceived = event
+		count++
+	}
+	assert.Equal(t, taskEventSubscriberBuffer, count)
+	assert.Equal(t, repository.StatusCompleted, lastReceived.Status)
+}
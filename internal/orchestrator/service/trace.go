@@ -0,0 +1,91 @@
+package service
+
+import (
+	"context"
+	"sort"
+	"strconv"
+	"sync"
+
+	"github.com/Qu1nel/YaLyceum-GoProject-Final/internal/orchestrator/repository"
+	"github.com/expr-lang/expr/ast"
+)
+
+// RootNodePath - путь корневого узла AST в трассировке операций.
+const RootNodePath = "0"
+
+type traceRecorderContextKey struct{}
+
+type nodePathContextKey struct{}
+
+// TraceRecorder накапливает вызовы Воркера одного вычисления. Безопасен для конкурентного использования.
+type TraceRecorder struct {
+	mu         sync.Mutex
+	operations []repository.TaskOperation
+	onRecord   func(recorded int)
+}
+
+// NewTraceRecorder создает накопитель трассировки. onRecord (может быть nil) вызывается
+// под блокировкой после каждой записанной операции с их текущим количеством, поэтому не должен блокироваться.
+func NewTraceRecorder(onRecord func(recorded int)) *TraceRecorder {
+	return &TraceRecorder{onRecord: onRecord}
+}
+
+func (r *TraceRecorder) record(op repository.TaskOperation) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.operations = append(r.operations, op)
+	if r.onRecord != nil {
+		r.onRecord(len(r.operations))
+	}
+}
+
+// Operations возвращает записанные операции в порядке их запуска с проставленным Seq.
+func (r *TraceRecorder) Operations() []repository.TaskOperation {
+	r.mu.Lock()
+	operations := make([]repository.TaskOperation, len(r.operations))
+	copy(operations, r.operations)
+	r.mu.Unlock()
+
+	sort.SliceStable(operations, func(i, j int) bool {
+		return operations[i].StartedAt.Before(operations[j].StartedAt)
+	})
+	for i := range operations {
+		operations[i].Seq = i + 1
+	}
+	return operations
+}
+
+func ContextWithTraceRecorder(ctx context.Context, recorder *TraceRecorder) context.Context {
+	return context.WithValue(ctx, traceRecorderContextKey{}, recorder)
+}
+
+func traceRecorderFromContext(ctx context.Context) *TraceRecorder {
+	recorder, _ := ctx.Value(traceRecorderContextKey{}).(*TraceRecorder)
+	return recorder
+}
+
+func contextWithChildNode(ctx context.Context, index int) context.Context {
+	return context.WithValue(ctx, nodePathContextKey{}, nodePathFromContext(ctx)+"."+strconv.Itoa(index))
+}
+
+func nodePathFromContext(ctx context.Context) string {
+	if path, ok := ctx.Value(nodePathContextKey{}).(string); ok {
+		return path
+	}
+	return RootNodePath
+}
+
+// CountOperations возвращает число вызовов Воркера, которое потребуется для вычисления узла.
+func CountOperations(node ast.Node) int {
+	switch n := node.(type) {
+	case *ast.UnaryNode:
+		if n.Operator == "-" {
+			return 1 + CountOperations(n.Node)
+		}
+		return CountOperations(n.Node)
+	case *ast.BinaryNode:
+		return 1 + CountOperations(n.Left) + CountOperations(n.Right)
+	default:
+		return 0
+	}
+}
@@ -0,0 +1,310 @@
+package service
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/Qu1nel/YaLyceum-GoProject-Final/internal/orchestrator/config"
+	"github.com/Qu1nel/YaLyceum-GoProject-Final/internal/orchestrator/repository"
+	"github.com/Qu1nel/YaLyceum-GoProject-Final/internal/pkg/netguard"
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+)
+
+const (
+	WebhookEventTaskCompleted = "task.completed"
+	WebhookEventTaskFailed    = "task.failed"
+
+	WebhookSignatureHeader  = "X-Webhook-Signature"
+	WebhookTimestampHeader  = "X-Webhook-Timestamp"
+	WebhookDeliveryIDHeader = "X-Webhook-Delivery-ID"
+	WebhookEventHeader      = "X-Webhook-Event"
+
+	webhookSecretPrefix = "whsec_"
+	// Сколько байт ответа получателя дочитывается, чтобы соединение вернулось в пул.
+	webhookDrainLimit = 64 << 10
+)
+
+// WebhookNotifier принимает уведомления о завершенных задачах для отправки на webhook пользователя.
+type WebhookNotifier interface {
+	TaskFinished(taskID uuid.UUID)
+	// Wake запускает внеочередной проход по ожидающим доставкам.
+	Wake()
+}
+
+type webhookTaskPayload struct {
+	ID           uuid.UUID `json:"id"`
+	Expression   string    `json:"expression"`
+	Status       string    `json:"status"`
+	Result       *float64  `json:"result,omitempty"`
+	ErrorMessage *string   `json:"error_message,omitempty"`
+	ErrorCode    *string   `json:"error_code,omitempty"`
+	CreatedAt    time.Time `json:"created_at"`
+	UpdatedAt    time.Time `json:"updated_at"`
+}
+
+type webhookPayload struct {
+	Event      string             `json:"event"`
+	OccurredAt time.Time          `json:"occurred_at"`
+	Task       webhookTaskPayload `json:"task"`
+}
+
+// WebhookDispatcher сохраняет события завершения задач в журнал доставок и отправляет их
+// подписанными POST запросами, повторяя неудачные попытки с экспоненциальной паузой.
+type WebhookDispatcher struct {
+	log         *zap.Logger
+	webhookRepo repository.WebhookRepository
+	taskRepo    repository.TaskRepository
+	cfg         config.WebhookConfig
+	client      *http.Client
+	now         func() time.Time
+	wake        chan struct{}
+}
+
+func NewWebhookDispatcher(log *zap.Logger, webhookRepo repository.WebhookRepository, taskRepo repository.TaskRepository, cfg *config.Config) *WebhookDispatcher {
+	return &WebhookDispatcher{
+		log:         log,
+		webhookRepo: webhookRepo,
+		taskRepo:    taskRepo,
+		cfg:         cfg.Webhook,
+		client:      netguard.NewHTTPClient(cfg.Webhook.Timeout),
+		now:         time.Now,
+		wake:        make(chan struct{}, 1),
+	}
+}
+
+// NewWebhookSecret генерирует случайный секрет для подписи webhook.
+func NewWebhookSecret() (string, error) {
+	buf := make([]byte, 24)
+	if _, err := rand.Read(buf); err != nil {
+		return "", fmt.Errorf("не удалось сгенерировать секрет webhook: %w", err)
+	}
+	return webhookSecretPrefix + hex.EncodeToString(buf), nil
+}
+
+// SignWebhookPayload вычисляет значение заголовка X-Webhook-Signature: HMAC-SHA256 от "<timestamp>.<тело>".
+func SignWebhookPayload(secret string, timestamp int64, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(strconv.FormatInt(timestamp, 10)))
+	mac.Write([]byte("."))
+	mac.Write(body)
+	return fmt.Sprintf("t=%d,v1=%s", timestamp, hex.EncodeToString(mac.Sum(nil)))
+}
+
+func (d *WebhookDispatcher) Wake() {
+	select {
+	case d.wake <- struct{}{}:
+	default:
+	}
+}
+
+func (d *WebhookDispatcher) TaskFinished(taskID uuid.UUID) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	if err := d.enqueueTask(ctx, taskID); err != nil {
+		d.log.Error("Не удалось поставить webhook о завершении задачи в очередь доставки", zap.Stringer("taskID", taskID), zap.Error(err))
+		return
+	}
+	d.Wake()
+}
+
+func (d *WebhookDispatcher) enqueueTask(ctx context.Context, taskID uuid.UUID) error {
+	task, err := d.taskRepo.GetTaskByID(ctx, taskID)
+	if err != nil {
+		return err
+	}
+
+	event := WebhookEventTaskCompleted
+	switch task.Status {
+	case repository.StatusCompleted:
+	case repository.StatusFailed:
+		event = WebhookEventTaskFailed
+	default:
+		return fmt.Errorf("задача в статусе '%s' еще не завершена", task.Status)
+	}
+
+	var urls []string
+	var endpointIDs []*uuid.UUID
+	if task.CallbackURL != nil && *task.CallbackURL != "" {
+		urls = append(urls, *task.CallbackURL)
+		endpointIDs = append(endpointIDs, nil)
+	}
+	endpoints, err := d.webhookRepo.ListEndpoints(ctx, task.UserID)
+	if err != nil {
+		return err
+	}
+	for _, e := range endpoints {
+		urls = append(urls, e.URL)
+		endpointIDs = append(endpointIDs, &e.ID)
+	}
+	if len(urls) == 0 {
+		return nil
+	}
+
+	payload, err := json.Marshal(webhookPayload{
+		Event:      event,
+		OccurredAt: d.now().UTC(),
+		Task: webhookTaskPayload{
+			ID:           task.ID,
+			Expression:   task.Expression,
+			Status:       task.Status,
+			Result:       task.Result,
+			ErrorMessage: task.ErrorMessage,
+			ErrorCode:    task.ErrorCode,
+			CreatedAt:    task.CreatedAt,
+			UpdatedAt:    task.UpdatedAt,
+		},
+	})
+	if err != nil {
+		return fmt.Errorf("не удалось сериализовать событие webhook: %w", err)
+	}
+
+	for i, url := range urls {
+		if _, err := d.webhookRepo.CreateDelivery(ctx, repository.WebhookDelivery{
+			UserID:     task.UserID,
+			TaskID:     &task.ID,
+			EndpointID: endpointIDs[i],
+			URL:        url,
+			Event:      event,
+			Payload:    payload,
+		}); err != nil {
+			return err
+		}
+	}
+	d.log.Debug("Webhook о завершении задачи поставлен в очередь доставки", zap.Stringer("taskID", taskID), zap.Int("deliveries", len(urls)))
+	return nil
+}
+
+func (d *WebhookDispatcher) Run(ctx context.Context) {
+	ticker := time.NewTicker(d.cfg.PollInterval)
+	defer ticker.Stop()
+
+	for {
+		if _, err := d.DispatchDue(ctx); err != nil && ctx.Err() == nil {
+			d.log.Error("Ошибка отправки webhook", zap.Error(err))
+		}
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		case <-d.wake:
+		}
+	}
+}
+
+// DispatchDue отправляет доставки, время очередной попытки которых наступило, и возвращает их количество.
+func (d *WebhookDispatcher) DispatchDue(ctx context.Context) (int, error) {
+	total := 0
+	for {
+		deliveries, err := d.webhookRepo.ClaimDueDeliveries(ctx, d.cfg.BatchSize, d.claimLease())
+		if err != nil {
+			return total, err
+		}
+		for _, delivery := range deliveries {
+			d.deliver(ctx, delivery)
+		}
+		total += len(deliveries)
+		if len(deliveries) < d.cfg.BatchSize || ctx.Err() != nil {
+			return total, ctx.Err()
+		}
+	}
+}
+
+// claimLease - на сколько выбранные доставки скрываются от других проходов и экземпляров Оркестратора.
+// Пакет отправляется последовательно, поэтому аренда покрывает таймауты всех запросов пакета и еще один в запас:
+// иначе хвост пакета с медленными получателями успел бы достаться другому экземпляру и был бы доставлен дважды.
+func (d *WebhookDispatcher) claimLease() time.Duration {
+	return time.Duration(d.cfg.BatchSize+1) * d.cfg.Timeout
+}
+
+func (d *WebhookDispatcher) deliver(ctx context.Context, delivery repository.WebhookDelivery) {
+	log := d.log.With(zap.Stringer("deliveryID", delivery.ID), zap.String("url", delivery.URL), zap.Int("attempt", delivery.Attempts+1))
+
+	statusCode, sendErr := d.send(ctx, delivery)
+	if sendErr == nil {
+		if err := d.webhookRepo.MarkDeliverySucceeded(ctx, delivery.ID, statusCode); err != nil {
+			log.Error("Не удалось отметить успешную доставку webhook", zap.Error(err))
+		}
+		log.Info("Webhook доставлен", zap.Int("statusCode", statusCode))
+		return
+	}
+
+	var code *int
+	if statusCode != 0 {
+		code = &statusCode
+	}
+	var nextAttemptAt *time.Time
+	if attempts := delivery.Attempts + 1; attempts < d.cfg.MaxAttempts {
+		next := d.now().Add(d.backoff(attempts))
+		nextAttemptAt = &next
+		log.Warn("Не удалось доставить webhook, доставка будет повторена", zap.Time("nextAttemptAt", next), zap.Error(sendErr))
+	} else {
+		log.Warn("Не удалось доставить webhook, попытки исчерпаны", zap.Error(sendErr))
+	}
+	if err := d.webhookRepo.MarkDeliveryFailed(ctx, delivery.ID, code, sendErr.Error(), nextAttemptAt); err != nil {
+		log.Error("Не удалось сохранить результат попытки доставки webhook", zap.Error(err))
+	}
+}
+
+// send выполняет одну попытку доставки. Код ответа возвращается и при ошибке, если сервер ответил.
+func (d *WebhookDispatcher) send(ctx context.Context, delivery repository.WebhookDelivery) (int, error) {
+	secret, err := d.secret(ctx, delivery.UserID)
+	if err != nil {
+		return 0, err
+	}
+
+	timestamp := d.now().Unix()
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, delivery.URL, bytes.NewReader(delivery.Payload))
+	if err != nil {
+		return 0, fmt.Errorf("невалидный URL webhook: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set(WebhookSignatureHeader, SignWebhookPayload(secret, timestamp, delivery.Payload))
+	req.Header.Set(WebhookTimestampHeader, strconv.FormatInt(timestamp, 10))
+	req.Header.Set(WebhookDeliveryIDHeader, delivery.ID.String())
+	req.Header.Set(WebhookEventHeader, delivery.Event)
+
+	resp, err := d.client.Do(req)
+	if err != nil {
+		return 0, err
+	}
+	defer resp.Body.Close()
+	_, _ = io.Copy(io.Discard, io.LimitReader(resp.Body, webhookDrainLimit))
+
+	// Тело ответа не сохраняется: last_error виден пользователю в журнале доставок.
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return resp.StatusCode, fmt.Errorf("получатель ответил HTTP %d", resp.StatusCode)
+	}
+	return resp.StatusCode, nil
+}
+
+func (d *WebhookDispatcher) secret(ctx context.Context, userID uuid.UUID) (string, error) {
+	candidate, err := NewWebhookSecret()
+	if err != nil {
+		return "", err
+	}
+	return d.webhookRepo.GetOrCreateSecret(ctx, userID, candidate)
+}
+
+// backoff возвращает паузу перед попыткой номер attempts+1: начальная пауза удваивается до WEBHOOK_MAX_BACKOFF.
+func (d *WebhookDispatcher) backoff(attempts int) time.Duration {
+	delay := d.cfg.InitialBackoff
+	for i := 1; i < attempts; i++ {
+		delay *= 2
+		if delay >= d.cfg.MaxBackoff {
+			return d.cfg.MaxBackoff
+		}
+	}
+	return delay
+}
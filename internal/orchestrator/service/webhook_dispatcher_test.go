@@ -0,0 +1,162 @@
+package service
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/Qu1nel/YaLyceum-GoProject-Final/internal/orchestrator/config"
+	"github.com/Qu1nel/YaLyceum-GoProject-Final/internal/orchestrator/repository"
+	repo_mocks "github.com/Qu1nel/YaLyceum-GoProject-Final/internal/orchestrator/repository/mocks"
+	"github.com/Qu1nel/YaLyceum-GoProject-Final/internal/pkg/netguard"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+func newTestWebhookDispatcher(t *testing.T) (*WebhookDispatcher, *repo_mocks.WebhookRepositoryMock, *repo_mocks.TaskRepositoryMock) {
+	webhookRepo := repo_mocks.NewWebhookRepositoryMock(t)
+	taskRepo := repo_mocks.NewTaskRepositoryMock(t)
+	d := NewWebhookDispatcher(zap.NewNop(), webhookRepo, taskRepo, &config.Config{
+		Webhook: config.WebhookConfig{
+			MaxAttempts:    3,
+			InitialBackoff: time.Second,
+			MaxBackoff:     3 * time.Second,
+			Timeout:        2 * time.Second,
+			PollInterval:   time.Second,
+			BatchSize:      10,
+		},
+	})
+	return d, webhookRepo, taskRepo
+}
+
+func TestWebhookDispatcher_TaskFinished_CreatesDeliveries(t *testing.T) {
+	d, webhookRepo, taskRepo := newTestWebhookDispatcher(t)
+	taskID, userID, endpointID := uuid.New(), uuid.New(), uuid.New()
+	result := 42.0
+	callbackURL := "https://client.example/callback"
+
+	taskRepo.On("GetTaskByID", mock.Anything, taskID).Return(&repository.Task{
+		ID: taskID, UserID: userID, Expression: "40+2", Status: repository.StatusCompleted, Result: &result, CallbackURL: &callbackURL,
+	}, nil).Once()
+	webhookRepo.On("ListEndpoints", mock.Anything, userID).Return([]repository.WebhookEndpoint{
+		{ID: endpointID, UserID: userID, URL: "https://client.example/hooks"},
+	}, nil).Once()
+	webhookRepo.On("CreateDelivery", mock.Anything, mock.MatchedBy(func(dl repository.WebhookDelivery) bool {
+		return dl.URL == callbackURL && dl.EndpointID == nil && dl.Event == WebhookEventTaskCompleted && *dl.TaskID == taskID
+	})).Return(uuid.New(), nil).Once()
+	webhookRepo.On("CreateDelivery", mock.Anything, mock.MatchedBy(func(dl repository.WebhookDelivery) bool {
+		return dl.URL == "https://client.example/hooks" && dl.EndpointID != nil && *dl.EndpointID == endpointID
+	})).Return(uuid.New(), nil).Once()
+
+	d.TaskFinished(taskID)
+
+	select {
+	case <-d.wake:
+	default:
+		t.Fatal("диспетчер должен быть разбужен после постановки доставок")
+	}
+}
+
+func TestWebhookDispatcher_DispatchDue_SignedDelivery(t *testing.T) {
+	d, webhookRepo, _ := newTestWebhookDispatcher(t)
+	now := time.Unix(1700000000, 0)
+	d.now = func() time.Time { return now }
+
+	payload := []byte(`{"event":"task.completed"}`)
+	delivery := repository.WebhookDelivery{ID: uuid.New(), UserID: uuid.New(), Event: WebhookEventTaskCompleted, Payload: payload}
+
+	received := make(chan *http.Request, 1)
+	var body []byte
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ = io.ReadAll(r.Body)
+		received <- r
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer server.Close()
+	// Тестовый сервер слушает loopback, который клиент диспетчера отклоняет.
+	d.client = server.Client()
+	delivery.URL = server.URL
+
+	webhookRepo.On("ClaimDueDeliveries", mock.Anything, 10, 22*time.Second).Return([]repository.WebhookDelivery{delivery}, nil).Once()
+	webhookRepo.On("GetOrCreateSecret", mock.Anything, delivery.UserID, mock.AnythingOfType("string")).Return("whsec_test", nil).Once()
+	webhookRepo.On("MarkDeliverySucceeded", mock.Anything, delivery.ID, http.StatusNoContent).Return(nil).Once()
+
+	sent, err := d.DispatchDue(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, 1, sent)
+
+	req := <-received
+	assert.Equal(t, payload, body)
+	assert.Equal(t, SignWebhookPayload("whsec_test", now.Unix(), payload), req.Header.Get(WebhookSignatureHeader))
+	assert.Equal(t, strconv.FormatInt(now.Unix(), 10), req.Header.Get(WebhookTimestampHeader))
+	assert.Equal(t, delivery.ID.String(), req.Header.Get(WebhookDeliveryIDHeader))
+	assert.Equal(t, WebhookEventTaskCompleted, req.Header.Get(WebhookEventHeader))
+}
+
+func TestWebhookDispatcher_DispatchDue_RetriesWithBackoff(t *testing.T) {
+	d, webhookRepo, _ := newTestWebhookDispatcher(t)
+	now := time.Now()
+	d.now = func() time.Time { return now }
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		http.Error(w, "boom", http.StatusInternalServerError)
+	}))
+	defer server.Close()
+	d.client = server.Client()
+
+	retried := repository.WebhookDelivery{ID: uuid.New(), UserID: uuid.New(), URL: server.URL, Attempts: 1}
+	exhausted := repository.WebhookDelivery{ID: uuid.New(), UserID: retried.UserID, URL: server.URL, Attempts: 2}
+
+	webhookRepo.On("ClaimDueDeliveries", mock.Anything, 10, 22*time.Second).Return([]repository.WebhookDelivery{retried, exhausted}, nil).Once()
+	webhookRepo.On("GetOrCreateSecret", mock.Anything, retried.UserID, mock.AnythingOfType("string")).Return("whsec_test", nil).Twice()
+	isHTTP500 := mock.MatchedBy(func(code *int) bool { return code != nil && *code == http.StatusInternalServerError })
+	webhookRepo.On("MarkDeliveryFailed", mock.Anything, retried.ID, isHTTP500, mock.AnythingOfType("string"),
+		mock.MatchedBy(func(next *time.Time) bool { return next != nil && next.Equal(now.Add(2*time.Second)) }),
+	).Return(nil).Once()
+	webhookRepo.On("MarkDeliveryFailed", mock.Anything, exhausted.ID, isHTTP500, "получатель ответил HTTP 500", (*time.Time)(nil)).Return(nil).Once()
+
+	sent, err := d.DispatchDue(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, 2, sent)
+}
+
+func TestWebhookDispatcher_DispatchDue_RefusesInternalAddresses(t *testing.T) {
+	d, webhookRepo, _ := newTestWebhookDispatcher(t)
+	received := make(chan struct{}, 1)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		received <- struct{}{}
+	}))
+	defer server.Close()
+
+	loopback := repository.WebhookDelivery{ID: uuid.New(), UserID: uuid.New(), URL: server.URL, Attempts: 2}
+	metadata := repository.WebhookDelivery{ID: uuid.New(), UserID: loopback.UserID, URL: "http://169.254.169.254/latest/meta-data/", Attempts: 2}
+
+	webhookRepo.On("ClaimDueDeliveries", mock.Anything, 10, 22*time.Second).Return([]repository.WebhookDelivery{loopback, metadata}, nil).Once()
+	webhookRepo.On("GetOrCreateSecret", mock.Anything, loopback.UserID, mock.AnythingOfType("string")).Return("whsec_test", nil).Twice()
+	refused := mock.MatchedBy(func(msg string) bool { return strings.Contains(msg, netguard.ErrForbiddenAddress.Error()) })
+	for _, delivery := range []repository.WebhookDelivery{loopback, metadata} {
+		webhookRepo.On("MarkDeliveryFailed", mock.Anything, delivery.ID, (*int)(nil), refused, (*time.Time)(nil)).Return(nil).Once()
+	}
+
+	sent, err := d.DispatchDue(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, 2, sent)
+	assert.Empty(t, received, "запрос не должен дойти до адреса во внутренней сети")
+}
+
+func TestWebhookDispatcher_Backoff(t *testing.T) {
+	d, _, _ := newTestWebhookDispatcher(t)
+
+	assert.Equal(t, time.Second, d.backoff(1))
+	assert.Equal(t, 2*time.Second, d.backoff(2))
+	assert.Equal(t, 3*time.Second, d.backoff(3))
+	assert.Equal(t, 3*time.Second, d.backoff(10))
+}
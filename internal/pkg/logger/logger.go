@@ -1,6 +1,7 @@
 package logger
 
 import (
+	"context"
 	"fmt"
 	"os"
 	"strings"
@@ -38,3 +39,18 @@ func New(level string, appEnv string) (*zap.Logger, error) {
 
 	return logger, nil
 }
+
+type contextKey struct{}
+
+// WithContext сохраняет в контексте логгер запроса (например, с полем request_id).
+func WithContext(ctx context.Context, log *zap.Logger) context.Context {
+	return context.WithValue(ctx, contextKey{}, log)
+}
+
+// FromContext возвращает логгер запроса или fallback, если контекст его не содержит.
+func FromContext(ctx context.Context, fallback *zap.Logger) *zap.Logger {
+	if log, ok := ctx.Value(contextKey{}).(*zap.Logger); ok {
+		return log
+	}
+	return fallback
+}
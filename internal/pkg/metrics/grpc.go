@@ -0,0 +1,77 @@
+package metrics
+
+import (
+	"context"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/status"
+)
+
+var (
+	grpcServerHandledTotal = promauto.NewCounterVec(prometheus.CounterOpts{
+		Name: "grpc_server_handled_total",
+		Help: "Количество завершенных gRPC вызовов на сервере по методу и коду ответа.",
+	}, []string{"grpc_method", "grpc_code"})
+
+	grpcServerHandlingSeconds = promauto.NewHistogramVec(prometheus.HistogramOpts{
+		Name:    "grpc_server_handling_seconds",
+		Help:    "Время обработки gRPC вызовов на сервере (для потоков - время жизни потока).",
+		Buckets: prometheus.DefBuckets,
+	}, []string{"grpc_method"})
+
+	grpcClientHandledTotal = promauto.NewCounterVec(prometheus.CounterOpts{
+		Name: "grpc_client_handled_total",
+		Help: "Количество завершенных исходящих gRPC вызовов по методу и коду ответа.",
+	}, []string{"grpc_method", "grpc_code"})
+
+	grpcClientHandlingSeconds = promauto.NewHistogramVec(prometheus.HistogramOpts{
+		Name:    "grpc_client_handling_seconds",
+		Help:    "Время исходящих gRPC вызовов до получения ответа.",
+		Buckets: prometheus.DefBuckets,
+	}, []string{"grpc_method"})
+)
+
+func observeGRPC(total *prometheus.CounterVec, seconds *prometheus.HistogramVec, method string, start time.Time, err error) {
+	total.WithLabelValues(method, status.Code(err).String()).Inc()
+	seconds.WithLabelValues(method).Observe(time.Since(start).Seconds())
+}
+
+func UnaryServerInterceptor() grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+		start := time.Now()
+		resp, err := handler(ctx, req)
+		observeGRPC(grpcServerHandledTotal, grpcServerHandlingSeconds, info.FullMethod, start, err)
+		return resp, err
+	}
+}
+
+func StreamServerInterceptor() grpc.StreamServerInterceptor {
+	return func(srv any, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		start := time.Now()
+		err := handler(srv, ss)
+		observeGRPC(grpcServerHandledTotal, grpcServerHandlingSeconds, info.FullMethod, start, err)
+		return err
+	}
+}
+
+func UnaryClientInterceptor() grpc.UnaryClientInterceptor {
+	return func(ctx context.Context, method string, req, reply any, cc *grpc.ClientConn, invoker grpc.UnaryInvoker, opts ...grpc.CallOption) error {
+		start := time.Now()
+		err := invoker(ctx, method, req, reply, cc, opts...)
+		observeGRPC(grpcClientHandledTotal, grpcClientHandlingSeconds, method, start, err)
+		return err
+	}
+}
+
+// StreamClientInterceptor учитывает только установку потока: его длительность зависит от клиента, а не от сервера.
+func StreamClientInterceptor() grpc.StreamClientInterceptor {
+	return func(ctx context.Context, desc *grpc.StreamDesc, cc *grpc.ClientConn, method string, streamer grpc.Streamer, opts ...grpc.CallOption) (grpc.ClientStream, error) {
+		start := time.Now()
+		stream, err := streamer(ctx, desc, cc, method, opts...)
+		observeGRPC(grpcClientHandledTotal, grpcClientHandlingSeconds, method, start, err)
+		return stream, err
+	}
+}
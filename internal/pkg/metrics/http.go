@@ -0,0 +1,56 @@
+package metrics
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/labstack/echo/v4"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+)
+
+// unmatchedRoute - метка маршрута для запросов, не совпавших ни с одним маршрутом Echo,
+// чтобы произвольные URL не раздували число временных рядов.
+const unmatchedRoute = "unmatched"
+
+var (
+	httpRequestsTotal = promauto.NewCounterVec(prometheus.CounterOpts{
+		Name: "http_requests_total",
+		Help: "Количество обработанных HTTP запросов.",
+	}, []string{"method", "route", "code"})
+
+	httpRequestDuration = promauto.NewHistogramVec(prometheus.HistogramOpts{
+		Name:    "http_request_duration_seconds",
+		Help:    "Время обработки HTTP запросов.",
+		Buckets: prometheus.DefBuckets,
+	}, []string{"method", "route"})
+)
+
+// EchoMiddleware считает запросы и их длительность по шаблону маршрута Echo (например, /api/v1/tasks/:id).
+func EchoMiddleware() echo.MiddlewareFunc {
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			start := time.Now()
+			err := next(c)
+
+			route := c.Path()
+			if route == "" {
+				route = unmatchedRoute
+			}
+			code := c.Response().Status
+			var httpError *echo.HTTPError
+			if err != nil && errors.As(err, &httpError) && !c.Response().Committed {
+				code = httpError.Code
+			} else if err != nil && !c.Response().Committed {
+				code = http.StatusInternalServerError
+			}
+
+			method := c.Request().Method
+			httpRequestsTotal.WithLabelValues(method, route, strconv.Itoa(code)).Inc()
+			httpRequestDuration.WithLabelValues(method, route).Observe(time.Since(start).Seconds())
+			return err
+		}
+	}
+}
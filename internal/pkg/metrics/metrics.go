@@ -0,0 +1,24 @@
+package metrics
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+)
+
+// Handler отдает все метрики процесса в формате Prometheus.
+func Handler() http.Handler {
+	return promhttp.Handler()
+}
+
+// NewServer создает отдельный HTTP сервер с эндпоинтом /metrics, недоступный через публичный API сервиса.
+func NewServer(port string) *http.Server {
+	mux := http.NewServeMux()
+	mux.Handle("/metrics", Handler())
+	return &http.Server{
+		Addr:              ":" + port,
+		Handler:           mux,
+		ReadHeaderTimeout: 5 * time.Second,
+	}
+}
@@ -0,0 +1,80 @@
+// Package netguard - защита исходящих запросов на адреса, заданные пользователями (webhook),
+// от обращений во внутреннюю сеть (SSRF).
+package netguard
+
+import (
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"net/netip"
+	"strings"
+	"syscall"
+	"time"
+)
+
+var ErrForbiddenAddress = errors.New("адрес во внутренней сети запрещен")
+
+// extraForbidden - диапазоны, которые netip не относит к частным, но которые ведут внутрь сети.
+var extraForbidden = []netip.Prefix{
+	netip.MustParsePrefix("0.0.0.0/8"),     // "эта сеть"
+	netip.MustParsePrefix("100.64.0.0/10"), // CGNAT
+}
+
+// IsForbidden сообщает, что адрес - loopback, частный, link-local, multicast или неуказанный.
+func IsForbidden(addr netip.Addr) bool {
+	addr = addr.Unmap()
+	if !addr.IsValid() || addr.IsLoopback() || addr.IsPrivate() || addr.IsUnspecified() ||
+		addr.IsLinkLocalUnicast() || addr.IsLinkLocalMulticast() || addr.IsInterfaceLocalMulticast() || addr.IsMulticast() {
+		return true
+	}
+	for _, prefix := range extraForbidden {
+		if prefix.Contains(addr) {
+			return true
+		}
+	}
+	return false
+}
+
+// Control - хук net.Dialer.Control. Проверяется уже разрешенный адрес соединения,
+// поэтому имя, которое после проверки начало указывать во внутреннюю сеть (DNS rebinding), тоже отклоняется.
+func Control(_, address string, _ syscall.RawConn) error {
+	host, _, err := net.SplitHostPort(address)
+	if err != nil {
+		return fmt.Errorf("%w: %s", ErrForbiddenAddress, address)
+	}
+	addr, err := netip.ParseAddr(host)
+	if err != nil || IsForbidden(addr) {
+		return fmt.Errorf("%w: %s", ErrForbiddenAddress, host)
+	}
+	return nil
+}
+
+// CheckHost заранее отклоняет хост URL, если это localhost или запрещенный IP-адрес.
+// Остальные имена проверяются при соединении через Control.
+func CheckHost(host string) error {
+	host = strings.TrimSuffix(strings.ToLower(host), ".")
+	if host == "localhost" || strings.HasSuffix(host, ".localhost") {
+		return fmt.Errorf("%w: %s", ErrForbiddenAddress, host)
+	}
+	if addr, err := netip.ParseAddr(host); err == nil && IsForbidden(addr) {
+		return fmt.Errorf("%w: %s", ErrForbiddenAddress, host)
+	}
+	return nil
+}
+
+// NewHTTPClient возвращает клиент, который соединяется только с внешними адресами и не следует перенаправлениям:
+// перенаправление могло бы увести запрос во внутреннюю сеть.
+func NewHTTPClient(timeout time.Duration) *http.Client {
+	dialer := &net.Dialer{Timeout: timeout, Control: Control}
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	transport.Proxy = nil
+	transport.DialContext = dialer.DialContext
+	return &http.Client{
+		Timeout:   timeout,
+		Transport: transport,
+		CheckRedirect: func(*http.Request, []*http.Request) error {
+			return http.ErrUseLastResponse
+		},
+	}
+}
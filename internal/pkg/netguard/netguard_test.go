@@ -0,0 +1,57 @@
+package netguard
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"net/netip"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestIsForbidden(t *testing.T) {
+	forbidden := []string{
+		"127.0.0.1", "10.1.2.3", "172.20.0.5", "192.168.1.1", "169.254.169.254", "0.0.0.0",
+		"100.64.0.1", "224.0.0.1", "::1", "fe80::1", "fd00::1", "::", "::ffff:127.0.0.1",
+	}
+	for _, raw := range forbidden {
+		assert.True(t, IsForbidden(netip.MustParseAddr(raw)), raw)
+	}
+	for _, raw := range []string{"8.8.8.8", "93.184.216.34", "2606:4700::1111"} {
+		assert.False(t, IsForbidden(netip.MustParseAddr(raw)), raw)
+	}
+}
+
+func TestCheckHost(t *testing.T) {
+	for _, host := range []string{"localhost", "LOCALHOST.", "api.localhost", "127.0.0.1", "169.254.169.254", "::1"} {
+		assert.ErrorIs(t, CheckHost(host), ErrForbiddenAddress, host)
+	}
+	for _, host := range []string{"example.com", "postgres", "8.8.8.8"} {
+		assert.NoError(t, CheckHost(host), host)
+	}
+}
+
+func TestNewHTTPClient_RefusesInternalAddresses(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+	client := NewHTTPClient(time.Second)
+
+	for _, url := range []string{server.URL, "http://169.254.169.254/latest/meta-data/"} {
+		resp, err := client.Get(url)
+		if resp != nil {
+			resp.Body.Close()
+		}
+		require.Error(t, err, url)
+		assert.ErrorIs(t, err, ErrForbiddenAddress, url)
+	}
+}
+
+func TestNewHTTPClient_DoesNotFollowRedirects(t *testing.T) {
+	client := NewHTTPClient(time.Second)
+	redirect := client.CheckRedirect(&http.Request{}, nil)
+	assert.ErrorIs(t, redirect, http.ErrUseLastResponse)
+}
@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"time"
 
+	"github.com/Qu1nel/YaLyceum-GoProject-Final/internal/pkg/tracing"
 	"github.com/jackc/pgx/v5/pgxpool"
 	"go.uber.org/zap"
 )
@@ -26,6 +27,7 @@ func NewPool(ctx context.Context, dsn string, maxPoolSize int, logger *zap.Logge
 
 	cfg.MaxConns = int32(maxPoolSize)
 	cfg.ConnConfig.ConnectTimeout = defaultConnTimeout
+	cfg.ConnConfig.Tracer = tracing.NewPgxTracer()
 
 	cfg.MaxConnIdleTime = 1 * time.Minute
 	cfg.HealthCheckPeriod = 30 * time.Second
@@ -0,0 +1,112 @@
+package requestid
+
+import (
+	"context"
+
+	"github.com/Qu1nel/YaLyceum-GoProject-Final/internal/pkg/logger"
+	"github.com/google/uuid"
+	"github.com/labstack/echo/v4"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+	"go.uber.org/zap"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+)
+
+// MetadataKey - ключ gRPC метаданных, в котором ID запроса Агента передается Оркестратору и Воркеру.
+const MetadataKey = "x-request-id"
+
+// maxLength ограничивает длину ID, пришедшего извне, чтобы он не раздувал логи и строки задач.
+const maxLength = 128
+
+type contextKey struct{}
+
+func NewContext(ctx context.Context, id string) context.Context {
+	return context.WithValue(ctx, contextKey{}, id)
+}
+
+func FromContext(ctx context.Context) string {
+	id, _ := ctx.Value(contextKey{}).(string)
+	return id
+}
+
+// Field - поле zap с ID запроса, одинаковое во всех сервисах.
+func Field(id string) zap.Field {
+	return zap.String("request_id", id)
+}
+
+func outgoing(ctx context.Context) context.Context {
+	if id := FromContext(ctx); id != "" {
+		return metadata.AppendToOutgoingContext(ctx, MetadataKey, id)
+	}
+	return ctx
+}
+
+// incoming достает ID из метаданных входящего вызова (или создает новый) и кладет в контекст
+// сам ID и логгер запроса с полем request_id.
+func incoming(ctx context.Context, log *zap.Logger) context.Context {
+	var id string
+	if md, ok := metadata.FromIncomingContext(ctx); ok {
+		if values := md.Get(MetadataKey); len(values) > 0 {
+			id = values[0]
+		}
+	}
+	if id == "" || len(id) > maxLength {
+		id = uuid.NewString()
+	}
+	trace.SpanFromContext(ctx).SetAttributes(attribute.String("request.id", id))
+	ctx = NewContext(ctx, id)
+	return logger.WithContext(ctx, log.With(Field(id)))
+}
+
+// EchoMiddleware переносит ID, назначенный echomiddleware.RequestID, в контекст запроса,
+// откуда его забирают клиентские gRPC перехватчики и логгер запроса.
+// Должен стоять после echomiddleware.RequestID.
+func EchoMiddleware(log *zap.Logger) echo.MiddlewareFunc {
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			id := c.Response().Header().Get(echo.HeaderXRequestID)
+			if id == "" || len(id) > maxLength {
+				id = uuid.NewString()
+				c.Response().Header().Set(echo.HeaderXRequestID, id)
+			}
+			trace.SpanFromContext(c.Request().Context()).SetAttributes(attribute.String("request.id", id))
+			ctx := logger.WithContext(NewContext(c.Request().Context(), id), log.With(Field(id)))
+			c.SetRequest(c.Request().WithContext(ctx))
+			return next(c)
+		}
+	}
+}
+
+func UnaryClientInterceptor() grpc.UnaryClientInterceptor {
+	return func(ctx context.Context, method string, req, reply any, cc *grpc.ClientConn, invoker grpc.UnaryInvoker, opts ...grpc.CallOption) error {
+		return invoker(outgoing(ctx), method, req, reply, cc, opts...)
+	}
+}
+
+func StreamClientInterceptor() grpc.StreamClientInterceptor {
+	return func(ctx context.Context, desc *grpc.StreamDesc, cc *grpc.ClientConn, method string, streamer grpc.Streamer, opts ...grpc.CallOption) (grpc.ClientStream, error) {
+		return streamer(outgoing(ctx), desc, cc, method, opts...)
+	}
+}
+
+func UnaryServerInterceptor(log *zap.Logger) grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+		return handler(incoming(ctx, log), req)
+	}
+}
+
+func StreamServerInterceptor(log *zap.Logger) grpc.StreamServerInterceptor {
+	return func(srv any, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		return handler(srv, &serverStream{ServerStream: ss, ctx: incoming(ss.Context(), log)})
+	}
+}
+
+type serverStream struct {
+	grpc.ServerStream
+	ctx context.Context
+}
+
+func (s *serverStream) Context() context.Context {
+	return s.ctx
+}
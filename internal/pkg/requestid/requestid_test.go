@@ -0,0 +1,70 @@
+package requestid
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/Qu1nel/YaLyceum-GoProject-Final/internal/pkg/logger"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zaptest/observer"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+)
+
+func TestUnaryClientInterceptor_ForwardsRequestID(t *testing.T) {
+	var outgoing metadata.MD
+	invoker := func(ctx context.Context, method string, req, reply any, cc *grpc.ClientConn, opts ...grpc.CallOption) error {
+		outgoing, _ = metadata.FromOutgoingContext(ctx)
+		return nil
+	}
+
+	err := UnaryClientInterceptor()(NewContext(context.Background(), "req-1"), "/svc/Method", nil, nil, nil, invoker)
+
+	require.NoError(t, err)
+	assert.Equal(t, []string{"req-1"}, outgoing.Get(MetadataKey))
+}
+
+func TestUnaryServerInterceptor_AttachesRequestLogger(t *testing.T) {
+	core, logs := observer.New(zap.InfoLevel)
+	ctx := metadata.NewIncomingContext(context.Background(), metadata.Pairs(MetadataKey, "req-2"))
+
+	var handlerID string
+	handler := func(ctx context.Context, req any) (any, error) {
+		handlerID = FromContext(ctx)
+		logger.FromContext(ctx, zap.NewNop()).Info("обработка")
+		return nil, nil
+	}
+	_, err := UnaryServerInterceptor(zap.New(core))(ctx, nil, &grpc.UnaryServerInfo{FullMethod: "/svc/Method"}, handler)
+
+	require.NoError(t, err)
+	assert.Equal(t, "req-2", handlerID)
+	require.Equal(t, 1, logs.Len())
+	assert.Equal(t, "req-2", logs.All()[0].ContextMap()["request_id"])
+}
+
+func TestUnaryServerInterceptor_GeneratesMissingOrOversizedID(t *testing.T) {
+	for name, md := range map[string]metadata.MD{
+		"нет метаданных":  nil,
+		"слишком длинный": metadata.Pairs(MetadataKey, strings.Repeat("x", maxLength+1)),
+	} {
+		t.Run(name, func(t *testing.T) {
+			ctx := context.Background()
+			if md != nil {
+				ctx = metadata.NewIncomingContext(ctx, md)
+			}
+			var handlerID string
+			handler := func(ctx context.Context, req any) (any, error) {
+				handlerID = FromContext(ctx)
+				return nil, nil
+			}
+			_, err := UnaryServerInterceptor(zap.NewNop())(ctx, nil, &grpc.UnaryServerInfo{}, handler)
+
+			require.NoError(t, err)
+			assert.NotEmpty(t, handlerID)
+			assert.LessOrEqual(t, len(handlerID), maxLength)
+		})
+	}
+}
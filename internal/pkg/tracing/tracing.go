@@ -0,0 +1,86 @@
+package tracing
+
+import (
+	"context"
+	"fmt"
+	"os"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracegrpc"
+	"go.opentelemetry.io/otel/exporters/stdout/stdouttrace"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/sdk/resource"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	semconv "go.opentelemetry.io/otel/semconv/v1.37.0"
+)
+
+const (
+	ExporterNone   = "none"
+	ExporterStdout = "stdout"
+	ExporterOTLP   = "otlp"
+)
+
+// Config - общие для всех сервисов настройки трассировки.
+type Config struct {
+	Exporter     string  `mapstructure:"TRACING_EXPORTER"`
+	OTLPEndpoint string  `mapstructure:"TRACING_OTLP_ENDPOINT"`
+	OTLPInsecure bool    `mapstructure:"TRACING_OTLP_INSECURE"`
+	SampleRatio  float64 `mapstructure:"TRACING_SAMPLE_RATIO"`
+}
+
+func (c Config) Validate() error {
+	switch c.Exporter {
+	case ExporterNone, ExporterStdout:
+	case ExporterOTLP:
+		if c.OTLPEndpoint == "" {
+			return fmt.Errorf("TRACING_OTLP_ENDPOINT обязателен при TRACING_EXPORTER=%s", ExporterOTLP)
+		}
+	default:
+		return fmt.Errorf("TRACING_EXPORTER должен быть одним из: %s, %s, %s", ExporterNone, ExporterStdout, ExporterOTLP)
+	}
+	if c.SampleRatio < 0 || c.SampleRatio > 1 {
+		return fmt.Errorf("TRACING_SAMPLE_RATIO должен быть в диапазоне [0, 1]")
+	}
+	return nil
+}
+
+// Setup настраивает глобальный провайдер трассировки и W3C propagator.
+// При ExporterNone спаны не экспортируются, но контекст трассировки все равно передается между сервисами.
+func Setup(ctx context.Context, serviceName string, cfg Config) (*sdktrace.TracerProvider, error) {
+	res, err := resource.Merge(resource.Default(), resource.NewWithAttributes(
+		semconv.SchemaURL,
+		semconv.ServiceName(serviceName),
+	))
+	if err != nil {
+		return nil, fmt.Errorf("не удалось описать ресурс трассировки: %w", err)
+	}
+
+	opts := []sdktrace.TracerProviderOption{
+		sdktrace.WithResource(res),
+		sdktrace.WithSampler(sdktrace.ParentBased(sdktrace.TraceIDRatioBased(cfg.SampleRatio))),
+	}
+
+	switch cfg.Exporter {
+	case ExporterStdout:
+		exporter, expErr := stdouttrace.New(stdouttrace.WithWriter(os.Stdout))
+		if expErr != nil {
+			return nil, fmt.Errorf("не удалось создать stdout экспортер трассировки: %w", expErr)
+		}
+		opts = append(opts, sdktrace.WithBatcher(exporter))
+	case ExporterOTLP:
+		clientOpts := []otlptracegrpc.Option{otlptracegrpc.WithEndpoint(cfg.OTLPEndpoint)}
+		if cfg.OTLPInsecure {
+			clientOpts = append(clientOpts, otlptracegrpc.WithInsecure())
+		}
+		exporter, expErr := otlptracegrpc.New(ctx, clientOpts...)
+		if expErr != nil {
+			return nil, fmt.Errorf("не удалось создать OTLP экспортер трассировки: %w", expErr)
+		}
+		opts = append(opts, sdktrace.WithBatcher(exporter))
+	}
+
+	provider := sdktrace.NewTracerProvider(opts...)
+	otel.SetTracerProvider(provider)
+	otel.SetTextMapPropagator(propagation.NewCompositeTextMapPropagator(propagation.TraceContext{}, propagation.Baggage{}))
+	return provider, nil
+}
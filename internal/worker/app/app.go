@@ -5,15 +5,20 @@ import (
 	"errors"
 	"fmt"
 	"net"
+	"net/http"
 	"os"
 
 	"github.com/Qu1nel/YaLyceum-GoProject-Final/internal/pkg/logger"
+	"github.com/Qu1nel/YaLyceum-GoProject-Final/internal/pkg/metrics"
+	"github.com/Qu1nel/YaLyceum-GoProject-Final/internal/pkg/requestid"
 	"github.com/Qu1nel/YaLyceum-GoProject-Final/internal/pkg/shutdown"
+	"github.com/Qu1nel/YaLyceum-GoProject-Final/internal/pkg/tracing"
 	"github.com/Qu1nel/YaLyceum-GoProject-Final/internal/worker/config"
 	"github.com/Qu1nel/YaLyceum-GoProject-Final/internal/worker/grpc_handler"
 	"github.com/Qu1nel/YaLyceum-GoProject-Final/internal/worker/service"
 	pb "github.com/Qu1nel/YaLyceum-GoProject-Final/proto/gen/worker"
 
+	"go.opentelemetry.io/contrib/instrumentation/google.golang.org/grpc/otelgrpc"
 	"go.uber.org/fx"
 	"go.uber.org/zap"
 	"google.golang.org/grpc"
@@ -56,11 +61,29 @@ func Run() {
 			grpc_handler.NewWorkerServer,
 			func(l *zap.Logger) *grpc.Server {
 
-				srv := grpc.NewServer()
+				srv := grpc.NewServer(
+					grpc.ChainUnaryInterceptor(metrics.UnaryServerInterceptor(), requestid.UnaryServerInterceptor(l)),
+					grpc.ChainStreamInterceptor(metrics.StreamServerInterceptor(), requestid.StreamServerInterceptor(l)),
+					grpc.StatsHandler(otelgrpc.NewServerHandler()),
+				)
 				l.Info("Worker: создан инстанс gRPC сервера")
 				return srv
 			},
 		),
+		fx.Invoke(func(lc fx.Lifecycle, cfg *config.Config, l *zap.Logger) error {
+			provider, err := tracing.Setup(appCtx, "worker", cfg.Tracing)
+			if err != nil {
+				l.Error("Worker: не удалось настроить трассировку", zap.Error(err))
+				return err
+			}
+			l.Info("Worker: трассировка настроена", zap.String("exporter", cfg.Tracing.Exporter))
+			lc.Append(fx.Hook{
+				OnStop: func(ctx context.Context) error {
+					return provider.Shutdown(ctx)
+				},
+			})
+			return nil
+		}),
 		fx.Invoke(func(lc fx.Lifecycle,
 			grpcServer *grpc.Server,
 			workerHandler *grpc_handler.WorkerServer,
@@ -108,6 +131,22 @@ func Run() {
 				},
 			})
 
+			metricsServer := metrics.NewServer(cfg.GRPCServer.MetricsPort)
+			lc.Append(fx.Hook{
+				OnStart: func(startCtx context.Context) error {
+					l.Info("Worker: запуск HTTP сервера метрик", zap.String("адрес", metricsServer.Addr))
+					go func() {
+						if serveErr := metricsServer.ListenAndServe(); serveErr != nil && !errors.Is(serveErr, http.ErrServerClosed) {
+							l.Error("Worker: HTTP сервер метрик неожиданно завершил работу", zap.Error(serveErr))
+						}
+					}()
+					return nil
+				},
+				OnStop: func(stopCtx context.Context) error {
+					return metricsServer.Shutdown(stopCtx)
+				},
+			})
+
 			go shutdown.Graceful(appCtx, cancel, l, cfg.GracefulTimeout, nil, nil)
 		}),
 	)
@@ -8,6 +8,7 @@ import (
 	"strings"
 	"time"
 
+	"github.com/Qu1nel/YaLyceum-GoProject-Final/internal/pkg/tracing"
 	"github.com/mitchellh/mapstructure"
 	"github.com/spf13/viper"
 )
@@ -18,10 +19,12 @@ type Config struct {
 	Logger          LoggerConfig          `mapstructure:",squash"`
 	GracefulTimeout time.Duration         `mapstructure:"GRACEFUL_TIMEOUT"`
 	CalculationTime CalculationTimeConfig `mapstructure:",squash"`
+	Tracing         tracing.Config        `mapstructure:",squash"`
 }
 
 type GRPCServerConfig struct {
-	Port string `mapstructure:"WORKER_GRPC_PORT"`
+	Port        string `mapstructure:"WORKER_GRPC_PORT"`
+	MetricsPort string `mapstructure:"WORKER_METRICS_PORT"`
 }
 
 type LoggerConfig struct {
@@ -46,11 +49,16 @@ func Load() (*Config, error) {
 	v.SetDefault("LOG_LEVEL", "info")
 	v.SetDefault("GRACEFUL_TIMEOUT", "5s")
 	v.SetDefault("WORKER_GRPC_PORT", "50052")
+	v.SetDefault("WORKER_METRICS_PORT", "9102")
 	v.SetDefault("TIME_ADDITION_MS", "200ms")
 	v.SetDefault("TIME_SUBTRACTION_MS", "200ms")
 	v.SetDefault("TIME_MULTIPLICATION_MS", "300ms")
 	v.SetDefault("TIME_DIVISION_MS", "400ms")
 	v.SetDefault("TIME_EXPONENTIATION_MS", "500ms")
+	v.SetDefault("TRACING_EXPORTER", tracing.ExporterNone)
+	v.SetDefault("TRACING_OTLP_ENDPOINT", "localhost:4317")
+	v.SetDefault("TRACING_OTLP_INSECURE", true)
+	v.SetDefault("TRACING_SAMPLE_RATIO", 1.0)
 
 	if appEnv := os.Getenv("APP_ENV"); appEnv != "test" {
 		v.SetConfigName(".env")
@@ -82,6 +90,9 @@ func Load() (*Config, error) {
 	if cfg.GRPCServer.Port == "" {
 		return nil, errors.New("worker config: WORKER_GRPC_PORT не может быть пустым")
 	}
+	if cfg.GRPCServer.MetricsPort == "" {
+		return nil, errors.New("worker config: WORKER_METRICS_PORT не может быть пустым")
+	}
 	if cfg.CalculationTime.Addition <= 0 {
 
 		log.Printf("Worker Config: TIME_ADDITION_MS имеет нетипичное значение: %s", cfg.CalculationTime.Addition)
@@ -89,6 +100,9 @@ func Load() (*Config, error) {
 	if cfg.GracefulTimeout <= 0 {
 		return nil, errors.New("worker config: GRACEFUL_TIMEOUT должен быть положительным")
 	}
+	if err := cfg.Tracing.Validate(); err != nil {
+		return nil, fmt.Errorf("worker config: %w", err)
+	}
 
 	return &cfg, nil
 }
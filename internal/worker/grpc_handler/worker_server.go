@@ -4,6 +4,7 @@ import (
 	"context"
 	"errors"
 
+	"github.com/Qu1nel/YaLyceum-GoProject-Final/internal/pkg/logger"
 	"github.com/Qu1nel/YaLyceum-GoProject-Final/internal/worker/service"
 	pb "github.com/Qu1nel/YaLyceum-GoProject-Final/proto/gen/worker"
 	"go.uber.org/zap"
@@ -25,8 +26,8 @@ func NewWorkerServer(log *zap.Logger, calcService service.Calculator) *WorkerSer
 }
 
 func (s *WorkerServer) CalculateOperation(ctx context.Context, req *pb.CalculateOperationRequest) (*pb.CalculateOperationResponse, error) {
-
-	s.log.Debug("WorkerServer: получен запрос CalculateOperation",
+	log := logger.FromContext(ctx, s.log)
+	log.Debug("WorkerServer: получен запрос CalculateOperation",
 		zap.String("operationID", req.GetOperationId()),
 		zap.String("symbol", req.GetOperationSymbol()),
 		zap.Float64("operandA", req.GetOperandA()),
@@ -34,7 +35,7 @@ func (s *WorkerServer) CalculateOperation(ctx context.Context, req *pb.Calculate
 	)
 
 	if req.GetOperationId() == "" || req.GetOperationSymbol() == "" {
-		s.log.Warn("WorkerServer: невалидный запрос - пустой ID или символ операции")
+		log.Warn("WorkerServer: невалидный запрос - пустой ID или символ операции")
 		return nil, status.Error(codes.InvalidArgument, "operation_id и operation_symbol обязательны")
 	}
 
@@ -43,16 +44,16 @@ func (s *WorkerServer) CalculateOperation(ctx context.Context, req *pb.Calculate
 	response := &pb.CalculateOperationResponse{OperationId: req.GetOperationId()}
 
 	if serviceErr != nil {
-		s.log.Warn("WorkerServer: сервис вычислений вернул ошибку",
+		log.Warn("WorkerServer: сервис вычислений вернул ошибку",
 			zap.String("operationID", req.GetOperationId()),
 			zap.Error(serviceErr),
 		)
 		response.ErrorMessage = serviceErr.Error()
 
-		if errors.Is(serviceErr, service.ErrDivisionByZero) ||
-			errors.Is(serviceErr, service.ErrUnknownOperator) {
-
-			return response, status.Error(codes.InvalidArgument, response.ErrorMessage)
+		// Ошибки вычисления возвращаются в теле ответа: при gRPC ошибке клиент тело не получает.
+		if code := errorCode(serviceErr); code != pb.ErrorCode_ERROR_CODE_UNSPECIFIED {
+			response.ErrorCode = code
+			return response, nil
 		}
 		if errors.Is(serviceErr, context.Canceled) || errors.Is(serviceErr, context.DeadlineExceeded) {
 			return response, status.Error(codes.DeadlineExceeded, "операция отменена или превышен таймаут")
@@ -62,9 +63,23 @@ func (s *WorkerServer) CalculateOperation(ctx context.Context, req *pb.Calculate
 	}
 
 	response.Result = result
-	s.log.Info("WorkerServer: операция успешно вычислена",
+	log.Info("WorkerServer: операция успешно вычислена",
 		zap.String("operationID", req.GetOperationId()),
 		zap.Float64("result", result),
 	)
 	return response, nil
 }
+
+func errorCode(err error) pb.ErrorCode {
+	switch {
+	case errors.Is(err, service.ErrDivisionByZero):
+		return pb.ErrorCode_DIVISION_BY_ZERO
+	case errors.Is(err, service.ErrDomain):
+		return pb.ErrorCode_DOMAIN_ERROR
+	case errors.Is(err, service.ErrOverflow):
+		return pb.ErrorCode_OVERFLOW
+	case errors.Is(err, service.ErrUnknownOperator):
+		return pb.ErrorCode_UNKNOWN_OPERATOR
+	}
+	return pb.ErrorCode_ERROR_CODE_UNSPECIFIED
+}
@@ -45,6 +45,42 @@ func TestWorkerServer_CalculateOperation_Success(t *testing.T) {
 	mockCalcService.AssertExpectations(t)
 }
 
+func TestWorkerServer_CalculateOperation_CalculationError(t *testing.T) {
+	testCases := []struct {
+		name       string
+		symbol     string
+		a, b       float64
+		serviceErr error
+		wantCode   pb_worker.ErrorCode
+	}{
+		{"Деление на ноль", "/", 10, 0, service.ErrDivisionByZero, pb_worker.ErrorCode_DIVISION_BY_ZERO},
+		{"Вне области определения", "^", -8, 0.5, fmt.Errorf("%w: -8 ^ 0.5", service.ErrDomain), pb_worker.ErrorCode_DOMAIN_ERROR},
+		{"Переполнение", "^", 10, 400, fmt.Errorf("%w: 10 ^ 400", service.ErrOverflow), pb_worker.ErrorCode_OVERFLOW},
+		{"Неизвестный оператор", "%", 10, 2, fmt.Errorf("%w: %%", service.ErrUnknownOperator), pb_worker.ErrorCode_UNKNOWN_OPERATOR},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			grpcServer, mockCalcService := newTestServer(t)
+			req := &pb_worker.CalculateOperationRequest{
+				OperationId: "op_err", OperationSymbol: tc.symbol, OperandA: tc.a, OperandB: tc.b,
+			}
+
+			mockCalcService.On("Calculate", mock.Anything, req.OperationSymbol, req.OperandA, req.OperandB).
+				Return(0.0, tc.serviceErr).Once()
+
+			res, err := grpcServer.CalculateOperation(context.Background(), req)
+
+			require.NoError(t, err, "Ошибка вычисления передается в теле ответа, а не gRPC статусом")
+			require.NotNil(t, res)
+			assert.Equal(t, req.OperationId, res.OperationId, "ID операции в ответе")
+			assert.Equal(t, tc.wantCode, res.ErrorCode, "ErrorCode в теле ответа")
+			assert.Equal(t, tc.serviceErr.Error(), res.ErrorMessage, "ErrorMessage в теле ответа")
+			assert.Zero(t, res.Result, "Результат при ошибке должен быть 0")
+		})
+	}
+}
+
 func TestWorkerServer_CalculateOperation_ServiceError(t *testing.T) {
 	testCases := []struct {
 		name             string
@@ -54,8 +90,6 @@ func TestWorkerServer_CalculateOperation_ServiceError(t *testing.T) {
 		expectedGRPCCode codes.Code
 		expectedMsgPart  string
 	}{
-		{"Деление на ноль", "/", 10, 0, service.ErrDivisionByZero, codes.InvalidArgument, service.ErrDivisionByZero.Error()},
-		{"Неизвестный оператор", "%", 10, 2, fmt.Errorf("%w: %%", service.ErrUnknownOperator), codes.InvalidArgument, service.ErrUnknownOperator.Error()},
 		{"Отмена контекста", "+", 1, 1, context.DeadlineExceeded, codes.DeadlineExceeded, "операция отменена или превышен таймаут"},
 		{"Другая ошибка сервиса", "*", 2, 2, errors.New("неожиданная ошибка"), codes.Internal, "внутренняя ошибка сервера"},
 	}
@@ -7,6 +7,7 @@ import (
 	"math"
 	"time"
 
+	"github.com/Qu1nel/YaLyceum-GoProject-Final/internal/pkg/logger"
 	"github.com/Qu1nel/YaLyceum-GoProject-Final/internal/worker/config"
 	"go.uber.org/zap"
 )
@@ -14,6 +15,8 @@ import (
 var (
 	ErrDivisionByZero  = errors.New("деление на ноль")
 	ErrUnknownOperator = errors.New("неизвестный оператор")
+	ErrDomain          = errors.New("результат не определен для операндов")
+	ErrOverflow        = errors.New("переполнение: результат вне диапазона чисел")
 )
 
 type Calculator interface {
@@ -26,6 +29,16 @@ type calculatorService struct {
 }
 
 func NewCalculatorService(log *zap.Logger, cfg *config.Config) Calculator {
+	for operator, delay := range map[string]time.Duration{
+		"+":   cfg.CalculationTime.Addition,
+		"-":   cfg.CalculationTime.Subtraction,
+		"*":   cfg.CalculationTime.Multiplication,
+		"/":   cfg.CalculationTime.Division,
+		"^":   cfg.CalculationTime.Exponentiation,
+		"neg": cfg.CalculationTime.Subtraction,
+	} {
+		operationSimulatedDelay.WithLabelValues(operator).Set(delay.Seconds())
+	}
 	return &calculatorService{
 		log: log,
 		cfg: &cfg.CalculationTime,
@@ -33,8 +46,11 @@ func NewCalculatorService(log *zap.Logger, cfg *config.Config) Calculator {
 }
 
 func (s *calculatorService) Calculate(ctx context.Context, operation string, a, b float64) (float64, error) {
+	log := logger.FromContext(ctx, s.log)
+	start := time.Now()
+	operator := operation
 
-	s.log.Debug("CalculatorService: начало вычисления",
+	log.Debug("CalculatorService: начало вычисления",
 		zap.String("operation", operation),
 		zap.Float64("a", a),
 		zap.Float64("b", b),
@@ -56,7 +72,7 @@ func (s *calculatorService) Calculate(ctx context.Context, operation string, a,
 		delay = s.cfg.Multiplication
 	case "/":
 		if b == 0.0 {
-			s.log.Warn("CalculatorService: попытка деления на ноль", zap.Float64("a", a))
+			log.Warn("CalculatorService: попытка деления на ноль", zap.Float64("a", a))
 			calcErr = ErrDivisionByZero
 		} else {
 			result = a / b
@@ -70,22 +86,47 @@ func (s *calculatorService) Calculate(ctx context.Context, operation string, a,
 		delay = s.cfg.Subtraction
 
 	default:
-		s.log.Warn("CalculatorService: неизвестный оператор", zap.String("operation", operation))
+		log.Warn("CalculatorService: неизвестный оператор", zap.String("operation", operation))
 		calcErr = fmt.Errorf("%w: '%s'", ErrUnknownOperator, operation)
 		delay = 0
+		operator = unknownOperatorLabel
+	}
+
+	if calcErr == nil {
+		calcErr = checkResult(operation, a, b, result)
+		if calcErr != nil {
+			log.Warn("CalculatorService: результат вне области определения", zap.String("operation", operation), zap.Error(calcErr))
+		}
 	}
 
 	if calcErr != nil {
+		observeOperation(operator, operationStatusError, time.Since(start))
 		return 0, calcErr
 	}
 
-	s.log.Debug("CalculatorService: имитация задержки", zap.Duration("delay", delay))
+	log.Debug("CalculatorService: имитация задержки", zap.Duration("delay", delay))
 	select {
 	case <-time.After(delay):
-		s.log.Debug("CalculatorService: вычисление завершено", zap.Float64("result", result))
+		log.Debug("CalculatorService: вычисление завершено", zap.Float64("result", result))
+		observeOperation(operator, operationStatusOK, time.Since(start))
 		return result, nil
 	case <-ctx.Done():
-		s.log.Warn("CalculatorService: вычисление отменено контекстом", zap.Error(ctx.Err()))
+		log.Warn("CalculatorService: вычисление отменено контекстом", zap.Error(ctx.Err()))
+		observeOperation(operator, operationStatusCanceled, time.Since(start))
 		return 0, fmt.Errorf("вычисление '%s' отменено: %w", operation, ctx.Err())
 	}
 }
+
+// checkResult отклоняет NaN и бесконечность, полученные из конечных операндов.
+func checkResult(operation string, a, b, result float64) error {
+	operandsFinite := !math.IsInf(a, 0) && !math.IsNaN(a) && !math.IsInf(b, 0) && !math.IsNaN(b)
+	switch {
+	case !operandsFinite:
+		return nil
+	case math.IsNaN(result):
+		return fmt.Errorf("%w: %g %s %g", ErrDomain, a, operation, b)
+	case math.IsInf(result, 0):
+		return fmt.Errorf("%w: %g %s %g", ErrOverflow, a, operation, b)
+	}
+	return nil
+}
@@ -45,6 +45,9 @@ func TestCalculatorService_Calculate_BasicOperations(t *testing.T) {
 		{name: "Унарный минус (neg)", operation: "neg", a: 7, b: 0, want: -7.0},
 		{name: "Деление на ноль", operation: "/", a: 10, b: 0, wantErrIs: service.ErrDivisionByZero},
 		{name: "Неизвестный оператор", operation: "%", a: 10, b: 5, wantErrIs: service.ErrUnknownOperator, wantErrMsg: "неизвестный оператор: '%'"},
+		{name: "Дробная степень отрицательного числа", operation: "^", a: -8, b: 0.5, wantErrIs: service.ErrDomain},
+		{name: "Переполнение степени", operation: "^", a: 10, b: 400, wantErrIs: service.ErrOverflow},
+		{name: "Переполнение умножения", operation: "*", a: 1e308, b: 10, wantErrIs: service.ErrOverflow},
 	}
 
 	for _, tc := range testCases {
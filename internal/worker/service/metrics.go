@@ -0,0 +1,40 @@
+package service
+
+import (
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+)
+
+const (
+	operationStatusOK       = "ok"
+	operationStatusError    = "error"
+	operationStatusCanceled = "canceled"
+
+	// unknownOperatorLabel заменяет неподдерживаемые символы операций, чтобы они не порождали новые временные ряды.
+	unknownOperatorLabel = "unknown"
+)
+
+var (
+	operationsTotal = promauto.NewCounterVec(prometheus.CounterOpts{
+		Name: "worker_operations_total",
+		Help: "Количество выполненных операций по оператору и результату (ok, error, canceled).",
+	}, []string{"operator", "status"})
+
+	operationDuration = promauto.NewHistogramVec(prometheus.HistogramOpts{
+		Name:    "worker_operation_duration_seconds",
+		Help:    "Время выполнения операции, включая имитацию задержки.",
+		Buckets: []float64{0.01, 0.05, 0.1, 0.2, 0.3, 0.5, 0.75, 1, 2, 5},
+	}, []string{"operator"})
+
+	operationSimulatedDelay = promauto.NewGaugeVec(prometheus.GaugeOpts{
+		Name: "worker_operation_simulated_delay_seconds",
+		Help: "Настроенная имитация задержки операции (TIME_*_MS).",
+	}, []string{"operator"})
+)
+
+func observeOperation(operator, status string, duration time.Duration) {
+	operationsTotal.WithLabelValues(operator, status).Inc()
+	operationDuration.WithLabelValues(operator).Observe(duration.Seconds())
+}
@@ -0,0 +1,35 @@
+package service
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/Qu1nel/YaLyceum-GoProject-Final/internal/worker/config"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/zap"
+)
+
+func TestCalculatorService_Calculate_RecordsMetrics(t *testing.T) {
+	calc := NewCalculatorService(zap.NewNop(), &config.Config{CalculationTime: config.CalculationTimeConfig{
+		Addition:       time.Millisecond,
+		Subtraction:    time.Millisecond,
+		Multiplication: 3 * time.Millisecond,
+		Division:       time.Millisecond,
+		Exponentiation: time.Millisecond,
+	}})
+
+	okBefore := testutil.ToFloat64(operationsTotal.WithLabelValues("*", operationStatusOK))
+	errBefore := testutil.ToFloat64(operationsTotal.WithLabelValues("/", operationStatusError))
+	unknownBefore := testutil.ToFloat64(operationsTotal.WithLabelValues(unknownOperatorLabel, operationStatusError))
+
+	_, _ = calc.Calculate(context.Background(), "*", 2, 3)
+	_, _ = calc.Calculate(context.Background(), "/", 1, 0)
+	_, _ = calc.Calculate(context.Background(), "%", 1, 2)
+
+	assert.Equal(t, okBefore+1, testutil.ToFloat64(operationsTotal.WithLabelValues("*", operationStatusOK)))
+	assert.Equal(t, errBefore+1, testutil.ToFloat64(operationsTotal.WithLabelValues("/", operationStatusError)))
+	assert.Equal(t, unknownBefore+1, testutil.ToFloat64(operationsTotal.WithLabelValues(unknownOperatorLabel, operationStatusError)))
+	assert.Equal(t, 0.003, testutil.ToFloat64(operationSimulatedDelay.WithLabelValues("*")))
+}
@@ -0,0 +1,14 @@
+CREATE TABLE task_attempts (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    task_id UUID NOT NULL REFERENCES tasks(id) ON DELETE CASCADE,
+    attempt_number INTEGER NOT NULL,
+    status VARCHAR(50) NOT NULL DEFAULT 'pending',
+    result DOUBLE PRECISION,
+    error_message TEXT,
+    started_at TIMESTAMP WITH TIME ZONE,
+    finished_at TIMESTAMP WITH TIME ZONE,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT NOW(),
+    UNIQUE (task_id, attempt_number)
+);
+
+CREATE INDEX idx_task_attempts_task_id ON task_attempts(task_id);
\ No newline at end of file
@@ -0,0 +1,3 @@
+CREATE EXTENSION IF NOT EXISTS pg_trgm;
+
+CREATE INDEX idx_tasks_expression_trgm ON tasks USING GIN (expression gin_trgm_ops);
\ No newline at end of file
@@ -0,0 +1,3 @@
+CREATE INDEX idx_tasks_user_status ON tasks(user_id, status) INCLUDE (created_at, updated_at) WHERE deleted_at IS NULL;
+
+CREATE INDEX idx_task_operations_task_symbol ON task_operations(task_id, symbol);
\ No newline at end of file
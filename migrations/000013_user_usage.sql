@@ -0,0 +1,8 @@
+CREATE TABLE user_usage (
+    user_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    day DATE NOT NULL,
+    tasks_submitted BIGINT NOT NULL DEFAULT 0,
+    operations BIGINT NOT NULL DEFAULT 0,
+    compute_ms BIGINT NOT NULL DEFAULT 0,
+    PRIMARY KEY (user_id, day)
+);
\ No newline at end of file
@@ -0,0 +1 @@
+ALTER TABLE tasks ADD COLUMN error_code VARCHAR(32);
\ No newline at end of file
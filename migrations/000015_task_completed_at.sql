@@ -0,0 +1,3 @@
+ALTER TABLE tasks ADD COLUMN completed_at TIMESTAMP WITH TIME ZONE;
+
+UPDATE tasks SET completed_at = updated_at WHERE status IN ('completed', 'failed');
\ No newline at end of file
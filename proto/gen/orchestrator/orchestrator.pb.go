@@ -24,11 +24,14 @@ const (
 
 // Запрос на вычисление
 type ExpressionRequest struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	UserId        string                 `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"` // ID пользователя из JWT
-	Expression    string                 `protobuf:"bytes,2,opt,name=expression,proto3" json:"expression,omitempty"`       // Математическое выражение
-	unknownFields protoimpl.UnknownFields
-	sizeCache     protoimpl.SizeCache
+	state                  protoimpl.MessageState `protogen:"open.v1"`
+	UserId                 string                 `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`                                                      // ID пользователя из JWT
+	Expression             string                 `protobuf:"bytes,2,opt,name=expression,proto3" json:"expression,omitempty"`                                                            // Математическое выражение
+	CallbackUrl            string                 `protobuf:"bytes,3,opt,name=callback_url,json=callbackUrl,proto3" json:"callback_url,omitempty"`                                       // URL для webhook о завершении задачи (необязательно)
+	IdempotencyKey         string                 `protobuf:"bytes,4,opt,name=idempotency_key,json=idempotencyKey,proto3" json:"idempotency_key,omitempty"`                              // Ключ идемпотентности клиента (необязательно)
+	MaxEstimatedDurationMs int64                  `protobuf:"varint,5,opt,name=max_estimated_duration_ms,json=maxEstimatedDurationMs,proto3" json:"max_estimated_duration_ms,omitempty"` // Отклонить выражение, если оценка времени вычисления больше (0 - без ограничения)
+	unknownFields          protoimpl.UnknownFields
+	sizeCache              protoimpl.SizeCache
 }
 
 func (x *ExpressionRequest) Reset() {
@@ -75,12 +78,36 @@ func (x *ExpressionRequest) GetExpression() string {
 	return ""
 }
 
+func (x *ExpressionRequest) GetCallbackUrl() string {
+	if x != nil {
+		return x.CallbackUrl
+	}
+	return ""
+}
+
+func (x *ExpressionRequest) GetIdempotencyKey() string {
+	if x != nil {
+		return x.IdempotencyKey
+	}
+	return ""
+}
+
+func (x *ExpressionRequest) GetMaxEstimatedDurationMs() int64 {
+	if x != nil {
+		return x.MaxEstimatedDurationMs
+	}
+	return 0
+}
+
 // Ответ с ID созданной задачи
 type ExpressionResponse struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	TaskId        string                 `protobuf:"bytes,1,opt,name=task_id,json=taskId,proto3" json:"task_id,omitempty"` // UUID созданной задачи
-	unknownFields protoimpl.UnknownFields
-	sizeCache     protoimpl.SizeCache
+	state            protoimpl.MessageState `protogen:"open.v1"`
+	TaskId           string                 `protobuf:"bytes,1,opt,name=task_id,json=taskId,proto3" json:"task_id,omitempty"`                                // UUID созданной задачи
+	QueuePosition    int32                  `protobuf:"varint,2,opt,name=queue_position,json=queuePosition,proto3" json:"queue_position,omitempty"`          // Позиция в очереди вычислений (0 - вычисление уже запущено)
+	IdempotentReplay bool                   `protobuf:"varint,3,opt,name=idempotent_replay,json=idempotentReplay,proto3" json:"idempotent_replay,omitempty"` // Задача создана ранее запросом с тем же ключом идемпотентности
+	Estimate         *CostEstimate          `protobuf:"bytes,4,opt,name=estimate,proto3" json:"estimate,omitempty"`                                          // Оценка вычисления (не заполняется при idempotent_replay)
+	unknownFields    protoimpl.UnknownFields
+	sizeCache        protoimpl.SizeCache
 }
 
 func (x *ExpressionResponse) Reset() {
@@ -120,29 +147,50 @@ func (x *ExpressionResponse) GetTaskId() string {
 	return ""
 }
 
-// Запрос деталей задачи
-type TaskDetailsRequest struct {
+func (x *ExpressionResponse) GetQueuePosition() int32 {
+	if x != nil {
+		return x.QueuePosition
+	}
+	return 0
+}
+
+func (x *ExpressionResponse) GetIdempotentReplay() bool {
+	if x != nil {
+		return x.IdempotentReplay
+	}
+	return false
+}
+
+func (x *ExpressionResponse) GetEstimate() *CostEstimate {
+	if x != nil {
+		return x.Estimate
+	}
+	return nil
+}
+
+// Число вызовов Воркера одной операции
+type OperatorCount struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	UserId        string                 `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"` // ID пользователя (для проверки прав)
-	TaskId        string                 `protobuf:"bytes,2,opt,name=task_id,json=taskId,proto3" json:"task_id,omitempty"` // ID запрашиваемой задачи
+	Symbol        string                 `protobuf:"bytes,1,opt,name=symbol,proto3" json:"symbol,omitempty"` // "+", "-", "*", "/", "^", "neg"
+	Count         int32                  `protobuf:"varint,2,opt,name=count,proto3" json:"count,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *TaskDetailsRequest) Reset() {
-	*x = TaskDetailsRequest{}
+func (x *OperatorCount) Reset() {
+	*x = OperatorCount{}
 	mi := &file_proto_orchestrator_proto_msgTypes[2]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *TaskDetailsRequest) String() string {
+func (x *OperatorCount) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*TaskDetailsRequest) ProtoMessage() {}
+func (*OperatorCount) ProtoMessage() {}
 
-func (x *TaskDetailsRequest) ProtoReflect() protoreflect.Message {
+func (x *OperatorCount) ProtoReflect() protoreflect.Message {
 	mi := &file_proto_orchestrator_proto_msgTypes[2]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -154,53 +202,52 @@ func (x *TaskDetailsRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use TaskDetailsRequest.ProtoReflect.Descriptor instead.
-func (*TaskDetailsRequest) Descriptor() ([]byte, []int) {
+// Deprecated: Use OperatorCount.ProtoReflect.Descriptor instead.
+func (*OperatorCount) Descriptor() ([]byte, []int) {
 	return file_proto_orchestrator_proto_rawDescGZIP(), []int{2}
 }
 
-func (x *TaskDetailsRequest) GetUserId() string {
+func (x *OperatorCount) GetSymbol() string {
 	if x != nil {
-		return x.UserId
+		return x.Symbol
 	}
 	return ""
 }
 
-func (x *TaskDetailsRequest) GetTaskId() string {
+func (x *OperatorCount) GetCount() int32 {
 	if x != nil {
-		return x.TaskId
+		return x.Count
 	}
-	return ""
+	return 0
 }
 
-// Детали задачи (статус, результат/ошибка)
-type TaskDetailsResponse struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
-	Expression    string                 `protobuf:"bytes,2,opt,name=expression,proto3" json:"expression,omitempty"`
-	Status        string                 `protobuf:"bytes,3,opt,name=status,proto3" json:"status,omitempty"`                                 // "pending", "processing", "completed", "failed"
-	Result        float64                `protobuf:"fixed64,4,opt,name=result,proto3" json:"result,omitempty"`                               // Результат, если статус "completed"
-	ErrorMessage  string                 `protobuf:"bytes,5,opt,name=error_message,json=errorMessage,proto3" json:"error_message,omitempty"` // Сообщение об ошибке, если статус "failed"
-	CreatedAt     string                 `protobuf:"bytes,6,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`          // Время создания (RFC3339)
-	UpdatedAt     string                 `protobuf:"bytes,7,opt,name=updated_at,json=updatedAt,proto3" json:"updated_at,omitempty"`          // Время последнего обновления (RFC3339)
-	unknownFields protoimpl.UnknownFields
-	sizeCache     protoimpl.SizeCache
+// Оценка вычисления по задержкам операций Воркера и текущей свободной пропускной способности
+type CostEstimate struct {
+	state                  protoimpl.MessageState `protogen:"open.v1"`
+	Operations             []*OperatorCount       `protobuf:"bytes,1,rep,name=operations,proto3" json:"operations,omitempty"`
+	CriticalPathOperations int32                  `protobuf:"varint,2,opt,name=critical_path_operations,json=criticalPathOperations,proto3" json:"critical_path_operations,omitempty"` // Вызовов в самой долгой цепочке зависимых операций
+	CriticalPathMs         float64                `protobuf:"fixed64,3,opt,name=critical_path_ms,json=criticalPathMs,proto3" json:"critical_path_ms,omitempty"`
+	TotalWorkMs            float64                `protobuf:"fixed64,4,opt,name=total_work_ms,json=totalWorkMs,proto3" json:"total_work_ms,omitempty"` // Сумма времени всех вызовов
+	Parallelism            int32                  `protobuf:"varint,5,opt,name=parallelism,proto3" json:"parallelism,omitempty"`                       // Сколько вызовов Воркера могут идти одновременно
+	EstimatedDurationMs    float64                `protobuf:"fixed64,6,opt,name=estimated_duration_ms,json=estimatedDurationMs,proto3" json:"estimated_duration_ms,omitempty"`
+	unknownFields          protoimpl.UnknownFields
+	sizeCache              protoimpl.SizeCache
 }
 
-func (x *TaskDetailsResponse) Reset() {
-	*x = TaskDetailsResponse{}
+func (x *CostEstimate) Reset() {
+	*x = CostEstimate{}
 	mi := &file_proto_orchestrator_proto_msgTypes[3]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *TaskDetailsResponse) String() string {
+func (x *CostEstimate) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*TaskDetailsResponse) ProtoMessage() {}
+func (*CostEstimate) ProtoMessage() {}
 
-func (x *TaskDetailsResponse) ProtoReflect() protoreflect.Message {
+func (x *CostEstimate) ProtoReflect() protoreflect.Message {
 	mi := &file_proto_orchestrator_proto_msgTypes[3]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -212,82 +259,76 @@ func (x *TaskDetailsResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use TaskDetailsResponse.ProtoReflect.Descriptor instead.
-func (*TaskDetailsResponse) Descriptor() ([]byte, []int) {
+// Deprecated: Use CostEstimate.ProtoReflect.Descriptor instead.
+func (*CostEstimate) Descriptor() ([]byte, []int) {
 	return file_proto_orchestrator_proto_rawDescGZIP(), []int{3}
 }
 
-func (x *TaskDetailsResponse) GetId() string {
-	if x != nil {
-		return x.Id
-	}
-	return ""
-}
-
-func (x *TaskDetailsResponse) GetExpression() string {
+func (x *CostEstimate) GetOperations() []*OperatorCount {
 	if x != nil {
-		return x.Expression
+		return x.Operations
 	}
-	return ""
+	return nil
 }
 
-func (x *TaskDetailsResponse) GetStatus() string {
+func (x *CostEstimate) GetCriticalPathOperations() int32 {
 	if x != nil {
-		return x.Status
+		return x.CriticalPathOperations
 	}
-	return ""
+	return 0
 }
 
-func (x *TaskDetailsResponse) GetResult() float64 {
+func (x *CostEstimate) GetCriticalPathMs() float64 {
 	if x != nil {
-		return x.Result
+		return x.CriticalPathMs
 	}
 	return 0
 }
 
-func (x *TaskDetailsResponse) GetErrorMessage() string {
+func (x *CostEstimate) GetTotalWorkMs() float64 {
 	if x != nil {
-		return x.ErrorMessage
+		return x.TotalWorkMs
 	}
-	return ""
+	return 0
 }
 
-func (x *TaskDetailsResponse) GetCreatedAt() string {
+func (x *CostEstimate) GetParallelism() int32 {
 	if x != nil {
-		return x.CreatedAt
+		return x.Parallelism
 	}
-	return ""
+	return 0
 }
 
-func (x *TaskDetailsResponse) GetUpdatedAt() string {
+func (x *CostEstimate) GetEstimatedDurationMs() float64 {
 	if x != nil {
-		return x.UpdatedAt
+		return x.EstimatedDurationMs
 	}
-	return ""
+	return 0
 }
 
-// Запрос списка задач пользователя
-type UserTasksRequest struct {
+// Выражение из пакетного запроса
+type BatchExpressionItem struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	UserId        string                 `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"` // ID пользователя, чьи задачи нужно получить
+	Expression    string                 `protobuf:"bytes,1,opt,name=expression,proto3" json:"expression,omitempty"`
+	CallbackUrl   string                 `protobuf:"bytes,2,opt,name=callback_url,json=callbackUrl,proto3" json:"callback_url,omitempty"` // URL для webhook о завершении задачи (необязательно)
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *UserTasksRequest) Reset() {
-	*x = UserTasksRequest{}
+func (x *BatchExpressionItem) Reset() {
+	*x = BatchExpressionItem{}
 	mi := &file_proto_orchestrator_proto_msgTypes[4]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *UserTasksRequest) String() string {
+func (x *BatchExpressionItem) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*UserTasksRequest) ProtoMessage() {}
+func (*BatchExpressionItem) ProtoMessage() {}
 
-func (x *UserTasksRequest) ProtoReflect() protoreflect.Message {
+func (x *BatchExpressionItem) ProtoReflect() protoreflect.Message {
 	mi := &file_proto_orchestrator_proto_msgTypes[4]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -299,40 +340,48 @@ func (x *UserTasksRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use UserTasksRequest.ProtoReflect.Descriptor instead.
-func (*UserTasksRequest) Descriptor() ([]byte, []int) {
+// Deprecated: Use BatchExpressionItem.ProtoReflect.Descriptor instead.
+func (*BatchExpressionItem) Descriptor() ([]byte, []int) {
 	return file_proto_orchestrator_proto_rawDescGZIP(), []int{4}
 }
 
-func (x *UserTasksRequest) GetUserId() string {
+func (x *BatchExpressionItem) GetExpression() string {
 	if x != nil {
-		return x.UserId
+		return x.Expression
 	}
 	return ""
 }
 
-// Ответ со списком задач пользователя
-type UserTasksResponse struct {
+func (x *BatchExpressionItem) GetCallbackUrl() string {
+	if x != nil {
+		return x.CallbackUrl
+	}
+	return ""
+}
+
+// Пакетный запрос на вычисление
+type BatchExpressionRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	Tasks         []*TaskBrief           `protobuf:"bytes,1,rep,name=tasks,proto3" json:"tasks,omitempty"` // Повторяющееся поле для списка задач
+	UserId        string                 `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"` // ID пользователя из JWT
+	Items         []*BatchExpressionItem `protobuf:"bytes,2,rep,name=items,proto3" json:"items,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *UserTasksResponse) Reset() {
-	*x = UserTasksResponse{}
+func (x *BatchExpressionRequest) Reset() {
+	*x = BatchExpressionRequest{}
 	mi := &file_proto_orchestrator_proto_msgTypes[5]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *UserTasksResponse) String() string {
+func (x *BatchExpressionRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*UserTasksResponse) ProtoMessage() {}
+func (*BatchExpressionRequest) ProtoMessage() {}
 
-func (x *UserTasksResponse) ProtoReflect() protoreflect.Message {
+func (x *BatchExpressionRequest) ProtoReflect() protoreflect.Message {
 	mi := &file_proto_orchestrator_proto_msgTypes[5]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -344,43 +393,50 @@ func (x *UserTasksResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use UserTasksResponse.ProtoReflect.Descriptor instead.
-func (*UserTasksResponse) Descriptor() ([]byte, []int) {
+// Deprecated: Use BatchExpressionRequest.ProtoReflect.Descriptor instead.
+func (*BatchExpressionRequest) Descriptor() ([]byte, []int) {
 	return file_proto_orchestrator_proto_rawDescGZIP(), []int{5}
 }
 
-func (x *UserTasksResponse) GetTasks() []*TaskBrief {
+func (x *BatchExpressionRequest) GetUserId() string {
 	if x != nil {
-		return x.Tasks
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *BatchExpressionRequest) GetItems() []*BatchExpressionItem {
+	if x != nil {
+		return x.Items
 	}
 	return nil
 }
 
-// Краткая информация о задаче для списка
-type TaskBrief struct {
+// Результат одного выражения пакета: task_id или error
+type BatchExpressionResult struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
-	Expression    string                 `protobuf:"bytes,2,opt,name=expression,proto3" json:"expression,omitempty"`
-	Status        string                 `protobuf:"bytes,3,opt,name=status,proto3" json:"status,omitempty"`
-	CreatedAt     string                 `protobuf:"bytes,4,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"` // RFC3339
+	Index         int32                  `protobuf:"varint,1,opt,name=index,proto3" json:"index,omitempty"`                // Номер выражения в запросе, с 0
+	TaskId        string                 `protobuf:"bytes,2,opt,name=task_id,json=taskId,proto3" json:"task_id,omitempty"` // UUID созданной задачи (пусто, если выражение отклонено)
+	QueuePosition int32                  `protobuf:"varint,3,opt,name=queue_position,json=queuePosition,proto3" json:"queue_position,omitempty"`
+	Error         string                 `protobuf:"bytes,4,opt,name=error,proto3" json:"error,omitempty"` // Причина отказа (пусто, если задача создана и поставлена в очередь)
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *TaskBrief) Reset() {
-	*x = TaskBrief{}
+func (x *BatchExpressionResult) Reset() {
+	*x = BatchExpressionResult{}
 	mi := &file_proto_orchestrator_proto_msgTypes[6]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *TaskBrief) String() string {
+func (x *BatchExpressionResult) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*TaskBrief) ProtoMessage() {}
+func (*BatchExpressionResult) ProtoMessage() {}
 
-func (x *TaskBrief) ProtoReflect() protoreflect.Message {
+func (x *BatchExpressionResult) ProtoReflect() protoreflect.Message {
 	mi := &file_proto_orchestrator_proto_msgTypes[6]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -392,70 +448,3833 @@ func (x *TaskBrief) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use TaskBrief.ProtoReflect.Descriptor instead.
-func (*TaskBrief) Descriptor() ([]byte, []int) {
+// Deprecated: Use BatchExpressionResult.ProtoReflect.Descriptor instead.
+func (*BatchExpressionResult) Descriptor() ([]byte, []int) {
 	return file_proto_orchestrator_proto_rawDescGZIP(), []int{6}
 }
 
-func (x *TaskBrief) GetId() string {
+func (x *BatchExpressionResult) GetIndex() int32 {
 	if x != nil {
-		return x.Id
+		return x.Index
+	}
+	return 0
+}
+
+func (x *BatchExpressionResult) GetTaskId() string {
+	if x != nil {
+		return x.TaskId
 	}
 	return ""
 }
 
-func (x *TaskBrief) GetExpression() string {
+func (x *BatchExpressionResult) GetQueuePosition() int32 {
 	if x != nil {
-		return x.Expression
+		return x.QueuePosition
+	}
+	return 0
+}
+
+func (x *BatchExpressionResult) GetError() string {
+	if x != nil {
+		return x.Error
 	}
 	return ""
 }
 
-func (x *TaskBrief) GetStatus() string {
+// Ответ на пакетный запрос, результаты в порядке выражений запроса
+type BatchExpressionResponse struct {
+	state         protoimpl.MessageState   `protogen:"open.v1"`
+	Results       []*BatchExpressionResult `protobuf:"bytes,1,rep,name=results,proto3" json:"results,omitempty"`
+	Accepted      int32                    `protobuf:"varint,2,opt,name=accepted,proto3" json:"accepted,omitempty"`
+	Rejected      int32                    `protobuf:"varint,3,opt,name=rejected,proto3" json:"rejected,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *BatchExpressionResponse) Reset() {
+	*x = BatchExpressionResponse{}
+	mi := &file_proto_orchestrator_proto_msgTypes[7]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *BatchExpressionResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BatchExpressionResponse) ProtoMessage() {}
+
+func (x *BatchExpressionResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_orchestrator_proto_msgTypes[7]
 	if x != nil {
-		return x.Status
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use BatchExpressionResponse.ProtoReflect.Descriptor instead.
+func (*BatchExpressionResponse) Descriptor() ([]byte, []int) {
+	return file_proto_orchestrator_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *BatchExpressionResponse) GetResults() []*BatchExpressionResult {
+	if x != nil {
+		return x.Results
+	}
+	return nil
+}
+
+func (x *BatchExpressionResponse) GetAccepted() int32 {
+	if x != nil {
+		return x.Accepted
+	}
+	return 0
+}
+
+func (x *BatchExpressionResponse) GetRejected() int32 {
+	if x != nil {
+		return x.Rejected
+	}
+	return 0
+}
+
+// Запрос проверки выражения
+type ValidateExpressionRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	UserId        string                 `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"` // ID пользователя из JWT (для логов)
+	Expression    string                 `protobuf:"bytes,2,opt,name=expression,proto3" json:"expression,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ValidateExpressionRequest) Reset() {
+	*x = ValidateExpressionRequest{}
+	mi := &file_proto_orchestrator_proto_msgTypes[8]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ValidateExpressionRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ValidateExpressionRequest) ProtoMessage() {}
+
+func (x *ValidateExpressionRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_orchestrator_proto_msgTypes[8]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ValidateExpressionRequest.ProtoReflect.Descriptor instead.
+func (*ValidateExpressionRequest) Descriptor() ([]byte, []int) {
+	return file_proto_orchestrator_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *ValidateExpressionRequest) GetUserId() string {
+	if x != nil {
+		return x.UserId
 	}
 	return ""
 }
 
-func (x *TaskBrief) GetCreatedAt() string {
+func (x *ValidateExpressionRequest) GetExpression() string {
 	if x != nil {
-		return x.CreatedAt
+		return x.Expression
 	}
 	return ""
 }
 
-var File_proto_orchestrator_proto protoreflect.FileDescriptor
+// Ошибка в выражении с позицией в исходном тексте
+type ExpressionError struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Message       string                 `protobuf:"bytes,1,opt,name=message,proto3" json:"message,omitempty"`
+	Line          int32                  `protobuf:"varint,2,opt,name=line,proto3" json:"line,omitempty"`     // Строка, с 1
+	Column        int32                  `protobuf:"varint,3,opt,name=column,proto3" json:"column,omitempty"` // Символ в строке, с 1
+	Token         string                 `protobuf:"bytes,4,opt,name=token,proto3" json:"token,omitempty"`    // Фрагмент выражения, на котором возникла ошибка (пусто для неожиданного конца выражения)
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
 
-const file_proto_orchestrator_proto_rawDesc = "" +
-	"\n" +
-	"\x18proto/orchestrator.proto\x12\forchestrator\"L\n" +
-	"\x11ExpressionRequest\x12\x17\n" +
-	"\auser_id\x18\x01 \x01(\tR\x06userId\x12\x1e\n" +
-	"\n" +
-	"expression\x18\x02 \x01(\tR\n" +
-	"expression\"-\n" +
-	"\x12ExpressionResponse\x12\x17\n" +
-	"\atask_id\x18\x01 \x01(\tR\x06taskId\"F\n" +
-	"\x12TaskDetailsRequest\x12\x17\n" +
-	"\auser_id\x18\x01 \x01(\tR\x06userId\x12\x17\n" +
-	"\atask_id\x18\x02 \x01(\tR\x06taskId\"\xd8\x01\n" +
-	"\x13TaskDetailsResponse\x12\x0e\n" +
-	"\x02id\x18\x01 \x01(\tR\x02id\x12\x1e\n" +
-	"\n" +
-	"expression\x18\x02 \x01(\tR\n" +
-	"expression\x12\x16\n" +
-	"\x06status\x18\x03 \x01(\tR\x06status\x12\x16\n" +
-	"\x06result\x18\x04 \x01(\x01R\x06result\x12#\n" +
-	"\rerror_message\x18\x05 \x01(\tR\ferrorMessage\x12\x1d\n" +
-	"\n" +
-	"created_at\x18\x06 \x01(\tR\tcreatedAt\x12\x1d\n" +
-	"\n" +
-	"updated_at\x18\a \x01(\tR\tupdatedAt\"+\n" +
-	"\x10UserTasksRequest\x12\x17\n" +
-	"\auser_id\x18\x01 \x01(\tR\x06userId\"B\n" +
-	"\x11UserTasksResponse\x12-\n" +
-	"\x05tasks\x18\x01 \x03(\v2\x17.orchestrator.TaskBriefR\x05tasks\"r\n" +
+func (x *ExpressionError) Reset() {
+	*x = ExpressionError{}
+	mi := &file_proto_orchestrator_proto_msgTypes[9]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ExpressionError) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ExpressionError) ProtoMessage() {}
+
+func (x *ExpressionError) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_orchestrator_proto_msgTypes[9]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ExpressionError.ProtoReflect.Descriptor instead.
+func (*ExpressionError) Descriptor() ([]byte, []int) {
+	return file_proto_orchestrator_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *ExpressionError) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+func (x *ExpressionError) GetLine() int32 {
+	if x != nil {
+		return x.Line
+	}
+	return 0
+}
+
+func (x *ExpressionError) GetColumn() int32 {
+	if x != nil {
+		return x.Column
+	}
+	return 0
+}
+
+func (x *ExpressionError) GetToken() string {
+	if x != nil {
+		return x.Token
+	}
+	return ""
+}
+
+// Результат проверки: errors для невалидного выражения, иначе сведения о дереве
+type ValidateExpressionResponse struct {
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	Valid          bool                   `protobuf:"varint,1,opt,name=valid,proto3" json:"valid,omitempty"`
+	Errors         []*ExpressionError     `protobuf:"bytes,2,rep,name=errors,proto3" json:"errors,omitempty"`
+	Normalized     string                 `protobuf:"bytes,3,opt,name=normalized,proto3" json:"normalized,omitempty"`                                // Выражение в канонической записи
+	NodeCount      int32                  `protobuf:"varint,4,opt,name=node_count,json=nodeCount,proto3" json:"node_count,omitempty"`                // Число узлов дерева
+	OperationCount int32                  `protobuf:"varint,5,opt,name=operation_count,json=operationCount,proto3" json:"operation_count,omitempty"` // Число вызовов Воркера при вычислении
+	Depth          int32                  `protobuf:"varint,6,opt,name=depth,proto3" json:"depth,omitempty"`                                         // Глубина дерева (у одного числа - 1)
+	Estimate       *CostEstimate          `protobuf:"bytes,7,opt,name=estimate,proto3" json:"estimate,omitempty"`                                    // Только для валидного выражения
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *ValidateExpressionResponse) Reset() {
+	*x = ValidateExpressionResponse{}
+	mi := &file_proto_orchestrator_proto_msgTypes[10]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ValidateExpressionResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ValidateExpressionResponse) ProtoMessage() {}
+
+func (x *ValidateExpressionResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_orchestrator_proto_msgTypes[10]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ValidateExpressionResponse.ProtoReflect.Descriptor instead.
+func (*ValidateExpressionResponse) Descriptor() ([]byte, []int) {
+	return file_proto_orchestrator_proto_rawDescGZIP(), []int{10}
+}
+
+func (x *ValidateExpressionResponse) GetValid() bool {
+	if x != nil {
+		return x.Valid
+	}
+	return false
+}
+
+func (x *ValidateExpressionResponse) GetErrors() []*ExpressionError {
+	if x != nil {
+		return x.Errors
+	}
+	return nil
+}
+
+func (x *ValidateExpressionResponse) GetNormalized() string {
+	if x != nil {
+		return x.Normalized
+	}
+	return ""
+}
+
+func (x *ValidateExpressionResponse) GetNodeCount() int32 {
+	if x != nil {
+		return x.NodeCount
+	}
+	return 0
+}
+
+func (x *ValidateExpressionResponse) GetOperationCount() int32 {
+	if x != nil {
+		return x.OperationCount
+	}
+	return 0
+}
+
+func (x *ValidateExpressionResponse) GetDepth() int32 {
+	if x != nil {
+		return x.Depth
+	}
+	return 0
+}
+
+func (x *ValidateExpressionResponse) GetEstimate() *CostEstimate {
+	if x != nil {
+		return x.Estimate
+	}
+	return nil
+}
+
+// Запрос деталей задачи
+type TaskDetailsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	UserId        string                 `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"` // ID пользователя (для проверки прав)
+	TaskId        string                 `protobuf:"bytes,2,opt,name=task_id,json=taskId,proto3" json:"task_id,omitempty"` // ID запрашиваемой задачи
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *TaskDetailsRequest) Reset() {
+	*x = TaskDetailsRequest{}
+	mi := &file_proto_orchestrator_proto_msgTypes[11]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *TaskDetailsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TaskDetailsRequest) ProtoMessage() {}
+
+func (x *TaskDetailsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_orchestrator_proto_msgTypes[11]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TaskDetailsRequest.ProtoReflect.Descriptor instead.
+func (*TaskDetailsRequest) Descriptor() ([]byte, []int) {
+	return file_proto_orchestrator_proto_rawDescGZIP(), []int{11}
+}
+
+func (x *TaskDetailsRequest) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *TaskDetailsRequest) GetTaskId() string {
+	if x != nil {
+		return x.TaskId
+	}
+	return ""
+}
+
+// Детали задачи (статус, результат/ошибка)
+type TaskDetailsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Expression    string                 `protobuf:"bytes,2,opt,name=expression,proto3" json:"expression,omitempty"`
+	Status        string                 `protobuf:"bytes,3,opt,name=status,proto3" json:"status,omitempty"`                                     // "pending", "processing", "completed", "failed"
+	Result        float64                `protobuf:"fixed64,4,opt,name=result,proto3" json:"result,omitempty"`                                   // Результат, если статус "completed"
+	ErrorMessage  string                 `protobuf:"bytes,5,opt,name=error_message,json=errorMessage,proto3" json:"error_message,omitempty"`     // Сообщение об ошибке, если статус "failed"
+	CreatedAt     string                 `protobuf:"bytes,6,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`              // Время создания (RFC3339)
+	UpdatedAt     string                 `protobuf:"bytes,7,opt,name=updated_at,json=updatedAt,proto3" json:"updated_at,omitempty"`              // Время последнего обновления (RFC3339)
+	QueuePosition int32                  `protobuf:"varint,8,opt,name=queue_position,json=queuePosition,proto3" json:"queue_position,omitempty"` // Позиция в очереди, если задача ожидает запуска (0 - не в очереди)
+	Attempts      []*TaskAttempt         `protobuf:"bytes,9,rep,name=attempts,proto3" json:"attempts,omitempty"`                                 // История попыток вычисления
+	DeletedAt     string                 `protobuf:"bytes,10,opt,name=deleted_at,json=deletedAt,proto3" json:"deleted_at,omitempty"`             // RFC3339, время перемещения в корзину (пусто - задача не удалена)
+	RequestId     string                 `protobuf:"bytes,11,opt,name=request_id,json=requestId,proto3" json:"request_id,omitempty"`             // ID HTTP запроса, создавшего задачу (поле request_id в логах сервисов)
+	ErrorCode     string                 `protobuf:"bytes,12,opt,name=error_code,json=errorCode,proto3" json:"error_code,omitempty"`             // Тип ошибки, если статус "failed": "DIVISION_BY_ZERO", "DOMAIN_ERROR", "OVERFLOW", "TIMEOUT", "WORKER_UNAVAILABLE", "CANCELLED" или "INTERNAL"
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *TaskDetailsResponse) Reset() {
+	*x = TaskDetailsResponse{}
+	mi := &file_proto_orchestrator_proto_msgTypes[12]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *TaskDetailsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TaskDetailsResponse) ProtoMessage() {}
+
+func (x *TaskDetailsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_orchestrator_proto_msgTypes[12]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TaskDetailsResponse.ProtoReflect.Descriptor instead.
+func (*TaskDetailsResponse) Descriptor() ([]byte, []int) {
+	return file_proto_orchestrator_proto_rawDescGZIP(), []int{12}
+}
+
+func (x *TaskDetailsResponse) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *TaskDetailsResponse) GetExpression() string {
+	if x != nil {
+		return x.Expression
+	}
+	return ""
+}
+
+func (x *TaskDetailsResponse) GetStatus() string {
+	if x != nil {
+		return x.Status
+	}
+	return ""
+}
+
+func (x *TaskDetailsResponse) GetResult() float64 {
+	if x != nil {
+		return x.Result
+	}
+	return 0
+}
+
+func (x *TaskDetailsResponse) GetErrorMessage() string {
+	if x != nil {
+		return x.ErrorMessage
+	}
+	return ""
+}
+
+func (x *TaskDetailsResponse) GetCreatedAt() string {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return ""
+}
+
+func (x *TaskDetailsResponse) GetUpdatedAt() string {
+	if x != nil {
+		return x.UpdatedAt
+	}
+	return ""
+}
+
+func (x *TaskDetailsResponse) GetQueuePosition() int32 {
+	if x != nil {
+		return x.QueuePosition
+	}
+	return 0
+}
+
+func (x *TaskDetailsResponse) GetAttempts() []*TaskAttempt {
+	if x != nil {
+		return x.Attempts
+	}
+	return nil
+}
+
+func (x *TaskDetailsResponse) GetDeletedAt() string {
+	if x != nil {
+		return x.DeletedAt
+	}
+	return ""
+}
+
+func (x *TaskDetailsResponse) GetRequestId() string {
+	if x != nil {
+		return x.RequestId
+	}
+	return ""
+}
+
+func (x *TaskDetailsResponse) GetErrorCode() string {
+	if x != nil {
+		return x.ErrorCode
+	}
+	return ""
+}
+
+// Попытка вычисления задачи
+type TaskAttempt struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Number        int32                  `protobuf:"varint,1,opt,name=number,proto3" json:"number,omitempty"` // Порядковый номер попытки (с 1)
+	Status        string                 `protobuf:"bytes,2,opt,name=status,proto3" json:"status,omitempty"`
+	Result        float64                `protobuf:"fixed64,3,opt,name=result,proto3" json:"result,omitempty"`                               // Результат, если статус "completed"
+	ErrorMessage  string                 `protobuf:"bytes,4,opt,name=error_message,json=errorMessage,proto3" json:"error_message,omitempty"` // Сообщение об ошибке, если статус "failed"
+	StartedAt     string                 `protobuf:"bytes,5,opt,name=started_at,json=startedAt,proto3" json:"started_at,omitempty"`          // RFC3339, пусто если попытка еще не началась
+	FinishedAt    string                 `protobuf:"bytes,6,opt,name=finished_at,json=finishedAt,proto3" json:"finished_at,omitempty"`       // RFC3339, пусто если попытка еще не завершилась
+	CreatedAt     string                 `protobuf:"bytes,7,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`          // RFC3339
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *TaskAttempt) Reset() {
+	*x = TaskAttempt{}
+	mi := &file_proto_orchestrator_proto_msgTypes[13]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *TaskAttempt) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TaskAttempt) ProtoMessage() {}
+
+func (x *TaskAttempt) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_orchestrator_proto_msgTypes[13]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TaskAttempt.ProtoReflect.Descriptor instead.
+func (*TaskAttempt) Descriptor() ([]byte, []int) {
+	return file_proto_orchestrator_proto_rawDescGZIP(), []int{13}
+}
+
+func (x *TaskAttempt) GetNumber() int32 {
+	if x != nil {
+		return x.Number
+	}
+	return 0
+}
+
+func (x *TaskAttempt) GetStatus() string {
+	if x != nil {
+		return x.Status
+	}
+	return ""
+}
+
+func (x *TaskAttempt) GetResult() float64 {
+	if x != nil {
+		return x.Result
+	}
+	return 0
+}
+
+func (x *TaskAttempt) GetErrorMessage() string {
+	if x != nil {
+		return x.ErrorMessage
+	}
+	return ""
+}
+
+func (x *TaskAttempt) GetStartedAt() string {
+	if x != nil {
+		return x.StartedAt
+	}
+	return ""
+}
+
+func (x *TaskAttempt) GetFinishedAt() string {
+	if x != nil {
+		return x.FinishedAt
+	}
+	return ""
+}
+
+func (x *TaskAttempt) GetCreatedAt() string {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return ""
+}
+
+// Запрос повторного вычисления задачи
+type RetryTaskRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	UserId        string                 `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"` // ID пользователя (для проверки прав)
+	TaskId        string                 `protobuf:"bytes,2,opt,name=task_id,json=taskId,proto3" json:"task_id,omitempty"` // ID перезапускаемой задачи
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RetryTaskRequest) Reset() {
+	*x = RetryTaskRequest{}
+	mi := &file_proto_orchestrator_proto_msgTypes[14]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RetryTaskRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RetryTaskRequest) ProtoMessage() {}
+
+func (x *RetryTaskRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_orchestrator_proto_msgTypes[14]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RetryTaskRequest.ProtoReflect.Descriptor instead.
+func (*RetryTaskRequest) Descriptor() ([]byte, []int) {
+	return file_proto_orchestrator_proto_rawDescGZIP(), []int{14}
+}
+
+func (x *RetryTaskRequest) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *RetryTaskRequest) GetTaskId() string {
+	if x != nil {
+		return x.TaskId
+	}
+	return ""
+}
+
+// Ответ на запрос повторного вычисления
+type RetryTaskResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	TaskId        string                 `protobuf:"bytes,1,opt,name=task_id,json=taskId,proto3" json:"task_id,omitempty"`
+	AttemptNumber int32                  `protobuf:"varint,2,opt,name=attempt_number,json=attemptNumber,proto3" json:"attempt_number,omitempty"` // Номер созданной попытки
+	QueuePosition int32                  `protobuf:"varint,3,opt,name=queue_position,json=queuePosition,proto3" json:"queue_position,omitempty"` // Позиция в очереди вычислений (0 - вычисление уже запущено)
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RetryTaskResponse) Reset() {
+	*x = RetryTaskResponse{}
+	mi := &file_proto_orchestrator_proto_msgTypes[15]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RetryTaskResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RetryTaskResponse) ProtoMessage() {}
+
+func (x *RetryTaskResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_orchestrator_proto_msgTypes[15]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RetryTaskResponse.ProtoReflect.Descriptor instead.
+func (*RetryTaskResponse) Descriptor() ([]byte, []int) {
+	return file_proto_orchestrator_proto_rawDescGZIP(), []int{15}
+}
+
+func (x *RetryTaskResponse) GetTaskId() string {
+	if x != nil {
+		return x.TaskId
+	}
+	return ""
+}
+
+func (x *RetryTaskResponse) GetAttemptNumber() int32 {
+	if x != nil {
+		return x.AttemptNumber
+	}
+	return 0
+}
+
+func (x *RetryTaskResponse) GetQueuePosition() int32 {
+	if x != nil {
+		return x.QueuePosition
+	}
+	return 0
+}
+
+// Запрос списка задач пользователя
+type UserTasksRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	UserId        string                 `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`                // ID пользователя, чьи задачи нужно получить
+	PageSize      int32                  `protobuf:"varint,2,opt,name=page_size,json=pageSize,proto3" json:"page_size,omitempty"`         // Размер страницы (0 - значение по умолчанию)
+	PageToken     string                 `protobuf:"bytes,3,opt,name=page_token,json=pageToken,proto3" json:"page_token,omitempty"`       // Курсор из next_page_token предыдущего ответа
+	Statuses      []string               `protobuf:"bytes,4,rep,name=statuses,proto3" json:"statuses,omitempty"`                          // Фильтр по статусам (пусто - все статусы)
+	CreatedFrom   string                 `protobuf:"bytes,5,opt,name=created_from,json=createdFrom,proto3" json:"created_from,omitempty"` // RFC3339, нижняя граница created_at (включительно)
+	CreatedTo     string                 `protobuf:"bytes,6,opt,name=created_to,json=createdTo,proto3" json:"created_to,omitempty"`       // RFC3339, верхняя граница created_at (не включительно)
+	SortOrder     string                 `protobuf:"bytes,7,opt,name=sort_order,json=sortOrder,proto3" json:"sort_order,omitempty"`       // "desc" (по умолчанию) или "asc" по created_at
+	Search        string                 `protobuf:"bytes,8,opt,name=search,proto3" json:"search,omitempty"`                              // Поиск по выражению: подстрока, оператор или число целиком
+	Deleted       bool                   `protobuf:"varint,9,opt,name=deleted,proto3" json:"deleted,omitempty"`                           // true - вернуть задачи из корзины вместо активных
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UserTasksRequest) Reset() {
+	*x = UserTasksRequest{}
+	mi := &file_proto_orchestrator_proto_msgTypes[16]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UserTasksRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UserTasksRequest) ProtoMessage() {}
+
+func (x *UserTasksRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_orchestrator_proto_msgTypes[16]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UserTasksRequest.ProtoReflect.Descriptor instead.
+func (*UserTasksRequest) Descriptor() ([]byte, []int) {
+	return file_proto_orchestrator_proto_rawDescGZIP(), []int{16}
+}
+
+func (x *UserTasksRequest) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *UserTasksRequest) GetPageSize() int32 {
+	if x != nil {
+		return x.PageSize
+	}
+	return 0
+}
+
+func (x *UserTasksRequest) GetPageToken() string {
+	if x != nil {
+		return x.PageToken
+	}
+	return ""
+}
+
+func (x *UserTasksRequest) GetStatuses() []string {
+	if x != nil {
+		return x.Statuses
+	}
+	return nil
+}
+
+func (x *UserTasksRequest) GetCreatedFrom() string {
+	if x != nil {
+		return x.CreatedFrom
+	}
+	return ""
+}
+
+func (x *UserTasksRequest) GetCreatedTo() string {
+	if x != nil {
+		return x.CreatedTo
+	}
+	return ""
+}
+
+func (x *UserTasksRequest) GetSortOrder() string {
+	if x != nil {
+		return x.SortOrder
+	}
+	return ""
+}
+
+func (x *UserTasksRequest) GetSearch() string {
+	if x != nil {
+		return x.Search
+	}
+	return ""
+}
+
+func (x *UserTasksRequest) GetDeleted() bool {
+	if x != nil {
+		return x.Deleted
+	}
+	return false
+}
+
+// Ответ со списком задач пользователя
+type UserTasksResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Tasks         []*TaskBrief           `protobuf:"bytes,1,rep,name=tasks,proto3" json:"tasks,omitempty"`                                        // Повторяющееся поле для списка задач
+	NextPageToken string                 `protobuf:"bytes,2,opt,name=next_page_token,json=nextPageToken,proto3" json:"next_page_token,omitempty"` // Курсор следующей страницы (пусто - страниц больше нет)
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UserTasksResponse) Reset() {
+	*x = UserTasksResponse{}
+	mi := &file_proto_orchestrator_proto_msgTypes[17]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UserTasksResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UserTasksResponse) ProtoMessage() {}
+
+func (x *UserTasksResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_orchestrator_proto_msgTypes[17]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UserTasksResponse.ProtoReflect.Descriptor instead.
+func (*UserTasksResponse) Descriptor() ([]byte, []int) {
+	return file_proto_orchestrator_proto_rawDescGZIP(), []int{17}
+}
+
+func (x *UserTasksResponse) GetTasks() []*TaskBrief {
+	if x != nil {
+		return x.Tasks
+	}
+	return nil
+}
+
+func (x *UserTasksResponse) GetNextPageToken() string {
+	if x != nil {
+		return x.NextPageToken
+	}
+	return ""
+}
+
+// Краткая информация о задаче для списка
+type TaskBrief struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Expression    string                 `protobuf:"bytes,2,opt,name=expression,proto3" json:"expression,omitempty"`
+	Status        string                 `protobuf:"bytes,3,opt,name=status,proto3" json:"status,omitempty"`
+	CreatedAt     string                 `protobuf:"bytes,4,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`          // RFC3339
+	Result        float64                `protobuf:"fixed64,5,opt,name=result,proto3" json:"result,omitempty"`                               // Результат, если статус "completed"
+	ErrorMessage  string                 `protobuf:"bytes,6,opt,name=error_message,json=errorMessage,proto3" json:"error_message,omitempty"` // Сообщение об ошибке, если статус "failed"
+	DeletedAt     string                 `protobuf:"bytes,7,opt,name=deleted_at,json=deletedAt,proto3" json:"deleted_at,omitempty"`          // RFC3339, время перемещения в корзину (пусто - задача не удалена)
+	ErrorCode     string                 `protobuf:"bytes,8,opt,name=error_code,json=errorCode,proto3" json:"error_code,omitempty"`          // Тип ошибки, если статус "failed" (см. TaskDetailsResponse.error_code)
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *TaskBrief) Reset() {
+	*x = TaskBrief{}
+	mi := &file_proto_orchestrator_proto_msgTypes[18]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *TaskBrief) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TaskBrief) ProtoMessage() {}
+
+func (x *TaskBrief) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_orchestrator_proto_msgTypes[18]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TaskBrief.ProtoReflect.Descriptor instead.
+func (*TaskBrief) Descriptor() ([]byte, []int) {
+	return file_proto_orchestrator_proto_rawDescGZIP(), []int{18}
+}
+
+func (x *TaskBrief) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *TaskBrief) GetExpression() string {
+	if x != nil {
+		return x.Expression
+	}
+	return ""
+}
+
+func (x *TaskBrief) GetStatus() string {
+	if x != nil {
+		return x.Status
+	}
+	return ""
+}
+
+func (x *TaskBrief) GetCreatedAt() string {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return ""
+}
+
+func (x *TaskBrief) GetResult() float64 {
+	if x != nil {
+		return x.Result
+	}
+	return 0
+}
+
+func (x *TaskBrief) GetErrorMessage() string {
+	if x != nil {
+		return x.ErrorMessage
+	}
+	return ""
+}
+
+func (x *TaskBrief) GetDeletedAt() string {
+	if x != nil {
+		return x.DeletedAt
+	}
+	return ""
+}
+
+func (x *TaskBrief) GetErrorCode() string {
+	if x != nil {
+		return x.ErrorCode
+	}
+	return ""
+}
+
+// Запрос удаления задачи
+type DeleteTaskRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	UserId        string                 `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"` // ID пользователя (для проверки прав)
+	TaskId        string                 `protobuf:"bytes,2,opt,name=task_id,json=taskId,proto3" json:"task_id,omitempty"`
+	Purge         bool                   `protobuf:"varint,3,opt,name=purge,proto3" json:"purge,omitempty"` // true - удалить окончательно, минуя корзину
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeleteTaskRequest) Reset() {
+	*x = DeleteTaskRequest{}
+	mi := &file_proto_orchestrator_proto_msgTypes[19]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeleteTaskRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteTaskRequest) ProtoMessage() {}
+
+func (x *DeleteTaskRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_orchestrator_proto_msgTypes[19]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteTaskRequest.ProtoReflect.Descriptor instead.
+func (*DeleteTaskRequest) Descriptor() ([]byte, []int) {
+	return file_proto_orchestrator_proto_rawDescGZIP(), []int{19}
+}
+
+func (x *DeleteTaskRequest) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *DeleteTaskRequest) GetTaskId() string {
+	if x != nil {
+		return x.TaskId
+	}
+	return ""
+}
+
+func (x *DeleteTaskRequest) GetPurge() bool {
+	if x != nil {
+		return x.Purge
+	}
+	return false
+}
+
+type DeleteTaskResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeleteTaskResponse) Reset() {
+	*x = DeleteTaskResponse{}
+	mi := &file_proto_orchestrator_proto_msgTypes[20]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeleteTaskResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteTaskResponse) ProtoMessage() {}
+
+func (x *DeleteTaskResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_orchestrator_proto_msgTypes[20]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteTaskResponse.ProtoReflect.Descriptor instead.
+func (*DeleteTaskResponse) Descriptor() ([]byte, []int) {
+	return file_proto_orchestrator_proto_rawDescGZIP(), []int{20}
+}
+
+// Запрос восстановления задачи из корзины
+type RestoreTaskRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	UserId        string                 `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"` // ID пользователя (для проверки прав)
+	TaskId        string                 `protobuf:"bytes,2,opt,name=task_id,json=taskId,proto3" json:"task_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RestoreTaskRequest) Reset() {
+	*x = RestoreTaskRequest{}
+	mi := &file_proto_orchestrator_proto_msgTypes[21]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RestoreTaskRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RestoreTaskRequest) ProtoMessage() {}
+
+func (x *RestoreTaskRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_orchestrator_proto_msgTypes[21]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RestoreTaskRequest.ProtoReflect.Descriptor instead.
+func (*RestoreTaskRequest) Descriptor() ([]byte, []int) {
+	return file_proto_orchestrator_proto_rawDescGZIP(), []int{21}
+}
+
+func (x *RestoreTaskRequest) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *RestoreTaskRequest) GetTaskId() string {
+	if x != nil {
+		return x.TaskId
+	}
+	return ""
+}
+
+type RestoreTaskResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RestoreTaskResponse) Reset() {
+	*x = RestoreTaskResponse{}
+	mi := &file_proto_orchestrator_proto_msgTypes[22]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RestoreTaskResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RestoreTaskResponse) ProtoMessage() {}
+
+func (x *RestoreTaskResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_orchestrator_proto_msgTypes[22]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RestoreTaskResponse.ProtoReflect.Descriptor instead.
+func (*RestoreTaskResponse) Descriptor() ([]byte, []int) {
+	return file_proto_orchestrator_proto_rawDescGZIP(), []int{22}
+}
+
+// Запрос трассировки вычисления задачи
+type TaskTraceRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	UserId        string                 `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"` // ID пользователя (для проверки прав)
+	TaskId        string                 `protobuf:"bytes,2,opt,name=task_id,json=taskId,proto3" json:"task_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *TaskTraceRequest) Reset() {
+	*x = TaskTraceRequest{}
+	mi := &file_proto_orchestrator_proto_msgTypes[23]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *TaskTraceRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TaskTraceRequest) ProtoMessage() {}
+
+func (x *TaskTraceRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_orchestrator_proto_msgTypes[23]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TaskTraceRequest.ProtoReflect.Descriptor instead.
+func (*TaskTraceRequest) Descriptor() ([]byte, []int) {
+	return file_proto_orchestrator_proto_rawDescGZIP(), []int{23}
+}
+
+func (x *TaskTraceRequest) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *TaskTraceRequest) GetTaskId() string {
+	if x != nil {
+		return x.TaskId
+	}
+	return ""
+}
+
+// Трассировка последней попытки вычисления
+type TaskTraceResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	TaskId        string                 `protobuf:"bytes,1,opt,name=task_id,json=taskId,proto3" json:"task_id,omitempty"`
+	AttemptNumber int32                  `protobuf:"varint,2,opt,name=attempt_number,json=attemptNumber,proto3" json:"attempt_number,omitempty"` // Номер попытки, к которой относится трассировка (0 - операций еще не было)
+	Operations    []*TaskOperation       `protobuf:"bytes,3,rep,name=operations,proto3" json:"operations,omitempty"`                             // Вызовы Воркера в порядке запуска
+	Tree          *TraceNode             `protobuf:"bytes,4,opt,name=tree,proto3" json:"tree,omitempty"`                                         // Дерево выражения с привязанными вызовами Воркера
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *TaskTraceResponse) Reset() {
+	*x = TaskTraceResponse{}
+	mi := &file_proto_orchestrator_proto_msgTypes[24]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *TaskTraceResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TaskTraceResponse) ProtoMessage() {}
+
+func (x *TaskTraceResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_orchestrator_proto_msgTypes[24]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TaskTraceResponse.ProtoReflect.Descriptor instead.
+func (*TaskTraceResponse) Descriptor() ([]byte, []int) {
+	return file_proto_orchestrator_proto_rawDescGZIP(), []int{24}
+}
+
+func (x *TaskTraceResponse) GetTaskId() string {
+	if x != nil {
+		return x.TaskId
+	}
+	return ""
+}
+
+func (x *TaskTraceResponse) GetAttemptNumber() int32 {
+	if x != nil {
+		return x.AttemptNumber
+	}
+	return 0
+}
+
+func (x *TaskTraceResponse) GetOperations() []*TaskOperation {
+	if x != nil {
+		return x.Operations
+	}
+	return nil
+}
+
+func (x *TaskTraceResponse) GetTree() *TraceNode {
+	if x != nil {
+		return x.Tree
+	}
+	return nil
+}
+
+// Один вызов Воркера
+type TaskOperation struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Seq           int32                  `protobuf:"varint,1,opt,name=seq,proto3" json:"seq,omitempty"` // Порядковый номер по времени запуска (с 1)
+	OperationId   string                 `protobuf:"bytes,2,opt,name=operation_id,json=operationId,proto3" json:"operation_id,omitempty"`
+	NodePath      string                 `protobuf:"bytes,3,opt,name=node_path,json=nodePath,proto3" json:"node_path,omitempty"` // Путь узла в AST: "0" - корень, ".0"/".1" - левый (единственный) и правый операнд
+	Symbol        string                 `protobuf:"bytes,4,opt,name=symbol,proto3" json:"symbol,omitempty"`
+	OperandA      float64                `protobuf:"fixed64,5,opt,name=operand_a,json=operandA,proto3" json:"operand_a,omitempty"`
+	OperandB      float64                `protobuf:"fixed64,6,opt,name=operand_b,json=operandB,proto3" json:"operand_b,omitempty"`
+	Result        float64                `protobuf:"fixed64,7,opt,name=result,proto3" json:"result,omitempty"` // Результат, если error_message пуст
+	ErrorMessage  string                 `protobuf:"bytes,8,opt,name=error_message,json=errorMessage,proto3" json:"error_message,omitempty"`
+	WorkerAddress string                 `protobuf:"bytes,9,opt,name=worker_address,json=workerAddress,proto3" json:"worker_address,omitempty"`
+	StartedAt     string                 `protobuf:"bytes,10,opt,name=started_at,json=startedAt,proto3" json:"started_at,omitempty"` // RFC3339
+	DurationMs    float64                `protobuf:"fixed64,11,opt,name=duration_ms,json=durationMs,proto3" json:"duration_ms,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *TaskOperation) Reset() {
+	*x = TaskOperation{}
+	mi := &file_proto_orchestrator_proto_msgTypes[25]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *TaskOperation) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TaskOperation) ProtoMessage() {}
+
+func (x *TaskOperation) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_orchestrator_proto_msgTypes[25]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TaskOperation.ProtoReflect.Descriptor instead.
+func (*TaskOperation) Descriptor() ([]byte, []int) {
+	return file_proto_orchestrator_proto_rawDescGZIP(), []int{25}
+}
+
+func (x *TaskOperation) GetSeq() int32 {
+	if x != nil {
+		return x.Seq
+	}
+	return 0
+}
+
+func (x *TaskOperation) GetOperationId() string {
+	if x != nil {
+		return x.OperationId
+	}
+	return ""
+}
+
+func (x *TaskOperation) GetNodePath() string {
+	if x != nil {
+		return x.NodePath
+	}
+	return ""
+}
+
+func (x *TaskOperation) GetSymbol() string {
+	if x != nil {
+		return x.Symbol
+	}
+	return ""
+}
+
+func (x *TaskOperation) GetOperandA() float64 {
+	if x != nil {
+		return x.OperandA
+	}
+	return 0
+}
+
+func (x *TaskOperation) GetOperandB() float64 {
+	if x != nil {
+		return x.OperandB
+	}
+	return 0
+}
+
+func (x *TaskOperation) GetResult() float64 {
+	if x != nil {
+		return x.Result
+	}
+	return 0
+}
+
+func (x *TaskOperation) GetErrorMessage() string {
+	if x != nil {
+		return x.ErrorMessage
+	}
+	return ""
+}
+
+func (x *TaskOperation) GetWorkerAddress() string {
+	if x != nil {
+		return x.WorkerAddress
+	}
+	return ""
+}
+
+func (x *TaskOperation) GetStartedAt() string {
+	if x != nil {
+		return x.StartedAt
+	}
+	return ""
+}
+
+func (x *TaskOperation) GetDurationMs() float64 {
+	if x != nil {
+		return x.DurationMs
+	}
+	return 0
+}
+
+// Узел дерева выражения
+type TraceNode struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	NodePath      string                 `protobuf:"bytes,1,opt,name=node_path,json=nodePath,proto3" json:"node_path,omitempty"`
+	Kind          string                 `protobuf:"bytes,2,opt,name=kind,proto3" json:"kind,omitempty"`           // "number", "unary", "binary" или "unsupported"
+	Symbol        string                 `protobuf:"bytes,3,opt,name=symbol,proto3" json:"symbol,omitempty"`       // Оператор или запись числа
+	Value         float64                `protobuf:"fixed64,4,opt,name=value,proto3" json:"value,omitempty"`       // Значение для "number"
+	Operation     *TaskOperation         `protobuf:"bytes,5,opt,name=operation,proto3" json:"operation,omitempty"` // Вызов Воркера для этого узла, если он был
+	Children      []*TraceNode           `protobuf:"bytes,6,rep,name=children,proto3" json:"children,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *TraceNode) Reset() {
+	*x = TraceNode{}
+	mi := &file_proto_orchestrator_proto_msgTypes[26]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *TraceNode) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TraceNode) ProtoMessage() {}
+
+func (x *TraceNode) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_orchestrator_proto_msgTypes[26]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TraceNode.ProtoReflect.Descriptor instead.
+func (*TraceNode) Descriptor() ([]byte, []int) {
+	return file_proto_orchestrator_proto_rawDescGZIP(), []int{26}
+}
+
+func (x *TraceNode) GetNodePath() string {
+	if x != nil {
+		return x.NodePath
+	}
+	return ""
+}
+
+func (x *TraceNode) GetKind() string {
+	if x != nil {
+		return x.Kind
+	}
+	return ""
+}
+
+func (x *TraceNode) GetSymbol() string {
+	if x != nil {
+		return x.Symbol
+	}
+	return ""
+}
+
+func (x *TraceNode) GetValue() float64 {
+	if x != nil {
+		return x.Value
+	}
+	return 0
+}
+
+func (x *TraceNode) GetOperation() *TaskOperation {
+	if x != nil {
+		return x.Operation
+	}
+	return nil
+}
+
+func (x *TraceNode) GetChildren() []*TraceNode {
+	if x != nil {
+		return x.Children
+	}
+	return nil
+}
+
+// Запрос дерева выражения задачи
+type TaskASTRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	UserId        string                 `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"` // ID пользователя (для проверки прав)
+	TaskId        string                 `protobuf:"bytes,2,opt,name=task_id,json=taskId,proto3" json:"task_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *TaskASTRequest) Reset() {
+	*x = TaskASTRequest{}
+	mi := &file_proto_orchestrator_proto_msgTypes[27]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *TaskASTRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TaskASTRequest) ProtoMessage() {}
+
+func (x *TaskASTRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_orchestrator_proto_msgTypes[27]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TaskASTRequest.ProtoReflect.Descriptor instead.
+func (*TaskASTRequest) Descriptor() ([]byte, []int) {
+	return file_proto_orchestrator_proto_rawDescGZIP(), []int{27}
+}
+
+func (x *TaskASTRequest) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *TaskASTRequest) GetTaskId() string {
+	if x != nil {
+		return x.TaskId
+	}
+	return ""
+}
+
+type TaskASTResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	TaskId        string                 `protobuf:"bytes,1,opt,name=task_id,json=taskId,proto3" json:"task_id,omitempty"`
+	Status        string                 `protobuf:"bytes,2,opt,name=status,proto3" json:"status,omitempty"`                                     // Статус задачи
+	AttemptNumber int32                  `protobuf:"varint,3,opt,name=attempt_number,json=attemptNumber,proto3" json:"attempt_number,omitempty"` // Попытка, по которой размечены узлы (0 - вызовов Воркера еще не было)
+	Live          bool                   `protobuf:"varint,4,opt,name=live,proto3" json:"live,omitempty"`                                        // true, если разметка взята из идущего вычисления
+	Root          *AstNode               `protobuf:"bytes,5,opt,name=root,proto3" json:"root,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *TaskASTResponse) Reset() {
+	*x = TaskASTResponse{}
+	mi := &file_proto_orchestrator_proto_msgTypes[28]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *TaskASTResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TaskASTResponse) ProtoMessage() {}
+
+func (x *TaskASTResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_orchestrator_proto_msgTypes[28]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TaskASTResponse.ProtoReflect.Descriptor instead.
+func (*TaskASTResponse) Descriptor() ([]byte, []int) {
+	return file_proto_orchestrator_proto_rawDescGZIP(), []int{28}
+}
+
+func (x *TaskASTResponse) GetTaskId() string {
+	if x != nil {
+		return x.TaskId
+	}
+	return ""
+}
+
+func (x *TaskASTResponse) GetStatus() string {
+	if x != nil {
+		return x.Status
+	}
+	return ""
+}
+
+func (x *TaskASTResponse) GetAttemptNumber() int32 {
+	if x != nil {
+		return x.AttemptNumber
+	}
+	return 0
+}
+
+func (x *TaskASTResponse) GetLive() bool {
+	if x != nil {
+		return x.Live
+	}
+	return false
+}
+
+func (x *TaskASTResponse) GetRoot() *AstNode {
+	if x != nil {
+		return x.Root
+	}
+	return nil
+}
+
+// Узел дерева выражения, размеченный результатами вычисления
+type AstNode struct {
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	NodePath       string                 `protobuf:"bytes,1,opt,name=node_path,json=nodePath,proto3" json:"node_path,omitempty"`
+	Kind           string                 `protobuf:"bytes,2,opt,name=kind,proto3" json:"kind,omitempty"`                          // "number", "unary", "binary" или "unsupported"
+	Operator       string                 `protobuf:"bytes,3,opt,name=operator,proto3" json:"operator,omitempty"`                  // Оператор или запись числа
+	HasValue       bool                   `protobuf:"varint,4,opt,name=has_value,json=hasValue,proto3" json:"has_value,omitempty"` // value заполнено: число или уже вычисленный результат узла
+	Value          float64                `protobuf:"fixed64,5,opt,name=value,proto3" json:"value,omitempty"`
+	Status         string                 `protobuf:"bytes,6,opt,name=status,proto3" json:"status,omitempty"`                                           // "pending", "processing", "completed", "failed" или "skipped"
+	DurationMs     float64                `protobuf:"fixed64,7,opt,name=duration_ms,json=durationMs,proto3" json:"duration_ms,omitempty"`               // Время вызова Воркера для узла (для "processing" - сколько он уже идет)
+	CriticalPathMs float64                `protobuf:"fixed64,8,opt,name=critical_path_ms,json=criticalPathMs,proto3" json:"critical_path_ms,omitempty"` // duration_ms плюс самое долгое поддерево: операнды вычисляются параллельно
+	ErrorMessage   string                 `protobuf:"bytes,9,opt,name=error_message,json=errorMessage,proto3" json:"error_message,omitempty"`
+	Children       []*AstNode             `protobuf:"bytes,10,rep,name=children,proto3" json:"children,omitempty"`
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *AstNode) Reset() {
+	*x = AstNode{}
+	mi := &file_proto_orchestrator_proto_msgTypes[29]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AstNode) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AstNode) ProtoMessage() {}
+
+func (x *AstNode) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_orchestrator_proto_msgTypes[29]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AstNode.ProtoReflect.Descriptor instead.
+func (*AstNode) Descriptor() ([]byte, []int) {
+	return file_proto_orchestrator_proto_rawDescGZIP(), []int{29}
+}
+
+func (x *AstNode) GetNodePath() string {
+	if x != nil {
+		return x.NodePath
+	}
+	return ""
+}
+
+func (x *AstNode) GetKind() string {
+	if x != nil {
+		return x.Kind
+	}
+	return ""
+}
+
+func (x *AstNode) GetOperator() string {
+	if x != nil {
+		return x.Operator
+	}
+	return ""
+}
+
+func (x *AstNode) GetHasValue() bool {
+	if x != nil {
+		return x.HasValue
+	}
+	return false
+}
+
+func (x *AstNode) GetValue() float64 {
+	if x != nil {
+		return x.Value
+	}
+	return 0
+}
+
+func (x *AstNode) GetStatus() string {
+	if x != nil {
+		return x.Status
+	}
+	return ""
+}
+
+func (x *AstNode) GetDurationMs() float64 {
+	if x != nil {
+		return x.DurationMs
+	}
+	return 0
+}
+
+func (x *AstNode) GetCriticalPathMs() float64 {
+	if x != nil {
+		return x.CriticalPathMs
+	}
+	return 0
+}
+
+func (x *AstNode) GetErrorMessage() string {
+	if x != nil {
+		return x.ErrorMessage
+	}
+	return ""
+}
+
+func (x *AstNode) GetChildren() []*AstNode {
+	if x != nil {
+		return x.Children
+	}
+	return nil
+}
+
+// Запрос подписки на события задачи
+type WatchTaskRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	UserId        string                 `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"` // ID пользователя (для проверки прав)
+	TaskId        string                 `protobuf:"bytes,2,opt,name=task_id,json=taskId,proto3" json:"task_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *WatchTaskRequest) Reset() {
+	*x = WatchTaskRequest{}
+	mi := &file_proto_orchestrator_proto_msgTypes[30]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *WatchTaskRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*WatchTaskRequest) ProtoMessage() {}
+
+func (x *WatchTaskRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_orchestrator_proto_msgTypes[30]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use WatchTaskRequest.ProtoReflect.Descriptor instead.
+func (*WatchTaskRequest) Descriptor() ([]byte, []int) {
+	return file_proto_orchestrator_proto_rawDescGZIP(), []int{30}
+}
+
+func (x *WatchTaskRequest) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *WatchTaskRequest) GetTaskId() string {
+	if x != nil {
+		return x.TaskId
+	}
+	return ""
+}
+
+// Состояние задачи в момент события. Первое событие потока - текущее состояние задачи.
+type TaskEvent struct {
+	state           protoimpl.MessageState `protogen:"open.v1"`
+	TaskId          string                 `protobuf:"bytes,1,opt,name=task_id,json=taskId,proto3" json:"task_id,omitempty"`
+	Status          string                 `protobuf:"bytes,2,opt,name=status,proto3" json:"status,omitempty"` // "pending", "processing", "completed", "failed"
+	AttemptNumber   int32                  `protobuf:"varint,3,opt,name=attempt_number,json=attemptNumber,proto3" json:"attempt_number,omitempty"`
+	QueuePosition   int32                  `protobuf:"varint,4,opt,name=queue_position,json=queuePosition,proto3" json:"queue_position,omitempty"`       // Позиция в очереди для "pending" (0 - неизвестна или вычисление уже запущено)
+	OperationsDone  int32                  `protobuf:"varint,5,opt,name=operations_done,json=operationsDone,proto3" json:"operations_done,omitempty"`    // Сколько вызовов Воркера уже выполнено
+	OperationsTotal int32                  `protobuf:"varint,6,opt,name=operations_total,json=operationsTotal,proto3" json:"operations_total,omitempty"` // Сколько вызовов Воркера потребуется всего
+	Result          float64                `protobuf:"fixed64,7,opt,name=result,proto3" json:"result,omitempty"`                                         // Результат, если статус "completed"
+	ErrorMessage    string                 `protobuf:"bytes,8,opt,name=error_message,json=errorMessage,proto3" json:"error_message,omitempty"`           // Сообщение об ошибке, если статус "failed"
+	Timestamp       string                 `protobuf:"bytes,9,opt,name=timestamp,proto3" json:"timestamp,omitempty"`                                     // RFC3339
+	ErrorCode       string                 `protobuf:"bytes,10,opt,name=error_code,json=errorCode,proto3" json:"error_code,omitempty"`                   // Тип ошибки, если статус "failed" (см. TaskDetailsResponse.error_code)
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
+}
+
+func (x *TaskEvent) Reset() {
+	*x = TaskEvent{}
+	mi := &file_proto_orchestrator_proto_msgTypes[31]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *TaskEvent) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TaskEvent) ProtoMessage() {}
+
+func (x *TaskEvent) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_orchestrator_proto_msgTypes[31]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TaskEvent.ProtoReflect.Descriptor instead.
+func (*TaskEvent) Descriptor() ([]byte, []int) {
+	return file_proto_orchestrator_proto_rawDescGZIP(), []int{31}
+}
+
+func (x *TaskEvent) GetTaskId() string {
+	if x != nil {
+		return x.TaskId
+	}
+	return ""
+}
+
+func (x *TaskEvent) GetStatus() string {
+	if x != nil {
+		return x.Status
+	}
+	return ""
+}
+
+func (x *TaskEvent) GetAttemptNumber() int32 {
+	if x != nil {
+		return x.AttemptNumber
+	}
+	return 0
+}
+
+func (x *TaskEvent) GetQueuePosition() int32 {
+	if x != nil {
+		return x.QueuePosition
+	}
+	return 0
+}
+
+func (x *TaskEvent) GetOperationsDone() int32 {
+	if x != nil {
+		return x.OperationsDone
+	}
+	return 0
+}
+
+func (x *TaskEvent) GetOperationsTotal() int32 {
+	if x != nil {
+		return x.OperationsTotal
+	}
+	return 0
+}
+
+func (x *TaskEvent) GetResult() float64 {
+	if x != nil {
+		return x.Result
+	}
+	return 0
+}
+
+func (x *TaskEvent) GetErrorMessage() string {
+	if x != nil {
+		return x.ErrorMessage
+	}
+	return ""
+}
+
+func (x *TaskEvent) GetTimestamp() string {
+	if x != nil {
+		return x.Timestamp
+	}
+	return ""
+}
+
+func (x *TaskEvent) GetErrorCode() string {
+	if x != nil {
+		return x.ErrorCode
+	}
+	return ""
+}
+
+type CreateWebhookEndpointRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	UserId        string                 `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	Url           string                 `protobuf:"bytes,2,opt,name=url,proto3" json:"url,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CreateWebhookEndpointRequest) Reset() {
+	*x = CreateWebhookEndpointRequest{}
+	mi := &file_proto_orchestrator_proto_msgTypes[32]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreateWebhookEndpointRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateWebhookEndpointRequest) ProtoMessage() {}
+
+func (x *CreateWebhookEndpointRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_orchestrator_proto_msgTypes[32]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateWebhookEndpointRequest.ProtoReflect.Descriptor instead.
+func (*CreateWebhookEndpointRequest) Descriptor() ([]byte, []int) {
+	return file_proto_orchestrator_proto_rawDescGZIP(), []int{32}
+}
+
+func (x *CreateWebhookEndpointRequest) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *CreateWebhookEndpointRequest) GetUrl() string {
+	if x != nil {
+		return x.Url
+	}
+	return ""
+}
+
+type WebhookEndpoint struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Url           string                 `protobuf:"bytes,2,opt,name=url,proto3" json:"url,omitempty"`
+	CreatedAt     string                 `protobuf:"bytes,3,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"` // RFC3339
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *WebhookEndpoint) Reset() {
+	*x = WebhookEndpoint{}
+	mi := &file_proto_orchestrator_proto_msgTypes[33]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *WebhookEndpoint) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*WebhookEndpoint) ProtoMessage() {}
+
+func (x *WebhookEndpoint) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_orchestrator_proto_msgTypes[33]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use WebhookEndpoint.ProtoReflect.Descriptor instead.
+func (*WebhookEndpoint) Descriptor() ([]byte, []int) {
+	return file_proto_orchestrator_proto_rawDescGZIP(), []int{33}
+}
+
+func (x *WebhookEndpoint) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *WebhookEndpoint) GetUrl() string {
+	if x != nil {
+		return x.Url
+	}
+	return ""
+}
+
+func (x *WebhookEndpoint) GetCreatedAt() string {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return ""
+}
+
+type ListWebhookEndpointsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	UserId        string                 `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListWebhookEndpointsRequest) Reset() {
+	*x = ListWebhookEndpointsRequest{}
+	mi := &file_proto_orchestrator_proto_msgTypes[34]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListWebhookEndpointsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListWebhookEndpointsRequest) ProtoMessage() {}
+
+func (x *ListWebhookEndpointsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_orchestrator_proto_msgTypes[34]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListWebhookEndpointsRequest.ProtoReflect.Descriptor instead.
+func (*ListWebhookEndpointsRequest) Descriptor() ([]byte, []int) {
+	return file_proto_orchestrator_proto_rawDescGZIP(), []int{34}
+}
+
+func (x *ListWebhookEndpointsRequest) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+type ListWebhookEndpointsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Endpoints     []*WebhookEndpoint     `protobuf:"bytes,1,rep,name=endpoints,proto3" json:"endpoints,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListWebhookEndpointsResponse) Reset() {
+	*x = ListWebhookEndpointsResponse{}
+	mi := &file_proto_orchestrator_proto_msgTypes[35]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListWebhookEndpointsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListWebhookEndpointsResponse) ProtoMessage() {}
+
+func (x *ListWebhookEndpointsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_orchestrator_proto_msgTypes[35]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListWebhookEndpointsResponse.ProtoReflect.Descriptor instead.
+func (*ListWebhookEndpointsResponse) Descriptor() ([]byte, []int) {
+	return file_proto_orchestrator_proto_rawDescGZIP(), []int{35}
+}
+
+func (x *ListWebhookEndpointsResponse) GetEndpoints() []*WebhookEndpoint {
+	if x != nil {
+		return x.Endpoints
+	}
+	return nil
+}
+
+type DeleteWebhookEndpointRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	UserId        string                 `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	EndpointId    string                 `protobuf:"bytes,2,opt,name=endpoint_id,json=endpointId,proto3" json:"endpoint_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeleteWebhookEndpointRequest) Reset() {
+	*x = DeleteWebhookEndpointRequest{}
+	mi := &file_proto_orchestrator_proto_msgTypes[36]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeleteWebhookEndpointRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteWebhookEndpointRequest) ProtoMessage() {}
+
+func (x *DeleteWebhookEndpointRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_orchestrator_proto_msgTypes[36]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteWebhookEndpointRequest.ProtoReflect.Descriptor instead.
+func (*DeleteWebhookEndpointRequest) Descriptor() ([]byte, []int) {
+	return file_proto_orchestrator_proto_rawDescGZIP(), []int{36}
+}
+
+func (x *DeleteWebhookEndpointRequest) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *DeleteWebhookEndpointRequest) GetEndpointId() string {
+	if x != nil {
+		return x.EndpointId
+	}
+	return ""
+}
+
+type DeleteWebhookEndpointResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeleteWebhookEndpointResponse) Reset() {
+	*x = DeleteWebhookEndpointResponse{}
+	mi := &file_proto_orchestrator_proto_msgTypes[37]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeleteWebhookEndpointResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteWebhookEndpointResponse) ProtoMessage() {}
+
+func (x *DeleteWebhookEndpointResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_orchestrator_proto_msgTypes[37]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteWebhookEndpointResponse.ProtoReflect.Descriptor instead.
+func (*DeleteWebhookEndpointResponse) Descriptor() ([]byte, []int) {
+	return file_proto_orchestrator_proto_rawDescGZIP(), []int{37}
+}
+
+type WebhookSecretRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	UserId        string                 `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	Rotate        bool                   `protobuf:"varint,2,opt,name=rotate,proto3" json:"rotate,omitempty"` // Сгенерировать новый секрет вместо текущего
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *WebhookSecretRequest) Reset() {
+	*x = WebhookSecretRequest{}
+	mi := &file_proto_orchestrator_proto_msgTypes[38]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *WebhookSecretRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*WebhookSecretRequest) ProtoMessage() {}
+
+func (x *WebhookSecretRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_orchestrator_proto_msgTypes[38]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use WebhookSecretRequest.ProtoReflect.Descriptor instead.
+func (*WebhookSecretRequest) Descriptor() ([]byte, []int) {
+	return file_proto_orchestrator_proto_rawDescGZIP(), []int{38}
+}
+
+func (x *WebhookSecretRequest) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *WebhookSecretRequest) GetRotate() bool {
+	if x != nil {
+		return x.Rotate
+	}
+	return false
+}
+
+type WebhookSecretResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Secret        string                 `protobuf:"bytes,1,opt,name=secret,proto3" json:"secret,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *WebhookSecretResponse) Reset() {
+	*x = WebhookSecretResponse{}
+	mi := &file_proto_orchestrator_proto_msgTypes[39]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *WebhookSecretResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*WebhookSecretResponse) ProtoMessage() {}
+
+func (x *WebhookSecretResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_orchestrator_proto_msgTypes[39]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use WebhookSecretResponse.ProtoReflect.Descriptor instead.
+func (*WebhookSecretResponse) Descriptor() ([]byte, []int) {
+	return file_proto_orchestrator_proto_rawDescGZIP(), []int{39}
+}
+
+func (x *WebhookSecretResponse) GetSecret() string {
+	if x != nil {
+		return x.Secret
+	}
+	return ""
+}
+
+type ListWebhookDeliveriesRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	UserId        string                 `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	TaskId        string                 `protobuf:"bytes,2,opt,name=task_id,json=taskId,proto3" json:"task_id,omitempty"` // Только доставки этой задачи (пусто - все)
+	Limit         int32                  `protobuf:"varint,3,opt,name=limit,proto3" json:"limit,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListWebhookDeliveriesRequest) Reset() {
+	*x = ListWebhookDeliveriesRequest{}
+	mi := &file_proto_orchestrator_proto_msgTypes[40]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListWebhookDeliveriesRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListWebhookDeliveriesRequest) ProtoMessage() {}
+
+func (x *ListWebhookDeliveriesRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_orchestrator_proto_msgTypes[40]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListWebhookDeliveriesRequest.ProtoReflect.Descriptor instead.
+func (*ListWebhookDeliveriesRequest) Descriptor() ([]byte, []int) {
+	return file_proto_orchestrator_proto_rawDescGZIP(), []int{40}
+}
+
+func (x *ListWebhookDeliveriesRequest) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *ListWebhookDeliveriesRequest) GetTaskId() string {
+	if x != nil {
+		return x.TaskId
+	}
+	return ""
+}
+
+func (x *ListWebhookDeliveriesRequest) GetLimit() int32 {
+	if x != nil {
+		return x.Limit
+	}
+	return 0
+}
+
+// Доставка события задачи на один URL
+type WebhookDelivery struct {
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	Id             string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	TaskId         string                 `protobuf:"bytes,2,opt,name=task_id,json=taskId,proto3" json:"task_id,omitempty"`             // Пусто, если задача уже удалена
+	EndpointId     string                 `protobuf:"bytes,3,opt,name=endpoint_id,json=endpointId,proto3" json:"endpoint_id,omitempty"` // Пусто для callback_url задачи
+	Url            string                 `protobuf:"bytes,4,opt,name=url,proto3" json:"url,omitempty"`
+	Event          string                 `protobuf:"bytes,5,opt,name=event,proto3" json:"event,omitempty"`   // "task.completed", "task.failed"
+	Status         string                 `protobuf:"bytes,6,opt,name=status,proto3" json:"status,omitempty"` // "pending", "delivered", "failed"
+	Attempts       int32                  `protobuf:"varint,7,opt,name=attempts,proto3" json:"attempts,omitempty"`
+	LastStatusCode int32                  `protobuf:"varint,8,opt,name=last_status_code,json=lastStatusCode,proto3" json:"last_status_code,omitempty"` // HTTP код последнего ответа (0 - ответа не было)
+	LastError      string                 `protobuf:"bytes,9,opt,name=last_error,json=lastError,proto3" json:"last_error,omitempty"`
+	NextAttemptAt  string                 `protobuf:"bytes,10,opt,name=next_attempt_at,json=nextAttemptAt,proto3" json:"next_attempt_at,omitempty"` // RFC3339, пусто - повторов не запланировано
+	DeliveredAt    string                 `protobuf:"bytes,11,opt,name=delivered_at,json=deliveredAt,proto3" json:"delivered_at,omitempty"`         // RFC3339
+	CreatedAt      string                 `protobuf:"bytes,12,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`               // RFC3339
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *WebhookDelivery) Reset() {
+	*x = WebhookDelivery{}
+	mi := &file_proto_orchestrator_proto_msgTypes[41]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *WebhookDelivery) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*WebhookDelivery) ProtoMessage() {}
+
+func (x *WebhookDelivery) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_orchestrator_proto_msgTypes[41]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use WebhookDelivery.ProtoReflect.Descriptor instead.
+func (*WebhookDelivery) Descriptor() ([]byte, []int) {
+	return file_proto_orchestrator_proto_rawDescGZIP(), []int{41}
+}
+
+func (x *WebhookDelivery) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *WebhookDelivery) GetTaskId() string {
+	if x != nil {
+		return x.TaskId
+	}
+	return ""
+}
+
+func (x *WebhookDelivery) GetEndpointId() string {
+	if x != nil {
+		return x.EndpointId
+	}
+	return ""
+}
+
+func (x *WebhookDelivery) GetUrl() string {
+	if x != nil {
+		return x.Url
+	}
+	return ""
+}
+
+func (x *WebhookDelivery) GetEvent() string {
+	if x != nil {
+		return x.Event
+	}
+	return ""
+}
+
+func (x *WebhookDelivery) GetStatus() string {
+	if x != nil {
+		return x.Status
+	}
+	return ""
+}
+
+func (x *WebhookDelivery) GetAttempts() int32 {
+	if x != nil {
+		return x.Attempts
+	}
+	return 0
+}
+
+func (x *WebhookDelivery) GetLastStatusCode() int32 {
+	if x != nil {
+		return x.LastStatusCode
+	}
+	return 0
+}
+
+func (x *WebhookDelivery) GetLastError() string {
+	if x != nil {
+		return x.LastError
+	}
+	return ""
+}
+
+func (x *WebhookDelivery) GetNextAttemptAt() string {
+	if x != nil {
+		return x.NextAttemptAt
+	}
+	return ""
+}
+
+func (x *WebhookDelivery) GetDeliveredAt() string {
+	if x != nil {
+		return x.DeliveredAt
+	}
+	return ""
+}
+
+func (x *WebhookDelivery) GetCreatedAt() string {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return ""
+}
+
+type ListWebhookDeliveriesResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Deliveries    []*WebhookDelivery     `protobuf:"bytes,1,rep,name=deliveries,proto3" json:"deliveries,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListWebhookDeliveriesResponse) Reset() {
+	*x = ListWebhookDeliveriesResponse{}
+	mi := &file_proto_orchestrator_proto_msgTypes[42]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListWebhookDeliveriesResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListWebhookDeliveriesResponse) ProtoMessage() {}
+
+func (x *ListWebhookDeliveriesResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_orchestrator_proto_msgTypes[42]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListWebhookDeliveriesResponse.ProtoReflect.Descriptor instead.
+func (*ListWebhookDeliveriesResponse) Descriptor() ([]byte, []int) {
+	return file_proto_orchestrator_proto_rawDescGZIP(), []int{42}
+}
+
+func (x *ListWebhookDeliveriesResponse) GetDeliveries() []*WebhookDelivery {
+	if x != nil {
+		return x.Deliveries
+	}
+	return nil
+}
+
+type RedeliverWebhookRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	UserId        string                 `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	DeliveryId    string                 `protobuf:"bytes,2,opt,name=delivery_id,json=deliveryId,proto3" json:"delivery_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RedeliverWebhookRequest) Reset() {
+	*x = RedeliverWebhookRequest{}
+	mi := &file_proto_orchestrator_proto_msgTypes[43]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RedeliverWebhookRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RedeliverWebhookRequest) ProtoMessage() {}
+
+func (x *RedeliverWebhookRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_orchestrator_proto_msgTypes[43]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RedeliverWebhookRequest.ProtoReflect.Descriptor instead.
+func (*RedeliverWebhookRequest) Descriptor() ([]byte, []int) {
+	return file_proto_orchestrator_proto_rawDescGZIP(), []int{43}
+}
+
+func (x *RedeliverWebhookRequest) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *RedeliverWebhookRequest) GetDeliveryId() string {
+	if x != nil {
+		return x.DeliveryId
+	}
+	return ""
+}
+
+type RedeliverWebhookResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RedeliverWebhookResponse) Reset() {
+	*x = RedeliverWebhookResponse{}
+	mi := &file_proto_orchestrator_proto_msgTypes[44]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RedeliverWebhookResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RedeliverWebhookResponse) ProtoMessage() {}
+
+func (x *RedeliverWebhookResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_orchestrator_proto_msgTypes[44]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RedeliverWebhookResponse.ProtoReflect.Descriptor instead.
+func (*RedeliverWebhookResponse) Descriptor() ([]byte, []int) {
+	return file_proto_orchestrator_proto_rawDescGZIP(), []int{44}
+}
+
+type UserStatsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	UserId        string                 `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UserStatsRequest) Reset() {
+	*x = UserStatsRequest{}
+	mi := &file_proto_orchestrator_proto_msgTypes[45]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UserStatsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UserStatsRequest) ProtoMessage() {}
+
+func (x *UserStatsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_orchestrator_proto_msgTypes[45]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UserStatsRequest.ProtoReflect.Descriptor instead.
+func (*UserStatsRequest) Descriptor() ([]byte, []int) {
+	return file_proto_orchestrator_proto_rawDescGZIP(), []int{45}
+}
+
+func (x *UserStatsRequest) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+// Число задач пользователя в одном статусе
+type StatusCount struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Status        string                 `protobuf:"bytes,1,opt,name=status,proto3" json:"status,omitempty"`
+	Count         int64                  `protobuf:"varint,2,opt,name=count,proto3" json:"count,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *StatusCount) Reset() {
+	*x = StatusCount{}
+	mi := &file_proto_orchestrator_proto_msgTypes[46]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *StatusCount) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*StatusCount) ProtoMessage() {}
+
+func (x *StatusCount) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_orchestrator_proto_msgTypes[46]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use StatusCount.ProtoReflect.Descriptor instead.
+func (*StatusCount) Descriptor() ([]byte, []int) {
+	return file_proto_orchestrator_proto_rawDescGZIP(), []int{46}
+}
+
+func (x *StatusCount) GetStatus() string {
+	if x != nil {
+		return x.Status
+	}
+	return ""
+}
+
+func (x *StatusCount) GetCount() int64 {
+	if x != nil {
+		return x.Count
+	}
+	return 0
+}
+
+// Использование оператора в вызовах Воркера
+type OperatorUsage struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Symbol        string                 `protobuf:"bytes,1,opt,name=symbol,proto3" json:"symbol,omitempty"` // "+", "-", "*", "/", "^", "neg"
+	Count         int64                  `protobuf:"varint,2,opt,name=count,proto3" json:"count,omitempty"`
+	Failed        int64                  `protobuf:"varint,3,opt,name=failed,proto3" json:"failed,omitempty"` // Вызовы, завершившиеся ошибкой
+	AvgDurationMs float64                `protobuf:"fixed64,4,opt,name=avg_duration_ms,json=avgDurationMs,proto3" json:"avg_duration_ms,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *OperatorUsage) Reset() {
+	*x = OperatorUsage{}
+	mi := &file_proto_orchestrator_proto_msgTypes[47]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *OperatorUsage) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*OperatorUsage) ProtoMessage() {}
+
+func (x *OperatorUsage) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_orchestrator_proto_msgTypes[47]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use OperatorUsage.ProtoReflect.Descriptor instead.
+func (*OperatorUsage) Descriptor() ([]byte, []int) {
+	return file_proto_orchestrator_proto_rawDescGZIP(), []int{47}
+}
+
+func (x *OperatorUsage) GetSymbol() string {
+	if x != nil {
+		return x.Symbol
+	}
+	return ""
+}
+
+func (x *OperatorUsage) GetCount() int64 {
+	if x != nil {
+		return x.Count
+	}
+	return 0
+}
+
+func (x *OperatorUsage) GetFailed() int64 {
+	if x != nil {
+		return x.Failed
+	}
+	return 0
+}
+
+func (x *OperatorUsage) GetAvgDurationMs() float64 {
+	if x != nil {
+		return x.AvgDurationMs
+	}
+	return 0
+}
+
+type UserStatsResponse struct {
+	state                protoimpl.MessageState `protogen:"open.v1"`
+	Total                int64                  `protobuf:"varint,1,opt,name=total,proto3" json:"total,omitempty"` // Все задачи вне корзины
+	StatusCounts         []*StatusCount         `protobuf:"bytes,2,rep,name=status_counts,json=statusCounts,proto3" json:"status_counts,omitempty"`
+	SuccessRate          float64                `protobuf:"fixed64,3,opt,name=success_rate,json=successRate,proto3" json:"success_rate,omitempty"`                              // completed / (completed + failed), 0 если завершенных задач нет
+	CompletedMeasured    int64                  `protobuf:"varint,4,opt,name=completed_measured,json=completedMeasured,proto3" json:"completed_measured,omitempty"`             // Число completed задач, по которым посчитано время выполнения
+	AvgCompletionSeconds float64                `protobuf:"fixed64,5,opt,name=avg_completion_seconds,json=avgCompletionSeconds,proto3" json:"avg_completion_seconds,omitempty"` // Среднее время от постановки последней попытки в очередь до завершения
+	P95CompletionSeconds float64                `protobuf:"fixed64,6,opt,name=p95_completion_seconds,json=p95CompletionSeconds,proto3" json:"p95_completion_seconds,omitempty"` // 95-й перцентиль времени от постановки последней попытки в очередь до завершения
+	Operators            []*OperatorUsage       `protobuf:"bytes,7,rep,name=operators,proto3" json:"operators,omitempty"`                                                       // По убыванию числа вызовов
+	unknownFields        protoimpl.UnknownFields
+	sizeCache            protoimpl.SizeCache
+}
+
+func (x *UserStatsResponse) Reset() {
+	*x = UserStatsResponse{}
+	mi := &file_proto_orchestrator_proto_msgTypes[48]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UserStatsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UserStatsResponse) ProtoMessage() {}
+
+func (x *UserStatsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_orchestrator_proto_msgTypes[48]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UserStatsResponse.ProtoReflect.Descriptor instead.
+func (*UserStatsResponse) Descriptor() ([]byte, []int) {
+	return file_proto_orchestrator_proto_rawDescGZIP(), []int{48}
+}
+
+func (x *UserStatsResponse) GetTotal() int64 {
+	if x != nil {
+		return x.Total
+	}
+	return 0
+}
+
+func (x *UserStatsResponse) GetStatusCounts() []*StatusCount {
+	if x != nil {
+		return x.StatusCounts
+	}
+	return nil
+}
+
+func (x *UserStatsResponse) GetSuccessRate() float64 {
+	if x != nil {
+		return x.SuccessRate
+	}
+	return 0
+}
+
+func (x *UserStatsResponse) GetCompletedMeasured() int64 {
+	if x != nil {
+		return x.CompletedMeasured
+	}
+	return 0
+}
+
+func (x *UserStatsResponse) GetAvgCompletionSeconds() float64 {
+	if x != nil {
+		return x.AvgCompletionSeconds
+	}
+	return 0
+}
+
+func (x *UserStatsResponse) GetP95CompletionSeconds() float64 {
+	if x != nil {
+		return x.P95CompletionSeconds
+	}
+	return 0
+}
+
+func (x *UserStatsResponse) GetOperators() []*OperatorUsage {
+	if x != nil {
+		return x.Operators
+	}
+	return nil
+}
+
+type UsageRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	UserId        string                 `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UsageRequest) Reset() {
+	*x = UsageRequest{}
+	mi := &file_proto_orchestrator_proto_msgTypes[49]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UsageRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UsageRequest) ProtoMessage() {}
+
+func (x *UsageRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_orchestrator_proto_msgTypes[49]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UsageRequest.ProtoReflect.Descriptor instead.
+func (*UsageRequest) Descriptor() ([]byte, []int) {
+	return file_proto_orchestrator_proto_rawDescGZIP(), []int{49}
+}
+
+func (x *UsageRequest) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+type UsageCounter struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Used          int64                  `protobuf:"varint,1,opt,name=used,proto3" json:"used,omitempty"`
+	Limit         int64                  `protobuf:"varint,2,opt,name=limit,proto3" json:"limit,omitempty"`         // 0 - квота не ограничена
+	Remaining     int64                  `protobuf:"varint,3,opt,name=remaining,proto3" json:"remaining,omitempty"` // 0 при limit = 0
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UsageCounter) Reset() {
+	*x = UsageCounter{}
+	mi := &file_proto_orchestrator_proto_msgTypes[50]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UsageCounter) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UsageCounter) ProtoMessage() {}
+
+func (x *UsageCounter) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_orchestrator_proto_msgTypes[50]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UsageCounter.ProtoReflect.Descriptor instead.
+func (*UsageCounter) Descriptor() ([]byte, []int) {
+	return file_proto_orchestrator_proto_rawDescGZIP(), []int{50}
+}
+
+func (x *UsageCounter) GetUsed() int64 {
+	if x != nil {
+		return x.Used
+	}
+	return 0
+}
+
+func (x *UsageCounter) GetLimit() int64 {
+	if x != nil {
+		return x.Limit
+	}
+	return 0
+}
+
+func (x *UsageCounter) GetRemaining() int64 {
+	if x != nil {
+		return x.Remaining
+	}
+	return 0
+}
+
+// Потребление за период квоты (сутки или календарный месяц по UTC)
+type UsagePeriod struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Start         string                 `protobuf:"bytes,1,opt,name=start,proto3" json:"start,omitempty"`                          // RFC3339Nano
+	ResetAt       string                 `protobuf:"bytes,2,opt,name=reset_at,json=resetAt,proto3" json:"reset_at,omitempty"`       // RFC3339Nano, начало следующего периода
+	Tasks         *UsageCounter          `protobuf:"bytes,3,opt,name=tasks,proto3" json:"tasks,omitempty"`                          // Отправленные задачи
+	Operations    *UsageCounter          `protobuf:"bytes,4,opt,name=operations,proto3" json:"operations,omitempty"`                // Выполненные вызовы Воркера
+	ComputeMs     *UsageCounter          `protobuf:"bytes,5,opt,name=compute_ms,json=computeMs,proto3" json:"compute_ms,omitempty"` // Суммарное время вызовов Воркера
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UsagePeriod) Reset() {
+	*x = UsagePeriod{}
+	mi := &file_proto_orchestrator_proto_msgTypes[51]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UsagePeriod) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UsagePeriod) ProtoMessage() {}
+
+func (x *UsagePeriod) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_orchestrator_proto_msgTypes[51]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UsagePeriod.ProtoReflect.Descriptor instead.
+func (*UsagePeriod) Descriptor() ([]byte, []int) {
+	return file_proto_orchestrator_proto_rawDescGZIP(), []int{51}
+}
+
+func (x *UsagePeriod) GetStart() string {
+	if x != nil {
+		return x.Start
+	}
+	return ""
+}
+
+func (x *UsagePeriod) GetResetAt() string {
+	if x != nil {
+		return x.ResetAt
+	}
+	return ""
+}
+
+func (x *UsagePeriod) GetTasks() *UsageCounter {
+	if x != nil {
+		return x.Tasks
+	}
+	return nil
+}
+
+func (x *UsagePeriod) GetOperations() *UsageCounter {
+	if x != nil {
+		return x.Operations
+	}
+	return nil
+}
+
+func (x *UsagePeriod) GetComputeMs() *UsageCounter {
+	if x != nil {
+		return x.ComputeMs
+	}
+	return nil
+}
+
+type UsageResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Day           *UsagePeriod           `protobuf:"bytes,1,opt,name=day,proto3" json:"day,omitempty"`
+	Month         *UsagePeriod           `protobuf:"bytes,2,opt,name=month,proto3" json:"month,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UsageResponse) Reset() {
+	*x = UsageResponse{}
+	mi := &file_proto_orchestrator_proto_msgTypes[52]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UsageResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UsageResponse) ProtoMessage() {}
+
+func (x *UsageResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_orchestrator_proto_msgTypes[52]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UsageResponse.ProtoReflect.Descriptor instead.
+func (*UsageResponse) Descriptor() ([]byte, []int) {
+	return file_proto_orchestrator_proto_rawDescGZIP(), []int{52}
+}
+
+func (x *UsageResponse) GetDay() *UsagePeriod {
+	if x != nil {
+		return x.Day
+	}
+	return nil
+}
+
+func (x *UsageResponse) GetMonth() *UsagePeriod {
+	if x != nil {
+		return x.Month
+	}
+	return nil
+}
+
+type ListActiveEvaluationsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListActiveEvaluationsRequest) Reset() {
+	*x = ListActiveEvaluationsRequest{}
+	mi := &file_proto_orchestrator_proto_msgTypes[53]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListActiveEvaluationsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListActiveEvaluationsRequest) ProtoMessage() {}
+
+func (x *ListActiveEvaluationsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_orchestrator_proto_msgTypes[53]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListActiveEvaluationsRequest.ProtoReflect.Descriptor instead.
+func (*ListActiveEvaluationsRequest) Descriptor() ([]byte, []int) {
+	return file_proto_orchestrator_proto_rawDescGZIP(), []int{53}
+}
+
+// Задача, вычисляемая в данный момент
+type ActiveEvaluation struct {
+	state              protoimpl.MessageState `protogen:"open.v1"`
+	TaskId             string                 `protobuf:"bytes,1,opt,name=task_id,json=taskId,proto3" json:"task_id,omitempty"`
+	UserId             string                 `protobuf:"bytes,2,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	Expression         string                 `protobuf:"bytes,3,opt,name=expression,proto3" json:"expression,omitempty"`
+	AttemptNumber      int32                  `protobuf:"varint,4,opt,name=attempt_number,json=attemptNumber,proto3" json:"attempt_number,omitempty"`
+	StartedAt          string                 `protobuf:"bytes,5,opt,name=started_at,json=startedAt,proto3" json:"started_at,omitempty"` // RFC3339Nano
+	ElapsedSeconds     float64                `protobuf:"fixed64,6,opt,name=elapsed_seconds,json=elapsedSeconds,proto3" json:"elapsed_seconds,omitempty"`
+	InflightOperations int32                  `protobuf:"varint,7,opt,name=inflight_operations,json=inflightOperations,proto3" json:"inflight_operations,omitempty"` // Вызовы Воркера, выполняющиеся сейчас
+	OperationsDone     int32                  `protobuf:"varint,8,opt,name=operations_done,json=operationsDone,proto3" json:"operations_done,omitempty"`
+	OperationsTotal    int32                  `protobuf:"varint,9,opt,name=operations_total,json=operationsTotal,proto3" json:"operations_total,omitempty"`
+	unknownFields      protoimpl.UnknownFields
+	sizeCache          protoimpl.SizeCache
+}
+
+func (x *ActiveEvaluation) Reset() {
+	*x = ActiveEvaluation{}
+	mi := &file_proto_orchestrator_proto_msgTypes[54]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ActiveEvaluation) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ActiveEvaluation) ProtoMessage() {}
+
+func (x *ActiveEvaluation) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_orchestrator_proto_msgTypes[54]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ActiveEvaluation.ProtoReflect.Descriptor instead.
+func (*ActiveEvaluation) Descriptor() ([]byte, []int) {
+	return file_proto_orchestrator_proto_rawDescGZIP(), []int{54}
+}
+
+func (x *ActiveEvaluation) GetTaskId() string {
+	if x != nil {
+		return x.TaskId
+	}
+	return ""
+}
+
+func (x *ActiveEvaluation) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *ActiveEvaluation) GetExpression() string {
+	if x != nil {
+		return x.Expression
+	}
+	return ""
+}
+
+func (x *ActiveEvaluation) GetAttemptNumber() int32 {
+	if x != nil {
+		return x.AttemptNumber
+	}
+	return 0
+}
+
+func (x *ActiveEvaluation) GetStartedAt() string {
+	if x != nil {
+		return x.StartedAt
+	}
+	return ""
+}
+
+func (x *ActiveEvaluation) GetElapsedSeconds() float64 {
+	if x != nil {
+		return x.ElapsedSeconds
+	}
+	return 0
+}
+
+func (x *ActiveEvaluation) GetInflightOperations() int32 {
+	if x != nil {
+		return x.InflightOperations
+	}
+	return 0
+}
+
+func (x *ActiveEvaluation) GetOperationsDone() int32 {
+	if x != nil {
+		return x.OperationsDone
+	}
+	return 0
+}
+
+func (x *ActiveEvaluation) GetOperationsTotal() int32 {
+	if x != nil {
+		return x.OperationsTotal
+	}
+	return 0
+}
+
+type ListActiveEvaluationsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Evaluations   []*ActiveEvaluation    `protobuf:"bytes,1,rep,name=evaluations,proto3" json:"evaluations,omitempty"` // По времени запуска, самые долгие первыми
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListActiveEvaluationsResponse) Reset() {
+	*x = ListActiveEvaluationsResponse{}
+	mi := &file_proto_orchestrator_proto_msgTypes[55]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListActiveEvaluationsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListActiveEvaluationsResponse) ProtoMessage() {}
+
+func (x *ListActiveEvaluationsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_orchestrator_proto_msgTypes[55]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListActiveEvaluationsResponse.ProtoReflect.Descriptor instead.
+func (*ListActiveEvaluationsResponse) Descriptor() ([]byte, []int) {
+	return file_proto_orchestrator_proto_rawDescGZIP(), []int{55}
+}
+
+func (x *ListActiveEvaluationsResponse) GetEvaluations() []*ActiveEvaluation {
+	if x != nil {
+		return x.Evaluations
+	}
+	return nil
+}
+
+type QueueStatusRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *QueueStatusRequest) Reset() {
+	*x = QueueStatusRequest{}
+	mi := &file_proto_orchestrator_proto_msgTypes[56]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *QueueStatusRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*QueueStatusRequest) ProtoMessage() {}
+
+func (x *QueueStatusRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_orchestrator_proto_msgTypes[56]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use QueueStatusRequest.ProtoReflect.Descriptor instead.
+func (*QueueStatusRequest) Descriptor() ([]byte, []int) {
+	return file_proto_orchestrator_proto_rawDescGZIP(), []int{56}
+}
+
+// Воркер, к которому обращались вычисления с момента запуска Оркестратора
+type WorkerStatus struct {
+	state            protoimpl.MessageState `protogen:"open.v1"`
+	Address          string                 `protobuf:"bytes,1,opt,name=address,proto3" json:"address,omitempty"`
+	LastSeenAt       string                 `protobuf:"bytes,2,opt,name=last_seen_at,json=lastSeenAt,proto3" json:"last_seen_at,omitempty"` // RFC3339Nano, время последнего вызова
+	Operations       int64                  `protobuf:"varint,3,opt,name=operations,proto3" json:"operations,omitempty"`
+	FailedOperations int64                  `protobuf:"varint,4,opt,name=failed_operations,json=failedOperations,proto3" json:"failed_operations,omitempty"`
+	unknownFields    protoimpl.UnknownFields
+	sizeCache        protoimpl.SizeCache
+}
+
+func (x *WorkerStatus) Reset() {
+	*x = WorkerStatus{}
+	mi := &file_proto_orchestrator_proto_msgTypes[57]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *WorkerStatus) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*WorkerStatus) ProtoMessage() {}
+
+func (x *WorkerStatus) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_orchestrator_proto_msgTypes[57]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use WorkerStatus.ProtoReflect.Descriptor instead.
+func (*WorkerStatus) Descriptor() ([]byte, []int) {
+	return file_proto_orchestrator_proto_rawDescGZIP(), []int{57}
+}
+
+func (x *WorkerStatus) GetAddress() string {
+	if x != nil {
+		return x.Address
+	}
+	return ""
+}
+
+func (x *WorkerStatus) GetLastSeenAt() string {
+	if x != nil {
+		return x.LastSeenAt
+	}
+	return ""
+}
+
+func (x *WorkerStatus) GetOperations() int64 {
+	if x != nil {
+		return x.Operations
+	}
+	return 0
+}
+
+func (x *WorkerStatus) GetFailedOperations() int64 {
+	if x != nil {
+		return x.FailedOperations
+	}
+	return 0
+}
+
+type QueueStatusResponse struct {
+	state                    protoimpl.MessageState `protogen:"open.v1"`
+	EvaluationsRunning       int32                  `protobuf:"varint,1,opt,name=evaluations_running,json=evaluationsRunning,proto3" json:"evaluations_running,omitempty"`
+	EvaluationsWaiting       int32                  `protobuf:"varint,2,opt,name=evaluations_waiting,json=evaluationsWaiting,proto3" json:"evaluations_waiting,omitempty"`
+	MaxConcurrentEvaluations int32                  `protobuf:"varint,3,opt,name=max_concurrent_evaluations,json=maxConcurrentEvaluations,proto3" json:"max_concurrent_evaluations,omitempty"`
+	MaxQueuedEvaluations     int32                  `protobuf:"varint,4,opt,name=max_queued_evaluations,json=maxQueuedEvaluations,proto3" json:"max_queued_evaluations,omitempty"`
+	OperationsInflight       int32                  `protobuf:"varint,5,opt,name=operations_inflight,json=operationsInflight,proto3" json:"operations_inflight,omitempty"` // Вызовы Воркера, занявшие слот планировщика
+	OperationsWaiting        int32                  `protobuf:"varint,6,opt,name=operations_waiting,json=operationsWaiting,proto3" json:"operations_waiting,omitempty"`    // Вызовы Воркера, ожидающие слот
+	MaxInflightOperations    int32                  `protobuf:"varint,7,opt,name=max_inflight_operations,json=maxInflightOperations,proto3" json:"max_inflight_operations,omitempty"`
+	Workers                  []*WorkerStatus        `protobuf:"bytes,8,rep,name=workers,proto3" json:"workers,omitempty"`
+	unknownFields            protoimpl.UnknownFields
+	sizeCache                protoimpl.SizeCache
+}
+
+func (x *QueueStatusResponse) Reset() {
+	*x = QueueStatusResponse{}
+	mi := &file_proto_orchestrator_proto_msgTypes[58]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *QueueStatusResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*QueueStatusResponse) ProtoMessage() {}
+
+func (x *QueueStatusResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_orchestrator_proto_msgTypes[58]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use QueueStatusResponse.ProtoReflect.Descriptor instead.
+func (*QueueStatusResponse) Descriptor() ([]byte, []int) {
+	return file_proto_orchestrator_proto_rawDescGZIP(), []int{58}
+}
+
+func (x *QueueStatusResponse) GetEvaluationsRunning() int32 {
+	if x != nil {
+		return x.EvaluationsRunning
+	}
+	return 0
+}
+
+func (x *QueueStatusResponse) GetEvaluationsWaiting() int32 {
+	if x != nil {
+		return x.EvaluationsWaiting
+	}
+	return 0
+}
+
+func (x *QueueStatusResponse) GetMaxConcurrentEvaluations() int32 {
+	if x != nil {
+		return x.MaxConcurrentEvaluations
+	}
+	return 0
+}
+
+func (x *QueueStatusResponse) GetMaxQueuedEvaluations() int32 {
+	if x != nil {
+		return x.MaxQueuedEvaluations
+	}
+	return 0
+}
+
+func (x *QueueStatusResponse) GetOperationsInflight() int32 {
+	if x != nil {
+		return x.OperationsInflight
+	}
+	return 0
+}
+
+func (x *QueueStatusResponse) GetOperationsWaiting() int32 {
+	if x != nil {
+		return x.OperationsWaiting
+	}
+	return 0
+}
+
+func (x *QueueStatusResponse) GetMaxInflightOperations() int32 {
+	if x != nil {
+		return x.MaxInflightOperations
+	}
+	return 0
+}
+
+func (x *QueueStatusResponse) GetWorkers() []*WorkerStatus {
+	if x != nil {
+		return x.Workers
+	}
+	return nil
+}
+
+type SystemTaskCountsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SystemTaskCountsRequest) Reset() {
+	*x = SystemTaskCountsRequest{}
+	mi := &file_proto_orchestrator_proto_msgTypes[59]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SystemTaskCountsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SystemTaskCountsRequest) ProtoMessage() {}
+
+func (x *SystemTaskCountsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_orchestrator_proto_msgTypes[59]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SystemTaskCountsRequest.ProtoReflect.Descriptor instead.
+func (*SystemTaskCountsRequest) Descriptor() ([]byte, []int) {
+	return file_proto_orchestrator_proto_rawDescGZIP(), []int{59}
+}
+
+type SystemTaskCountsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Total         int64                  `protobuf:"varint,1,opt,name=total,proto3" json:"total,omitempty"` // Задачи всех пользователей вне корзины
+	StatusCounts  []*StatusCount         `protobuf:"bytes,2,rep,name=status_counts,json=statusCounts,proto3" json:"status_counts,omitempty"`
+	Trashed       int64                  `protobuf:"varint,3,opt,name=trashed,proto3" json:"trashed,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SystemTaskCountsResponse) Reset() {
+	*x = SystemTaskCountsResponse{}
+	mi := &file_proto_orchestrator_proto_msgTypes[60]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SystemTaskCountsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SystemTaskCountsResponse) ProtoMessage() {}
+
+func (x *SystemTaskCountsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_orchestrator_proto_msgTypes[60]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SystemTaskCountsResponse.ProtoReflect.Descriptor instead.
+func (*SystemTaskCountsResponse) Descriptor() ([]byte, []int) {
+	return file_proto_orchestrator_proto_rawDescGZIP(), []int{60}
+}
+
+func (x *SystemTaskCountsResponse) GetTotal() int64 {
+	if x != nil {
+		return x.Total
+	}
+	return 0
+}
+
+func (x *SystemTaskCountsResponse) GetStatusCounts() []*StatusCount {
+	if x != nil {
+		return x.StatusCounts
+	}
+	return nil
+}
+
+func (x *SystemTaskCountsResponse) GetTrashed() int64 {
+	if x != nil {
+		return x.Trashed
+	}
+	return 0
+}
+
+type ForceFailTaskRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	TaskId        string                 `protobuf:"bytes,1,opt,name=task_id,json=taskId,proto3" json:"task_id,omitempty"`
+	Reason        string                 `protobuf:"bytes,2,opt,name=reason,proto3" json:"reason,omitempty"` // Попадает в error_message задачи
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ForceFailTaskRequest) Reset() {
+	*x = ForceFailTaskRequest{}
+	mi := &file_proto_orchestrator_proto_msgTypes[61]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ForceFailTaskRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ForceFailTaskRequest) ProtoMessage() {}
+
+func (x *ForceFailTaskRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_orchestrator_proto_msgTypes[61]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ForceFailTaskRequest.ProtoReflect.Descriptor instead.
+func (*ForceFailTaskRequest) Descriptor() ([]byte, []int) {
+	return file_proto_orchestrator_proto_rawDescGZIP(), []int{61}
+}
+
+func (x *ForceFailTaskRequest) GetTaskId() string {
+	if x != nil {
+		return x.TaskId
+	}
+	return ""
+}
+
+func (x *ForceFailTaskRequest) GetReason() string {
+	if x != nil {
+		return x.Reason
+	}
+	return ""
+}
+
+type ForceFailTaskResponse struct {
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	TaskId         string                 `protobuf:"bytes,1,opt,name=task_id,json=taskId,proto3" json:"task_id,omitempty"`
+	PreviousStatus string                 `protobuf:"bytes,2,opt,name=previous_status,json=previousStatus,proto3" json:"previous_status,omitempty"`
+	ErrorMessage   string                 `protobuf:"bytes,3,opt,name=error_message,json=errorMessage,proto3" json:"error_message,omitempty"`
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *ForceFailTaskResponse) Reset() {
+	*x = ForceFailTaskResponse{}
+	mi := &file_proto_orchestrator_proto_msgTypes[62]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ForceFailTaskResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ForceFailTaskResponse) ProtoMessage() {}
+
+func (x *ForceFailTaskResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_orchestrator_proto_msgTypes[62]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ForceFailTaskResponse.ProtoReflect.Descriptor instead.
+func (*ForceFailTaskResponse) Descriptor() ([]byte, []int) {
+	return file_proto_orchestrator_proto_rawDescGZIP(), []int{62}
+}
+
+func (x *ForceFailTaskResponse) GetTaskId() string {
+	if x != nil {
+		return x.TaskId
+	}
+	return ""
+}
+
+func (x *ForceFailTaskResponse) GetPreviousStatus() string {
+	if x != nil {
+		return x.PreviousStatus
+	}
+	return ""
+}
+
+func (x *ForceFailTaskResponse) GetErrorMessage() string {
+	if x != nil {
+		return x.ErrorMessage
+	}
+	return ""
+}
+
+var File_proto_orchestrator_proto protoreflect.FileDescriptor
+
+const file_proto_orchestrator_proto_rawDesc = "" +
+	"\n" +
+	"\x18proto/orchestrator.proto\x12\forchestrator\"\xd3\x01\n" +
+	"\x11ExpressionRequest\x12\x17\n" +
+	"\auser_id\x18\x01 \x01(\tR\x06userId\x12\x1e\n" +
+	"\n" +
+	"expression\x18\x02 \x01(\tR\n" +
+	"expression\x12!\n" +
+	"\fcallback_url\x18\x03 \x01(\tR\vcallbackUrl\x12'\n" +
+	"\x0fidempotency_key\x18\x04 \x01(\tR\x0eidempotencyKey\x129\n" +
+	"\x19max_estimated_duration_ms\x18\x05 \x01(\x03R\x16maxEstimatedDurationMs\"\xb9\x01\n" +
+	"\x12ExpressionResponse\x12\x17\n" +
+	"\atask_id\x18\x01 \x01(\tR\x06taskId\x12%\n" +
+	"\x0equeue_position\x18\x02 \x01(\x05R\rqueuePosition\x12+\n" +
+	"\x11idempotent_replay\x18\x03 \x01(\bR\x10idempotentReplay\x126\n" +
+	"\bestimate\x18\x04 \x01(\v2\x1a.orchestrator.CostEstimateR\bestimate\"=\n" +
+	"\rOperatorCount\x12\x16\n" +
+	"\x06symbol\x18\x01 \x01(\tR\x06symbol\x12\x14\n" +
+	"\x05count\x18\x02 \x01(\x05R\x05count\"\xa9\x02\n" +
+	"\fCostEstimate\x12;\n" +
+	"\n" +
+	"operations\x18\x01 \x03(\v2\x1b.orchestrator.OperatorCountR\n" +
+	"operations\x128\n" +
+	"\x18critical_path_operations\x18\x02 \x01(\x05R\x16criticalPathOperations\x12(\n" +
+	"\x10critical_path_ms\x18\x03 \x01(\x01R\x0ecriticalPathMs\x12\"\n" +
+	"\rtotal_work_ms\x18\x04 \x01(\x01R\vtotalWorkMs\x12 \n" +
+	"\vparallelism\x18\x05 \x01(\x05R\vparallelism\x122\n" +
+	"\x15estimated_duration_ms\x18\x06 \x01(\x01R\x13estimatedDurationMs\"X\n" +
+	"\x13BatchExpressionItem\x12\x1e\n" +
+	"\n" +
+	"expression\x18\x01 \x01(\tR\n" +
+	"expression\x12!\n" +
+	"\fcallback_url\x18\x02 \x01(\tR\vcallbackUrl\"j\n" +
+	"\x16BatchExpressionRequest\x12\x17\n" +
+	"\auser_id\x18\x01 \x01(\tR\x06userId\x127\n" +
+	"\x05items\x18\x02 \x03(\v2!.orchestrator.BatchExpressionItemR\x05items\"\x83\x01\n" +
+	"\x15BatchExpressionResult\x12\x14\n" +
+	"\x05index\x18\x01 \x01(\x05R\x05index\x12\x17\n" +
+	"\atask_id\x18\x02 \x01(\tR\x06taskId\x12%\n" +
+	"\x0equeue_position\x18\x03 \x01(\x05R\rqueuePosition\x12\x14\n" +
+	"\x05error\x18\x04 \x01(\tR\x05error\"\x90\x01\n" +
+	"\x17BatchExpressionResponse\x12=\n" +
+	"\aresults\x18\x01 \x03(\v2#.orchestrator.BatchExpressionResultR\aresults\x12\x1a\n" +
+	"\baccepted\x18\x02 \x01(\x05R\baccepted\x12\x1a\n" +
+	"\brejected\x18\x03 \x01(\x05R\brejected\"T\n" +
+	"\x19ValidateExpressionRequest\x12\x17\n" +
+	"\auser_id\x18\x01 \x01(\tR\x06userId\x12\x1e\n" +
+	"\n" +
+	"expression\x18\x02 \x01(\tR\n" +
+	"expression\"m\n" +
+	"\x0fExpressionError\x12\x18\n" +
+	"\amessage\x18\x01 \x01(\tR\amessage\x12\x12\n" +
+	"\x04line\x18\x02 \x01(\x05R\x04line\x12\x16\n" +
+	"\x06column\x18\x03 \x01(\x05R\x06column\x12\x14\n" +
+	"\x05token\x18\x04 \x01(\tR\x05token\"\x9f\x02\n" +
+	"\x1aValidateExpressionResponse\x12\x14\n" +
+	"\x05valid\x18\x01 \x01(\bR\x05valid\x125\n" +
+	"\x06errors\x18\x02 \x03(\v2\x1d.orchestrator.ExpressionErrorR\x06errors\x12\x1e\n" +
+	"\n" +
+	"normalized\x18\x03 \x01(\tR\n" +
+	"normalized\x12\x1d\n" +
+	"\n" +
+	"node_count\x18\x04 \x01(\x05R\tnodeCount\x12'\n" +
+	"\x0foperation_count\x18\x05 \x01(\x05R\x0eoperationCount\x12\x14\n" +
+	"\x05depth\x18\x06 \x01(\x05R\x05depth\x126\n" +
+	"\bestimate\x18\a \x01(\v2\x1a.orchestrator.CostEstimateR\bestimate\"F\n" +
+	"\x12TaskDetailsRequest\x12\x17\n" +
+	"\auser_id\x18\x01 \x01(\tR\x06userId\x12\x17\n" +
+	"\atask_id\x18\x02 \x01(\tR\x06taskId\"\x93\x03\n" +
+	"\x13TaskDetailsResponse\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x1e\n" +
+	"\n" +
+	"expression\x18\x02 \x01(\tR\n" +
+	"expression\x12\x16\n" +
+	"\x06status\x18\x03 \x01(\tR\x06status\x12\x16\n" +
+	"\x06result\x18\x04 \x01(\x01R\x06result\x12#\n" +
+	"\rerror_message\x18\x05 \x01(\tR\ferrorMessage\x12\x1d\n" +
+	"\n" +
+	"created_at\x18\x06 \x01(\tR\tcreatedAt\x12\x1d\n" +
+	"\n" +
+	"updated_at\x18\a \x01(\tR\tupdatedAt\x12%\n" +
+	"\x0equeue_position\x18\b \x01(\x05R\rqueuePosition\x125\n" +
+	"\battempts\x18\t \x03(\v2\x19.orchestrator.TaskAttemptR\battempts\x12\x1d\n" +
+	"\n" +
+	"deleted_at\x18\n" +
+	" \x01(\tR\tdeletedAt\x12\x1d\n" +
+	"\n" +
+	"request_id\x18\v \x01(\tR\trequestId\x12\x1d\n" +
+	"\n" +
+	"error_code\x18\f \x01(\tR\terrorCode\"\xd9\x01\n" +
+	"\vTaskAttempt\x12\x16\n" +
+	"\x06number\x18\x01 \x01(\x05R\x06number\x12\x16\n" +
+	"\x06status\x18\x02 \x01(\tR\x06status\x12\x16\n" +
+	"\x06result\x18\x03 \x01(\x01R\x06result\x12#\n" +
+	"\rerror_message\x18\x04 \x01(\tR\ferrorMessage\x12\x1d\n" +
+	"\n" +
+	"started_at\x18\x05 \x01(\tR\tstartedAt\x12\x1f\n" +
+	"\vfinished_at\x18\x06 \x01(\tR\n" +
+	"finishedAt\x12\x1d\n" +
+	"\n" +
+	"created_at\x18\a \x01(\tR\tcreatedAt\"D\n" +
+	"\x10RetryTaskRequest\x12\x17\n" +
+	"\auser_id\x18\x01 \x01(\tR\x06userId\x12\x17\n" +
+	"\atask_id\x18\x02 \x01(\tR\x06taskId\"z\n" +
+	"\x11RetryTaskResponse\x12\x17\n" +
+	"\atask_id\x18\x01 \x01(\tR\x06taskId\x12%\n" +
+	"\x0eattempt_number\x18\x02 \x01(\x05R\rattemptNumber\x12%\n" +
+	"\x0equeue_position\x18\x03 \x01(\x05R\rqueuePosition\"\x96\x02\n" +
+	"\x10UserTasksRequest\x12\x17\n" +
+	"\auser_id\x18\x01 \x01(\tR\x06userId\x12\x1b\n" +
+	"\tpage_size\x18\x02 \x01(\x05R\bpageSize\x12\x1d\n" +
+	"\n" +
+	"page_token\x18\x03 \x01(\tR\tpageToken\x12\x1a\n" +
+	"\bstatuses\x18\x04 \x03(\tR\bstatuses\x12!\n" +
+	"\fcreated_from\x18\x05 \x01(\tR\vcreatedFrom\x12\x1d\n" +
+	"\n" +
+	"created_to\x18\x06 \x01(\tR\tcreatedTo\x12\x1d\n" +
+	"\n" +
+	"sort_order\x18\a \x01(\tR\tsortOrder\x12\x16\n" +
+	"\x06search\x18\b \x01(\tR\x06search\x12\x18\n" +
+	"\adeleted\x18\t \x01(\bR\adeleted\"j\n" +
+	"\x11UserTasksResponse\x12-\n" +
+	"\x05tasks\x18\x01 \x03(\v2\x17.orchestrator.TaskBriefR\x05tasks\x12&\n" +
+	"\x0fnext_page_token\x18\x02 \x01(\tR\rnextPageToken\"\xed\x01\n" +
 	"\tTaskBrief\x12\x0e\n" +
 	"\x02id\x18\x01 \x01(\tR\x02id\x12\x1e\n" +
 	"\n" +
@@ -463,11 +4282,252 @@ const file_proto_orchestrator_proto_rawDesc = "" +
 	"expression\x12\x16\n" +
 	"\x06status\x18\x03 \x01(\tR\x06status\x12\x1d\n" +
 	"\n" +
-	"created_at\x18\x04 \x01(\tR\tcreatedAt2\x95\x02\n" +
+	"created_at\x18\x04 \x01(\tR\tcreatedAt\x12\x16\n" +
+	"\x06result\x18\x05 \x01(\x01R\x06result\x12#\n" +
+	"\rerror_message\x18\x06 \x01(\tR\ferrorMessage\x12\x1d\n" +
+	"\n" +
+	"deleted_at\x18\a \x01(\tR\tdeletedAt\x12\x1d\n" +
+	"\n" +
+	"error_code\x18\b \x01(\tR\terrorCode\"[\n" +
+	"\x11DeleteTaskRequest\x12\x17\n" +
+	"\auser_id\x18\x01 \x01(\tR\x06userId\x12\x17\n" +
+	"\atask_id\x18\x02 \x01(\tR\x06taskId\x12\x14\n" +
+	"\x05purge\x18\x03 \x01(\bR\x05purge\"\x14\n" +
+	"\x12DeleteTaskResponse\"F\n" +
+	"\x12RestoreTaskRequest\x12\x17\n" +
+	"\auser_id\x18\x01 \x01(\tR\x06userId\x12\x17\n" +
+	"\atask_id\x18\x02 \x01(\tR\x06taskId\"\x15\n" +
+	"\x13RestoreTaskResponse\"D\n" +
+	"\x10TaskTraceRequest\x12\x17\n" +
+	"\auser_id\x18\x01 \x01(\tR\x06userId\x12\x17\n" +
+	"\atask_id\x18\x02 \x01(\tR\x06taskId\"\xbd\x01\n" +
+	"\x11TaskTraceResponse\x12\x17\n" +
+	"\atask_id\x18\x01 \x01(\tR\x06taskId\x12%\n" +
+	"\x0eattempt_number\x18\x02 \x01(\x05R\rattemptNumber\x12;\n" +
+	"\n" +
+	"operations\x18\x03 \x03(\v2\x1b.orchestrator.TaskOperationR\n" +
+	"operations\x12+\n" +
+	"\x04tree\x18\x04 \x01(\v2\x17.orchestrator.TraceNodeR\x04tree\"\xd7\x02\n" +
+	"\rTaskOperation\x12\x10\n" +
+	"\x03seq\x18\x01 \x01(\x05R\x03seq\x12!\n" +
+	"\foperation_id\x18\x02 \x01(\tR\voperationId\x12\x1b\n" +
+	"\tnode_path\x18\x03 \x01(\tR\bnodePath\x12\x16\n" +
+	"\x06symbol\x18\x04 \x01(\tR\x06symbol\x12\x1b\n" +
+	"\toperand_a\x18\x05 \x01(\x01R\boperandA\x12\x1b\n" +
+	"\toperand_b\x18\x06 \x01(\x01R\boperandB\x12\x16\n" +
+	"\x06result\x18\a \x01(\x01R\x06result\x12#\n" +
+	"\rerror_message\x18\b \x01(\tR\ferrorMessage\x12%\n" +
+	"\x0eworker_address\x18\t \x01(\tR\rworkerAddress\x12\x1d\n" +
+	"\n" +
+	"started_at\x18\n" +
+	" \x01(\tR\tstartedAt\x12\x1f\n" +
+	"\vduration_ms\x18\v \x01(\x01R\n" +
+	"durationMs\"\xda\x01\n" +
+	"\tTraceNode\x12\x1b\n" +
+	"\tnode_path\x18\x01 \x01(\tR\bnodePath\x12\x12\n" +
+	"\x04kind\x18\x02 \x01(\tR\x04kind\x12\x16\n" +
+	"\x06symbol\x18\x03 \x01(\tR\x06symbol\x12\x14\n" +
+	"\x05value\x18\x04 \x01(\x01R\x05value\x129\n" +
+	"\toperation\x18\x05 \x01(\v2\x1b.orchestrator.TaskOperationR\toperation\x123\n" +
+	"\bchildren\x18\x06 \x03(\v2\x17.orchestrator.TraceNodeR\bchildren\"B\n" +
+	"\x0eTaskASTRequest\x12\x17\n" +
+	"\auser_id\x18\x01 \x01(\tR\x06userId\x12\x17\n" +
+	"\atask_id\x18\x02 \x01(\tR\x06taskId\"\xa8\x01\n" +
+	"\x0fTaskASTResponse\x12\x17\n" +
+	"\atask_id\x18\x01 \x01(\tR\x06taskId\x12\x16\n" +
+	"\x06status\x18\x02 \x01(\tR\x06status\x12%\n" +
+	"\x0eattempt_number\x18\x03 \x01(\x05R\rattemptNumber\x12\x12\n" +
+	"\x04live\x18\x04 \x01(\bR\x04live\x12)\n" +
+	"\x04root\x18\x05 \x01(\v2\x15.orchestrator.AstNodeR\x04root\"\xc4\x02\n" +
+	"\aAstNode\x12\x1b\n" +
+	"\tnode_path\x18\x01 \x01(\tR\bnodePath\x12\x12\n" +
+	"\x04kind\x18\x02 \x01(\tR\x04kind\x12\x1a\n" +
+	"\boperator\x18\x03 \x01(\tR\boperator\x12\x1b\n" +
+	"\thas_value\x18\x04 \x01(\bR\bhasValue\x12\x14\n" +
+	"\x05value\x18\x05 \x01(\x01R\x05value\x12\x16\n" +
+	"\x06status\x18\x06 \x01(\tR\x06status\x12\x1f\n" +
+	"\vduration_ms\x18\a \x01(\x01R\n" +
+	"durationMs\x12(\n" +
+	"\x10critical_path_ms\x18\b \x01(\x01R\x0ecriticalPathMs\x12#\n" +
+	"\rerror_message\x18\t \x01(\tR\ferrorMessage\x121\n" +
+	"\bchildren\x18\n" +
+	" \x03(\v2\x15.orchestrator.AstNodeR\bchildren\"D\n" +
+	"\x10WatchTaskRequest\x12\x17\n" +
+	"\auser_id\x18\x01 \x01(\tR\x06userId\x12\x17\n" +
+	"\atask_id\x18\x02 \x01(\tR\x06taskId\"\xd8\x02\n" +
+	"\tTaskEvent\x12\x17\n" +
+	"\atask_id\x18\x01 \x01(\tR\x06taskId\x12\x16\n" +
+	"\x06status\x18\x02 \x01(\tR\x06status\x12%\n" +
+	"\x0eattempt_number\x18\x03 \x01(\x05R\rattemptNumber\x12%\n" +
+	"\x0equeue_position\x18\x04 \x01(\x05R\rqueuePosition\x12'\n" +
+	"\x0foperations_done\x18\x05 \x01(\x05R\x0eoperationsDone\x12)\n" +
+	"\x10operations_total\x18\x06 \x01(\x05R\x0foperationsTotal\x12\x16\n" +
+	"\x06result\x18\a \x01(\x01R\x06result\x12#\n" +
+	"\rerror_message\x18\b \x01(\tR\ferrorMessage\x12\x1c\n" +
+	"\ttimestamp\x18\t \x01(\tR\ttimestamp\x12\x1d\n" +
+	"\n" +
+	"error_code\x18\n" +
+	" \x01(\tR\terrorCode\"I\n" +
+	"\x1cCreateWebhookEndpointRequest\x12\x17\n" +
+	"\auser_id\x18\x01 \x01(\tR\x06userId\x12\x10\n" +
+	"\x03url\x18\x02 \x01(\tR\x03url\"R\n" +
+	"\x0fWebhookEndpoint\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x10\n" +
+	"\x03url\x18\x02 \x01(\tR\x03url\x12\x1d\n" +
+	"\n" +
+	"created_at\x18\x03 \x01(\tR\tcreatedAt\"6\n" +
+	"\x1bListWebhookEndpointsRequest\x12\x17\n" +
+	"\auser_id\x18\x01 \x01(\tR\x06userId\"[\n" +
+	"\x1cListWebhookEndpointsResponse\x12;\n" +
+	"\tendpoints\x18\x01 \x03(\v2\x1d.orchestrator.WebhookEndpointR\tendpoints\"X\n" +
+	"\x1cDeleteWebhookEndpointRequest\x12\x17\n" +
+	"\auser_id\x18\x01 \x01(\tR\x06userId\x12\x1f\n" +
+	"\vendpoint_id\x18\x02 \x01(\tR\n" +
+	"endpointId\"\x1f\n" +
+	"\x1dDeleteWebhookEndpointResponse\"G\n" +
+	"\x14WebhookSecretRequest\x12\x17\n" +
+	"\auser_id\x18\x01 \x01(\tR\x06userId\x12\x16\n" +
+	"\x06rotate\x18\x02 \x01(\bR\x06rotate\"/\n" +
+	"\x15WebhookSecretResponse\x12\x16\n" +
+	"\x06secret\x18\x01 \x01(\tR\x06secret\"f\n" +
+	"\x1cListWebhookDeliveriesRequest\x12\x17\n" +
+	"\auser_id\x18\x01 \x01(\tR\x06userId\x12\x17\n" +
+	"\atask_id\x18\x02 \x01(\tR\x06taskId\x12\x14\n" +
+	"\x05limit\x18\x03 \x01(\x05R\x05limit\"\xea\x02\n" +
+	"\x0fWebhookDelivery\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x17\n" +
+	"\atask_id\x18\x02 \x01(\tR\x06taskId\x12\x1f\n" +
+	"\vendpoint_id\x18\x03 \x01(\tR\n" +
+	"endpointId\x12\x10\n" +
+	"\x03url\x18\x04 \x01(\tR\x03url\x12\x14\n" +
+	"\x05event\x18\x05 \x01(\tR\x05event\x12\x16\n" +
+	"\x06status\x18\x06 \x01(\tR\x06status\x12\x1a\n" +
+	"\battempts\x18\a \x01(\x05R\battempts\x12(\n" +
+	"\x10last_status_code\x18\b \x01(\x05R\x0elastStatusCode\x12\x1d\n" +
+	"\n" +
+	"last_error\x18\t \x01(\tR\tlastError\x12&\n" +
+	"\x0fnext_attempt_at\x18\n" +
+	" \x01(\tR\rnextAttemptAt\x12!\n" +
+	"\fdelivered_at\x18\v \x01(\tR\vdeliveredAt\x12\x1d\n" +
+	"\n" +
+	"created_at\x18\f \x01(\tR\tcreatedAt\"^\n" +
+	"\x1dListWebhookDeliveriesResponse\x12=\n" +
+	"\n" +
+	"deliveries\x18\x01 \x03(\v2\x1d.orchestrator.WebhookDeliveryR\n" +
+	"deliveries\"S\n" +
+	"\x17RedeliverWebhookRequest\x12\x17\n" +
+	"\auser_id\x18\x01 \x01(\tR\x06userId\x12\x1f\n" +
+	"\vdelivery_id\x18\x02 \x01(\tR\n" +
+	"deliveryId\"\x1a\n" +
+	"\x18RedeliverWebhookResponse\"+\n" +
+	"\x10UserStatsRequest\x12\x17\n" +
+	"\auser_id\x18\x01 \x01(\tR\x06userId\";\n" +
+	"\vStatusCount\x12\x16\n" +
+	"\x06status\x18\x01 \x01(\tR\x06status\x12\x14\n" +
+	"\x05count\x18\x02 \x01(\x03R\x05count\"}\n" +
+	"\rOperatorUsage\x12\x16\n" +
+	"\x06symbol\x18\x01 \x01(\tR\x06symbol\x12\x14\n" +
+	"\x05count\x18\x02 \x01(\x03R\x05count\x12\x16\n" +
+	"\x06failed\x18\x03 \x01(\x03R\x06failed\x12&\n" +
+	"\x0favg_duration_ms\x18\x04 \x01(\x01R\ravgDurationMs\"\xe2\x02\n" +
+	"\x11UserStatsResponse\x12\x14\n" +
+	"\x05total\x18\x01 \x01(\x03R\x05total\x12>\n" +
+	"\rstatus_counts\x18\x02 \x03(\v2\x19.orchestrator.StatusCountR\fstatusCounts\x12!\n" +
+	"\fsuccess_rate\x18\x03 \x01(\x01R\vsuccessRate\x12-\n" +
+	"\x12completed_measured\x18\x04 \x01(\x03R\x11completedMeasured\x124\n" +
+	"\x16avg_completion_seconds\x18\x05 \x01(\x01R\x14avgCompletionSeconds\x124\n" +
+	"\x16p95_completion_seconds\x18\x06 \x01(\x01R\x14p95CompletionSeconds\x129\n" +
+	"\toperators\x18\a \x03(\v2\x1b.orchestrator.OperatorUsageR\toperators\"'\n" +
+	"\fUsageRequest\x12\x17\n" +
+	"\auser_id\x18\x01 \x01(\tR\x06userId\"V\n" +
+	"\fUsageCounter\x12\x12\n" +
+	"\x04used\x18\x01 \x01(\x03R\x04used\x12\x14\n" +
+	"\x05limit\x18\x02 \x01(\x03R\x05limit\x12\x1c\n" +
+	"\tremaining\x18\x03 \x01(\x03R\tremaining\"\xe7\x01\n" +
+	"\vUsagePeriod\x12\x14\n" +
+	"\x05start\x18\x01 \x01(\tR\x05start\x12\x19\n" +
+	"\breset_at\x18\x02 \x01(\tR\aresetAt\x120\n" +
+	"\x05tasks\x18\x03 \x01(\v2\x1a.orchestrator.UsageCounterR\x05tasks\x12:\n" +
+	"\n" +
+	"operations\x18\x04 \x01(\v2\x1a.orchestrator.UsageCounterR\n" +
+	"operations\x129\n" +
+	"\n" +
+	"compute_ms\x18\x05 \x01(\v2\x1a.orchestrator.UsageCounterR\tcomputeMs\"m\n" +
+	"\rUsageResponse\x12+\n" +
+	"\x03day\x18\x01 \x01(\v2\x19.orchestrator.UsagePeriodR\x03day\x12/\n" +
+	"\x05month\x18\x02 \x01(\v2\x19.orchestrator.UsagePeriodR\x05month\"\x1e\n" +
+	"\x1cListActiveEvaluationsRequest\"\xd8\x02\n" +
+	"\x10ActiveEvaluation\x12\x17\n" +
+	"\atask_id\x18\x01 \x01(\tR\x06taskId\x12\x17\n" +
+	"\auser_id\x18\x02 \x01(\tR\x06userId\x12\x1e\n" +
+	"\n" +
+	"expression\x18\x03 \x01(\tR\n" +
+	"expression\x12%\n" +
+	"\x0eattempt_number\x18\x04 \x01(\x05R\rattemptNumber\x12\x1d\n" +
+	"\n" +
+	"started_at\x18\x05 \x01(\tR\tstartedAt\x12'\n" +
+	"\x0felapsed_seconds\x18\x06 \x01(\x01R\x0eelapsedSeconds\x12/\n" +
+	"\x13inflight_operations\x18\a \x01(\x05R\x12inflightOperations\x12'\n" +
+	"\x0foperations_done\x18\b \x01(\x05R\x0eoperationsDone\x12)\n" +
+	"\x10operations_total\x18\t \x01(\x05R\x0foperationsTotal\"a\n" +
+	"\x1dListActiveEvaluationsResponse\x12@\n" +
+	"\vevaluations\x18\x01 \x03(\v2\x1e.orchestrator.ActiveEvaluationR\vevaluations\"\x14\n" +
+	"\x12QueueStatusRequest\"\x97\x01\n" +
+	"\fWorkerStatus\x12\x18\n" +
+	"\aaddress\x18\x01 \x01(\tR\aaddress\x12 \n" +
+	"\flast_seen_at\x18\x02 \x01(\tR\n" +
+	"lastSeenAt\x12\x1e\n" +
+	"\n" +
+	"operations\x18\x03 \x01(\x03R\n" +
+	"operations\x12+\n" +
+	"\x11failed_operations\x18\x04 \x01(\x03R\x10failedOperations\"\xb9\x03\n" +
+	"\x13QueueStatusResponse\x12/\n" +
+	"\x13evaluations_running\x18\x01 \x01(\x05R\x12evaluationsRunning\x12/\n" +
+	"\x13evaluations_waiting\x18\x02 \x01(\x05R\x12evaluationsWaiting\x12<\n" +
+	"\x1amax_concurrent_evaluations\x18\x03 \x01(\x05R\x18maxConcurrentEvaluations\x124\n" +
+	"\x16max_queued_evaluations\x18\x04 \x01(\x05R\x14maxQueuedEvaluations\x12/\n" +
+	"\x13operations_inflight\x18\x05 \x01(\x05R\x12operationsInflight\x12-\n" +
+	"\x12operations_waiting\x18\x06 \x01(\x05R\x11operationsWaiting\x126\n" +
+	"\x17max_inflight_operations\x18\a \x01(\x05R\x15maxInflightOperations\x124\n" +
+	"\aworkers\x18\b \x03(\v2\x1a.orchestrator.WorkerStatusR\aworkers\"\x19\n" +
+	"\x17SystemTaskCountsRequest\"\x8a\x01\n" +
+	"\x18SystemTaskCountsResponse\x12\x14\n" +
+	"\x05total\x18\x01 \x01(\x03R\x05total\x12>\n" +
+	"\rstatus_counts\x18\x02 \x03(\v2\x19.orchestrator.StatusCountR\fstatusCounts\x12\x18\n" +
+	"\atrashed\x18\x03 \x01(\x03R\atrashed\"G\n" +
+	"\x14ForceFailTaskRequest\x12\x17\n" +
+	"\atask_id\x18\x01 \x01(\tR\x06taskId\x12\x16\n" +
+	"\x06reason\x18\x02 \x01(\tR\x06reason\"~\n" +
+	"\x15ForceFailTaskResponse\x12\x17\n" +
+	"\atask_id\x18\x01 \x01(\tR\x06taskId\x12'\n" +
+	"\x0fprevious_status\x18\x02 \x01(\tR\x0epreviousStatus\x12#\n" +
+	"\rerror_message\x18\x03 \x01(\tR\ferrorMessage2\xcd\x10\n" +
 	"\x13OrchestratorService\x12U\n" +
-	"\x10SubmitExpression\x12\x1f.orchestrator.ExpressionRequest\x1a .orchestrator.ExpressionResponse\x12U\n" +
+	"\x10SubmitExpression\x12\x1f.orchestrator.ExpressionRequest\x1a .orchestrator.ExpressionResponse\x12`\n" +
+	"\x11SubmitExpressions\x12$.orchestrator.BatchExpressionRequest\x1a%.orchestrator.BatchExpressionResponse\x12g\n" +
+	"\x12ValidateExpression\x12'.orchestrator.ValidateExpressionRequest\x1a(.orchestrator.ValidateExpressionResponse\x12U\n" +
 	"\x0eGetTaskDetails\x12 .orchestrator.TaskDetailsRequest\x1a!.orchestrator.TaskDetailsResponse\x12P\n" +
-	"\rListUserTasks\x12\x1e.orchestrator.UserTasksRequest\x1a\x1f.orchestrator.UserTasksResponseBUZSgithub.com/Qu1nel/YaLyceum-GoProject-Final/proto/gen/orchestrator;orchestrator_grpcb\x06proto3"
+	"\rListUserTasks\x12\x1e.orchestrator.UserTasksRequest\x1a\x1f.orchestrator.UserTasksResponse\x12L\n" +
+	"\tRetryTask\x12\x1e.orchestrator.RetryTaskRequest\x1a\x1f.orchestrator.RetryTaskResponse\x12O\n" +
+	"\n" +
+	"DeleteTask\x12\x1f.orchestrator.DeleteTaskRequest\x1a .orchestrator.DeleteTaskResponse\x12R\n" +
+	"\vRestoreTask\x12 .orchestrator.RestoreTaskRequest\x1a!.orchestrator.RestoreTaskResponse\x12O\n" +
+	"\fGetTaskTrace\x12\x1e.orchestrator.TaskTraceRequest\x1a\x1f.orchestrator.TaskTraceResponse\x12I\n" +
+	"\n" +
+	"GetTaskAST\x12\x1c.orchestrator.TaskASTRequest\x1a\x1d.orchestrator.TaskASTResponse\x12F\n" +
+	"\tWatchTask\x12\x1e.orchestrator.WatchTaskRequest\x1a\x17.orchestrator.TaskEvent0\x01\x12b\n" +
+	"\x15CreateWebhookEndpoint\x12*.orchestrator.CreateWebhookEndpointRequest\x1a\x1d.orchestrator.WebhookEndpoint\x12m\n" +
+	"\x14ListWebhookEndpoints\x12).orchestrator.ListWebhookEndpointsRequest\x1a*.orchestrator.ListWebhookEndpointsResponse\x12p\n" +
+	"\x15DeleteWebhookEndpoint\x12*.orchestrator.DeleteWebhookEndpointRequest\x1a+.orchestrator.DeleteWebhookEndpointResponse\x12[\n" +
+	"\x10GetWebhookSecret\x12\".orchestrator.WebhookSecretRequest\x1a#.orchestrator.WebhookSecretResponse\x12p\n" +
+	"\x15ListWebhookDeliveries\x12*.orchestrator.ListWebhookDeliveriesRequest\x1a+.orchestrator.ListWebhookDeliveriesResponse\x12a\n" +
+	"\x10RedeliverWebhook\x12%.orchestrator.RedeliverWebhookRequest\x1a&.orchestrator.RedeliverWebhookResponse\x12O\n" +
+	"\fGetUserStats\x12\x1e.orchestrator.UserStatsRequest\x1a\x1f.orchestrator.UserStatsResponse\x12C\n" +
+	"\bGetUsage\x12\x1a.orchestrator.UsageRequest\x1a\x1b.orchestrator.UsageResponse\x12p\n" +
+	"\x15ListActiveEvaluations\x12*.orchestrator.ListActiveEvaluationsRequest\x1a+.orchestrator.ListActiveEvaluationsResponse\x12U\n" +
+	"\x0eGetQueueStatus\x12 .orchestrator.QueueStatusRequest\x1a!.orchestrator.QueueStatusResponse\x12d\n" +
+	"\x13GetSystemTaskCounts\x12%.orchestrator.SystemTaskCountsRequest\x1a&.orchestrator.SystemTaskCountsResponse\x12X\n" +
+	"\rForceFailTask\x12\".orchestrator.ForceFailTaskRequest\x1a#.orchestrator.ForceFailTaskResponseBUZSgithub.com/Qu1nel/YaLyceum-GoProject-Final/proto/gen/orchestrator;orchestrator_grpcb\x06proto3"
 
 var (
 	file_proto_orchestrator_proto_rawDescOnce sync.Once
@@ -481,29 +4541,150 @@ func file_proto_orchestrator_proto_rawDescGZIP() []byte {
 	return file_proto_orchestrator_proto_rawDescData
 }
 
-var file_proto_orchestrator_proto_msgTypes = make([]protoimpl.MessageInfo, 7)
+var file_proto_orchestrator_proto_msgTypes = make([]protoimpl.MessageInfo, 63)
 var file_proto_orchestrator_proto_goTypes = []any{
-	(*ExpressionRequest)(nil),   // 0: orchestrator.ExpressionRequest
-	(*ExpressionResponse)(nil),  // 1: orchestrator.ExpressionResponse
-	(*TaskDetailsRequest)(nil),  // 2: orchestrator.TaskDetailsRequest
-	(*TaskDetailsResponse)(nil), // 3: orchestrator.TaskDetailsResponse
-	(*UserTasksRequest)(nil),    // 4: orchestrator.UserTasksRequest
-	(*UserTasksResponse)(nil),   // 5: orchestrator.UserTasksResponse
-	(*TaskBrief)(nil),           // 6: orchestrator.TaskBrief
+	(*ExpressionRequest)(nil),             // 0: orchestrator.ExpressionRequest
+	(*ExpressionResponse)(nil),            // 1: orchestrator.ExpressionResponse
+	(*OperatorCount)(nil),                 // 2: orchestrator.OperatorCount
+	(*CostEstimate)(nil),                  // 3: orchestrator.CostEstimate
+	(*BatchExpressionItem)(nil),           // 4: orchestrator.BatchExpressionItem
+	(*BatchExpressionRequest)(nil),        // 5: orchestrator.BatchExpressionRequest
+	(*BatchExpressionResult)(nil),         // 6: orchestrator.BatchExpressionResult
+	(*BatchExpressionResponse)(nil),       // 7: orchestrator.BatchExpressionResponse
+	(*ValidateExpressionRequest)(nil),     // 8: orchestrator.ValidateExpressionRequest
+	(*ExpressionError)(nil),               // 9: orchestrator.ExpressionError
+	(*ValidateExpressionResponse)(nil),    // 10: orchestrator.ValidateExpressionResponse
+	(*TaskDetailsRequest)(nil),            // 11: orchestrator.TaskDetailsRequest
+	(*TaskDetailsResponse)(nil),           // 12: orchestrator.TaskDetailsResponse
+	(*TaskAttempt)(nil),                   // 13: orchestrator.TaskAttempt
+	(*RetryTaskRequest)(nil),              // 14: orchestrator.RetryTaskRequest
+	(*RetryTaskResponse)(nil),             // 15: orchestrator.RetryTaskResponse
+	(*UserTasksRequest)(nil),              // 16: orchestrator.UserTasksRequest
+	(*UserTasksResponse)(nil),             // 17: orchestrator.UserTasksResponse
+	(*TaskBrief)(nil),                     // 18: orchestrator.TaskBrief
+	(*DeleteTaskRequest)(nil),             // 19: orchestrator.DeleteTaskRequest
+	(*DeleteTaskResponse)(nil),            // 20: orchestrator.DeleteTaskResponse
+	(*RestoreTaskRequest)(nil),            // 21: orchestrator.RestoreTaskRequest
+	(*RestoreTaskResponse)(nil),           // 22: orchestrator.RestoreTaskResponse
+	(*TaskTraceRequest)(nil),              // 23: orchestrator.TaskTraceRequest
+	(*TaskTraceResponse)(nil),             // 24: orchestrator.TaskTraceResponse
+	(*TaskOperation)(nil),                 // 25: orchestrator.TaskOperation
+	(*TraceNode)(nil),                     // 26: orchestrator.TraceNode
+	(*TaskASTRequest)(nil),                // 27: orchestrator.TaskASTRequest
+	(*TaskASTResponse)(nil),               // 28: orchestrator.TaskASTResponse
+	(*AstNode)(nil),                       // 29: orchestrator.AstNode
+	(*WatchTaskRequest)(nil),              // 30: orchestrator.WatchTaskRequest
+	(*TaskEvent)(nil),                     // 31: orchestrator.TaskEvent
+	(*CreateWebhookEndpointRequest)(nil),  // 32: orchestrator.CreateWebhookEndpointRequest
+	(*WebhookEndpoint)(nil),               // 33: orchestrator.WebhookEndpoint
+	(*ListWebhookEndpointsRequest)(nil),   // 34: orchestrator.ListWebhookEndpointsRequest
+	(*ListWebhookEndpointsResponse)(nil),  // 35: orchestrator.ListWebhookEndpointsResponse
+	(*DeleteWebhookEndpointRequest)(nil),  // 36: orchestrator.DeleteWebhookEndpointRequest
+	(*DeleteWebhookEndpointResponse)(nil), // 37: orchestrator.DeleteWebhookEndpointResponse
+	(*WebhookSecretRequest)(nil),          // 38: orchestrator.WebhookSecretRequest
+	(*WebhookSecretResponse)(nil),         // 39: orchestrator.WebhookSecretResponse
+	(*ListWebhookDeliveriesRequest)(nil),  // 40: orchestrator.ListWebhookDeliveriesRequest
+	(*WebhookDelivery)(nil),               // 41: orchestrator.WebhookDelivery
+	(*ListWebhookDeliveriesResponse)(nil), // 42: orchestrator.ListWebhookDeliveriesResponse
+	(*RedeliverWebhookRequest)(nil),       // 43: orchestrator.RedeliverWebhookRequest
+	(*RedeliverWebhookResponse)(nil),      // 44: orchestrator.RedeliverWebhookResponse
+	(*UserStatsRequest)(nil),              // 45: orchestrator.UserStatsRequest
+	(*StatusCount)(nil),                   // 46: orchestrator.StatusCount
+	(*OperatorUsage)(nil),                 // 47: orchestrator.OperatorUsage
+	(*UserStatsResponse)(nil),             // 48: orchestrator.UserStatsResponse
+	(*UsageRequest)(nil),                  // 49: orchestrator.UsageRequest
+	(*UsageCounter)(nil),                  // 50: orchestrator.UsageCounter
+	(*UsagePeriod)(nil),                   // 51: orchestrator.UsagePeriod
+	(*UsageResponse)(nil),                 // 52: orchestrator.UsageResponse
+	(*ListActiveEvaluationsRequest)(nil),  // 53: orchestrator.ListActiveEvaluationsRequest
+	(*ActiveEvaluation)(nil),              // 54: orchestrator.ActiveEvaluation
+	(*ListActiveEvaluationsResponse)(nil), // 55: orchestrator.ListActiveEvaluationsResponse
+	(*QueueStatusRequest)(nil),            // 56: orchestrator.QueueStatusRequest
+	(*WorkerStatus)(nil),                  // 57: orchestrator.WorkerStatus
+	(*QueueStatusResponse)(nil),           // 58: orchestrator.QueueStatusResponse
+	(*SystemTaskCountsRequest)(nil),       // 59: orchestrator.SystemTaskCountsRequest
+	(*SystemTaskCountsResponse)(nil),      // 60: orchestrator.SystemTaskCountsResponse
+	(*ForceFailTaskRequest)(nil),          // 61: orchestrator.ForceFailTaskRequest
+	(*ForceFailTaskResponse)(nil),         // 62: orchestrator.ForceFailTaskResponse
 }
 var file_proto_orchestrator_proto_depIdxs = []int32{
-	6, // 0: orchestrator.UserTasksResponse.tasks:type_name -> orchestrator.TaskBrief
-	0, // 1: orchestrator.OrchestratorService.SubmitExpression:input_type -> orchestrator.ExpressionRequest
-	2, // 2: orchestrator.OrchestratorService.GetTaskDetails:input_type -> orchestrator.TaskDetailsRequest
-	4, // 3: orchestrator.OrchestratorService.ListUserTasks:input_type -> orchestrator.UserTasksRequest
-	1, // 4: orchestrator.OrchestratorService.SubmitExpression:output_type -> orchestrator.ExpressionResponse
-	3, // 5: orchestrator.OrchestratorService.GetTaskDetails:output_type -> orchestrator.TaskDetailsResponse
-	5, // 6: orchestrator.OrchestratorService.ListUserTasks:output_type -> orchestrator.UserTasksResponse
-	4, // [4:7] is the sub-list for method output_type
-	1, // [1:4] is the sub-list for method input_type
-	1, // [1:1] is the sub-list for extension type_name
-	1, // [1:1] is the sub-list for extension extendee
-	0, // [0:1] is the sub-list for field type_name
+	3,  // 0: orchestrator.ExpressionResponse.estimate:type_name -> orchestrator.CostEstimate
+	2,  // 1: orchestrator.CostEstimate.operations:type_name -> orchestrator.OperatorCount
+	4,  // 2: orchestrator.BatchExpressionRequest.items:type_name -> orchestrator.BatchExpressionItem
+	6,  // 3: orchestrator.BatchExpressionResponse.results:type_name -> orchestrator.BatchExpressionResult
+	9,  // 4: orchestrator.ValidateExpressionResponse.errors:type_name -> orchestrator.ExpressionError
+	3,  // 5: orchestrator.ValidateExpressionResponse.estimate:type_name -> orchestrator.CostEstimate
+	13, // 6: orchestrator.TaskDetailsResponse.attempts:type_name -> orchestrator.TaskAttempt
+	18, // 7: orchestrator.UserTasksResponse.tasks:type_name -> orchestrator.TaskBrief
+	25, // 8: orchestrator.TaskTraceResponse.operations:type_name -> orchestrator.TaskOperation
+	26, // 9: orchestrator.TaskTraceResponse.tree:type_name -> orchestrator.TraceNode
+	25, // 10: orchestrator.TraceNode.operation:type_name -> orchestrator.TaskOperation
+	26, // 11: orchestrator.TraceNode.children:type_name -> orchestrator.TraceNode
+	29, // 12: orchestrator.TaskASTResponse.root:type_name -> orchestrator.AstNode
+	29, // 13: orchestrator.AstNode.children:type_name -> orchestrator.AstNode
+	33, // 14: orchestrator.ListWebhookEndpointsResponse.endpoints:type_name -> orchestrator.WebhookEndpoint
+	41, // 15: orchestrator.ListWebhookDeliveriesResponse.deliveries:type_name -> orchestrator.WebhookDelivery
+	46, // 16: orchestrator.UserStatsResponse.status_counts:type_name -> orchestrator.StatusCount
+	47, // 17: orchestrator.UserStatsResponse.operators:type_name -> orchestrator.OperatorUsage
+	50, // 18: orchestrator.UsagePeriod.tasks:type_name -> orchestrator.UsageCounter
+	50, // 19: orchestrator.UsagePeriod.operations:type_name -> orchestrator.UsageCounter
+	50, // 20: orchestrator.UsagePeriod.compute_ms:type_name -> orchestrator.UsageCounter
+	51, // 21: orchestrator.UsageResponse.day:type_name -> orchestrator.UsagePeriod
+	51, // 22: orchestrator.UsageResponse.month:type_name -> orchestrator.UsagePeriod
+	54, // 23: orchestrator.ListActiveEvaluationsResponse.evaluations:type_name -> orchestrator.ActiveEvaluation
+	57, // 24: orchestrator.QueueStatusResponse.workers:type_name -> orchestrator.WorkerStatus
+	46, // 25: orchestrator.SystemTaskCountsResponse.status_counts:type_name -> orchestrator.StatusCount
+	0,  // 26: orchestrator.OrchestratorService.SubmitExpression:input_type -> orchestrator.ExpressionRequest
+	5,  // 27: orchestrator.OrchestratorService.SubmitExpressions:input_type -> orchestrator.BatchExpressionRequest
+	8,  // 28: orchestrator.OrchestratorService.ValidateExpression:input_type -> orchestrator.ValidateExpressionRequest
+	11, // 29: orchestrator.OrchestratorService.GetTaskDetails:input_type -> orchestrator.TaskDetailsRequest
+	16, // 30: orchestrator.OrchestratorService.ListUserTasks:input_type -> orchestrator.UserTasksRequest
+	14, // 31: orchestrator.OrchestratorService.RetryTask:input_type -> orchestrator.RetryTaskRequest
+	19, // 32: orchestrator.OrchestratorService.DeleteTask:input_type -> orchestrator.DeleteTaskRequest
+	21, // 33: orchestrator.OrchestratorService.RestoreTask:input_type -> orchestrator.RestoreTaskRequest
+	23, // 34: orchestrator.OrchestratorService.GetTaskTrace:input_type -> orchestrator.TaskTraceRequest
+	27, // 35: orchestrator.OrchestratorService.GetTaskAST:input_type -> orchestrator.TaskASTRequest
+	30, // 36: orchestrator.OrchestratorService.WatchTask:input_type -> orchestrator.WatchTaskRequest
+	32, // 37: orchestrator.OrchestratorService.CreateWebhookEndpoint:input_type -> orchestrator.CreateWebhookEndpointRequest
+	34, // 38: orchestrator.OrchestratorService.ListWebhookEndpoints:input_type -> orchestrator.ListWebhookEndpointsRequest
+	36, // 39: orchestrator.OrchestratorService.DeleteWebhookEndpoint:input_type -> orchestrator.DeleteWebhookEndpointRequest
+	38, // 40: orchestrator.OrchestratorService.GetWebhookSecret:input_type -> orchestrator.WebhookSecretRequest
+	40, // 41: orchestrator.OrchestratorService.ListWebhookDeliveries:input_type -> orchestrator.ListWebhookDeliveriesRequest
+	43, // 42: orchestrator.OrchestratorService.RedeliverWebhook:input_type -> orchestrator.RedeliverWebhookRequest
+	45, // 43: orchestrator.OrchestratorService.GetUserStats:input_type -> orchestrator.UserStatsRequest
+	49, // 44: orchestrator.OrchestratorService.GetUsage:input_type -> orchestrator.UsageRequest
+	53, // 45: orchestrator.OrchestratorService.ListActiveEvaluations:input_type -> orchestrator.ListActiveEvaluationsRequest
+	56, // 46: orchestrator.OrchestratorService.GetQueueStatus:input_type -> orchestrator.QueueStatusRequest
+	59, // 47: orchestrator.OrchestratorService.GetSystemTaskCounts:input_type -> orchestrator.SystemTaskCountsRequest
+	61, // 48: orchestrator.OrchestratorService.ForceFailTask:input_type -> orchestrator.ForceFailTaskRequest
+	1,  // 49: orchestrator.OrchestratorService.SubmitExpression:output_type -> orchestrator.ExpressionResponse
+	7,  // 50: orchestrator.OrchestratorService.SubmitExpressions:output_type -> orchestrator.BatchExpressionResponse
+	10, // 51: orchestrator.OrchestratorService.ValidateExpression:output_type -> orchestrator.ValidateExpressionResponse
+	12, // 52: orchestrator.OrchestratorService.GetTaskDetails:output_type -> orchestrator.TaskDetailsResponse
+	17, // 53: orchestrator.OrchestratorService.ListUserTasks:output_type -> orchestrator.UserTasksResponse
+	15, // 54: orchestrator.OrchestratorService.RetryTask:output_type -> orchestrator.RetryTaskResponse
+	20, // 55: orchestrator.OrchestratorService.DeleteTask:output_type -> orchestrator.DeleteTaskResponse
+	22, // 56: orchestrator.OrchestratorService.RestoreTask:output_type -> orchestrator.RestoreTaskResponse
+	24, // 57: orchestrator.OrchestratorService.GetTaskTrace:output_type -> orchestrator.TaskTraceResponse
+	28, // 58: orchestrator.OrchestratorService.GetTaskAST:output_type -> orchestrator.TaskASTResponse
+	31, // 59: orchestrator.OrchestratorService.WatchTask:output_type -> orchestrator.TaskEvent
+	33, // 60: orchestrator.OrchestratorService.CreateWebhookEndpoint:output_type -> orchestrator.WebhookEndpoint
+	35, // 61: orchestrator.OrchestratorService.ListWebhookEndpoints:output_type -> orchestrator.ListWebhookEndpointsResponse
+	37, // 62: orchestrator.OrchestratorService.DeleteWebhookEndpoint:output_type -> orchestrator.DeleteWebhookEndpointResponse
+	39, // 63: orchestrator.OrchestratorService.GetWebhookSecret:output_type -> orchestrator.WebhookSecretResponse
+	42, // 64: orchestrator.OrchestratorService.ListWebhookDeliveries:output_type -> orchestrator.ListWebhookDeliveriesResponse
+	44, // 65: orchestrator.OrchestratorService.RedeliverWebhook:output_type -> orchestrator.RedeliverWebhookResponse
+	48, // 66: orchestrator.OrchestratorService.GetUserStats:output_type -> orchestrator.UserStatsResponse
+	52, // 67: orchestrator.OrchestratorService.GetUsage:output_type -> orchestrator.UsageResponse
+	55, // 68: orchestrator.OrchestratorService.ListActiveEvaluations:output_type -> orchestrator.ListActiveEvaluationsResponse
+	58, // 69: orchestrator.OrchestratorService.GetQueueStatus:output_type -> orchestrator.QueueStatusResponse
+	60, // 70: orchestrator.OrchestratorService.GetSystemTaskCounts:output_type -> orchestrator.SystemTaskCountsResponse
+	62, // 71: orchestrator.OrchestratorService.ForceFailTask:output_type -> orchestrator.ForceFailTaskResponse
+	49, // [49:72] is the sub-list for method output_type
+	26, // [26:49] is the sub-list for method input_type
+	26, // [26:26] is the sub-list for extension type_name
+	26, // [26:26] is the sub-list for extension extendee
+	0,  // [0:26] is the sub-list for field type_name
 }
 
 func init() { file_proto_orchestrator_proto_init() }
@@ -517,7 +4698,7 @@ func file_proto_orchestrator_proto_init() {
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: unsafe.Slice(unsafe.StringData(file_proto_orchestrator_proto_rawDesc), len(file_proto_orchestrator_proto_rawDesc)),
 			NumEnums:      0,
-			NumMessages:   7,
+			NumMessages:   63,
 			NumExtensions: 0,
 			NumServices:   1,
 		},
@@ -20,9 +20,29 @@ import (
 const _ = grpc.SupportPackageIsVersion9
 
 const (
-	OrchestratorService_SubmitExpression_FullMethodName = "/orchestrator.OrchestratorService/SubmitExpression"
-	OrchestratorService_GetTaskDetails_FullMethodName   = "/orchestrator.OrchestratorService/GetTaskDetails"
-	OrchestratorService_ListUserTasks_FullMethodName    = "/orchestrator.OrchestratorService/ListUserTasks"
+	OrchestratorService_SubmitExpression_FullMethodName      = "/orchestrator.OrchestratorService/SubmitExpression"
+	OrchestratorService_SubmitExpressions_FullMethodName     = "/orchestrator.OrchestratorService/SubmitExpressions"
+	OrchestratorService_ValidateExpression_FullMethodName    = "/orchestrator.OrchestratorService/ValidateExpression"
+	OrchestratorService_GetTaskDetails_FullMethodName        = "/orchestrator.OrchestratorService/GetTaskDetails"
+	OrchestratorService_ListUserTasks_FullMethodName         = "/orchestrator.OrchestratorService/ListUserTasks"
+	OrchestratorService_RetryTask_FullMethodName             = "/orchestrator.OrchestratorService/RetryTask"
+	OrchestratorService_DeleteTask_FullMethodName            = "/orchestrator.OrchestratorService/DeleteTask"
+	OrchestratorService_RestoreTask_FullMethodName           = "/orchestrator.OrchestratorService/RestoreTask"
+	OrchestratorService_GetTaskTrace_FullMethodName          = "/orchestrator.OrchestratorService/GetTaskTrace"
+	OrchestratorService_GetTaskAST_FullMethodName            = "/orchestrator.OrchestratorService/GetTaskAST"
+	OrchestratorService_WatchTask_FullMethodName             = "/orchestrator.OrchestratorService/WatchTask"
+	OrchestratorService_CreateWebhookEndpoint_FullMethodName = "/orchestrator.OrchestratorService/CreateWebhookEndpoint"
+	OrchestratorService_ListWebhookEndpoints_FullMethodName  = "/orchestrator.OrchestratorService/ListWebhookEndpoints"
+	OrchestratorService_DeleteWebhookEndpoint_FullMethodName = "/orchestrator.OrchestratorService/DeleteWebhookEndpoint"
+	OrchestratorService_GetWebhookSecret_FullMethodName      = "/orchestrator.OrchestratorService/GetWebhookSecret"
+	OrchestratorService_ListWebhookDeliveries_FullMethodName = "/orchestrator.OrchestratorService/ListWebhookDeliveries"
+	OrchestratorService_RedeliverWebhook_FullMethodName      = "/orchestrator.OrchestratorService/RedeliverWebhook"
+	OrchestratorService_GetUserStats_FullMethodName          = "/orchestrator.OrchestratorService/GetUserStats"
+	OrchestratorService_GetUsage_FullMethodName              = "/orchestrator.OrchestratorService/GetUsage"
+	OrchestratorService_ListActiveEvaluations_FullMethodName = "/orchestrator.OrchestratorService/ListActiveEvaluations"
+	OrchestratorService_GetQueueStatus_FullMethodName        = "/orchestrator.OrchestratorService/GetQueueStatus"
+	OrchestratorService_GetSystemTaskCounts_FullMethodName   = "/orchestrator.OrchestratorService/GetSystemTaskCounts"
+	OrchestratorService_ForceFailTask_FullMethodName         = "/orchestrator.OrchestratorService/ForceFailTask"
 )
 
 // OrchestratorServiceClient is the client API for OrchestratorService service.
@@ -33,10 +53,45 @@ const (
 type OrchestratorServiceClient interface {
 	// Отправка выражения на вычисление (вызывается Агентом)
 	SubmitExpression(ctx context.Context, in *ExpressionRequest, opts ...grpc.CallOption) (*ExpressionResponse, error)
+	// Отправка нескольких выражений одним вызовом, каждое проверяется отдельно (вызывается Агентом)
+	SubmitExpressions(ctx context.Context, in *BatchExpressionRequest, opts ...grpc.CallOption) (*BatchExpressionResponse, error)
+	// Проверка выражения без создания задачи (вызывается Агентом)
+	ValidateExpression(ctx context.Context, in *ValidateExpressionRequest, opts ...grpc.CallOption) (*ValidateExpressionResponse, error)
 	// Получение статуса и результата задачи (вызывается Агентом) (TBD)
 	GetTaskDetails(ctx context.Context, in *TaskDetailsRequest, opts ...grpc.CallOption) (*TaskDetailsResponse, error)
 	// Получение списка задач пользователя (вызывается Агентом) (TBD)
 	ListUserTasks(ctx context.Context, in *UserTasksRequest, opts ...grpc.CallOption) (*UserTasksResponse, error)
+	// Повторное вычисление завершенной или упавшей задачи (вызывается Агентом)
+	RetryTask(ctx context.Context, in *RetryTaskRequest, opts ...grpc.CallOption) (*RetryTaskResponse, error)
+	// Перемещение задачи в корзину или окончательное удаление (вызывается Агентом)
+	DeleteTask(ctx context.Context, in *DeleteTaskRequest, opts ...grpc.CallOption) (*DeleteTaskResponse, error)
+	// Восстановление задачи из корзины (вызывается Агентом)
+	RestoreTask(ctx context.Context, in *RestoreTaskRequest, opts ...grpc.CallOption) (*RestoreTaskResponse, error)
+	// Трассировка вызовов Воркера последней попытки вычисления (вызывается Агентом)
+	GetTaskTrace(ctx context.Context, in *TaskTraceRequest, opts ...grpc.CallOption) (*TaskTraceResponse, error)
+	// Дерево выражения с состоянием и временем вычисления каждого узла (вызывается Агентом)
+	GetTaskAST(ctx context.Context, in *TaskASTRequest, opts ...grpc.CallOption) (*TaskASTResponse, error)
+	// Поток изменений статуса и прогресса задачи до терминального статуса (вызывается Агентом)
+	WatchTask(ctx context.Context, in *WatchTaskRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[TaskEvent], error)
+	// Регистрация, список и удаление webhook пользователя (вызывается Агентом)
+	CreateWebhookEndpoint(ctx context.Context, in *CreateWebhookEndpointRequest, opts ...grpc.CallOption) (*WebhookEndpoint, error)
+	ListWebhookEndpoints(ctx context.Context, in *ListWebhookEndpointsRequest, opts ...grpc.CallOption) (*ListWebhookEndpointsResponse, error)
+	DeleteWebhookEndpoint(ctx context.Context, in *DeleteWebhookEndpointRequest, opts ...grpc.CallOption) (*DeleteWebhookEndpointResponse, error)
+	// Секрет для проверки подписи webhook, при rotate = true выдается новый (вызывается Агентом)
+	GetWebhookSecret(ctx context.Context, in *WebhookSecretRequest, opts ...grpc.CallOption) (*WebhookSecretResponse, error)
+	// Журнал доставок webhook и ручная повторная доставка (вызывается Агентом)
+	ListWebhookDeliveries(ctx context.Context, in *ListWebhookDeliveriesRequest, opts ...grpc.CallOption) (*ListWebhookDeliveriesResponse, error)
+	RedeliverWebhook(ctx context.Context, in *RedeliverWebhookRequest, opts ...grpc.CallOption) (*RedeliverWebhookResponse, error)
+	// Сводная статистика задач пользователя (вызывается Агентом)
+	GetUserStats(ctx context.Context, in *UserStatsRequest, opts ...grpc.CallOption) (*UserStatsResponse, error)
+	// Потребление пользователя за текущие сутки и месяц и остаток квот (вызывается Агентом)
+	GetUsage(ctx context.Context, in *UsageRequest, opts ...grpc.CallOption) (*UsageResponse, error)
+	// Административные представления: вычисляемые задачи, очередь и Воркеры, число задач по статусам (вызывается Агентом)
+	ListActiveEvaluations(ctx context.Context, in *ListActiveEvaluationsRequest, opts ...grpc.CallOption) (*ListActiveEvaluationsResponse, error)
+	GetQueueStatus(ctx context.Context, in *QueueStatusRequest, opts ...grpc.CallOption) (*QueueStatusResponse, error)
+	GetSystemTaskCounts(ctx context.Context, in *SystemTaskCountsRequest, opts ...grpc.CallOption) (*SystemTaskCountsResponse, error)
+	// Принудительное завершение незавершенной задачи со статусом failed (вызывается Агентом)
+	ForceFailTask(ctx context.Context, in *ForceFailTaskRequest, opts ...grpc.CallOption) (*ForceFailTaskResponse, error)
 }
 
 type orchestratorServiceClient struct {
@@ -57,6 +112,26 @@ func (c *orchestratorServiceClient) SubmitExpression(ctx context.Context, in *Ex
 	return out, nil
 }
 
+func (c *orchestratorServiceClient) SubmitExpressions(ctx context.Context, in *BatchExpressionRequest, opts ...grpc.CallOption) (*BatchExpressionResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(BatchExpressionResponse)
+	err := c.cc.Invoke(ctx, OrchestratorService_SubmitExpressions_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *orchestratorServiceClient) ValidateExpression(ctx context.Context, in *ValidateExpressionRequest, opts ...grpc.CallOption) (*ValidateExpressionResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ValidateExpressionResponse)
+	err := c.cc.Invoke(ctx, OrchestratorService_ValidateExpression_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *orchestratorServiceClient) GetTaskDetails(ctx context.Context, in *TaskDetailsRequest, opts ...grpc.CallOption) (*TaskDetailsResponse, error) {
 	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
 	out := new(TaskDetailsResponse)
@@ -77,6 +152,195 @@ func (c *orchestratorServiceClient) ListUserTasks(ctx context.Context, in *UserT
 	return out, nil
 }
 
+func (c *orchestratorServiceClient) RetryTask(ctx context.Context, in *RetryTaskRequest, opts ...grpc.CallOption) (*RetryTaskResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(RetryTaskResponse)
+	err := c.cc.Invoke(ctx, OrchestratorService_RetryTask_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *orchestratorServiceClient) DeleteTask(ctx context.Context, in *DeleteTaskRequest, opts ...grpc.CallOption) (*DeleteTaskResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(DeleteTaskResponse)
+	err := c.cc.Invoke(ctx, OrchestratorService_DeleteTask_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *orchestratorServiceClient) RestoreTask(ctx context.Context, in *RestoreTaskRequest, opts ...grpc.CallOption) (*RestoreTaskResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(RestoreTaskResponse)
+	err := c.cc.Invoke(ctx, OrchestratorService_RestoreTask_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *orchestratorServiceClient) GetTaskTrace(ctx context.Context, in *TaskTraceRequest, opts ...grpc.CallOption) (*TaskTraceResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(TaskTraceResponse)
+	err := c.cc.Invoke(ctx, OrchestratorService_GetTaskTrace_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *orchestratorServiceClient) GetTaskAST(ctx context.Context, in *TaskASTRequest, opts ...grpc.CallOption) (*TaskASTResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(TaskASTResponse)
+	err := c.cc.Invoke(ctx, OrchestratorService_GetTaskAST_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *orchestratorServiceClient) WatchTask(ctx context.Context, in *WatchTaskRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[TaskEvent], error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &OrchestratorService_ServiceDesc.Streams[0], OrchestratorService_WatchTask_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &grpc.GenericClientStream[WatchTaskRequest, TaskEvent]{ClientStream: stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type OrchestratorService_WatchTaskClient = grpc.ServerStreamingClient[TaskEvent]
+
+func (c *orchestratorServiceClient) CreateWebhookEndpoint(ctx context.Context, in *CreateWebhookEndpointRequest, opts ...grpc.CallOption) (*WebhookEndpoint, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(WebhookEndpoint)
+	err := c.cc.Invoke(ctx, OrchestratorService_CreateWebhookEndpoint_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *orchestratorServiceClient) ListWebhookEndpoints(ctx context.Context, in *ListWebhookEndpointsRequest, opts ...grpc.CallOption) (*ListWebhookEndpointsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListWebhookEndpointsResponse)
+	err := c.cc.Invoke(ctx, OrchestratorService_ListWebhookEndpoints_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *orchestratorServiceClient) DeleteWebhookEndpoint(ctx context.Context, in *DeleteWebhookEndpointRequest, opts ...grpc.CallOption) (*DeleteWebhookEndpointResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(DeleteWebhookEndpointResponse)
+	err := c.cc.Invoke(ctx, OrchestratorService_DeleteWebhookEndpoint_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *orchestratorServiceClient) GetWebhookSecret(ctx context.Context, in *WebhookSecretRequest, opts ...grpc.CallOption) (*WebhookSecretResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(WebhookSecretResponse)
+	err := c.cc.Invoke(ctx, OrchestratorService_GetWebhookSecret_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *orchestratorServiceClient) ListWebhookDeliveries(ctx context.Context, in *ListWebhookDeliveriesRequest, opts ...grpc.CallOption) (*ListWebhookDeliveriesResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListWebhookDeliveriesResponse)
+	err := c.cc.Invoke(ctx, OrchestratorService_ListWebhookDeliveries_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *orchestratorServiceClient) RedeliverWebhook(ctx context.Context, in *RedeliverWebhookRequest, opts ...grpc.CallOption) (*RedeliverWebhookResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(RedeliverWebhookResponse)
+	err := c.cc.Invoke(ctx, OrchestratorService_RedeliverWebhook_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *orchestratorServiceClient) GetUserStats(ctx context.Context, in *UserStatsRequest, opts ...grpc.CallOption) (*UserStatsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(UserStatsResponse)
+	err := c.cc.Invoke(ctx, OrchestratorService_GetUserStats_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *orchestratorServiceClient) GetUsage(ctx context.Context, in *UsageRequest, opts ...grpc.CallOption) (*UsageResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(UsageResponse)
+	err := c.cc.Invoke(ctx, OrchestratorService_GetUsage_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *orchestratorServiceClient) ListActiveEvaluations(ctx context.Context, in *ListActiveEvaluationsRequest, opts ...grpc.CallOption) (*ListActiveEvaluationsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListActiveEvaluationsResponse)
+	err := c.cc.Invoke(ctx, OrchestratorService_ListActiveEvaluations_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *orchestratorServiceClient) GetQueueStatus(ctx context.Context, in *QueueStatusRequest, opts ...grpc.CallOption) (*QueueStatusResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(QueueStatusResponse)
+	err := c.cc.Invoke(ctx, OrchestratorService_GetQueueStatus_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *orchestratorServiceClient) GetSystemTaskCounts(ctx context.Context, in *SystemTaskCountsRequest, opts ...grpc.CallOption) (*SystemTaskCountsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(SystemTaskCountsResponse)
+	err := c.cc.Invoke(ctx, OrchestratorService_GetSystemTaskCounts_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *orchestratorServiceClient) ForceFailTask(ctx context.Context, in *ForceFailTaskRequest, opts ...grpc.CallOption) (*ForceFailTaskResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ForceFailTaskResponse)
+	err := c.cc.Invoke(ctx, OrchestratorService_ForceFailTask_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // OrchestratorServiceServer is the server API for OrchestratorService service.
 // All implementations must embed UnimplementedOrchestratorServiceServer
 // for forward compatibility.
@@ -85,10 +349,45 @@ func (c *orchestratorServiceClient) ListUserTasks(ctx context.Context, in *UserT
 type OrchestratorServiceServer interface {
 	// Отправка выражения на вычисление (вызывается Агентом)
 	SubmitExpression(context.Context, *ExpressionRequest) (*ExpressionResponse, error)
+	// Отправка нескольких выражений одним вызовом, каждое проверяется отдельно (вызывается Агентом)
+	SubmitExpressions(context.Context, *BatchExpressionRequest) (*BatchExpressionResponse, error)
+	// Проверка выражения без создания задачи (вызывается Агентом)
+	ValidateExpression(context.Context, *ValidateExpressionRequest) (*ValidateExpressionResponse, error)
 	// Получение статуса и результата задачи (вызывается Агентом) (TBD)
 	GetTaskDetails(context.Context, *TaskDetailsRequest) (*TaskDetailsResponse, error)
 	// Получение списка задач пользователя (вызывается Агентом) (TBD)
 	ListUserTasks(context.Context, *UserTasksRequest) (*UserTasksResponse, error)
+	// Повторное вычисление завершенной или упавшей задачи (вызывается Агентом)
+	RetryTask(context.Context, *RetryTaskRequest) (*RetryTaskResponse, error)
+	// Перемещение задачи в корзину или окончательное удаление (вызывается Агентом)
+	DeleteTask(context.Context, *DeleteTaskRequest) (*DeleteTaskResponse, error)
+	// Восстановление задачи из корзины (вызывается Агентом)
+	RestoreTask(context.Context, *RestoreTaskRequest) (*RestoreTaskResponse, error)
+	// Трассировка вызовов Воркера последней попытки вычисления (вызывается Агентом)
+	GetTaskTrace(context.Context, *TaskTraceRequest) (*TaskTraceResponse, error)
+	// Дерево выражения с состоянием и временем вычисления каждого узла (вызывается Агентом)
+	GetTaskAST(context.Context, *TaskASTRequest) (*TaskASTResponse, error)
+	// Поток изменений статуса и прогресса задачи до терминального статуса (вызывается Агентом)
+	WatchTask(*WatchTaskRequest, grpc.ServerStreamingServer[TaskEvent]) error
+	// Регистрация, список и удаление webhook пользователя (вызывается Агентом)
+	CreateWebhookEndpoint(context.Context, *CreateWebhookEndpointRequest) (*WebhookEndpoint, error)
+	ListWebhookEndpoints(context.Context, *ListWebhookEndpointsRequest) (*ListWebhookEndpointsResponse, error)
+	DeleteWebhookEndpoint(context.Context, *DeleteWebhookEndpointRequest) (*DeleteWebhookEndpointResponse, error)
+	// Секрет для проверки подписи webhook, при rotate = true выдается новый (вызывается Агентом)
+	GetWebhookSecret(context.Context, *WebhookSecretRequest) (*WebhookSecretResponse, error)
+	// Журнал доставок webhook и ручная повторная доставка (вызывается Агентом)
+	ListWebhookDeliveries(context.Context, *ListWebhookDeliveriesRequest) (*ListWebhookDeliveriesResponse, error)
+	RedeliverWebhook(context.Context, *RedeliverWebhookRequest) (*RedeliverWebhookResponse, error)
+	// Сводная статистика задач пользователя (вызывается Агентом)
+	GetUserStats(context.Context, *UserStatsRequest) (*UserStatsResponse, error)
+	// Потребление пользователя за текущие сутки и месяц и остаток квот (вызывается Агентом)
+	GetUsage(context.Context, *UsageRequest) (*UsageResponse, error)
+	// Административные представления: вычисляемые задачи, очередь и Воркеры, число задач по статусам (вызывается Агентом)
+	ListActiveEvaluations(context.Context, *ListActiveEvaluationsRequest) (*ListActiveEvaluationsResponse, error)
+	GetQueueStatus(context.Context, *QueueStatusRequest) (*QueueStatusResponse, error)
+	GetSystemTaskCounts(context.Context, *SystemTaskCountsRequest) (*SystemTaskCountsResponse, error)
+	// Принудительное завершение незавершенной задачи со статусом failed (вызывается Агентом)
+	ForceFailTask(context.Context, *ForceFailTaskRequest) (*ForceFailTaskResponse, error)
 	mustEmbedUnimplementedOrchestratorServiceServer()
 }
 
@@ -102,12 +401,72 @@ type UnimplementedOrchestratorServiceServer struct{}
 func (UnimplementedOrchestratorServiceServer) SubmitExpression(context.Context, *ExpressionRequest) (*ExpressionResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method SubmitExpression not implemented")
 }
+func (UnimplementedOrchestratorServiceServer) SubmitExpressions(context.Context, *BatchExpressionRequest) (*BatchExpressionResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SubmitExpressions not implemented")
+}
+func (UnimplementedOrchestratorServiceServer) ValidateExpression(context.Context, *ValidateExpressionRequest) (*ValidateExpressionResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ValidateExpression not implemented")
+}
 func (UnimplementedOrchestratorServiceServer) GetTaskDetails(context.Context, *TaskDetailsRequest) (*TaskDetailsResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method GetTaskDetails not implemented")
 }
 func (UnimplementedOrchestratorServiceServer) ListUserTasks(context.Context, *UserTasksRequest) (*UserTasksResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method ListUserTasks not implemented")
 }
+func (UnimplementedOrchestratorServiceServer) RetryTask(context.Context, *RetryTaskRequest) (*RetryTaskResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RetryTask not implemented")
+}
+func (UnimplementedOrchestratorServiceServer) DeleteTask(context.Context, *DeleteTaskRequest) (*DeleteTaskResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DeleteTask not implemented")
+}
+func (UnimplementedOrchestratorServiceServer) RestoreTask(context.Context, *RestoreTaskRequest) (*RestoreTaskResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RestoreTask not implemented")
+}
+func (UnimplementedOrchestratorServiceServer) GetTaskTrace(context.Context, *TaskTraceRequest) (*TaskTraceResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetTaskTrace not implemented")
+}
+func (UnimplementedOrchestratorServiceServer) GetTaskAST(context.Context, *TaskASTRequest) (*TaskASTResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetTaskAST not implemented")
+}
+func (UnimplementedOrchestratorServiceServer) WatchTask(*WatchTaskRequest, grpc.ServerStreamingServer[TaskEvent]) error {
+	return status.Errorf(codes.Unimplemented, "method WatchTask not implemented")
+}
+func (UnimplementedOrchestratorServiceServer) CreateWebhookEndpoint(context.Context, *CreateWebhookEndpointRequest) (*WebhookEndpoint, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CreateWebhookEndpoint not implemented")
+}
+func (UnimplementedOrchestratorServiceServer) ListWebhookEndpoints(context.Context, *ListWebhookEndpointsRequest) (*ListWebhookEndpointsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListWebhookEndpoints not implemented")
+}
+func (UnimplementedOrchestratorServiceServer) DeleteWebhookEndpoint(context.Context, *DeleteWebhookEndpointRequest) (*DeleteWebhookEndpointResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DeleteWebhookEndpoint not implemented")
+}
+func (UnimplementedOrchestratorServiceServer) GetWebhookSecret(context.Context, *WebhookSecretRequest) (*WebhookSecretResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetWebhookSecret not implemented")
+}
+func (UnimplementedOrchestratorServiceServer) ListWebhookDeliveries(context.Context, *ListWebhookDeliveriesRequest) (*ListWebhookDeliveriesResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListWebhookDeliveries not implemented")
+}
+func (UnimplementedOrchestratorServiceServer) RedeliverWebhook(context.Context, *RedeliverWebhookRequest) (*RedeliverWebhookResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RedeliverWebhook not implemented")
+}
+func (UnimplementedOrchestratorServiceServer) GetUserStats(context.Context, *UserStatsRequest) (*UserStatsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetUserStats not implemented")
+}
+func (UnimplementedOrchestratorServiceServer) GetUsage(context.Context, *UsageRequest) (*UsageResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetUsage not implemented")
+}
+func (UnimplementedOrchestratorServiceServer) ListActiveEvaluations(context.Context, *ListActiveEvaluationsRequest) (*ListActiveEvaluationsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListActiveEvaluations not implemented")
+}
+func (UnimplementedOrchestratorServiceServer) GetQueueStatus(context.Context, *QueueStatusRequest) (*QueueStatusResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetQueueStatus not implemented")
+}
+func (UnimplementedOrchestratorServiceServer) GetSystemTaskCounts(context.Context, *SystemTaskCountsRequest) (*SystemTaskCountsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetSystemTaskCounts not implemented")
+}
+func (UnimplementedOrchestratorServiceServer) ForceFailTask(context.Context, *ForceFailTaskRequest) (*ForceFailTaskResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ForceFailTask not implemented")
+}
 func (UnimplementedOrchestratorServiceServer) mustEmbedUnimplementedOrchestratorServiceServer() {}
 func (UnimplementedOrchestratorServiceServer) testEmbeddedByValue()                             {}
 
@@ -147,6 +506,42 @@ func _OrchestratorService_SubmitExpression_Handler(srv interface{}, ctx context.
 	return interceptor(ctx, in, info, handler)
 }
 
+func _OrchestratorService_SubmitExpressions_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(BatchExpressionRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(OrchestratorServiceServer).SubmitExpressions(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: OrchestratorService_SubmitExpressions_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(OrchestratorServiceServer).SubmitExpressions(ctx, req.(*BatchExpressionRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _OrchestratorService_ValidateExpression_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ValidateExpressionRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(OrchestratorServiceServer).ValidateExpression(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: OrchestratorService_ValidateExpression_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(OrchestratorServiceServer).ValidateExpression(ctx, req.(*ValidateExpressionRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _OrchestratorService_GetTaskDetails_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(TaskDetailsRequest)
 	if err := dec(in); err != nil {
@@ -183,6 +578,323 @@ func _OrchestratorService_ListUserTasks_Handler(srv interface{}, ctx context.Con
 	return interceptor(ctx, in, info, handler)
 }
 
+func _OrchestratorService_RetryTask_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RetryTaskRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(OrchestratorServiceServer).RetryTask(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: OrchestratorService_RetryTask_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(OrchestratorServiceServer).RetryTask(ctx, req.(*RetryTaskRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _OrchestratorService_DeleteTask_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DeleteTaskRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(OrchestratorServiceServer).DeleteTask(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: OrchestratorService_DeleteTask_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(OrchestratorServiceServer).DeleteTask(ctx, req.(*DeleteTaskRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _OrchestratorService_RestoreTask_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RestoreTaskRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(OrchestratorServiceServer).RestoreTask(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: OrchestratorService_RestoreTask_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(OrchestratorServiceServer).RestoreTask(ctx, req.(*RestoreTaskRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _OrchestratorService_GetTaskTrace_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(TaskTraceRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(OrchestratorServiceServer).GetTaskTrace(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: OrchestratorService_GetTaskTrace_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(OrchestratorServiceServer).GetTaskTrace(ctx, req.(*TaskTraceRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _OrchestratorService_GetTaskAST_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(TaskASTRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(OrchestratorServiceServer).GetTaskAST(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: OrchestratorService_GetTaskAST_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(OrchestratorServiceServer).GetTaskAST(ctx, req.(*TaskASTRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _OrchestratorService_WatchTask_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(WatchTaskRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(OrchestratorServiceServer).WatchTask(m, &grpc.GenericServerStream[WatchTaskRequest, TaskEvent]{ServerStream: stream})
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type OrchestratorService_WatchTaskServer = grpc.ServerStreamingServer[TaskEvent]
+
+func _OrchestratorService_CreateWebhookEndpoint_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CreateWebhookEndpointRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(OrchestratorServiceServer).CreateWebhookEndpoint(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: OrchestratorService_CreateWebhookEndpoint_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(OrchestratorServiceServer).CreateWebhookEndpoint(ctx, req.(*CreateWebhookEndpointRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _OrchestratorService_ListWebhookEndpoints_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListWebhookEndpointsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(OrchestratorServiceServer).ListWebhookEndpoints(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: OrchestratorService_ListWebhookEndpoints_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(OrchestratorServiceServer).ListWebhookEndpoints(ctx, req.(*ListWebhookEndpointsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _OrchestratorService_DeleteWebhookEndpoint_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DeleteWebhookEndpointRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(OrchestratorServiceServer).DeleteWebhookEndpoint(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: OrchestratorService_DeleteWebhookEndpoint_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(OrchestratorServiceServer).DeleteWebhookEndpoint(ctx, req.(*DeleteWebhookEndpointRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _OrchestratorService_GetWebhookSecret_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(WebhookSecretRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(OrchestratorServiceServer).GetWebhookSecret(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: OrchestratorService_GetWebhookSecret_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(OrchestratorServiceServer).GetWebhookSecret(ctx, req.(*WebhookSecretRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _OrchestratorService_ListWebhookDeliveries_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListWebhookDeliveriesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(OrchestratorServiceServer).ListWebhookDeliveries(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: OrchestratorService_ListWebhookDeliveries_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(OrchestratorServiceServer).ListWebhookDeliveries(ctx, req.(*ListWebhookDeliveriesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _OrchestratorService_RedeliverWebhook_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RedeliverWebhookRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(OrchestratorServiceServer).RedeliverWebhook(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: OrchestratorService_RedeliverWebhook_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(OrchestratorServiceServer).RedeliverWebhook(ctx, req.(*RedeliverWebhookRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _OrchestratorService_GetUserStats_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UserStatsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(OrchestratorServiceServer).GetUserStats(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: OrchestratorService_GetUserStats_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(OrchestratorServiceServer).GetUserStats(ctx, req.(*UserStatsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _OrchestratorService_GetUsage_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UsageRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(OrchestratorServiceServer).GetUsage(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: OrchestratorService_GetUsage_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(OrchestratorServiceServer).GetUsage(ctx, req.(*UsageRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _OrchestratorService_ListActiveEvaluations_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListActiveEvaluationsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(OrchestratorServiceServer).ListActiveEvaluations(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: OrchestratorService_ListActiveEvaluations_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(OrchestratorServiceServer).ListActiveEvaluations(ctx, req.(*ListActiveEvaluationsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _OrchestratorService_GetQueueStatus_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(QueueStatusRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(OrchestratorServiceServer).GetQueueStatus(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: OrchestratorService_GetQueueStatus_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(OrchestratorServiceServer).GetQueueStatus(ctx, req.(*QueueStatusRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _OrchestratorService_GetSystemTaskCounts_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SystemTaskCountsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(OrchestratorServiceServer).GetSystemTaskCounts(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: OrchestratorService_GetSystemTaskCounts_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(OrchestratorServiceServer).GetSystemTaskCounts(ctx, req.(*SystemTaskCountsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _OrchestratorService_ForceFailTask_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ForceFailTaskRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(OrchestratorServiceServer).ForceFailTask(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: OrchestratorService_ForceFailTask_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(OrchestratorServiceServer).ForceFailTask(ctx, req.(*ForceFailTaskRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 // OrchestratorService_ServiceDesc is the grpc.ServiceDesc for OrchestratorService service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -194,6 +906,14 @@ var OrchestratorService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "SubmitExpression",
 			Handler:    _OrchestratorService_SubmitExpression_Handler,
 		},
+		{
+			MethodName: "SubmitExpressions",
+			Handler:    _OrchestratorService_SubmitExpressions_Handler,
+		},
+		{
+			MethodName: "ValidateExpression",
+			Handler:    _OrchestratorService_ValidateExpression_Handler,
+		},
 		{
 			MethodName: "GetTaskDetails",
 			Handler:    _OrchestratorService_GetTaskDetails_Handler,
@@ -202,7 +922,81 @@ var OrchestratorService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "ListUserTasks",
 			Handler:    _OrchestratorService_ListUserTasks_Handler,
 		},
+		{
+			MethodName: "RetryTask",
+			Handler:    _OrchestratorService_RetryTask_Handler,
+		},
+		{
+			MethodName: "DeleteTask",
+			Handler:    _OrchestratorService_DeleteTask_Handler,
+		},
+		{
+			MethodName: "RestoreTask",
+			Handler:    _OrchestratorService_RestoreTask_Handler,
+		},
+		{
+			MethodName: "GetTaskTrace",
+			Handler:    _OrchestratorService_GetTaskTrace_Handler,
+		},
+		{
+			MethodName: "GetTaskAST",
+			Handler:    _OrchestratorService_GetTaskAST_Handler,
+		},
+		{
+			MethodName: "CreateWebhookEndpoint",
+			Handler:    _OrchestratorService_CreateWebhookEndpoint_Handler,
+		},
+		{
+			MethodName: "ListWebhookEndpoints",
+			Handler:    _OrchestratorService_ListWebhookEndpoints_Handler,
+		},
+		{
+			MethodName: "DeleteWebhookEndpoint",
+			Handler:    _OrchestratorService_DeleteWebhookEndpoint_Handler,
+		},
+		{
+			MethodName: "GetWebhookSecret",
+			Handler:    _OrchestratorService_GetWebhookSecret_Handler,
+		},
+		{
+			MethodName: "ListWebhookDeliveries",
+			Handler:    _OrchestratorService_ListWebhookDeliveries_Handler,
+		},
+		{
+			MethodName: "RedeliverWebhook",
+			Handler:    _OrchestratorService_RedeliverWebhook_Handler,
+		},
+		{
+			MethodName: "GetUserStats",
+			Handler:    _OrchestratorService_GetUserStats_Handler,
+		},
+		{
+			MethodName: "GetUsage",
+			Handler:    _OrchestratorService_GetUsage_Handler,
+		},
+		{
+			MethodName: "ListActiveEvaluations",
+			Handler:    _OrchestratorService_ListActiveEvaluations_Handler,
+		},
+		{
+			MethodName: "GetQueueStatus",
+			Handler:    _OrchestratorService_GetQueueStatus_Handler,
+		},
+		{
+			MethodName: "GetSystemTaskCounts",
+			Handler:    _OrchestratorService_GetSystemTaskCounts_Handler,
+		},
+		{
+			MethodName: "ForceFailTask",
+			Handler:    _OrchestratorService_ForceFailTask_Handler,
+		},
+	},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "WatchTask",
+			Handler:       _OrchestratorService_WatchTask_Handler,
+			ServerStreams: true,
+		},
 	},
-	Streams:  []grpc.StreamDesc{},
 	Metadata: "proto/orchestrator.proto",
 }
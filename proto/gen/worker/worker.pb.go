@@ -22,6 +22,62 @@ const (
 	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
 )
 
+// Тип ошибки вычисления операции. Отмена и внутренние ошибки Воркера возвращаются gRPC статусом.
+type ErrorCode int32
+
+const (
+	ErrorCode_ERROR_CODE_UNSPECIFIED ErrorCode = 0 // Нет ошибки
+	ErrorCode_DIVISION_BY_ZERO       ErrorCode = 1
+	ErrorCode_DOMAIN_ERROR           ErrorCode = 2 // Результат не определен для операндов (e.g., дробная степень отрицательного числа)
+	ErrorCode_OVERFLOW               ErrorCode = 3 // Результат вышел за пределы double
+	ErrorCode_UNKNOWN_OPERATOR       ErrorCode = 4
+)
+
+// Enum value maps for ErrorCode.
+var (
+	ErrorCode_name = map[int32]string{
+		0: "ERROR_CODE_UNSPECIFIED",
+		1: "DIVISION_BY_ZERO",
+		2: "DOMAIN_ERROR",
+		3: "OVERFLOW",
+		4: "UNKNOWN_OPERATOR",
+	}
+	ErrorCode_value = map[string]int32{
+		"ERROR_CODE_UNSPECIFIED": 0,
+		"DIVISION_BY_ZERO":       1,
+		"DOMAIN_ERROR":           2,
+		"OVERFLOW":               3,
+		"UNKNOWN_OPERATOR":       4,
+	}
+)
+
+func (x ErrorCode) Enum() *ErrorCode {
+	p := new(ErrorCode)
+	*p = x
+	return p
+}
+
+func (x ErrorCode) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (ErrorCode) Descriptor() protoreflect.EnumDescriptor {
+	return file_proto_worker_proto_enumTypes[0].Descriptor()
+}
+
+func (ErrorCode) Type() protoreflect.EnumType {
+	return &file_proto_worker_proto_enumTypes[0]
+}
+
+func (x ErrorCode) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use ErrorCode.Descriptor instead.
+func (ErrorCode) EnumDescriptor() ([]byte, []int) {
+	return file_proto_worker_proto_rawDescGZIP(), []int{0}
+}
+
 // Запрос на вычисление операции
 type CalculateOperationRequest struct {
 	state protoimpl.MessageState `protogen:"open.v1"`
@@ -102,7 +158,9 @@ type CalculateOperationResponse struct {
 	// Результат вычисления
 	Result float64 `protobuf:"fixed64,2,opt,name=result,proto3" json:"result,omitempty"`
 	// Сообщение об ошибке, если вычисление не удалось (e.g., деление на ноль)
-	ErrorMessage  string `protobuf:"bytes,3,opt,name=error_message,json=errorMessage,proto3" json:"error_message,omitempty"` // Пустое, если нет ошибки
+	ErrorMessage string `protobuf:"bytes,3,opt,name=error_message,json=errorMessage,proto3" json:"error_message,omitempty"` // Пустое, если нет ошибки
+	// Тип ошибки, по которому клиент выбирает реакцию (ERROR_CODE_UNSPECIFIED, если нет ошибки)
+	ErrorCode     ErrorCode `protobuf:"varint,4,opt,name=error_code,json=errorCode,proto3,enum=worker.ErrorCode" json:"error_code,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
@@ -158,6 +216,13 @@ func (x *CalculateOperationResponse) GetErrorMessage() string {
 	return ""
 }
 
+func (x *CalculateOperationResponse) GetErrorCode() ErrorCode {
+	if x != nil {
+		return x.ErrorCode
+	}
+	return ErrorCode_ERROR_CODE_UNSPECIFIED
+}
+
 var File_proto_worker_proto protoreflect.FileDescriptor
 
 const file_proto_worker_proto_rawDesc = "" +
@@ -167,11 +232,19 @@ const file_proto_worker_proto_rawDesc = "" +
 	"\foperation_id\x18\x01 \x01(\tR\voperationId\x12)\n" +
 	"\x10operation_symbol\x18\x02 \x01(\tR\x0foperationSymbol\x12\x1b\n" +
 	"\toperand_a\x18\x03 \x01(\x01R\boperandA\x12\x1b\n" +
-	"\toperand_b\x18\x04 \x01(\x01R\boperandB\"|\n" +
+	"\toperand_b\x18\x04 \x01(\x01R\boperandB\"\xae\x01\n" +
 	"\x1aCalculateOperationResponse\x12!\n" +
 	"\foperation_id\x18\x01 \x01(\tR\voperationId\x12\x16\n" +
 	"\x06result\x18\x02 \x01(\x01R\x06result\x12#\n" +
-	"\rerror_message\x18\x03 \x01(\tR\ferrorMessage2l\n" +
+	"\rerror_message\x18\x03 \x01(\tR\ferrorMessage\x120\n" +
+	"\n" +
+	"error_code\x18\x04 \x01(\x0e2\x11.worker.ErrorCodeR\terrorCode*s\n" +
+	"\tErrorCode\x12\x1a\n" +
+	"\x16ERROR_CODE_UNSPECIFIED\x10\x00\x12\x14\n" +
+	"\x10DIVISION_BY_ZERO\x10\x01\x12\x10\n" +
+	"\fDOMAIN_ERROR\x10\x02\x12\f\n" +
+	"\bOVERFLOW\x10\x03\x12\x14\n" +
+	"\x10UNKNOWN_OPERATOR\x10\x042l\n" +
 	"\rWorkerService\x12[\n" +
 	"\x12CalculateOperation\x12!.worker.CalculateOperationRequest\x1a\".worker.CalculateOperationResponseBIZGgithub.com/Qu1nel/YaLyceum-GoProject-Final/proto/gen/worker;worker_grpcb\x06proto3"
 
@@ -187,19 +260,22 @@ func file_proto_worker_proto_rawDescGZIP() []byte {
 	return file_proto_worker_proto_rawDescData
 }
 
+var file_proto_worker_proto_enumTypes = make([]protoimpl.EnumInfo, 1)
 var file_proto_worker_proto_msgTypes = make([]protoimpl.MessageInfo, 2)
 var file_proto_worker_proto_goTypes = []any{
-	(*CalculateOperationRequest)(nil),  // 0: worker.CalculateOperationRequest
-	(*CalculateOperationResponse)(nil), // 1: worker.CalculateOperationResponse
+	(ErrorCode)(0),                     // 0: worker.ErrorCode
+	(*CalculateOperationRequest)(nil),  // 1: worker.CalculateOperationRequest
+	(*CalculateOperationResponse)(nil), // 2: worker.CalculateOperationResponse
 }
 var file_proto_worker_proto_depIdxs = []int32{
-	0, // 0: worker.WorkerService.CalculateOperation:input_type -> worker.CalculateOperationRequest
-	1, // 1: worker.WorkerService.CalculateOperation:output_type -> worker.CalculateOperationResponse
-	1, // [1:2] is the sub-list for method output_type
-	0, // [0:1] is the sub-list for method input_type
-	0, // [0:0] is the sub-list for extension type_name
-	0, // [0:0] is the sub-list for extension extendee
-	0, // [0:0] is the sub-list for field type_name
+	0, // 0: worker.CalculateOperationResponse.error_code:type_name -> worker.ErrorCode
+	1, // 1: worker.WorkerService.CalculateOperation:input_type -> worker.CalculateOperationRequest
+	2, // 2: worker.WorkerService.CalculateOperation:output_type -> worker.CalculateOperationResponse
+	2, // [2:3] is the sub-list for method output_type
+	1, // [1:2] is the sub-list for method input_type
+	1, // [1:1] is the sub-list for extension type_name
+	1, // [1:1] is the sub-list for extension extendee
+	0, // [0:1] is the sub-list for field type_name
 }
 
 func init() { file_proto_worker_proto_init() }
@@ -212,13 +288,14 @@ func file_proto_worker_proto_init() {
 		File: protoimpl.DescBuilder{
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: unsafe.Slice(unsafe.StringData(file_proto_worker_proto_rawDesc), len(file_proto_worker_proto_rawDesc)),
-			NumEnums:      0,
+			NumEnums:      1,
 			NumMessages:   2,
 			NumExtensions: 0,
 			NumServices:   1,
 		},
 		GoTypes:           file_proto_worker_proto_goTypes,
 		DependencyIndexes: file_proto_worker_proto_depIdxs,
+		EnumInfos:         file_proto_worker_proto_enumTypes,
 		MessageInfos:      file_proto_worker_proto_msgTypes,
 	}.Build()
 	File_proto_worker_proto = out.File
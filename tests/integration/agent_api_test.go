@@ -30,6 +30,7 @@ type CalculateResponse struct {
 }
 
 type ErrorResponse struct {
+	Code  string `json:"code"`
 	Error string `json:"error"`
 }
 
@@ -39,6 +40,7 @@ type TaskDetailsResponse struct {
 	Status     string    `json:"status"`
 	Result     *float64  `json:"result,omitempty"`
 	ErrorMsg   *string   `json:"error_message,omitempty"`
+	ErrorCode  *string   `json:"error_code,omitempty"`
 	CreatedAt  time.Time `json:"created_at"`
 	UpdatedAt  time.Time `json:"updated_at"`
 }
@@ -305,6 +307,8 @@ func TestIntegration_SubmitExpressionThatFailsAtEvaluation(t *testing.T) {
 	assert.Equal(t, repository.StatusFailed, taskDetails.Status, "Ожидался статус 'failed'")
 	require.NotNil(t, taskDetails.ErrorMsg, "Сообщение об ошибке не должно быть nil")
 	assert.Contains(t, strings.ToLower(*taskDetails.ErrorMsg), "деление на ноль", "Ожидалось сообщение об ошибке деления на ноль")
+	require.NotNil(t, taskDetails.ErrorCode, "Тип ошибки не должен быть nil")
+	assert.Equal(t, "DIVISION_BY_ZERO", *taskDetails.ErrorCode)
 	assert.Nil(t, taskDetails.Result, "Результат должен быть nil для задачи 'failed'")
 }
 
@@ -344,6 +348,7 @@ func TestIntegration_TasksAPI_AccessControls(t *testing.T) {
 	var errorDataB ErrorResponse
 	if json.NewDecoder(detailsResp_A_by_B.Body).Decode(&errorDataB) == nil {
 		assert.Contains(t, errorDataB.Error, "не найдена", "Сообщение об ошибке 'не найдена'")
+		assert.Equal(t, "TASK_NOT_FOUND", errorDataB.Code, "Стабильный код ошибки")
 	}
 
 	tasksReqB, _ := http.NewRequestWithContext(ctx, http.MethodGet, testAgentBaseURL+"/tasks", nil)
@@ -0,0 +1,67 @@
+package integration
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/Qu1nel/YaLyceum-GoProject-Final/internal/orchestrator/repository"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type TaskTraceResponse struct {
+	Operations []struct {
+		NodePath string `json:"node_path"`
+	} `json:"operations"`
+}
+
+// Выражение максимальной глубины (EXPR_MAX_DEPTH по умолчанию 200) вычисляется целиком,
+// а путь самого глубокого узла длиной почти 400 символов сохраняется в трассировке.
+func TestIntegration_SubmitExpressionAtMaxDepth(t *testing.T) {
+	require.NotEmpty(t, testAgentBaseURL, "Базовый URL Агента не должен быть пустым")
+	client := &http.Client{Timeout: 40 * time.Second}
+	ctx := context.Background()
+
+	userLogin := fmt.Sprintf("maxdepth_usr_%d", time.Now().UnixNano()%100000)
+	jwtToken := registerAndLoginUser(t, client, ctx, userLogin, "password123")
+
+	const maxDepth = 200
+	expression := strings.Repeat("1+", maxDepth-1) + "1"
+	calcBody, _ := json.Marshal(map[string]string{"expression": expression})
+	calcReq, _ := http.NewRequestWithContext(ctx, http.MethodPost, testAgentBaseURL+"/calculate?wait=30s", bytes.NewBuffer(calcBody))
+	calcReq.Header.Set("Content-Type", "application/json")
+	calcReq.Header.Set("Authorization", "Bearer "+jwtToken)
+
+	calcResp, err := client.Do(calcReq)
+	require.NoError(t, err)
+	defer calcResp.Body.Close()
+	require.Equal(t, http.StatusOK, calcResp.StatusCode, "выражение глубины %d должно быть вычислено", maxDepth)
+
+	var taskDetails TaskDetailsResponse
+	require.NoError(t, json.NewDecoder(calcResp.Body).Decode(&taskDetails))
+	require.Equal(t, repository.StatusCompleted, taskDetails.Status)
+	require.NotNil(t, taskDetails.Result)
+	assert.Equal(t, float64(maxDepth), *taskDetails.Result)
+
+	traceReq, _ := http.NewRequestWithContext(ctx, http.MethodGet, fmt.Sprintf("%s/tasks/%s/trace", testAgentBaseURL, taskDetails.ID), nil)
+	traceReq.Header.Set("Authorization", "Bearer "+jwtToken)
+	traceResp, err := client.Do(traceReq)
+	require.NoError(t, err)
+	defer traceResp.Body.Close()
+	require.Equal(t, http.StatusOK, traceResp.StatusCode)
+
+	var trace TaskTraceResponse
+	require.NoError(t, json.NewDecoder(traceResp.Body).Decode(&trace))
+	require.Len(t, trace.Operations, maxDepth-1, "трассировка должна сохраниться для всех операций")
+	longest := 0
+	for _, op := range trace.Operations {
+		longest = max(longest, len(op.NodePath))
+	}
+	assert.Equal(t, len("0")+2*(maxDepth-2), longest)
+}
@@ -0,0 +1,24 @@
+package integration
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// Метрики Агента отдаются только на внутреннем AGENT_METRICS_PORT, публичный порт API их не раскрывает.
+func TestIntegration_AgentMetricsNotOnPublicPort(t *testing.T) {
+	require.NotEmpty(t, testAgentHTTPPort, "Порт Агента не должен быть пустым")
+	client := &http.Client{Timeout: 5 * time.Second}
+
+	req, _ := http.NewRequestWithContext(context.Background(), http.MethodGet, fmt.Sprintf("http://localhost:%s/metrics", testAgentHTTPPort), nil)
+	resp, err := client.Do(req)
+	require.NoError(t, err)
+	defer resp.Body.Close()
+	assert.Equal(t, http.StatusNotFound, resp.StatusCode)
+}
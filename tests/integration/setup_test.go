@@ -111,6 +111,8 @@ func setupTestEnvironmentVariables() {
 
 	os.Setenv("AGENT_HTTP_PORT", testAgentHTTPPort)
 	os.Setenv("ORCHESTRATOR_GRPC_ADDRESS", fmt.Sprintf("localhost:%s", testOrchestratorGRPCPort))
+	os.Setenv("RATE_LIMIT_DEFAULT", "1000/1m")
+	os.Setenv("RATE_LIMIT_ROUTES", "POST /login=1000/1m,POST /register=1000/1m")
 	log.Println("Переменные окружения для тестов установлены.")
 }
 
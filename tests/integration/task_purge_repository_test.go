@@ -0,0 +1,52 @@
+package integration
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/Qu1nel/YaLyceum-GoProject-Final/internal/orchestrator/repository"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+// Срок хранения отсчитывается от завершения: восстановление из корзины и освобождение ключа его не продлевают.
+func TestIntegration_TaskRepository_PurgeCountsFromCompletion(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 15*time.Second)
+	defer cancel()
+
+	pool, err := pgxpool.New(ctx, testPostgresDSN)
+	require.NoError(t, err)
+	defer pool.Close()
+
+	var userID uuid.UUID
+	err = pool.QueryRow(ctx, `INSERT INTO users (login, password_hash) VALUES ($1, 'hash') RETURNING id`,
+		"purge_completed_"+uuid.NewString()[:8]).Scan(&userID)
+	require.NoError(t, err)
+	defer pool.Exec(context.Background(), `DELETE FROM users WHERE id = $1`, userID)
+
+	repo := repository.NewPgxTaskRepository(pool, zap.NewNop())
+	taskID, err := repo.CreateIdempotentTask(ctx, repository.NewTask{UserID: userID, Expression: "2+2"}, "purge-key", "hash")
+	require.NoError(t, err)
+	require.NoError(t, repo.SetTaskResult(ctx, taskID, 4))
+	_, err = pool.Exec(ctx, `UPDATE tasks SET completed_at = NOW() - interval '10 days' WHERE id = $1`, taskID)
+	require.NoError(t, err)
+
+	require.NoError(t, repo.SoftDeleteTask(ctx, taskID))
+	require.NoError(t, repo.RestoreTask(ctx, taskID))
+	require.NoError(t, repo.ReleaseIdempotencyKey(ctx, taskID))
+
+	completedBefore := time.Now().AddDate(0, 0, -7)
+	deleted, err := repo.PurgeTasks(ctx, repository.PurgeCriteria{
+		UserIDs:         []uuid.UUID{userID},
+		CompletedBefore: &completedBefore,
+		Limit:           10,
+	})
+	require.NoError(t, err)
+	assert.Equal(t, int64(1), deleted)
+	_, err = repo.GetTaskByID(ctx, taskID)
+	assert.ErrorIs(t, err, repository.ErrTaskNotFound)
+}
@@ -0,0 +1,60 @@
+package integration
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/Qu1nel/YaLyceum-GoProject-Final/internal/orchestrator/repository"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+// Перемещение в корзину и обратно и освобождение ключа идемпотентности меняют updated_at,
+// но не время выполнения задачи в статистике.
+func TestIntegration_TaskRepository_StatsDurationSurvivesRestore(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 15*time.Second)
+	defer cancel()
+
+	pool, err := pgxpool.New(ctx, testPostgresDSN)
+	require.NoError(t, err)
+	defer pool.Close()
+
+	var userID uuid.UUID
+	err = pool.QueryRow(ctx, `INSERT INTO users (login, password_hash) VALUES ($1, 'hash') RETURNING id`,
+		"stats_duration_"+uuid.NewString()[:8]).Scan(&userID)
+	require.NoError(t, err)
+	defer pool.Exec(context.Background(), `DELETE FROM users WHERE id = $1`, userID)
+
+	repo := repository.NewPgxTaskRepository(pool, zap.NewNop())
+	taskID, err := repo.CreateIdempotentTask(ctx, repository.NewTask{UserID: userID, Expression: "2+2"}, "stats-key", "hash")
+	require.NoError(t, err)
+	attempt, err := repo.CreateAttempt(ctx, taskID)
+	require.NoError(t, err)
+	require.NoError(t, repo.SetTaskResult(ctx, taskID, 4))
+
+	// Задача выполнялась 2 секунды и завершилась час назад.
+	_, err = pool.Exec(ctx, `UPDATE task_attempts SET created_at = NOW() - interval '1 hour 2 seconds' WHERE id = $1`, attempt.ID)
+	require.NoError(t, err)
+	_, err = pool.Exec(ctx, `UPDATE tasks SET created_at = NOW() - interval '1 hour 2 seconds', completed_at = NOW() - interval '1 hour' WHERE id = $1`, taskID)
+	require.NoError(t, err)
+
+	stats, err := repo.GetUserStats(ctx, userID)
+	require.NoError(t, err)
+	require.NotNil(t, stats.AvgCompletionSeconds)
+	assert.InDelta(t, 2, *stats.AvgCompletionSeconds, 0.01)
+
+	require.NoError(t, repo.SoftDeleteTask(ctx, taskID))
+	require.NoError(t, repo.RestoreTask(ctx, taskID))
+	require.NoError(t, repo.ReleaseIdempotencyKey(ctx, taskID))
+
+	stats, err = repo.GetUserStats(ctx, userID)
+	require.NoError(t, err)
+	require.NotNil(t, stats.AvgCompletionSeconds)
+	assert.InDelta(t, 2, *stats.AvgCompletionSeconds, 0.01)
+	require.NotNil(t, stats.P95CompletionSeconds)
+	assert.InDelta(t, 2, *stats.P95CompletionSeconds, 0.01)
+}
@@ -0,0 +1,59 @@
+package integration
+
+import (
+	"context"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/Qu1nel/YaLyceum-GoProject-Final/internal/orchestrator/repository"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+func TestIntegration_UsageRepository_ConcurrentReservationsRespectQuota(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+
+	pool, err := pgxpool.New(ctx, testPostgresDSN)
+	require.NoError(t, err)
+	defer pool.Close()
+
+	var userID uuid.UUID
+	err = pool.QueryRow(ctx, `INSERT INTO users (login, password_hash) VALUES ($1, 'hash') RETURNING id`,
+		"usage_quota_"+uuid.NewString()[:8]).Scan(&userID)
+	require.NoError(t, err)
+	defer pool.Exec(context.Background(), `DELETE FROM users WHERE id = $1`, userID)
+
+	repo := repository.NewPgxUsageRepository(pool, zap.NewNop())
+	day := time.Now().UTC()
+	delta := repository.Usage{TasksSubmitted: 1, Operations: 2}
+	dailyLimit := repository.Usage{TasksSubmitted: 5}
+	monthlyLimit := repository.Usage{Operations: 8}
+
+	const reservations = 20
+	var reserved atomic.Int32
+	var wg sync.WaitGroup
+	for range reservations {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			ok, err := repo.ReserveUsage(ctx, userID, day, delta, dailyLimit, monthlyLimit)
+			assert.NoError(t, err)
+			if ok {
+				reserved.Add(1)
+			}
+		}()
+	}
+	wg.Wait()
+
+	// Месячный лимит операций 8 вмещает только 4 резерва по 2 операции, хотя суточный лимит задач допускает 5.
+	assert.Equal(t, int32(4), reserved.Load())
+	usage, err := repo.GetUsage(ctx, userID, day)
+	require.NoError(t, err)
+	assert.Equal(t, repository.Usage{TasksSubmitted: 4, Operations: 8}, usage.Day)
+}
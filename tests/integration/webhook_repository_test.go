@@ -0,0 +1,58 @@
+package integration
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/Qu1nel/YaLyceum-GoProject-Final/internal/orchestrator/repository"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+// Выборка идет в транзакции, которая откатывается: запущенный в окружении диспетчер не видит доставку и не отправляет ее.
+func TestIntegration_WebhookRepository_LeasedDeliveryIsNotClaimedAgain(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 15*time.Second)
+	defer cancel()
+
+	conn, err := pgx.Connect(ctx, testPostgresDSN)
+	require.NoError(t, err)
+	defer conn.Close(context.Background())
+	tx, err := conn.Begin(ctx)
+	require.NoError(t, err)
+	defer tx.Rollback(context.Background())
+
+	var userID uuid.UUID
+	err = tx.QueryRow(ctx, `INSERT INTO users (login, password_hash) VALUES ($1, 'hash') RETURNING id`,
+		"webhook_lease_"+uuid.NewString()[:8]).Scan(&userID)
+	require.NoError(t, err)
+
+	repo := repository.NewPgxWebhookRepository(tx, zap.NewNop())
+	deliveryID, err := repo.CreateDelivery(ctx, repository.WebhookDelivery{
+		UserID:  userID,
+		URL:     "https://example.com/hook",
+		Event:   "task.completed",
+		Payload: []byte(`{}`),
+	})
+	require.NoError(t, err)
+
+	claimed, err := repo.ClaimDueDeliveries(ctx, 100, time.Minute)
+	require.NoError(t, err)
+	assert.True(t, containsDelivery(claimed, deliveryID), "доставка должна быть выбрана первым проходом")
+
+	claimed, err = repo.ClaimDueDeliveries(ctx, 100, time.Minute)
+	require.NoError(t, err)
+	assert.False(t, containsDelivery(claimed, deliveryID), "арендованная доставка не должна выбираться повторно")
+}
+
+func containsDelivery(deliveries []repository.WebhookDelivery, id uuid.UUID) bool {
+	for _, d := range deliveries {
+		if d.ID == id {
+			return true
+		}
+	}
+	return false
+}
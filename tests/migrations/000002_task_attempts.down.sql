@@ -0,0 +1 @@
+DROP TABLE IF EXISTS task_attempts;
\ No newline at end of file
@@ -0,0 +1 @@
+CREATE INDEX idx_tasks_user_created_at_id ON tasks(user_id, created_at, id);
\ No newline at end of file
@@ -0,0 +1 @@
+DROP INDEX IF EXISTS idx_tasks_expression_trgm;
\ No newline at end of file
@@ -0,0 +1 @@
+DROP TABLE IF EXISTS task_operations;
\ No newline at end of file
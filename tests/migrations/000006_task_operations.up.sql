@@ -0,0 +1,19 @@
+CREATE TABLE task_operations (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    task_id UUID NOT NULL REFERENCES tasks(id) ON DELETE CASCADE,
+    attempt_id UUID NOT NULL REFERENCES task_attempts(id) ON DELETE CASCADE,
+    seq INTEGER NOT NULL,
+    operation_id VARCHAR(64) NOT NULL,
+    node_path TEXT NOT NULL,
+    symbol VARCHAR(16) NOT NULL,
+    operand_a DOUBLE PRECISION NOT NULL,
+    operand_b DOUBLE PRECISION NOT NULL,
+    result DOUBLE PRECISION,
+    error_message TEXT,
+    worker_address VARCHAR(255),
+    started_at TIMESTAMP WITH TIME ZONE NOT NULL,
+    duration_ms DOUBLE PRECISION NOT NULL,
+    UNIQUE (attempt_id, seq)
+);
+
+CREATE INDEX idx_task_operations_task_id ON task_operations(task_id);
\ No newline at end of file
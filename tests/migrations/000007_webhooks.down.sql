@@ -0,0 +1,5 @@
+DROP TABLE IF EXISTS webhook_deliveries;
+DROP TABLE IF EXISTS webhook_endpoints;
+DROP TABLE IF EXISTS webhook_secrets;
+
+ALTER TABLE tasks DROP COLUMN IF EXISTS callback_url;
\ No newline at end of file
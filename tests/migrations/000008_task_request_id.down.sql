@@ -0,0 +1,3 @@
+DROP INDEX IF EXISTS idx_tasks_request_id;
+
+ALTER TABLE tasks DROP COLUMN IF EXISTS request_id;
\ No newline at end of file
@@ -0,0 +1,3 @@
+ALTER TABLE tasks ADD COLUMN request_id VARCHAR(128);
+
+CREATE INDEX idx_tasks_request_id ON tasks(request_id) WHERE request_id IS NOT NULL;
\ No newline at end of file
@@ -0,0 +1,2 @@
+DROP INDEX IF EXISTS idx_task_operations_task_symbol;
+DROP INDEX IF EXISTS idx_tasks_user_status;
\ No newline at end of file
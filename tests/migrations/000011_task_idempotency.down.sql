@@ -0,0 +1,4 @@
+DROP INDEX IF EXISTS idx_tasks_user_idempotency_key;
+
+ALTER TABLE tasks DROP COLUMN IF EXISTS request_hash;
+ALTER TABLE tasks DROP COLUMN IF EXISTS idempotency_key;
\ No newline at end of file
@@ -0,0 +1,4 @@
+ALTER TABLE tasks ADD COLUMN idempotency_key VARCHAR(255);
+ALTER TABLE tasks ADD COLUMN request_hash CHAR(64);
+
+CREATE UNIQUE INDEX idx_tasks_user_idempotency_key ON tasks(user_id, idempotency_key) WHERE idempotency_key IS NOT NULL;
\ No newline at end of file
@@ -0,0 +1 @@
+DROP TABLE IF EXISTS user_usage;
\ No newline at end of file
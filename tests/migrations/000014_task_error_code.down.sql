@@ -0,0 +1 @@
+ALTER TABLE tasks DROP COLUMN IF EXISTS error_code;
\ No newline at end of file